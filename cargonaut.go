@@ -2,20 +2,615 @@ package cargonaut
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut/pkg/ledger"
+	"github.com/my-cargonaut/cargonaut/pkg/pricing"
+)
+
+// Booking is the booking of a trip by a rider. Bookings of trips which require
+// approval are pending until the driver accepts or declines them, or they
+// expire.
+type Booking struct {
+	ID        uuid.UUID     `json:"id" db:"id" sql:"type:uuid"`
+	TripID    uuid.UUID     `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	UserID    uuid.UUID     `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Status    BookingStatus `json:"status" db:"status"`
+	ExpiresAt time.Time     `json:"expires_at" db:"expires_at"`
+	DecidedAt *time.Time    `json:"decided_at" db:"decided_at"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+
+	// PromoCode is the promo code redeemed when creating the booking, if
+	// any. Discount is the discount granted by it in cents.
+	PromoCode string `json:"promo_code,omitempty" db:"-"`
+	Discount  int64  `json:"discount,omitempty" db:"-"`
+}
+
+// BookingStatus is the status of a booking.
+type BookingStatus string
+
+// All available booking statuses.
+const (
+	BookingStatusPending   BookingStatus = "pending"
+	BookingStatusAccepted  BookingStatus = "accepted"
+	BookingStatusDeclined  BookingStatus = "declined"
+	BookingStatusExpired   BookingStatus = "expired"
+	BookingStatusCancelled BookingStatus = "cancelled"
+)
+
+// Conversation is the message thread of a trip as seen by one of its
+// participants.
+type Conversation struct {
+	TripID      uuid.UUID `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	LastMessage Message   `json:"last_message" db:"last_message"`
+	UnreadCount int       `json:"unread_count" db:"unread_count"`
+}
+
+// EmailVerification is a pending change of a users E-Mail address. The new
+// address replaces the current one once the user proves ownership of it by
+// presenting the verification ID.
+type EmailVerification struct {
+	ID        uuid.UUID `db:"id" sql:"type:uuid"`
+	UserID    uuid.UUID `db:"user_id" sql:"type:uuid"`
+	Email     string    `db:"email"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Event is a notification about a change of a trip or one of its messages and
+// ratings which is pushed to the affected user.
+type Event struct {
+	ID        uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
+	Type      EventType `json:"type" db:"type"`
+	UserID    uuid.UUID `json:"-" db:"user_id" sql:"type:uuid"`
+	TripID    uuid.UUID `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewEvent returns a new event of the given type about the trip identified by
+// its unique ID which is addressed to the user identified by his unique ID.
+func NewEvent(typ EventType, userID, tripID uuid.UUID) *Event {
+	return &Event{
+		ID:        uuid.NewV4(),
+		Type:      typ,
+		UserID:    userID,
+		TripID:    tripID,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// OutboxEvent is an event written to the outbox which has not been published
+// to all subscribers yet.
+type OutboxEvent struct {
+	Event
+	Attempts int `db:"attempts"`
+}
+
+// EventHandler handles events. Handlers of events relayed from the outbox must
+// be idempotent as events are delivered at least once. The event ID serves as
+// idempotency key.
+type EventHandler func(ctx context.Context, events ...*Event) error
+
+// EventType is the type of an event.
+type EventType string
+
+// EventTypes is a list of event types.
+type EventTypes []EventType
+
+// Scan implements the sql.Scanner interface.
+func (e *EventTypes) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, e)
+	case string:
+		return json.Unmarshal([]byte(src), e)
+	}
+	return errors.New("incompatible type for event types")
+}
+
+// Value implements the driver.Valuer interface.
+func (e EventTypes) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+// Contains returns true if the list contains the event type.
+func (e EventTypes) Contains(typ EventType) bool {
+	for _, t := range e {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// All available event types. Trip cancelled events are addressed to the
+// driver of a trip whose booking the rider cancelled, while trip cancelled by
+// driver events are addressed to the rider of a trip the driver cancelled.
+const (
+	EventTypeTripCreated           EventType = "trip_created"
+	EventTypeTripUpdated           EventType = "trip_updated"
+	EventTypeTripBooked            EventType = "trip_booked"
+	EventTypeTripCancelled         EventType = "trip_cancelled"
+	EventTypeTripCancelledByDriver EventType = "trip_cancelled_by_driver"
+	EventTypeTripStarted           EventType = "trip_started"
+	EventTypeTripFinished          EventType = "trip_finished"
+	EventTypeTripReminder          EventType = "trip_reminder"
+	EventTypeTripCompleted         EventType = "trip_completed"
+	EventTypeBookingRequested      EventType = "booking_requested"
+	EventTypeBookingAccepted       EventType = "booking_accepted"
+	EventTypeBookingDeclined       EventType = "booking_declined"
+	EventTypeBookingExpired        EventType = "booking_expired"
+	EventTypeWaitlistOffered       EventType = "waitlist_offered"
+	EventTypeWaitlistOfferExpired  EventType = "waitlist_offer_expired"
+	EventTypeMessageCreated        EventType = "message_created"
+	EventTypeRatingCreated         EventType = "rating_created"
+)
+
+// Job is a background job which runs on a schedule. A job is run by a single
+// server instance at a time, which holds its lease while running it.
+type Job struct {
+	Name           string     `json:"name" db:"name"`
+	Schedule       string     `json:"schedule" db:"schedule"`
+	LockedBy       *string    `json:"locked_by" db:"locked_by"`
+	LockedUntil    *time.Time `json:"locked_until" db:"locked_until"`
+	LastRunAt      *time.Time `json:"last_run_at" db:"last_run_at"`
+	LastFinishedAt *time.Time `json:"last_finished_at" db:"last_finished_at"`
+	LastError      *string    `json:"last_error" db:"last_error"`
+	NextRunAt      time.Time  `json:"next_run_at" db:"next_run_at"`
+}
+
+// LedgerAccountType is the type of an account of the wallet ledger. Users have
+// a wallet account holding their available balance and a hold account holding
+// the balance reserved for booked trips. The platform has a fees account, a
+// funding account, which money enters and leaves the platform through, and a
+// promotions account paying for discounts and referral credits.
+type LedgerAccountType string
+
+// All available ledger account types.
+const (
+	LedgerAccountTypeWallet     LedgerAccountType = "wallet"
+	LedgerAccountTypeHold       LedgerAccountType = "hold"
+	LedgerAccountTypeFees       LedgerAccountType = "fees"
+	LedgerAccountTypeFunding    LedgerAccountType = "funding"
+	LedgerAccountTypePromotions LedgerAccountType = "promotions"
+)
+
+// LedgerEntryType is the type of a journal entry of the wallet ledger.
+type LedgerEntryType string
+
+// All available ledger entry types.
+const (
+	LedgerEntryTypeDeposit    LedgerEntryType = "deposit"
+	LedgerEntryTypeWithdrawal LedgerEntryType = "withdrawal"
+	LedgerEntryTypeReversal   LedgerEntryType = "reversal"
+	LedgerEntryTypeHold       LedgerEntryType = "hold"
+	LedgerEntryTypeRelease    LedgerEntryType = "release"
+	LedgerEntryTypeSettle     LedgerEntryType = "settle"
+	LedgerEntryTypeReferral   LedgerEntryType = "referral"
+)
+
+// LedgerPosting is a posting to an account of a user. Amounts are in cents.
+type LedgerPosting struct {
+	ID          uuid.UUID         `json:"id" db:"id" sql:"type:uuid"`
+	EntryID     uuid.UUID         `json:"entry_id" db:"entry_id" sql:"type:uuid"`
+	EntryType   LedgerEntryType   `json:"entry_type" db:"entry_type"`
+	TripID      *uuid.UUID        `json:"trip_id,omitempty" db:"trip_id" sql:"type:uuid"`
+	PaymentID   *uuid.UUID        `json:"payment_id,omitempty" db:"payment_id" sql:"type:uuid"`
+	AccountType LedgerAccountType `json:"account_type" db:"account_type"`
+	Amount      int64             `json:"amount" db:"amount"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+}
+
+// Message is a message posted to the conversation of a trip. Only the driver
+// and the rider of a trip participate in its conversation. A message is read
+// once the other participant has opened the conversation after it was posted.
+type Message struct {
+	ID        uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
+	TripID    uuid.UUID `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	AuthorID  uuid.UUID `json:"author_id" db:"author_id" sql:"type:uuid"`
+	Body      string    `json:"body" db:"body"`
+	Read      bool      `json:"read" db:"read"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Notification is an entry of the in-app inbox of a user. It informs the user
+// about an event concerning one of his trips.
+type Notification struct {
+	ID        uuid.UUID  `json:"id" db:"id" sql:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id" sql:"type:uuid"`
+	TripID    uuid.UUID  `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	EventID   *uuid.UUID `json:"event_id,omitempty" db:"event_id" sql:"type:uuid"`
+	Type      EventType  `json:"type" db:"type"`
+	Title     string     `json:"title" db:"title"`
+	ReadAt    *time.Time `json:"read_at" db:"read_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NotificationSettings control through which channels and in which language a
+// user is notified.
+type NotificationSettings struct {
+	Inbox    bool   `json:"inbox"`
+	Email    bool   `json:"email"`
+	Language string `json:"language"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (n *NotificationSettings) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, n)
+	case string:
+		return json.Unmarshal([]byte(src), n)
+	}
+	return errors.New("incompatible type for notification settings")
+}
+
+// Value implements the driver.Valuer interface.
+func (n NotificationSettings) Value() (driver.Value, error) {
+	return json.Marshal(n)
+}
+
+// Organization is a company operating a fleet of vehicles. Its members drive
+// trips with the vehicles of the organization.
+type Organization struct {
+	ID        uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrganizationMember is a user who is a member of an organization.
+type OrganizationMember struct {
+	OrganizationID uuid.UUID        `json:"organization_id" db:"organization_id" sql:"type:uuid"`
+	UserID         uuid.UUID        `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// OrganizationRole is the role of a member of an organization.
+type OrganizationRole string
+
+// The roles of a member of an organization. Owners manage the members,
+// dispatchers manage the vehicles and trips and drivers drive the trips.
+const (
+	OrganizationRoleOwner      OrganizationRole = "owner"
+	OrganizationRoleDispatcher OrganizationRole = "dispatcher"
+	OrganizationRoleDriver     OrganizationRole = "driver"
+)
+
+// CanDispatch returns true if the role permits to manage the vehicles and
+// trips of the organization.
+func (r OrganizationRole) CanDispatch() bool {
+	return r == OrganizationRoleOwner || r == OrganizationRoleDispatcher
+}
+
+// Payment is a top-up of a wallet or a payout from it through the payment
+// provider. Amounts are in cents. Top-ups are credited to the wallet once the
+// provider captured them, while payouts are withdrawn from the wallet right
+// away and credited back if they fail.
+type Payment struct {
+	ID          uuid.UUID     `json:"id" db:"id" sql:"type:uuid"`
+	UserID      uuid.UUID     `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Type        PaymentType   `json:"type" db:"type"`
+	Amount      int64         `json:"amount" db:"amount"`
+	Refunded    int64         `json:"refunded" db:"refunded"`
+	Status      PaymentStatus `json:"status" db:"status"`
+	Reference   *string       `json:"-" db:"reference"`
+	RedirectURL *string       `json:"redirect_url,omitempty" db:"redirect_url"`
+	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// PaymentType is the type of a payment.
+type PaymentType string
+
+// All available payment types.
+const (
+	PaymentTypeTopUp  PaymentType = "top_up"
+	PaymentTypePayout PaymentType = "payout"
+)
+
+// PaymentStatus is the status of a payment.
+type PaymentStatus string
+
+// All available payment statuses.
+const (
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusSucceeded  PaymentStatus = "succeeded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// PaymentIntent is an intent created by the payment provider to move the
+// amount of a payment. Users authorize top-ups at the redirect URL, if any.
+type PaymentIntent struct {
+	Reference   string
+	RedirectURL string
+}
+
+// PaymentEvent is a change of a payment intent the payment provider notified
+// us about. Providers deliver an event until it is acknowledged, so events are
+// deduplicated by their idempotency key.
+type PaymentEvent struct {
+	IdempotencyKey string           `json:"idempotency_key" db:"idempotency_key"`
+	Type           PaymentEventType `json:"type" db:"type"`
+	Reference      string           `json:"reference" db:"reference"`
+	Amount         int64            `json:"amount" db:"amount"`
+}
+
+// PaymentEventType is the type of a payment event.
+type PaymentEventType string
+
+// All available payment event types.
+const (
+	PaymentEventTypeAuthorized   PaymentEventType = "intent.authorized"
+	PaymentEventTypeCaptured     PaymentEventType = "intent.captured"
+	PaymentEventTypeFailed       PaymentEventType = "intent.failed"
+	PaymentEventTypeRefunded     PaymentEventType = "refund.succeeded"
+	PaymentEventTypeRefundFailed PaymentEventType = "refund.failed"
+	PaymentEventTypePayoutPaid   PaymentEventType = "payout.paid"
+	PaymentEventTypePayoutFailed PaymentEventType = "payout.failed"
+)
+
+// PriceSuggestion is a suggested price range for a trip. The distance by road
+// in km is unset if the locations are unknown, and comparables is the number
+// of comparable trips taken into account.
+type PriceSuggestion struct {
+	Distance    *float64 `json:"distance,omitempty"`
+	Price       float32  `json:"price"`
+	MinPrice    float32  `json:"min_price"`
+	MaxPrice    float32  `json:"max_price"`
+	Comparables int      `json:"comparables"`
+}
+
+// Far returns true if the price is far outside of the suggested range.
+func (s *PriceSuggestion) Far(price float32) bool {
+	r := pricing.Range{
+		Min: ledger.Cents(float64(s.MinPrice)),
+		Max: ledger.Cents(float64(s.MaxPrice)),
+	}
+	return r.Far(ledger.Cents(float64(price)))
+}
+
+// PromoCode grants riders a discount on the price of a trip when booking it.
+// The value of percentage discounts is given in basis points, the one of fixed
+// discounts in cents. A promo code without maximum redemptions can be redeemed
+// any number of times. Redemptions is the number of redemptions counting
+// against the limits, which are the ones of pending and accepted bookings.
+type PromoCode struct {
+	ID                    uuid.UUID     `json:"id" db:"id" sql:"type:uuid"`
+	Code                  string        `json:"code" db:"code"`
+	Type                  PromoCodeType `json:"type" db:"type"`
+	Value                 int64         `json:"value" db:"value"`
+	MaxRedemptions        *int          `json:"max_redemptions" db:"max_redemptions"`
+	MaxRedemptionsPerUser int           `json:"max_redemptions_per_user" db:"max_redemptions_per_user"`
+	FirstTripOnly         bool          `json:"first_trip_only" db:"first_trip_only"`
+	ValidFrom             *time.Time    `json:"valid_from" db:"valid_from"`
+	ValidUntil            *time.Time    `json:"valid_until" db:"valid_until"`
+	Redemptions           int           `json:"redemptions" db:"redemptions"`
+	CreatedAt             time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Valid returns true if the promo code is valid at the given time.
+func (c *PromoCode) Valid(now time.Time) bool {
+	return (c.ValidFrom == nil || !now.Before(*c.ValidFrom)) && (c.ValidUntil == nil || now.Before(*c.ValidUntil))
+}
+
+// Discount returns the discount granted on the amount in cents. It never
+// exceeds the amount.
+func (c *PromoCode) Discount(amount int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+	switch c.Type {
+	case PromoCodeTypePercentage:
+		return ledger.Fee(amount, c.Value)
+	case PromoCodeTypeFixed:
+		if c.Value < amount {
+			return c.Value
+		}
+		return amount
+	default:
+		return 0
+	}
+}
+
+// PromoCodeType is the type of the discount of a promo code.
+type PromoCodeType string
+
+// All available promo code types.
+const (
+	PromoCodeTypePercentage PromoCodeType = "percentage"
+	PromoCodeTypeFixed      PromoCodeType = "fixed"
 )
 
+// PromoRedemption is the redemption of a promo code by a booking. The amount
+// of the discount is given in cents.
+type PromoRedemption struct {
+	ID            uuid.UUID     `json:"id" db:"id" sql:"type:uuid"`
+	PromoCodeID   uuid.UUID     `json:"promo_code_id" db:"promo_code_id" sql:"type:uuid"`
+	BookingID     uuid.UUID     `json:"booking_id" db:"booking_id" sql:"type:uuid"`
+	TripID        uuid.UUID     `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	UserID        uuid.UUID     `json:"user_id" db:"user_id" sql:"type:uuid"`
+	BookingStatus BookingStatus `json:"booking_status" db:"booking_status"`
+	Amount        int64         `json:"amount" db:"amount"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+}
+
+// PrivacySettings control which optional fields of a users profile are visible
+// to other users.
+type PrivacySettings struct {
+	ShowEmail      bool `json:"show_email"`
+	ShowBirthday   bool `json:"show_birthday"`
+	ShowAgeBracket bool `json:"show_age_bracket"`
+	ShowRating     bool `json:"show_rating"`
+	ShowTripCount  bool `json:"show_trip_count"`
+}
+
+// Scan implements the sql.Scanner interface.
+func (p *PrivacySettings) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, p)
+	case string:
+		return json.Unmarshal([]byte(src), p)
+	}
+	return errors.New("incompatible type for privacy settings")
+}
+
+// Value implements the driver.Valuer interface.
+func (p PrivacySettings) Value() (driver.Value, error) {
+	return json.Marshal(p)
+}
+
 // Rating is a rating given by a user (Author) to another user. Users can't rate
-// themselves.
+// themselves. The role is the role the rated user had on the trip: Drivers and
+// riders of a trip rate each other.
 type Rating struct {
-	ID        uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
+	ID        uuid.UUID    `json:"id" db:"id" sql:"type:uuid"`
+	UserID    uuid.UUID    `json:"user_id" db:"user_id" sql:"type:uuid"`
+	AuthorID  uuid.UUID    `json:"author_id" db:"author_id" sql:"type:uuid"`
+	TripID    uuid.UUID    `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	Role      RatingRole   `json:"role" db:"role"`
+	Comment   string       `json:"comment" db:"comment"`
+	Value     float32      `json:"value" db:"value"`
+	Status    RatingStatus `json:"status" db:"status"`
+	Reply     *string      `json:"reply,omitempty" db:"reply"`
+	RepliedAt *time.Time   `json:"replied_at,omitempty" db:"replied_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// RatingStatus is the moderation status of a rating. Hidden ratings are not
+// listed and don't count towards the rating summary of the rated user.
+type RatingStatus string
+
+// The moderation states of a rating.
+const (
+	RatingStatusVisible RatingStatus = "visible"
+	RatingStatusHidden  RatingStatus = "hidden"
+)
+
+// RatingReport is a report of a rating which is considered inappropriate by
+// the reporter. Reports are resolved by moderators.
+type RatingReport struct {
+	ID          uuid.UUID          `json:"id" db:"id" sql:"type:uuid"`
+	RatingID    uuid.UUID          `json:"rating_id" db:"rating_id" sql:"type:uuid"`
+	ReporterID  uuid.UUID          `json:"reporter_id" db:"reporter_id" sql:"type:uuid"`
+	Reason      string             `json:"reason" db:"reason"`
+	Status      RatingReportStatus `json:"status" db:"status"`
+	ModeratorID *uuid.UUID         `json:"moderator_id,omitempty" db:"moderator_id" sql:"type:uuid"`
+	CreatedAt   time.Time          `json:"created_at" db:"created_at"`
+	ResolvedAt  *time.Time         `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// RatingReportStatus is the status of a rating report. A resolved report
+// carries the moderation decision.
+type RatingReportStatus string
+
+// The states of a rating report.
+const (
+	RatingReportStatusOpen     RatingReportStatus = "open"
+	RatingReportStatusApproved RatingReportStatus = "approved"
+	RatingReportStatusHidden   RatingReportStatus = "hidden"
+)
+
+// ModerationAction is a moderation decision about a reported rating.
+type ModerationAction string
+
+// The moderation decisions about a reported rating.
+const (
+	ModerationActionApprove ModerationAction = "approve"
+	ModerationActionHide    ModerationAction = "hide"
+	ModerationActionDelete  ModerationAction = "delete"
+)
+
+// RatingRole is the role a user had on the trip he was rated for.
+type RatingRole string
+
+// The roles a user can be rated in.
+const (
+	RatingRoleDriver RatingRole = "driver"
+	RatingRoleRider  RatingRole = "rider"
+)
+
+// RatingHistogram counts the ratings of a user by their number of stars.
+type RatingHistogram struct {
+	OneStar    int `json:"1" db:"stars_1"`
+	TwoStars   int `json:"2" db:"stars_2"`
+	ThreeStars int `json:"3" db:"stars_3"`
+	FourStars  int `json:"4" db:"stars_4"`
+	FiveStars  int `json:"5" db:"stars_5"`
+}
+
+// RatingSummary aggregates the ratings a user received in one role. The score
+// is a Bayesian average which accounts for users having only a few ratings.
+type RatingSummary struct {
+	UserID    uuid.UUID       `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Role      RatingRole      `json:"role" db:"role"`
+	Count     int             `json:"count" db:"count"`
+	Mean      float64         `json:"mean" db:"mean"`
+	Score     float64         `json:"score" db:"score"`
+	Histogram RatingHistogram `json:"histogram" db:"histogram"`
+}
+
+// Receipt is the receipt of a completed booking. Receipts are numbered
+// sequentially per year and keep a copy of the details of the booking at the
+// time they are issued, as they must not change afterwards. The amount is the
+// fare settled for the trip and the discount the part of it paid by the
+// platform, both in cents. The total paid by the rider includes VAT at the
+// rate given in basis points.
+type Receipt struct {
+	ID          uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
+	BookingID   uuid.UUID `json:"booking_id" db:"booking_id" sql:"type:uuid"`
+	TripID      uuid.UUID `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	Year        int       `json:"year" db:"year"`
+	Sequence    int       `json:"sequence" db:"sequence"`
+	DriverName  string    `json:"driver_name" db:"driver_name"`
+	RiderName   string    `json:"rider_name" db:"rider_name"`
+	Start       string    `json:"start" db:"start"`
+	Destination string    `json:"destination" db:"destination"`
+	Depature    time.Time `json:"depature" db:"depature"`
+	Amount      int64     `json:"amount" db:"amount"`
+	Discount    int64     `json:"discount" db:"discount"`
+	VATRate     int64     `json:"vat_rate" db:"vat_rate"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// Number returns the receipt number made up of the year and the sequence
+// number within it.
+func (r *Receipt) Number() string {
+	return fmt.Sprintf("%d-%06d", r.Year, r.Sequence)
+}
+
+// Total returns the amount paid by the rider in cents, which is the amount
+// less the discount.
+func (r *Receipt) Total() int64 {
+	return r.Amount - r.Discount
+}
+
+// VAT returns the VAT included in the total in cents.
+func (r *Receipt) VAT() int64 {
+	return ledger.Tax(r.Total(), r.VATRate)
+}
+
+// ReferralCode is the code of a user referring others to the platform.
+// Referred is the number of users who signed up with it, credited the number
+// of them who completed their first trip.
+type ReferralCode struct {
 	UserID    uuid.UUID `json:"user_id" db:"user_id" sql:"type:uuid"`
-	AuthorID  uuid.UUID `json:"author_id" db:"author_id" sql:"type:uuid"`
-	TripID    uuid.UUID `json:"trip_id" db:"trip_id" sql:"type:uuid"`
-	Comment   string    `json:"comment" db:"comment"`
-	Value     float32   `json:"value" db:"value"`
+	Code      string    `json:"code" db:"code"`
+	Referred  int       `json:"referred" db:"referred"`
+	Credited  int       `json:"credited" db:"credited"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -28,45 +623,683 @@ type Token struct {
 	CreatedAt time.Time `db:"created_at"`
 }
 
+// CancellationPolicy decides whether cancelling a trip is free of charge.
+// Cancellations within the free window before the planned departure or after
+// the trip has been started are late.
+type CancellationPolicy struct {
+	FreeWindow time.Duration
+}
+
+// Late returns true if cancelling the trip at the given time is a late
+// cancellation.
+func (p CancellationPolicy) Late(trip *Trip, now time.Time) bool {
+	if trip.Started() {
+		return true
+	} else if trip.PlannedDepature == nil {
+		return false
+	}
+	return trip.PlannedDepature.Sub(now) < p.FreeWindow
+}
+
+// FeePolicy decides the platform fee withheld from the price of a trip when it
+// is settled to the driver. The rate is given in basis points.
+type FeePolicy struct {
+	Rate int64
+}
+
+// Fee returns the platform fee for the amount in cents.
+func (p FeePolicy) Fee(amount int64) int64 {
+	return ledger.Fee(amount, p.Rate)
+}
+
+// ReferralPolicy decides the credit both the referring and the referred user
+// receive once the referred user completes his first paid trip. The credit is
+// given in cents.
+type ReferralPolicy struct {
+	Credit int64
+}
+
+// PricingPolicy suggests prices for trips using a per-km cost model. Base and
+// per-km prices are given in cents, the spread of suggested ranges in basis
+// points.
+type PricingPolicy struct {
+	Base   int64
+	PerKm  int64
+	Spread int64
+}
+
+// Suggest returns a price suggestion for a trip over the distance by road in
+// km with a vehicle of the given type, taking the historic prices of
+// comparable trips into account. A distance of zero or less is unknown. It
+// returns false if neither the distance is known nor there are enough
+// comparable trips.
+func (p PricingPolicy) Suggest(distance float64, typ VehicleType, historic []float32) (*PriceSuggestion, bool) {
+	model := pricing.Model{Base: p.Base, PerKm: p.PerKm, Spread: p.Spread}
+
+	var cost int64
+	if distance > 0 {
+		cost = model.Cost(distance, typ.PriceFactor())
+	}
+	prices := make([]int64, len(historic))
+	for i, price := range historic {
+		prices[i] = ledger.Cents(float64(price))
+	}
+
+	r, ok := model.Suggest(cost, prices)
+	if !ok {
+		return nil, false
+	}
+	s := &PriceSuggestion{
+		Price:       float32(r.Price) / 100,
+		MinPrice:    float32(r.Min) / 100,
+		MaxPrice:    float32(r.Max) / 100,
+		Comparables: len(historic),
+	}
+	if distance > 0 {
+		s.Distance = &distance
+	}
+	return s, true
+}
+
 // Trip is a trip from one location to another one. If no RiderID is assigned,
-// the trip is still available.
+// the trip is still available. Trips which require approval have to be
+// accepted by the driver instead of being booked instantly. The cancellation
+// reason is given by the driver when cancelling the trip.
 type Trip struct {
-	ID          uuid.UUID  `json:"id" db:"id" sql:"type:uuid"`
-	UserID      uuid.UUID  `json:"user_id" db:"user_id" sql:"type:uuid"`
-	VehicleID   uuid.UUID  `json:"vehicle_id" db:"vehicle_id" sql:"type:uuid"`
-	RiderID     *uuid.UUID `json:"rider_id" db:"rider_id" sql:"type:uuid"`
-	Start       string     `json:"start" db:"start"`
-	Destination string     `json:"destination" db:"destination"`
-	Price       float32    `json:"price" db:"price"`
-	Depature    time.Time  `json:"depature" db:"depature"`
-	Arrival     time.Time  `json:"arrival" db:"arrival"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	ID                 uuid.UUID  `json:"id" db:"id" sql:"type:uuid"`
+	UserID             uuid.UUID  `json:"user_id" db:"user_id" sql:"type:uuid"`
+	VehicleID          uuid.UUID  `json:"vehicle_id" db:"vehicle_id" sql:"type:uuid"`
+	RiderID            *uuid.UUID `json:"rider_id" db:"rider_id" sql:"type:uuid"`
+	Start              string     `json:"start" db:"start"`
+	Destination        string     `json:"destination" db:"destination"`
+	Price              float32    `json:"price" db:"price"`
+	ApprovalRequired   bool       `json:"approval_required" db:"approval_required"`
+	PlannedDepature    *time.Time `json:"planned_depature" db:"planned_depature"`
+	Depature           time.Time  `json:"depature" db:"depature"`
+	Arrival            time.Time  `json:"arrival" db:"arrival"`
+	CompletedAt        *time.Time `json:"completed_at" db:"completed_at"`
+	CancelledAt        *time.Time `json:"cancelled_at" db:"cancelled_at"`
+	CancellationReason *string    `json:"cancellation_reason" db:"cancellation_reason"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Cancelled returns true if the trip has been cancelled by the driver.
+func (t *Trip) Cancelled() bool {
+	return t.CancelledAt != nil
+}
+
+// Started returns true if the trip has been started.
+func (t *Trip) Started() bool {
+	return t.Depature.After(time.Unix(0, 0))
+}
+
+// Finished returns true if the trip has been finished.
+func (t *Trip) Finished() bool {
+	return t.Arrival.After(time.Unix(0, 0))
+}
+
+// Completed returns true if the trip has been booked and its arrival has
+// passed.
+func (t *Trip) Completed() bool {
+	return t.RiderID != nil && t.CompletedAt != nil
+}
+
+// TripCancellation is the cancellation of a trip by its driver or of a booking
+// by the rider of a trip.
+type TripCancellation struct {
+	ID        uuid.UUID  `json:"id" db:"id" sql:"type:uuid"`
+	TripID    uuid.UUID  `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Role      RatingRole `json:"role" db:"role"`
+	Reason    string     `json:"reason" db:"reason"`
+	Late      bool       `json:"late" db:"late"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 }
 
 // User represents a user identity.
 type User struct {
-	ID          uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
-	Email       string    `json:"email" db:"email"`
-	Password    string    `json:"-" db:"password_hash"`
-	DisplayName string    `json:"display_name" db:"display_name"`
-	Birthday    time.Time `json:"birthday" db:"birthday"`
-	Avatar      string    `json:"-" db:"avatar"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID            uuid.UUID            `json:"id" db:"id" sql:"type:uuid"`
+	Email         string               `json:"email" db:"email"`
+	Password      string               `json:"-" db:"password_hash"`
+	DisplayName   string               `json:"display_name" db:"display_name"`
+	Birthday      time.Time            `json:"birthday" db:"birthday"`
+	Privacy       PrivacySettings      `json:"privacy" db:"privacy"`
+	Notifications NotificationSettings `json:"notifications" db:"notifications"`
+	Role          UserRole             `json:"role" db:"role"`
+	ReferredBy    *uuid.UUID           `json:"-" db:"referred_by" sql:"type:uuid"`
+	CreatedAt     time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// AvatarKey returns the key the avatar image of the user identified by the ID
+// is stored under in the blob store.
+func AvatarKey(userID uuid.UUID) string {
+	return path.Join("users", userID.String(), "avatar.png")
+}
+
+// UserRole is the role of a user which grants additional permissions.
+type UserRole string
+
+// The roles of a user.
+const (
+	UserRoleUser      UserRole = "user"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleAdmin     UserRole = "admin"
+)
+
+// UserStatistics are figures about the activity of a user. The rating count and
+// average span the ratings of all roles. The cancellation rate is the share of
+// cancellations in completed and cancelled trips of the user.
+type UserStatistics struct {
+	RatingCount           int              `json:"rating_count" db:"rating_count"`
+	RatingAverage         float64          `json:"rating_average" db:"rating_average"`
+	Ratings               []*RatingSummary `json:"ratings" db:"-"`
+	TripCount             int              `json:"trip_count" db:"trip_count"`
+	CancellationCount     int              `json:"cancellation_count" db:"cancellation_count"`
+	LateCancellationCount int              `json:"late_cancellation_count" db:"late_cancellation_count"`
+	CancellationRate      float64          `json:"cancellation_rate" db:"-"`
+}
+
+// TripSort is the order trips are listed in.
+type TripSort string
+
+// The orders trips can be listed in.
+const (
+	TripSortUpdated     TripSort = "updated"
+	TripSortDriverScore TripSort = "driver_score"
+)
+
+// TripFilter narrows down and orders a listing of trips. Trips are only listed
+// if driven with a vehicle of the organization, if given.
+type TripFilter struct {
+	Sort           TripSort
+	OrganizationID *uuid.UUID
 }
 
-// Vehicle is a vehicle belonging to a user.
+// Wallet is the balance of a user in cents. Held is the balance reserved for
+// booked trips, which is released on cancellation or settled to the driver on
+// completion.
+type Wallet struct {
+	UserID  uuid.UUID `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Balance int64     `json:"balance" db:"balance"`
+	Held    int64     `json:"held" db:"held"`
+}
+
+// Vehicle is a vehicle belonging to a user. Vehicles of an organization have
+// been registered by one of its members but can be driven by all of them. The
+// dimensions of the loading area are given in cm, its volume in cubic metres
+// and the maximum payload in kg.
 type Vehicle struct {
-	ID                uuid.UUID `json:"id" db:"id" sql:"type:uuid"`
-	UserID            uuid.UUID `json:"user_id" db:"user_id" sql:"type:uuid"`
-	Brand             string    `json:"brand" db:"brand"`
-	Model             string    `json:"model" db:"model"`
-	Passengers        uint8     `json:"passengers" db:"passengers"`
-	LoadingAreaLength float32   `json:"loading_area_length" db:"loading_area_length"`
-	LoadingAreaWidth  float32   `json:"loading_area_width" db:"loading_area_width"`
-	CreatedAt         time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+	ID                uuid.UUID        `json:"id" db:"id" sql:"type:uuid"`
+	UserID            uuid.UUID        `json:"user_id" db:"user_id" sql:"type:uuid"`
+	OrganizationID    *uuid.UUID       `json:"organization_id" db:"organization_id" sql:"type:uuid"`
+	LicensePlate      string           `json:"license_plate" db:"license_plate"`
+	Type              VehicleType      `json:"type" db:"type"`
+	Brand             string           `json:"brand" db:"brand"`
+	Model             string           `json:"model" db:"model"`
+	Color             string           `json:"color" db:"color"`
+	Passengers        uint8            `json:"passengers" db:"passengers"`
+	MaxPayload        float32          `json:"max_payload" db:"max_payload"`
+	LoadingAreaLength float32          `json:"loading_area_length" db:"loading_area_length"`
+	LoadingAreaWidth  float32          `json:"loading_area_width" db:"loading_area_width"`
+	LoadingAreaHeight float32          `json:"loading_area_height" db:"loading_area_height"`
+	LoadingVolume     float32          `json:"loading_volume" db:"loading_volume"`
+	Amenities         VehicleAmenities `json:"amenities" db:"amenities"`
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// VehicleType is the type of a vehicle.
+type VehicleType string
+
+// All available vehicle types.
+const (
+	VehicleTypeCar     VehicleType = "car"
+	VehicleTypeVan     VehicleType = "van"
+	VehicleTypeTruck   VehicleType = "truck"
+	VehicleTypeTrailer VehicleType = "trailer"
+)
+
+// PriceFactor returns the factor the per-km cost of a trip is scaled by for
+// vehicles of the type.
+func (t VehicleType) PriceFactor() float64 {
+	switch t {
+	case VehicleTypeVan, VehicleTypeTrailer:
+		return 1.5
+	case VehicleTypeTruck:
+		return 2.5
+	default:
+		return 1
+	}
+}
+
+// VehicleFile is a photo or a document of a vehicle. Its content is kept in
+// the blob store.
+type VehicleFile struct {
+	ID          uuid.UUID       `json:"id" db:"id" sql:"type:uuid"`
+	VehicleID   uuid.UUID       `json:"vehicle_id" db:"vehicle_id" sql:"type:uuid"`
+	Kind        VehicleFileKind `json:"kind" db:"kind"`
+	Name        string          `json:"name" db:"name"`
+	ContentType string          `json:"content_type" db:"content_type"`
+	Size        int64           `json:"size" db:"size"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Key returns the key the content of the file is stored under in the blob
+// store.
+func (f *VehicleFile) Key() string {
+	return path.Join("vehicles", f.VehicleID.String(), f.ID.String())
+}
+
+// VehicleFileKind is the kind of a file of a vehicle.
+type VehicleFileKind string
+
+// All available kinds of vehicle files.
+const (
+	VehicleFileKindPhoto    VehicleFileKind = "photo"
+	VehicleFileKindDocument VehicleFileKind = "document"
+)
+
+// VehicleAmenity is an equipment of a vehicle which helps with the cargo.
+type VehicleAmenity string
+
+// All available vehicle amenities.
+const (
+	VehicleAmenityAirConditioning VehicleAmenity = "air_conditioning"
+	VehicleAmenityCooling         VehicleAmenity = "cooling"
+	VehicleAmenityHandTruck       VehicleAmenity = "hand_truck"
+	VehicleAmenityMovingBlankets  VehicleAmenity = "moving_blankets"
+	VehicleAmenityRamp            VehicleAmenity = "ramp"
+	VehicleAmenityTailLift        VehicleAmenity = "tail_lift"
+	VehicleAmenityTieDowns        VehicleAmenity = "tie_downs"
+)
+
+// VehicleAmenities are the amenities of a vehicle.
+type VehicleAmenities []VehicleAmenity
+
+// Scan implements the sql.Scanner interface.
+func (a *VehicleAmenities) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, a)
+	case string:
+		return json.Unmarshal([]byte(src), a)
+	}
+	return errors.New("incompatible type for vehicle amenities")
+}
+
+// Value implements the driver.Valuer interface.
+func (a VehicleAmenities) Value() (driver.Value, error) {
+	if a == nil {
+		a = VehicleAmenities{}
+	}
+	return json.Marshal(a)
+}
+
+// Webhook is an endpoint of a user which receives the events addressed to the
+// user it is subscribed to. Payloads are signed with the secret of the webhook.
+// Webhooks of an organization instead receive the events addressed to the
+// drivers of trips driven with the vehicles of the organization.
+type Webhook struct {
+	ID             uuid.UUID  `json:"id" db:"id" sql:"type:uuid"`
+	UserID         *uuid.UUID `json:"user_id" db:"user_id" sql:"type:uuid"`
+	OrganizationID *uuid.UUID `json:"organization_id" db:"organization_id" sql:"type:uuid"`
+	URL            string     `json:"url" db:"url"`
+	Secret         string     `json:"-" db:"secret"`
+	Events         EventTypes `json:"events" db:"events"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookDelivery is the delivery of an event to a webhook. Failed deliveries
+// are retried until they succeed or the maximum number of attempts is reached.
+type WebhookDelivery struct {
+	ID            uuid.UUID             `json:"id" db:"id" sql:"type:uuid"`
+	WebhookID     uuid.UUID             `json:"webhook_id" db:"webhook_id" sql:"type:uuid"`
+	EventID       uuid.UUID             `json:"event_id" db:"event_id" sql:"type:uuid"`
+	EventType     EventType             `json:"event_type" db:"event_type"`
+	Payload       string                `json:"payload" db:"payload"`
+	Redelivery    bool                  `json:"redelivery" db:"redelivery"`
+	Status        WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts      int                   `json:"attempts" db:"attempts"`
+	ResponseCode  *int                  `json:"response_code,omitempty" db:"response_code"`
+	Error         *string               `json:"error,omitempty" db:"error"`
+	NextAttemptAt *time.Time            `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	DeliveredAt   *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt     time.Time             `json:"created_at" db:"created_at"`
+}
+
+// WaitlistEntry is the place of a rider in the waitlist of a fully booked
+// trip. When the trip becomes available again, the first waiting rider is
+// offered it and holds it until the offer expires.
+type WaitlistEntry struct {
+	ID        uuid.UUID      `json:"id" db:"id" sql:"type:uuid"`
+	TripID    uuid.UUID      `json:"trip_id" db:"trip_id" sql:"type:uuid"`
+	UserID    uuid.UUID      `json:"user_id" db:"user_id" sql:"type:uuid"`
+	Status    WaitlistStatus `json:"status" db:"status"`
+	HoldUntil *time.Time     `json:"hold_until,omitempty" db:"hold_until"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// WaitlistStatus is the status of a waitlist entry.
+type WaitlistStatus string
+
+// All available waitlist statuses.
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"
+	WaitlistStatusOffered   WaitlistStatus = "offered"
+	WaitlistStatusConfirmed WaitlistStatus = "confirmed"
+	WaitlistStatusExpired   WaitlistStatus = "expired"
+	WaitlistStatusLeft      WaitlistStatus = "left"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// All available webhook delivery states.
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// BlobStore stores binary content under keys. Keys are slash separated
+// paths.
+type BlobStore interface {
+	// PutBlob stores the content of the given size and type under the key,
+	// replacing any content stored under it before.
+	PutBlob(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// GetBlob returns the content stored under the key. It is up to the
+	// caller to close it.
+	GetBlob(ctx context.Context, key string) (io.ReadCloser, error)
+	// DeleteBlob deletes the content stored under the key. Deleting a key
+	// without content is no error.
+	DeleteBlob(ctx context.Context, key string) error
+}
+
+// BookingRepository provides access to the booking resource.
+type BookingRepository interface {
+	// ListBookings lists all bookings of the trip identified by its unique
+	// ID.
+	ListBookings(ctx context.Context, tripID uuid.UUID) ([]*Booking, error)
+	// GetBooking returns a booking identified by its unique ID.
+	GetBooking(ctx context.Context, id uuid.UUID) (*Booking, error)
+	// CreateBooking creates a new booking. An accepted booking books the trip
+	// for the rider right away, while a pending booking waits for the driver
+	// to decide. Either way, the price of the trip is held on the wallet of
+	// the rider, or ErrInsufficientFunds is returned if it does not cover it.
+	// If the booking carries a promo code, it is redeemed and the discount is
+	// paid from the promotions account instead. ErrPromoCodeNotFound or
+	// ErrPromoCodeNotRedeemable is returned if it can not be redeemed. The
+	// events are written to the outbox along with it.
+	CreateBooking(ctx context.Context, booking *Booking, events ...*Event) error
+	// AcceptBooking accepts the pending booking identified by its unique ID
+	// and books the trip for its rider. Other pending bookings of the trip
+	// are declined and the balance held for them is released. The events,
+	// along with booking declined events for the declined bookings, are
+	// written to the outbox.
+	AcceptBooking(ctx context.Context, id uuid.UUID, events ...*Event) error
+	// DeclineBooking declines the pending booking identified by its unique
+	// ID and releases the balance held for it. The events are written to the
+	// outbox along with it.
+	DeclineBooking(ctx context.Context, id uuid.UUID, events ...*Event) error
+	// ExpireBookings expires bookings still pending at the given time and
+	// releases the balance held for them. Booking expired events for the
+	// riders are written to the outbox along with them.
+	ExpireBookings(ctx context.Context, before time.Time) (int64, error)
+}
+
+// DistanceEstimator estimates distances between locations.
+type DistanceEstimator interface {
+	// EstimateDistance returns the estimated distance by road between the
+	// start and the destination in km. It returns ErrLocationNotFound if one
+	// of the locations is unknown.
+	EstimateDistance(ctx context.Context, start, destination string) (float64, error)
+}
+
+// EventBroker distributes events to the users they are addressed to.
+type EventBroker interface {
+	// Publish publishes events to the users they are addressed to. Events
+	// are not buffered for users which are not subscribed.
+	Publish(ctx context.Context, events ...*Event) error
+	// Subscribe subscribes to the events addressed to the user identified by
+	// his unique ID. The returned channel is closed once the context is done.
+	Subscribe(ctx context.Context, userID uuid.UUID) (<-chan *Event, error)
+}
+
+// JobRepository provides access to the background jobs and their leases.
+type JobRepository interface {
+	// ListJobs lists all jobs.
+	ListJobs(context.Context) ([]*Job, error)
+	// RegisterJob creates the job or updates its schedule. The next run of an
+	// existing job is only replaced, if the schedule changed.
+	RegisterJob(context.Context, *Job) error
+	// AcquireJob takes the lease of the job identified by its unique name for
+	// the given owner and duration. It returns false, if the job is not due
+	// or its lease is held by another owner.
+	AcquireJob(ctx context.Context, name, owner string, lease time.Duration) (bool, error)
+	// ReleaseJob releases the lease of the job identified by its unique name
+	// held by the given owner and records the result of the run.
+	ReleaseJob(ctx context.Context, name, owner string, next time.Time, runErr error) error
+}
+
+// Mailer sends E-Mails.
+type Mailer interface {
+	// SendMail sends a plain text E-Mail to the given address.
+	SendMail(ctx context.Context, to, subject, body string) error
+}
+
+// MessageRepository provides access to the message resource.
+type MessageRepository interface {
+	// ListMessages lists all messages of the conversation of the trip
+	// identified by its unique ID.
+	ListMessages(ctx context.Context, tripID uuid.UUID) ([]*Message, error)
+	// CreateMessage creates a new message. The events are written to the
+	// outbox along with it.
+	CreateMessage(ctx context.Context, message *Message, events ...*Event) error
+	// MarkRead marks all messages of the conversation of the trip identified
+	// by its unique ID as read by the user identified by his unique ID.
+	MarkRead(ctx context.Context, tripID, userID uuid.UUID) error
+	// ListConversations lists the conversations of all trips the user
+	// identified by his unique ID participates in, most recent first.
+	ListConversations(ctx context.Context, userID uuid.UUID) ([]*Conversation, error)
+}
+
+// NotificationRepository provides access to the notification resource.
+type NotificationRepository interface {
+	// ListNotifications lists all notifications of the user identified by
+	// his unique ID, most recent first.
+	ListNotifications(ctx context.Context, userID uuid.UUID) ([]*Notification, error)
+	// CreateNotification creates a new notification. A notification about an
+	// event that has already been notified about is not created again and
+	// its ID is left unset.
+	CreateNotification(context.Context, *Notification) error
+	// MarkNotificationRead marks the notification identified by its unique ID
+	// which belongs to the user identified by his unique ID as read.
+	MarkNotificationRead(ctx context.Context, id, userID uuid.UUID) error
+	// MarkAllNotificationsRead marks all notifications of the user identified
+	// by his unique ID as read.
+	MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error
+}
+
+// Notifier notifies users about events through the channels they have
+// enabled.
+type Notifier interface {
+	// Notify notifies the users the events are addressed to. Events users are
+	// not notified about are ignored.
+	Notify(ctx context.Context, events ...*Event) error
+	// NotifyEmailVerification sends the verification token of a pending
+	// E-Mail address change to the new address.
+	NotifyEmailVerification(ctx context.Context, user *User, verification *EmailVerification) error
+}
+
+// OrganizationRepository provides access to the organization resource, its
+// members and its fleet.
+type OrganizationRepository interface {
+	// ListOrganizations lists the organizations the user identified by its
+	// unique ID is a member of.
+	ListOrganizations(ctx context.Context, userID uuid.UUID) ([]*Organization, error)
+	// GetOrganization returns an organization identified by its unique ID.
+	GetOrganization(ctx context.Context, id uuid.UUID) (*Organization, error)
+	// CreateOrganization creates a new organization with the user identified
+	// by its unique ID as its owner.
+	CreateOrganization(ctx context.Context, organization *Organization, ownerID uuid.UUID) error
+	// UpdateOrganization updates a given organization.
+	UpdateOrganization(context.Context, *Organization) error
+	// DeleteOrganization deletes an organization identified by its unique ID.
+	// Its vehicles are handed over to its longest standing owner.
+	DeleteOrganization(ctx context.Context, id uuid.UUID) error
+	// ListMembers lists the members of the organization identified by its
+	// unique ID.
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*OrganizationMember, error)
+	// GetMember returns the membership of the user in the organization, both
+	// identified by their unique ID.
+	GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*OrganizationMember, error)
+	// SaveMember adds a member to an organization or changes the role of an
+	// existing member. An organization must keep at least one owner.
+	SaveMember(context.Context, *OrganizationMember) error
+	// RemoveMember removes the user from the organization, both identified by
+	// their unique ID. An organization must keep at least one owner, who takes
+	// over the vehicles the member registered for it.
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+	// ListVehicles lists the vehicles of the organization identified by its
+	// unique ID.
+	ListVehicles(ctx context.Context, organizationID uuid.UUID) ([]*Vehicle, error)
+	// ListTrips lists the trips driven with the vehicles of the organization
+	// identified by its unique ID, including cancelled ones.
+	ListTrips(ctx context.Context, organizationID uuid.UUID) ([]*Trip, error)
+}
+
+// OutboxRepository provides access to the events written to the outbox.
+type OutboxRepository interface {
+	// ClaimPendingEvents claims up to limit unpublished events which are due
+	// for the given duration. Claimed events are not returned by other calls
+	// until the duration has passed.
+	ClaimPendingEvents(ctx context.Context, limit int, lease time.Duration) ([]*OutboxEvent, error)
+	// ListEventSubscribers lists the subscribers an event identified by its
+	// unique ID has been delivered to.
+	ListEventSubscribers(ctx context.Context, eventID uuid.UUID) ([]string, error)
+	// MarkEventDelivered records the delivery of an event identified by its
+	// unique ID to a subscriber.
+	MarkEventDelivered(ctx context.Context, eventID uuid.UUID, subscriber string) error
+	// MarkEventPublished marks an event identified by its unique ID as
+	// delivered to all subscribers.
+	MarkEventPublished(ctx context.Context, eventID uuid.UUID) error
+	// RetryEvent records a failed attempt to publish an event identified by
+	// its unique ID along with its error and schedules the next one.
+	RetryEvent(ctx context.Context, eventID uuid.UUID, next time.Time, reason string) error
+	// MarkEventDead records the last failed attempt to publish an event
+	// identified by its unique ID along with its error. Dead events are never
+	// claimed again.
+	MarkEventDead(ctx context.Context, eventID uuid.UUID, reason string) error
+	// PrunePublishedEvents deletes events which have been published before
+	// the given time.
+	PrunePublishedEvents(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PaymentProvider moves money between the platform and the bank accounts or
+// cards of users.
+type PaymentProvider interface {
+	// CreateIntent creates an intent to move the amount of the payment.
+	// Top-ups have to be authorized and are captured afterwards, payouts are
+	// paid out without further ado.
+	CreateIntent(context.Context, *Payment) (*PaymentIntent, error)
+	// Capture captures the authorized intent identified by its reference.
+	// Capturing a captured intent is a no-op.
+	Capture(ctx context.Context, reference string) error
+	// Refund refunds the amount of the captured intent identified by its
+	// reference.
+	Refund(ctx context.Context, reference string, amount int64) error
+	// VerifyWebhook verifies the signature of a webhook delivered by the
+	// provider and returns the event it carries. It returns
+	// ErrInvalidPaymentWebhook if the webhook can not be verified.
+	VerifyWebhook(payload []byte, header http.Header) (*PaymentEvent, error)
+}
+
+// PaymentRepository provides access to the payment resource. Payments move
+// money in and out of wallets, so changes are posted to the wallet ledger
+// along with them.
+type PaymentRepository interface {
+	// ListPayments lists all payments of the user identified by his unique
+	// ID, most recent first.
+	ListPayments(ctx context.Context, userID uuid.UUID) ([]*Payment, error)
+	// GetPayment returns a payment identified by its unique ID.
+	GetPayment(ctx context.Context, id uuid.UUID) (*Payment, error)
+	// GetPaymentByReference returns a payment identified by the reference of
+	// its intent.
+	GetPaymentByReference(ctx context.Context, reference string) (*Payment, error)
+	// CreatePayment creates a new pending payment. The amount of a payout is
+	// withdrawn from the wallet of the user, or ErrInsufficientFunds is
+	// returned if it does not cover it.
+	CreatePayment(context.Context, *Payment) error
+	// SetPaymentIntent sets the intent of the payment identified by its
+	// unique ID.
+	SetPaymentIntent(ctx context.Context, id uuid.UUID, intent *PaymentIntent) error
+	// FailPayment marks the pending payment identified by its unique ID as
+	// failed. The amount of a payout is credited back to the wallet.
+	FailPayment(ctx context.Context, id uuid.UUID) error
+	// RefundPayment withdraws the amount to refund from the wallet of the
+	// user of the succeeded top-up identified by its unique ID.
+	RefundPayment(ctx context.Context, id uuid.UUID, amount int64) error
+	// FailRefund credits the amount of a failed refund of the top-up
+	// identified by its unique ID back to the wallet.
+	FailRefund(ctx context.Context, id uuid.UUID, amount int64) error
+	// ProcessPaymentEvent reconciles the payment the event refers to and the
+	// wallet ledger with the event. It returns ErrPaymentEventProcessed if an
+	// event with the same idempotency key has been processed before.
+	ProcessPaymentEvent(context.Context, *PaymentEvent) error
+}
+
+// PromotionRepository provides access to promo codes and referral codes.
+type PromotionRepository interface {
+	// ListPromoCodes lists all promo codes.
+	ListPromoCodes(ctx context.Context) ([]*PromoCode, error)
+	// GetPromoCode returns a promo code identified by its unique ID.
+	GetPromoCode(ctx context.Context, id uuid.UUID) (*PromoCode, error)
+	// CreatePromoCode creates a new promo code. Codes are unique regardless
+	// of case.
+	CreatePromoCode(ctx context.Context, code *PromoCode) error
+	// UpdatePromoCode updates a given promo code.
+	UpdatePromoCode(ctx context.Context, code *PromoCode) error
+	// ListPromoRedemptions lists the redemptions of the promo code identified
+	// by its unique ID.
+	ListPromoRedemptions(ctx context.Context, promoCodeID uuid.UUID) ([]*PromoRedemption, error)
+	// GetReferralCode returns the referral code of the user identified by
+	// its unique ID. It is created if the user does not have one yet.
+	GetReferralCode(ctx context.Context, userID uuid.UUID) (*ReferralCode, error)
+	// GetReferrer returns the unique ID of the user the referral code belongs
+	// to.
+	GetReferrer(ctx context.Context, code string) (uuid.UUID, error)
+}
+
+// RatingRepository provides access to the rating resource and its moderation.
+type RatingRepository interface {
+	// GetRating returns a rating identified by its unique ID.
+	GetRating(ctx context.Context, id uuid.UUID) (*Rating, error)
+	// ReplyToRating stores the reply of the rated user to a rating identified
+	// by its unique ID. A rating can only be replied to once.
+	ReplyToRating(ctx context.Context, id uuid.UUID, reply string) error
+	// CreateReport creates a new report of a rating.
+	CreateReport(context.Context, *RatingReport) error
+	// ListReportedRatings lists all ratings with open reports.
+	ListReportedRatings(context.Context) ([]*Rating, error)
+	// ListReports lists all reports of a rating identified by its unique ID.
+	ListReports(ctx context.Context, ratingID uuid.UUID) ([]*RatingReport, error)
+	// ModerateRating applies the moderation decision to the rating identified
+	// by its unique ID and resolves all open reports of it. The rating
+	// summary of the rated user is updated accordingly.
+	ModerateRating(ctx context.Context, id, moderatorID uuid.UUID, action ModerationAction) error
+}
+
+// ReceiptRepository provides access to the receipts of completed bookings.
+type ReceiptRepository interface {
+	// IssueReceipt returns the receipt of the booking identified by its
+	// unique ID. If it has not been issued before, it is issued with the next
+	// number of the current year and the VAT rate given in basis points. It
+	// returns ErrReceiptNotAvailable if the booking has not been completed.
+	IssueReceipt(ctx context.Context, bookingID uuid.UUID, vatRate int64) (*Receipt, error)
 }
 
 // TokenBlacklist provides methods for blacklisting authentication tokens.
@@ -81,20 +1314,56 @@ type TokenBlacklist interface {
 
 // TripRepository provides access to the trip resource.
 type TripRepository interface {
-	// ListTrips lists all trips.
-	ListTrips(context.Context) ([]*Trip, error)
+	// ListTrips lists all trips matching the filter.
+	ListTrips(context.Context, *TripFilter) ([]*Trip, error)
 	// GetTrip returns a trip identified by its unique ID.
 	GetTrip(ctx context.Context, id uuid.UUID) (*Trip, error)
-	// CreateTrip creates a new trip.
-	CreateTrip(context.Context, *Trip) error
-	// UpdateTrip updates a given trip.
-	UpdateTrip(context.Context, *Trip) error
-	// DeleteTrip deletes a trip identified by his unique ID.
+	// CreateTrip creates a new trip. Its ID is generated unless set. Trips
+	// are created without a rider, who can only book the trip. The events
+	// are written to the outbox along with it.
+	CreateTrip(ctx context.Context, trip *Trip, events ...*Event) error
+	// UpdateTrip updates a given trip, but neither its rider nor its
+	// depature and arrival. The price and vehicle of booked trips can not
+	// be changed. The events are written to the outbox along with it.
+	UpdateTrip(ctx context.Context, trip *Trip, events ...*Event) error
+	// StartTrip records the depature of the trip identified by its unique
+	// ID at the given time. The events are written to the outbox along with
+	// it.
+	StartTrip(ctx context.Context, id uuid.UUID, at time.Time, events ...*Event) error
+	// FinishTrip records the arrival of the trip identified by its unique ID
+	// at the given time, which must be after its depature. The events are
+	// written to the outbox along with it.
+	FinishTrip(ctx context.Context, id uuid.UUID, at time.Time, events ...*Event) error
+	// DeleteTrip deletes a trip identified by his unique ID and releases the
+	// balance held for its pending bookings.
 	DeleteTrip(ctx context.Context, id uuid.UUID) error
-	// GetRating gets a rating for a trip.
-	GetRating(ctx context.Context, id uuid.UUID) (*Rating, error)
-	// CreateRating creates a new rating fro a trip.
-	CreateRating(context.Context, *Rating) error
+	// ListRatings lists the ratings of the driver and the rider of the trip
+	// identified by its unique ID.
+	ListRatings(ctx context.Context, tripID uuid.UUID) ([]*Rating, error)
+	// CreateRating creates a new rating fro a trip. The rating summary of the
+	// rated user is updated accordingly and the events are written to the
+	// outbox along with it.
+	CreateRating(ctx context.Context, rating *Rating, events ...*Event) error
+	// CancelTrip cancels the trip as its driver or the booking of the trip as
+	// its rider, depending on the role of the cancellation, releases the
+	// balance held for the affected bookings and records the cancellation.
+	// The events are written to the outbox along with it.
+	CancelTrip(ctx context.Context, cancellation *TripCancellation, events ...*Event) error
+	// RemindTrips marks trips departing between now and the given time, which
+	// have not been reminded of yet, as reminded. Trip reminder events for the driver
+	// and the rider are written to the outbox along with them.
+	RemindTrips(ctx context.Context, before time.Time) (int64, error)
+	// CompleteTrips marks trips which arrived before the given time as
+	// completed and settles the balance held for them to their drivers,
+	// withholding the platform fee. Users completing their first paid trip
+	// and the users who referred them are credited according to the referral
+	// policy, unless their accounts are linked. Trip completed events for the driver and the rider are written
+	// to the outbox along with them.
+	CompleteTrips(ctx context.Context, before time.Time, fees FeePolicy, referrals ReferralPolicy) (int64, error)
+	// ListRoutePrices lists the prices of trips from the start to the
+	// destination with vehicles of the given type, which have been created
+	// since the given time and were not cancelled, most recent first.
+	ListRoutePrices(ctx context.Context, start, destination string, typ VehicleType, since time.Time) ([]float32, error)
 }
 
 // UserRepository provides access to the user resource.
@@ -105,11 +1374,18 @@ type UserRepository interface {
 	GetUser(ctx context.Context, userID uuid.UUID) (*User, error)
 	// GetUserByEmail returns a user identified by his E-Mail address.
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	// GetUserStatistics returns the statistics of the user identified by his
+	// unique ID.
+	GetUserStatistics(ctx context.Context, userID uuid.UUID) (*UserStatistics, error)
 	// CreateUser creates a new user.
 	CreateUser(context.Context, *User) error
 	// UpdateUser updates a given user.
 	UpdateUser(context.Context, *User) error
-	// DeleteUser deletes a user identified by his unique ID.
+	// DeleteUser deletes a user identified by his unique ID. Completed trips
+	// and ratings of the user are kept, but anonymized. The balance held for
+	// other trips is released. Users with a wallet balance can not be
+	// deleted. Neither can the last owner of an organization, whose other
+	// owners take over the vehicles the user registered for it.
 	DeleteUser(ctx context.Context, userID uuid.UUID) error
 	// ListTokens lists all authentication tokens for the user identified by his
 	// unique ID.
@@ -120,22 +1396,131 @@ type UserRepository interface {
 	// DeleteToken deletes an users authentication token. Token and user are
 	// identified by their unique IDs.
 	DeleteToken(ctx context.Context, userID, tokenID uuid.UUID) error
+	// DeleteExpiredTokens deletes all authentication tokens which expired
+	// before the given time.
+	DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error)
+	// CreateEmailVerification creates a pending E-Mail address change for the
+	// user identified by the verifications unique user ID. A previously
+	// pending change of the same user is replaced.
+	CreateEmailVerification(ctx context.Context, verification *EmailVerification) error
+	// VerifyEmail applies the pending E-Mail address change identified by its
+	// unique ID and returns the updated user.
+	VerifyEmail(ctx context.Context, id uuid.UUID) (*User, error)
 	// ListRatings lists all ratings for the user identified by his unique ID.
 	ListRatings(ctx context.Context, userID uuid.UUID) ([]*Rating, error)
-	// ListVehicles lists all vehicles for the user identified by his unique ID.
+	// ListRatingSummaries lists the rating summaries of all roles for the user
+	// identified by his unique ID.
+	ListRatingSummaries(ctx context.Context, userID uuid.UUID) ([]*RatingSummary, error)
+	// ListAuthoredRatings lists all ratings given by the user identified by
+	// his unique ID.
+	ListAuthoredRatings(ctx context.Context, userID uuid.UUID) ([]*Rating, error)
+	// ListTrips lists all trips offered by the user identified by his unique
+	// ID.
+	ListTrips(ctx context.Context, userID uuid.UUID) ([]*Trip, error)
+	// ListRides lists all trips booked by the user identified by his unique
+	// ID.
+	ListRides(ctx context.Context, userID uuid.UUID) ([]*Trip, error)
+	// ListVehicles lists all vehicles for the user identified by his unique ID,
+	// except those registered for an organization.
 	ListVehicles(ctx context.Context, userID uuid.UUID) ([]*Vehicle, error)
 }
 
 // VehicleRepository provides access to the vehicle resource.
 type VehicleRepository interface {
-	// ListVehicles lists all vehicles.
-	ListVehicles(context.Context) ([]*Vehicle, error)
+	// ListVehicles lists all vehicles of the organization identified by its
+	// unique ID or, if it is nil, all vehicles not owned by an organization.
+	ListVehicles(ctx context.Context, organizationID *uuid.UUID) ([]*Vehicle, error)
 	// GetVehicle returns a vehicle identified by its unique ID.
 	GetVehicle(ctx context.Context, id uuid.UUID) (*Vehicle, error)
-	// CreateVehicle creates a new vehicle.
+	// CreateVehicle creates a new vehicle. Its ID is generated.
 	CreateVehicle(context.Context, *Vehicle) error
 	// UpdateVehicle updates a given vehicle.
 	UpdateVehicle(context.Context, *Vehicle) error
 	// DeleteVehicle deletes a vehicle identified by his unique ID.
 	DeleteVehicle(ctx context.Context, id uuid.UUID) error
+	// ListFiles lists the files of the given kind of the vehicle identified
+	// by its unique ID.
+	ListFiles(ctx context.Context, vehicleID uuid.UUID, kind VehicleFileKind) ([]*VehicleFile, error)
+	// GetFile returns a vehicle file identified by its unique ID.
+	GetFile(ctx context.Context, id uuid.UUID) (*VehicleFile, error)
+	// CreateFile creates a new vehicle file. Its ID is generated unless set.
+	CreateFile(context.Context, *VehicleFile) error
+	// DeleteFile deletes a vehicle file identified by its unique ID.
+	DeleteFile(ctx context.Context, id uuid.UUID) error
+}
+
+// WaitlistRepository provides access to the waitlists of trips.
+type WaitlistRepository interface {
+	// ListWaitlist lists the waiting and offered entries of the waitlist of
+	// the trip identified by its unique ID, in order.
+	ListWaitlist(ctx context.Context, tripID uuid.UUID) ([]*WaitlistEntry, error)
+	// JoinWaitlist appends a new entry to the waitlist of a trip, which must
+	// be fully booked or have riders waiting for it already.
+	JoinWaitlist(context.Context, *WaitlistEntry) error
+	// LeaveWaitlist removes the rider identified by his unique ID from the
+	// waitlist of the trip identified by its unique ID.
+	LeaveWaitlist(ctx context.Context, tripID, userID uuid.UUID) error
+	// ConfirmWaitlistOffer books the trip identified by its unique ID for the
+	// rider identified by his unique ID, who must hold an offer for it, and
+	// holds its price on the wallet of the rider. The events are written to
+	// the outbox along with it.
+	ConfirmWaitlistOffer(ctx context.Context, tripID, userID uuid.UUID, events ...*Event) error
+	// AdvanceWaitlists expires offers not confirmed at the given time and
+	// offers available trips to the first waiting rider, who holds it until
+	// holdUntil or the planned departure, whichever comes first. Waitlist offered and
+	// offer expired events are written to the outbox along with them.
+	AdvanceWaitlists(ctx context.Context, now, holdUntil time.Time) (int64, error)
+}
+
+// WalletRepository provides access to the wallets of users backed by the
+// wallet ledger.
+type WalletRepository interface {
+	// GetWallet returns the wallet of the user identified by his unique ID.
+	GetWallet(ctx context.Context, userID uuid.UUID) (*Wallet, error)
+	// ListPostings lists the postings to the accounts of the user identified
+	// by his unique ID, most recent first.
+	ListPostings(ctx context.Context, userID uuid.UUID) ([]*LedgerPosting, error)
+	// Deposit credits the amount in cents to the wallet of the user
+	// identified by his unique ID.
+	Deposit(ctx context.Context, userID uuid.UUID, amount int64) error
+}
+
+// WebhookDispatcher delivers events to the webhooks subscribed to them.
+type WebhookDispatcher interface {
+	// Dispatch schedules the delivery of the events to all webhooks of the
+	// users the events are addressed to which are subscribed to them.
+	Dispatch(ctx context.Context, events ...*Event) error
+}
+
+// WebhookRepository provides access to the webhook resource and its
+// deliveries.
+type WebhookRepository interface {
+	// ListWebhooks lists all webhooks of the user identified by his unique
+	// ID.
+	ListWebhooks(ctx context.Context, userID uuid.UUID) ([]*Webhook, error)
+	// ListOrganizationWebhooks lists all webhooks of the organization
+	// identified by its unique ID.
+	ListOrganizationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]*Webhook, error)
+	// GetWebhook returns a webhook identified by its unique ID.
+	GetWebhook(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	// CreateWebhook creates a new webhook.
+	CreateWebhook(context.Context, *Webhook) error
+	// DeleteWebhook deletes a webhook identified by its unique ID.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	// ListDeliveries lists all deliveries of the webhook identified by its
+	// unique ID, most recent first.
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID) ([]*WebhookDelivery, error)
+	// GetDelivery returns a webhook delivery identified by its unique ID.
+	GetDelivery(ctx context.Context, id uuid.UUID) (*WebhookDelivery, error)
+	// CreateDelivery creates a new pending webhook delivery which is due
+	// immediately. Unless it is a redelivery, only one delivery is created
+	// per webhook and event and the ID of a duplicate is left unset.
+	CreateDelivery(context.Context, *WebhookDelivery) error
+	// ClaimDueDeliveries claims up to limit pending deliveries which are due
+	// for the given duration. Claimed deliveries are not returned by other
+	// calls until the duration has passed.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error)
+	// UpdateDelivery updates the status, attempts and result of a webhook
+	// delivery.
+	UpdateDelivery(context.Context, *WebhookDelivery) error
 }
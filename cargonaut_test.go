@@ -0,0 +1,49 @@
+package cargonaut_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut"
+)
+
+func TestCancellationPolicyLate(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	planned := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+	policy := CancellationPolicy{FreeWindow: 24 * time.Hour}
+
+	tests := []struct {
+		name string
+		trip Trip
+		late bool
+	}{
+		{"not planned", Trip{}, false},
+		{"before window", Trip{PlannedDepature: planned(48 * time.Hour)}, false},
+		{"within window", Trip{PlannedDepature: planned(time.Hour)}, true},
+		{"planned depature passed", Trip{PlannedDepature: planned(-time.Hour)}, true},
+		{"started", Trip{PlannedDepature: planned(48 * time.Hour), Depature: now}, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.late, policy.Late(&tt.trip, now), tt.name)
+	}
+}
+
+func TestOrganizationRoleCanDispatch(t *testing.T) {
+	tests := []struct {
+		role     OrganizationRole
+		dispatch bool
+	}{
+		{OrganizationRoleOwner, true},
+		{OrganizationRoleDispatcher, true},
+		{OrganizationRoleDriver, false},
+		{OrganizationRole(""), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.dispatch, tt.role.CanDispatch(), string(tt.role))
+	}
+}
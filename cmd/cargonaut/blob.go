@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/blob"
+)
+
+// blobConfig configures the blob store, which is used by the migrate and serve
+// commands.
+type blobConfig struct {
+	BlobStore   string
+	BlobDir     string
+	S3Endpoint  string
+	S3Bucket    string
+	S3Region    string
+	S3AccessKey string
+	S3SecretKey string
+}
+
+// registerBlobFlags registers the flags of the blob store configuration with
+// the flag set.
+func registerBlobFlags(fs *flag.FlagSet, cfg *blobConfig) {
+	fs.StringVar(&cfg.BlobStore, "blob-store", "file", "blob store for avatars and uploaded files, either file or s3")
+	fs.StringVar(&cfg.BlobDir, "blob-dir", "blobs", "directory of the file blob store")
+	fs.StringVar(&cfg.S3Endpoint, "s3-endpoint", "", "URL of the S3 compatible object store, e.g. http://localhost:9000 for MinIO")
+	fs.StringVar(&cfg.S3Bucket, "s3-bucket", "cargonaut", "bucket of the S3 blob store")
+	fs.StringVar(&cfg.S3Region, "s3-region", "us-east-1", "region of the S3 blob store")
+	fs.StringVar(&cfg.S3AccessKey, "s3-access-key", "", "access key of the S3 blob store")
+	fs.StringVar(&cfg.S3SecretKey, "s3-secret-key", "", "secret key of the S3 blob store")
+}
+
+// newBlobStore creates the configured blob store.
+func newBlobStore(cfg *blobConfig) (cargonaut.BlobStore, error) {
+	switch cfg.BlobStore {
+	case "file":
+		return blob.NewFileStore(cfg.BlobDir)
+	case "s3":
+		return blob.NewS3Store(cfg.S3Endpoint, cfg.S3Bucket, cfg.S3Region, cfg.S3AccessKey, cfg.S3SecretKey)
+	default:
+		return nil, fmt.Errorf("unknown blob store %q", cfg.BlobStore)
+	}
+}
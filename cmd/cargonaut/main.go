@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/peterbourgon/ff/v2"
 	"github.com/peterbourgon/ff/v2/ffcli"
@@ -92,11 +93,30 @@ tank management service.
 	}
 
 	migrate.FlagSet.StringVar(&migrateCfg.PostgresURL, "postgres-url", "", "URL of the Postgres instance")
+	registerBlobFlags(migrate.FlagSet, &migrateCfg.blobConfig)
+	registerBlobFlags(serve.FlagSet, &serveCfg.blobConfig)
 	serve.FlagSet.BoolVar(&serveCfg.Automigrate, "automigrate", false, "automatically run database migrations")
+	serve.FlagSet.DurationVar(&serveCfg.BookingDeadline, "booking-deadline", 24*time.Hour, "duration drivers have to decide on booking requests")
+	serve.FlagSet.BoolVar(&serveCfg.Dev, "dev", false, "enable development features like the fake payment provider, never enable in production")
+	serve.FlagSet.DurationVar(&serveCfg.CancellationWindow, "cancellation-window", 24*time.Hour, "cancellations within this duration before departure are late")
+	serve.FlagSet.StringVar(&serveCfg.EventBroker, "event-broker", "redis", "event broker to use, either redis or memory for a single instance")
 	serve.FlagSet.StringVar(&serveCfg.ListenAddress, "listen-address", "", "listen address")
 	serve.FlagSet.StringVar(&serveCfg.PostgresURL, "postgres-url", "", "URL of the Postgres instance")
 	serve.FlagSet.StringVar(&serveCfg.RedisURL, "redis-url", "", "URL of the Redis instance")
 	serve.FlagSet.StringVar(&serveCfg.Secret, "secret", "", "Hex encoded 32 byte secret key for AES-128 GCM and HS256")
+	serve.FlagSet.StringVar(&serveCfg.SMTPAddress, "smtp-address", "", "address of the SMTP server, E-Mails are logged if not set")
+	serve.FlagSet.StringVar(&serveCfg.SMTPUsername, "smtp-username", "", "username for the SMTP server")
+	serve.FlagSet.StringVar(&serveCfg.SMTPPassword, "smtp-password", "", "password for the SMTP server")
+	serve.FlagSet.StringVar(&serveCfg.MailFrom, "mail-from", "Cargonaut <noreply@cargonaut.local>", "sender address of E-Mails")
+	serve.FlagSet.StringVar(&serveCfg.PaymentProvider, "payment-provider", "", "payment provider to use, only fake is available for now and requires -dev")
+	serve.FlagSet.StringVar(&serveCfg.PaymentWebhookSecret, "payment-webhook-secret", "", "Hex encoded secret of at least 32 bytes webhooks of the payment provider are signed with")
+	serve.FlagSet.Int64Var(&serveCfg.PlatformFee, "platform-fee", 1000, "platform fee withheld from trip prices on settlement in basis points")
+	serve.FlagSet.Int64Var(&serveCfg.PriceBase, "price-base", 200, "base price of suggested trip prices in cents")
+	serve.FlagSet.Int64Var(&serveCfg.PricePerKm, "price-per-km", 12, "price per km of suggested trip prices in cents")
+	serve.FlagSet.Int64Var(&serveCfg.PriceSpread, "price-spread", 2000, "spread of suggested trip price ranges in basis points")
+	serve.FlagSet.Int64Var(&serveCfg.ReferralCredit, "referral-credit", 500, "credit for referring and referred users after the first completed trip in cents")
+	serve.FlagSet.Int64Var(&serveCfg.VATRate, "vat-rate", 1900, "VAT rate included in trip prices shown on receipts in basis points")
+	serve.FlagSet.DurationVar(&serveCfg.WaitlistHold, "waitlist-hold", 2*time.Hour, "duration riders on a waitlist hold an offered trip, at the latest until departure")
 
 	if err := root.ParseAndRun(ctx, os.Args[1:]); err != nil && err != flag.ErrHelp {
 		logger.Print(err)
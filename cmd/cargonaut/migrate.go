@@ -11,6 +11,7 @@ import (
 )
 
 type migrateConfig struct {
+	blobConfig
 	PostgresURL string
 }
 
@@ -46,5 +47,19 @@ func migrateCmd(ctx context.Context, args []string, cfg *migrateConfig) error {
 		logger.Printf("%s %d database migrations", logPrefix, n)
 	}
 
+	// Move data which is kept outside of the database, once the schema is up
+	// to date.
+	if direction == migrate.Up {
+		store, err := newBlobStore(&cfg.blobConfig)
+		if err != nil {
+			return fmt.Errorf("create blob store: %w", err)
+		}
+		if n, err := sql.MigrateAvatars(ctx, db, store); err != nil {
+			return fmt.Errorf("migrate avatars: %w", err)
+		} else if n > 0 {
+			logger.Printf("Moved %d avatars to the blob store", n)
+		}
+	}
+
 	return nil
 }
@@ -1,28 +1,58 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	redigo "github.com/gomodule/redigo/redis"
 	"github.com/jmoiron/sqlx"
 	migrate "github.com/rubenv/sql-migrate"
 
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/dispatch"
+	"github.com/my-cargonaut/cargonaut/internal/gazetteer"
 	"github.com/my-cargonaut/cargonaut/internal/handler"
+	"github.com/my-cargonaut/cargonaut/internal/job"
+	"github.com/my-cargonaut/cargonaut/internal/mail"
+	"github.com/my-cargonaut/cargonaut/internal/memory"
+	"github.com/my-cargonaut/cargonaut/internal/notify"
+	"github.com/my-cargonaut/cargonaut/internal/payment"
 	"github.com/my-cargonaut/cargonaut/internal/redis"
+	"github.com/my-cargonaut/cargonaut/internal/relay"
 	"github.com/my-cargonaut/cargonaut/internal/sql"
+	"github.com/my-cargonaut/cargonaut/pkg/cron"
 	"github.com/my-cargonaut/cargonaut/pkg/http"
 )
 
 type serveConfig struct {
-	Automigrate   bool
-	ListenAddress string
-	PostgresURL   string
-	RedisURL      string
-	Secret        string
+	blobConfig
+	Automigrate          bool
+	BookingDeadline      time.Duration
+	CancellationWindow   time.Duration
+	Dev                  bool
+	EventBroker          string
+	ListenAddress        string
+	PostgresURL          string
+	RedisURL             string
+	Secret               string
+	SMTPAddress          string
+	SMTPUsername         string
+	SMTPPassword         string
+	MailFrom             string
+	PaymentProvider      string
+	PaymentWebhookSecret string
+	PlatformFee          int64
+	PriceBase            int64
+	PricePerKm           int64
+	PriceSpread          int64
+	ReferralCredit       int64
+	VATRate              int64
+	WaitlistHold         time.Duration
 }
 
 func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
@@ -34,6 +64,17 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 		return errors.New("secret must be 32 bytes long")
 	}
 
+	// Webhooks of the payment provider are verified with a secret of their
+	// own, so they can not be forged with the secret signing tokens.
+	paymentWebhookSecret, err := hex.DecodeString(cfg.PaymentWebhookSecret)
+	if err != nil {
+		return fmt.Errorf("decode payment webhook secret: %w", err)
+	} else if len(paymentWebhookSecret) < 32 {
+		return errors.New("payment webhook secret must be at least 32 bytes long")
+	} else if bytes.Equal(paymentWebhookSecret, secret) {
+		return errors.New("payment webhook secret must differ from secret")
+	}
+
 	// Connect to PostgreSQL database.
 	db, err := sqlx.ConnectContext(ctx, "postgres", cfg.PostgresURL)
 	if err != nil {
@@ -49,12 +90,7 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 	db.SetConnMaxLifetime(time.Minute * 5)
 
 	// Connect to the Redis cache.
-	cache, err := redigo.DialURL(cfg.RedisURL,
-		redigo.DialConnectTimeout(time.Second*5),
-		redigo.DialReadTimeout(time.Second*5),
-		redigo.DialWriteTimeout(time.Second*5),
-		redigo.DialKeepAlive(time.Minute*5),
-	)
+	cache, err := dialRedis(cfg.RedisURL, redigo.DialReadTimeout(time.Second*5))
 	if err != nil {
 		return fmt.Errorf("connect to cache: %w", err)
 	}
@@ -69,6 +105,12 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 		logger.Printf("check cache connection: %s", err)
 	}
 
+	// Create the blob store for avatars and uploaded files.
+	blobStore, err := newBlobStore(&cfg.blobConfig)
+	if err != nil {
+		return fmt.Errorf("create blob store: %w", err)
+	}
+
 	// Run database migrations, if auto migrate is set.
 	if cfg.Automigrate {
 		var n int
@@ -77,7 +119,112 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 		} else if n > 0 {
 			logger.Printf("Applied %d database migrations", n)
 		}
+		if n, err = sql.MigrateAvatars(ctx, db, blobStore); err != nil {
+			return fmt.Errorf("migrate avatars: %w", err)
+		} else if n > 0 {
+			logger.Printf("Moved %d avatars to the blob store", n)
+		}
+	}
+
+	bookingRepository, err := sql.NewBookingRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create booking repository: %w", err)
+	}
+	defer func() {
+		if err = bookingRepository.Close(); err != nil {
+			logger.Printf("close booking repository: %s", err)
+		}
+	}()
+
+	jobRepository, err := sql.NewJobRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create job repository: %w", err)
+	}
+	defer func() {
+		if err = jobRepository.Close(); err != nil {
+			logger.Printf("close job repository: %s", err)
+		}
+	}()
+
+	messageRepository, err := sql.NewMessageRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create message repository: %w", err)
 	}
+	defer func() {
+		if err = messageRepository.Close(); err != nil {
+			logger.Printf("close message repository: %s", err)
+		}
+	}()
+
+	outboxRepository, err := sql.NewOutboxRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create outbox repository: %w", err)
+	}
+	defer func() {
+		if err = outboxRepository.Close(); err != nil {
+			logger.Printf("close outbox repository: %s", err)
+		}
+	}()
+
+	notificationRepository, err := sql.NewNotificationRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create notification repository: %w", err)
+	}
+	defer func() {
+		if err = notificationRepository.Close(); err != nil {
+			logger.Printf("close notification repository: %s", err)
+		}
+	}()
+
+	organizationRepository, err := sql.NewOrganizationRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create organization repository: %w", err)
+	}
+	defer func() {
+		if err = organizationRepository.Close(); err != nil {
+			logger.Printf("close organization repository: %s", err)
+		}
+	}()
+
+	paymentRepository, err := sql.NewPaymentRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create payment repository: %w", err)
+	}
+	defer func() {
+		if err = paymentRepository.Close(); err != nil {
+			logger.Printf("close payment repository: %s", err)
+		}
+	}()
+
+	ratingRepository, err := sql.NewRatingRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create rating repository: %w", err)
+	}
+	defer func() {
+		if err = ratingRepository.Close(); err != nil {
+			logger.Printf("close rating repository: %s", err)
+		}
+	}()
+
+	promotionRepository, err := sql.NewPromotionRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create promotion repository: %w", err)
+	}
+	defer func() {
+		if err = promotionRepository.Close(); err != nil {
+			logger.Printf("close promotion repository: %s", err)
+		}
+	}()
+
+	receiptRepository, err := sql.NewReceiptRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create receipt repository: %w", err)
+	}
+	defer func() {
+		if err = receiptRepository.Close(); err != nil {
+			logger.Printf("close receipt repository: %s", err)
+		}
+	}()
 
 	tripRepository, err := sql.NewTripRepository(ctx, db)
 	if err != nil {
@@ -109,17 +256,167 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 		}
 	}()
 
+	walletRepository, err := sql.NewWalletRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create wallet repository: %w", err)
+	}
+	defer func() {
+		if err = walletRepository.Close(); err != nil {
+			logger.Printf("close wallet repository: %s", err)
+		}
+	}()
+
+	webhookRepository, err := sql.NewWebhookRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create webhook repository: %w", err)
+	}
+	defer func() {
+		if err = webhookRepository.Close(); err != nil {
+			logger.Printf("close webhook repository: %s", err)
+		}
+	}()
+
+	waitlistRepository, err := sql.NewWaitlistRepository(ctx, db)
+	if err != nil {
+		return fmt.Errorf("create waitlist repository: %w", err)
+	}
+	defer func() {
+		if err = waitlistRepository.Close(); err != nil {
+			logger.Printf("close waitlist repository: %s", err)
+		}
+	}()
+
 	tokenBlacklist := redis.NewTokenBlacklist(cache)
 
+	// Create the event broker. The Redis event broker distributes events
+	// across all server instances while the in-process event broker is
+	// limited to a single one.
+	var eventBroker cargonaut.EventBroker
+	switch cfg.EventBroker {
+	case "memory":
+		eventBroker = memory.NewEventBroker()
+	case "redis":
+		redisEventBroker := redis.NewEventBroker(logger, func() (redigo.Conn, error) {
+			return dialRedis(cfg.RedisURL)
+		}, memory.NewEventBroker())
+		defer func() {
+			if err = redisEventBroker.Close(); err != nil {
+				logger.Printf("close event broker: %s", err)
+			}
+		}()
+		go func() {
+			if err := redisEventBroker.Run(ctx); err != nil {
+				logger.Printf("run event broker: %s", err)
+			}
+		}()
+		eventBroker = redisEventBroker
+	default:
+		return fmt.Errorf("unknown event broker %q", cfg.EventBroker)
+	}
+
+	// Create the payment provider. The fake payment provider moves no real
+	// money and delivers its webhooks to the handler directly. Users authorize
+	// their own top-ups with it, so it is only available in development mode.
+	var (
+		paymentProvider     cargonaut.PaymentProvider
+		fakePaymentProvider *payment.FakeProvider
+	)
+	switch cfg.PaymentProvider {
+	case "":
+		return errors.New("payment provider must be set")
+	case "fake":
+		if !cfg.Dev {
+			return errors.New("fake payment provider is only available in development mode")
+		}
+		fakePaymentProvider = payment.NewFakeProvider(logger, paymentWebhookSecret)
+		paymentProvider = fakePaymentProvider
+	default:
+		return fmt.Errorf("unknown payment provider %q", cfg.PaymentProvider)
+	}
+
+	// Create the mailer. E-Mails are logged if no SMTP server is configured.
+	var mailer cargonaut.Mailer = mail.NewLogMailer(logger)
+	if cfg.SMTPAddress != "" {
+		if mailer, err = mail.NewSMTPMailer(cfg.SMTPAddress, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom); err != nil {
+			return fmt.Errorf("create mailer: %w", err)
+		}
+	}
+	notifier := notify.NewNotifier(userRepository, tripRepository, notificationRepository, mailer)
+
+	// Create the webhook dispatcher and attempt due deliveries in the
+	// background.
+	webhookDispatcher := dispatch.NewWebhookDispatcher(logger, webhookRepository, tripRepository, vehicleRepository)
+	go webhookDispatcher.Run(ctx)
+
+	// Relay the events written to the outbox to the event broker, the
+	// notifier and the webhook dispatcher. The subscriber names are recorded
+	// in the database and must not be changed.
+	eventRelay := relay.NewRelay(logger, outboxRepository)
+	eventRelay.Subscribe("broker", eventBroker.Publish)
+	eventRelay.Subscribe("notifier", notifier.Notify)
+	eventRelay.Subscribe("webhooks", webhookDispatcher.Dispatch)
+	go eventRelay.Run(ctx)
+
+	// Run background jobs. Running jobs are cancelled and waited for before
+	// the repositories and database connection are closed.
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("get hostname: %w", err)
+	}
+	scheduler := job.NewScheduler(logger, jobRepository, fmt.Sprintf("%s/%d", hostname, os.Getpid()))
+	scheduler.Register("delete_expired_tokens", cron.MustParse("@hourly"), time.Minute, job.DeleteExpiredTokens(userRepository))
+	scheduler.Register("prune_outbox", cron.MustParse("@daily"), 5*time.Minute, job.PruneOutbox(outboxRepository, 7*24*time.Hour))
+	scheduler.Register("remind_trips", cron.MustParse("*/5 * * * *"), time.Minute, job.RemindTrips(tripRepository, time.Hour))
+	scheduler.Register("complete_trips", cron.MustParse("*/5 * * * *"), time.Minute, job.CompleteTrips(tripRepository, cargonaut.FeePolicy{Rate: cfg.PlatformFee}, cargonaut.ReferralPolicy{Credit: cfg.ReferralCredit}))
+	scheduler.Register("expire_bookings", cron.MustParse("* * * * *"), time.Minute, job.ExpireBookings(bookingRepository))
+	scheduler.Register("advance_waitlists", cron.MustParse("* * * * *"), time.Minute, job.AdvanceWaitlists(waitlistRepository, cfg.WaitlistHold))
+
+	schedulerCtx, cancelScheduler := context.WithCancel(ctx)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		if err := scheduler.Run(schedulerCtx); err != nil {
+			logger.Printf("run job scheduler: %s", err)
+		}
+	}()
+	defer func() {
+		cancelScheduler()
+		<-schedulerDone
+	}()
+
 	// Create http handlers.
 	h, err := handler.NewHandler(logger, secret)
 	if err != nil {
 		return fmt.Errorf("create http handler: %w", err)
 	}
+	h.BookingRepository = bookingRepository
+	h.JobRepository = jobRepository
+	h.MessageRepository = messageRepository
+	h.NotificationRepository = notificationRepository
+	h.OrganizationRepository = organizationRepository
+	h.PaymentRepository = paymentRepository
+	h.PromotionRepository = promotionRepository
+	h.RatingRepository = ratingRepository
+	h.ReceiptRepository = receiptRepository
 	h.TripRepository = tripRepository
 	h.UserRepository = userRepository
 	h.VehicleRepository = vehicleRepository
+	h.WaitlistRepository = waitlistRepository
+	h.WalletRepository = walletRepository
+	h.WebhookRepository = webhookRepository
+	h.EventBroker = eventBroker
+	h.Notifier = notifier
+	h.PaymentProvider = paymentProvider
+	h.DistanceEstimator = gazetteer.New()
+	h.CancellationPolicy = cargonaut.CancellationPolicy{FreeWindow: cfg.CancellationWindow}
+	h.PricingPolicy = cargonaut.PricingPolicy{Base: cfg.PriceBase, PerKm: cfg.PricePerKm, Spread: cfg.PriceSpread}
+	h.BookingDeadline = cfg.BookingDeadline
+	h.VATRate = cfg.VATRate
 	h.TokenBlacklist = tokenBlacklist
+	h.BlobStore = blobStore
+	if fakePaymentProvider != nil {
+		fakePaymentProvider.Deliver = h.HandlePaymentWebhook
+	}
 
 	// Run http server.
 	srv, err := http.NewServer(logger, cfg.ListenAddress, h)
@@ -145,3 +442,14 @@ func serveCmd(ctx context.Context, _ []string, cfg *serveConfig) error {
 
 	return nil
 }
+
+// dialRedis connects to the Redis instance identified by the URL. Additional
+// options are applied after the default ones.
+func dialRedis(url string, options ...redigo.DialOption) (redigo.Conn, error) {
+	options = append([]redigo.DialOption{
+		redigo.DialConnectTimeout(time.Second * 5),
+		redigo.DialWriteTimeout(time.Second * 5),
+		redigo.DialKeepAlive(time.Minute * 5),
+	}, options...)
+	return redigo.DialURL(url, options...)
+}
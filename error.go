@@ -3,6 +3,54 @@ package cargonaut
 import "errors"
 
 var (
+	// ErrBlobNotFound is raised when no content is stored under a key.
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrBookingExists is raised when a booking with the same unique
+	// constraints already exists.
+	ErrBookingExists = errors.New("booking exists")
+	// ErrBookingNotFound is raised when a booking does not exist.
+	ErrBookingNotFound = errors.New("booking not found")
+	// ErrBookingNotPending is raised when a booking has already been decided
+	// on or has expired.
+	ErrBookingNotPending = errors.New("booking not pending")
+	// ErrInsufficientFunds is raised when the available balance of a wallet
+	// does not cover an amount.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrInvalidPaymentWebhook is raised when a webhook of the payment
+	// provider can not be verified.
+	ErrInvalidPaymentWebhook = errors.New("invalid payment webhook")
+	// ErrLocationNotFound is raised when a location is unknown.
+	ErrLocationNotFound = errors.New("location not found")
+	// ErrOrganizationNotFound is raised when an organization does not exist.
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrOrganizationMemberNotFound is raised when a user is not a member of
+	// an organization.
+	ErrOrganizationMemberNotFound = errors.New("organization member not found")
+	// ErrOrganizationOwnerRequired is raised when the last owner of an
+	// organization would be removed or lose the role.
+	ErrOrganizationOwnerRequired = errors.New("organization owner required")
+	// ErrPaymentNotFound is raised when a payment does not exist.
+	ErrPaymentNotFound = errors.New("payment not found")
+	// ErrPaymentNotRefundable is raised when a payment is no succeeded top-up
+	// or the amount exceeds what is left to refund.
+	ErrPaymentNotRefundable = errors.New("payment not refundable")
+	// ErrPaymentEventProcessed is raised when a payment event with the same
+	// idempotency key has been processed before.
+	ErrPaymentEventProcessed = errors.New("payment event processed")
+	// ErrPromoCodeExists is raised when a promo code with the same unique
+	// constraints already exists.
+	ErrPromoCodeExists = errors.New("promo code exists")
+	// ErrPromoCodeNotFound is raised when a promo code does not exist.
+	ErrPromoCodeNotFound = errors.New("promo code not found")
+	// ErrPromoCodeNotRedeemable is raised when a promo code is not valid,
+	// has reached its redemption limits or is restricted to the first trip
+	// of a rider.
+	ErrPromoCodeNotRedeemable = errors.New("promo code not redeemable")
+	// ErrReceiptNotAvailable is raised when a receipt is requested for a
+	// booking which has not been completed.
+	ErrReceiptNotAvailable = errors.New("receipt not available")
+	// ErrReferralCodeNotFound is raised when a referral code does not exist.
+	ErrReferralCodeNotFound = errors.New("referral code not found")
 	// ErrTokenExists is raised when a token with the same unique constraints
 	// already exists.
 	ErrTokenExists = errors.New("token exists")
@@ -13,19 +61,68 @@ var (
 	ErrUserExists = errors.New("user exists")
 	// ErrUserNotFound is raised when a user does not exist.
 	ErrUserNotFound = errors.New("user not found")
+	// ErrWalletNotEmpty is raised when a user is deleted whose wallet still
+	// has a balance or holds a balance for his bookings.
+	ErrWalletNotEmpty = errors.New("wallet not empty")
+	// ErrEmailVerificationNotFound is raised when a pending E-Mail verification
+	// does not exist or has expired.
+	ErrEmailVerificationNotFound = errors.New("email verification not found")
+	// ErrNotificationNotFound is raised when a notification does not exist.
+	ErrNotificationNotFound = errors.New("notification not found")
 	// ErrRatingExists is raised when a rating with the same unique constraints
 	// already exists.
 	ErrRatingExists = errors.New("rating exists")
 	// ErrRatingNotFound is raised when a rating does not exist.
 	ErrRatingNotFound = errors.New("rating not found")
+	// ErrRatingReplyExists is raised when a rating has already been replied
+	// to.
+	ErrRatingReplyExists = errors.New("rating reply exists")
+	// ErrRatingReportExists is raised when a rating report with the same
+	// unique constraints already exists.
+	ErrRatingReportExists = errors.New("rating report exists")
 	// ErrVehicleExists is raised when a vehicle with the same unique
 	// constraints already exists.
 	ErrVehicleExists = errors.New("vehicle exists")
 	// ErrVehicleNotFound is raised when a vehicle does not exist.
 	ErrVehicleNotFound = errors.New("vehicle not found")
+	// ErrVehicleFileNotFound is raised when a vehicle file does not exist.
+	ErrVehicleFileNotFound = errors.New("vehicle file not found")
+	// ErrWebhookNotFound is raised when a webhook does not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is raised when a webhook delivery does not
+	// exist.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
 	// ErrTripExists is raised when a trip with the same unique constraints
 	// already exists.
 	ErrTripExists = errors.New("trip exists")
 	// ErrTripNotFound is raised when a trip does not exist.
 	ErrTripNotFound = errors.New("trip not found")
+	// ErrTripNotAvailable is raised when a trip has already been booked,
+	// started, cancelled or completed, or is held for riders on its waitlist.
+	ErrTripNotAvailable = errors.New("trip not available")
+	// ErrTripNotCancellable is raised when a trip has already been cancelled,
+	// finished or completed, or its booking does not belong to the cancelling
+	// rider.
+	ErrTripNotCancellable = errors.New("trip not cancellable")
+	// ErrTripBooked is raised when the price or vehicle of a trip is changed
+	// after it has been booked.
+	ErrTripBooked = errors.New("trip booked")
+	// ErrTripNotStartable is raised when a trip has already been started or
+	// cancelled.
+	ErrTripNotStartable = errors.New("trip not startable")
+	// ErrTripNotFinishable is raised when a trip has not been started yet, or
+	// has already been finished or cancelled.
+	ErrTripNotFinishable = errors.New("trip not finishable")
+	// ErrTripNotFull is raised when a trip can be booked right away and thus
+	// has no waitlist to join.
+	ErrTripNotFull = errors.New("trip not full")
+	// ErrWaitlistEntryExists is raised when a rider is already on the
+	// waitlist of a trip.
+	ErrWaitlistEntryExists = errors.New("waitlist entry exists")
+	// ErrWaitlistEntryNotFound is raised when a rider is not on the waitlist
+	// of a trip.
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	// ErrWaitlistOfferNotFound is raised when a rider does not hold an offer
+	// for a trip or it has expired.
+	ErrWaitlistOfferNotFound = errors.New("waitlist offer not found")
 )
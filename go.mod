@@ -6,7 +6,7 @@ module github.com/my-cargonaut/cargonaut
 go 1.14
 
 require (
-	github.com/SermoDigital/jose v0.9.2-0.20180104203859-803625baeddc
+	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/fatih/structtag v1.2.0
 	github.com/go-chi/chi v4.1.2+incompatible
 	github.com/go-chi/cors v1.1.1
@@ -0,0 +1,53 @@
+package blob_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// testBlobStore tests the behaviour every blob store has to implement. Keys are
+// unique, so the store may be shared.
+func testBlobStore(t *testing.T, store cargonaut.BlobStore) {
+	ctx := context.Background()
+	key := "test/" + uuid.NewV4().String() + "/blob"
+
+	get := func() []byte {
+		r, err := store.GetBlob(ctx, key)
+		require.NoError(t, err)
+		defer r.Close()
+		content, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		return content
+	}
+	put := func(content []byte) {
+		require.NoError(t, store.PutBlob(ctx, key, bytes.NewReader(content), int64(len(content)), "text/plain"))
+	}
+
+	_, err := store.GetBlob(ctx, key)
+	assert.Equal(t, cargonaut.ErrBlobNotFound, err)
+
+	put([]byte("content"))
+	assert.Equal(t, []byte("content"), get())
+
+	// Blobs are replaced as a whole.
+	put([]byte("new"))
+	assert.Equal(t, []byte("new"), get())
+
+	put(nil)
+	assert.Empty(t, get())
+
+	require.NoError(t, store.DeleteBlob(ctx, key))
+	_, err = store.GetBlob(ctx, key)
+	assert.Equal(t, cargonaut.ErrBlobNotFound, err)
+
+	// Deleting blobs which do not exist is not an error.
+	assert.NoError(t, store.DeleteBlob(ctx, key))
+}
@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+var _ cargonaut.BlobStore = (*FileStore)(nil)
+
+// FileStore stores blobs as files below a directory of the local file system.
+// It is meant for single instance deployments and development.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a new FileStore storing blobs below the directory,
+// which is created if it does not exist yet.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("create blob directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// PutBlob stores the content under the key. It is written to a temporary file
+// first, so readers never see partially written content.
+func (s *FileStore) PutBlob(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return fmt.Errorf("create directory of blob %q: %w", key, err)
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(name), ".blob-")
+	if err != nil {
+		return fmt.Errorf("create temporary file of blob %q: %w", key, err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	n, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write blob %q: %w", key, err)
+	} else if size >= 0 && n != size {
+		return fmt.Errorf("write blob %q: wrote %d of %d bytes", key, n, size)
+	}
+
+	if err = os.Rename(f.Name(), name); err != nil {
+		return fmt.Errorf("write blob %q: %w", key, err)
+	}
+	return nil
+}
+
+// GetBlob returns the content stored under the key.
+func (s *FileStore) GetBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, cargonaut.ErrBlobNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("open blob %q: %w", key, err)
+	}
+	return f, nil
+}
+
+// DeleteBlob deletes the content stored under the key.
+func (s *FileStore) DeleteBlob(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete blob %q: %w", key, err)
+	}
+	return nil
+}
+
+// path returns the name of the file the blob stored under the key is written
+// to. Keys which are not clean relative paths are rejected, so blobs can never
+// be written outside of the directory.
+func (s *FileStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || path.IsAbs(key) || path.Clean(key) != key || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
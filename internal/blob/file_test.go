@@ -0,0 +1,68 @@
+package blob_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/blob"
+)
+
+// newTestFileStore returns a FileStore storing blobs in the "blobs"
+// subdirectory of a temporary directory, which is returned as well.
+func newTestFileStore(t *testing.T) (*FileStore, string) {
+	dir, err := ioutil.TempDir("", "cargonaut-blob-")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	store, err := NewFileStore(filepath.Join(dir, "blobs"))
+	require.NoError(t, err)
+	return store, dir
+}
+
+func TestFileStore(t *testing.T) {
+	store, _ := newTestFileStore(t)
+	testBlobStore(t, store)
+}
+
+func TestFileStorePutBlobSizeMismatch(t *testing.T) {
+	store, _ := newTestFileStore(t)
+	ctx := context.Background()
+
+	assert.Error(t, store.PutBlob(ctx, "blob", bytes.NewReader([]byte("content")), 3, "text/plain"))
+	_, err := store.GetBlob(ctx, "blob")
+	assert.Equal(t, cargonaut.ErrBlobNotFound, err)
+}
+
+func TestFileStoreInvalidKey(t *testing.T) {
+	store, dir := newTestFileStore(t)
+	ctx := context.Background()
+
+	for _, key := range []string{
+		"",
+		".",
+		"..",
+		"../escaped",
+		"a/../../escaped",
+		"/escaped",
+		"a//b",
+		"a/./b",
+		"a/",
+	} {
+		assert.Error(t, store.PutBlob(ctx, key, bytes.NewReader([]byte("x")), 1, "text/plain"), key)
+		_, err := store.GetBlob(ctx, key)
+		assert.Error(t, err, key)
+		assert.NotEqual(t, cargonaut.ErrBlobNotFound, err, key)
+		assert.Error(t, store.DeleteBlob(ctx, key), key)
+	}
+
+	_, err := os.Stat(filepath.Join(dir, "escaped"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,157 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/sigv4"
+)
+
+var _ cargonaut.BlobStore = (*S3Store)(nil)
+
+// s3Timeout is the timeout of requests to the object store. It does not apply
+// to reading the content of a blob.
+const s3Timeout = 30 * time.Second
+
+// S3Store stores blobs as objects in a bucket of an S3 compatible object store
+// like MinIO. Objects are addressed path-style, so the endpoint does not need
+// to resolve subdomains for buckets.
+type S3Store struct {
+	client   *http.Client
+	endpoint *url.URL
+	bucket   string
+	region   string
+	creds    sigv4.Credentials
+}
+
+// NewS3Store returns a new S3Store storing blobs in the bucket of the object
+// store at the endpoint, e.g. "https://s3.eu-central-1.amazonaws.com" or
+// "http://localhost:9000".
+func NewS3Store(endpoint, bucket, region, accessKey, secretKey string) (*S3Store, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parse S3 endpoint: %w", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("S3 endpoint %q must be an absolute http or https URL", endpoint)
+	}
+	if bucket == "" {
+		return nil, errors.New("S3 bucket must not be empty")
+	}
+
+	return &S3Store{
+		client:   &http.Client{},
+		endpoint: u,
+		bucket:   bucket,
+		region:   region,
+		creds:    sigv4.Credentials{AccessKey: accessKey, SecretKey: secretKey},
+	}, nil
+}
+
+// PutBlob stores the content under the key. The content is streamed to the
+// object store without being signed.
+func (s *S3Store) PutBlob(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.do(req, sigv4.UnsignedPayload)
+	if err != nil {
+		return fmt.Errorf("put blob %q: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("put blob %q: %w", key, responseError(res))
+	}
+	return nil
+}
+
+// GetBlob returns the content stored under the key.
+func (s *S3Store) GetBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(req, sigv4.EmptyPayload)
+	if err != nil {
+		return nil, fmt.Errorf("get blob %q: %w", key, err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, cargonaut.ErrBlobNotFound
+	default:
+		defer res.Body.Close()
+		return nil, fmt.Errorf("get blob %q: %w", key, responseError(res))
+	}
+}
+
+// DeleteBlob deletes the content stored under the key. Object stores do not
+// complain about deleting objects which do not exist.
+func (s *S3Store) DeleteBlob(ctx context.Context, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, s3Timeout)
+	defer cancel()
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(req, sigv4.EmptyPayload)
+	if err != nil {
+		return fmt.Errorf("delete blob %q: %w", key, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete blob %q: %w", key, responseError(res))
+	}
+	return nil
+}
+
+// newRequest returns a new request for the object stored under the key.
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = ""
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("create request of blob %q: %w", key, err)
+	}
+	return req, nil
+}
+
+// do signs and sends the request.
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	sigv4.Sign(req, s.creds, s.region, "s3", payloadHash, time.Now())
+	return s.client.Do(req)
+}
+
+// responseError returns an error describing an unexpected response of the
+// object store, which explains the failure in its body.
+func responseError(res *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected status %q: %s", res.Status, strings.TrimSpace(string(body)))
+}
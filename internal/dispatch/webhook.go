@@ -0,0 +1,255 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/backoff"
+	"github.com/my-cargonaut/cargonaut/pkg/version"
+	"github.com/my-cargonaut/cargonaut/pkg/webhook"
+)
+
+var _ cargonaut.WebhookDispatcher = (*WebhookDispatcher)(nil)
+
+const (
+	// maxAttempts is the number of attempts after which a delivery is
+	// considered failed.
+	maxAttempts = 8
+	// backoffBase and backoffMax bound the delay between attempts.
+	backoffBase = 30 * time.Second
+	backoffMax  = time.Hour
+
+	// pollInterval is the interval in which due deliveries are claimed.
+	pollInterval = 5 * time.Second
+	// claimLimit is the maximum number of deliveries claimed at once.
+	claimLimit = 16
+	// claimLease is the duration claimed deliveries are hidden from other
+	// dispatchers. It must exceed the time needed to attempt all claimed
+	// deliveries.
+	claimLease = 5 * time.Minute
+	// requestTimeout is the timeout of a single delivery attempt.
+	requestTimeout = 10 * time.Second
+)
+
+// webhookPayload is the JSON payload of a webhook delivery.
+type webhookPayload struct {
+	Event *cargonaut.Event `json:"event"`
+	Trip  *cargonaut.Trip  `json:"trip"`
+}
+
+// WebhookDispatcher stores deliveries of events to webhooks and attempts them
+// in the background until they succeed or the maximum number of attempts is
+// reached.
+type WebhookDispatcher struct {
+	log    *log.Logger
+	client *http.Client
+
+	webhooks cargonaut.WebhookRepository
+	trips    cargonaut.TripRepository
+	vehicles cargonaut.VehicleRepository
+}
+
+// NewWebhookDispatcher returns a new WebhookDispatcher which stores deliveries
+// in the webhook repository. The trips the events are about are looked up in
+// the trip repository and included in the payload. Their vehicles are looked
+// up in the vehicle repository to find the organization they are driven for.
+func NewWebhookDispatcher(log *log.Logger, webhooks cargonaut.WebhookRepository, trips cargonaut.TripRepository, vehicles cargonaut.VehicleRepository) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		log:    log,
+		client: newClient(),
+
+		webhooks: webhooks,
+		trips:    trips,
+		vehicles: vehicles,
+	}
+}
+
+// newClient returns a new HTTP client for webhook deliveries. It refuses to
+// connect to addresses which are not public, even if the target of a webhook
+// resolves to one after it has been created or redirects to one. Proxies are
+// not used, since they would connect on behalf of the client.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: webhook.Control,
+	}
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: requestTimeout,
+			MaxIdleConns:        16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// Dispatch schedules the delivery of the events to all webhooks of the users
+// the events are addressed to which are subscribed to them. Events addressed
+// to the driver of a trip driven with a vehicle of an organization are also
+// delivered to the subscribed webhooks of the organization.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, events ...*cargonaut.Event) error {
+	for _, event := range events {
+		// The trip might have been deleted in the meantime.
+		trip, err := d.trips.GetTrip(ctx, event.TripID)
+		if err != nil && err != cargonaut.ErrTripNotFound {
+			return err
+		}
+
+		webhooks, err := d.webhooks.ListWebhooks(ctx, event.UserID)
+		if err != nil {
+			return err
+		}
+		if trip != nil && uuid.Equal(trip.UserID, event.UserID) {
+			organizationWebhooks, err := d.organizationWebhooks(ctx, trip)
+			if err != nil {
+				return err
+			}
+			webhooks = append(webhooks, organizationWebhooks...)
+		}
+
+		var subscribed []*cargonaut.Webhook
+		for _, webhook := range webhooks {
+			if webhook.Events.Contains(event.Type) {
+				subscribed = append(subscribed, webhook)
+			}
+		}
+		if len(subscribed) == 0 {
+			continue
+		}
+
+		payload, err := json.Marshal(&webhookPayload{
+			Event: event,
+			Trip:  trip,
+		})
+		if err != nil {
+			return fmt.Errorf("encode webhook payload: %w", err)
+		}
+
+		for _, webhook := range subscribed {
+			if err = d.webhooks.CreateDelivery(ctx, &cargonaut.WebhookDelivery{
+				WebhookID: webhook.ID,
+				EventID:   event.ID,
+				EventType: event.Type,
+				Payload:   string(payload),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// organizationWebhooks returns the webhooks of the organization the trip is
+// driven for, if its vehicle belongs to one.
+func (d *WebhookDispatcher) organizationWebhooks(ctx context.Context, trip *cargonaut.Trip) ([]*cargonaut.Webhook, error) {
+	vehicle, err := d.vehicles.GetVehicle(ctx, trip.VehicleID)
+	if err == cargonaut.ErrVehicleNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if vehicle.OrganizationID == nil {
+		return nil, nil
+	}
+	return d.webhooks.ListOrganizationWebhooks(ctx, *vehicle.OrganizationID)
+}
+
+// Run attempts due deliveries until the context is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deliveries, err := d.webhooks.ClaimDueDeliveries(ctx, claimLimit, claimLease)
+		if err != nil {
+			d.log.Printf("claim due webhook deliveries: %s", err)
+			continue
+		}
+		for _, delivery := range deliveries {
+			if err = d.attempt(ctx, delivery); err != nil {
+				d.log.Printf("attempt webhook delivery %q: %s", delivery.ID, err)
+			}
+		}
+	}
+}
+
+// attempt attempts a delivery and records its result.
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *cargonaut.WebhookDelivery) error {
+	hook, err := d.webhooks.GetWebhook(ctx, delivery.WebhookID)
+	if err == cargonaut.ErrWebhookNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	code, err := d.post(ctx, hook, delivery)
+	if code != 0 {
+		delivery.ResponseCode = &code
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		delivery.Status = cargonaut.WebhookDeliveryStatusSucceeded
+		delivery.Error = nil
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= maxAttempts:
+		msg := err.Error()
+		delivery.Status = cargonaut.WebhookDeliveryStatusFailed
+		delivery.Error = &msg
+		delivery.NextAttemptAt = nil
+	default:
+		msg := err.Error()
+		next := now.Add(backoff.Exponential(delivery.Attempts, backoffBase, backoffMax))
+		delivery.Error = &msg
+		delivery.NextAttemptAt = &next
+	}
+
+	return d.webhooks.UpdateDelivery(ctx, delivery)
+}
+
+// post posts the signed payload of the delivery to the webhook. It returns the
+// status code of the response, if any, and an error if the delivery failed.
+func (d *WebhookDispatcher) post(ctx context.Context, hook *cargonaut.Webhook, delivery *cargonaut.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("cargonaut/%s", version.Release()))
+	req.Header.Set("X-Cargonaut-Event", string(delivery.EventType))
+	req.Header.Set("X-Cargonaut-Delivery", delivery.ID.String())
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign([]byte(hook.Secret), payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,146 @@
+package dispatch_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/dispatch"
+)
+
+// fakeWebhookRepository keeps webhooks in memory and records the deliveries
+// created for them. Methods not needed for dispatching are not implemented.
+type fakeWebhookRepository struct {
+	cargonaut.WebhookRepository
+
+	webhooks   []*cargonaut.Webhook
+	deliveries []*cargonaut.WebhookDelivery
+}
+
+func (f *fakeWebhookRepository) ListWebhooks(_ context.Context, userID uuid.UUID) ([]*cargonaut.Webhook, error) {
+	var webhooks []*cargonaut.Webhook
+	for _, webhook := range f.webhooks {
+		if webhook.UserID != nil && uuid.Equal(*webhook.UserID, userID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (f *fakeWebhookRepository) ListOrganizationWebhooks(_ context.Context, organizationID uuid.UUID) ([]*cargonaut.Webhook, error) {
+	var webhooks []*cargonaut.Webhook
+	for _, webhook := range f.webhooks {
+		if webhook.OrganizationID != nil && uuid.Equal(*webhook.OrganizationID, organizationID) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (f *fakeWebhookRepository) CreateDelivery(_ context.Context, delivery *cargonaut.WebhookDelivery) error {
+	f.deliveries = append(f.deliveries, delivery)
+	return nil
+}
+
+// fakeTripRepository returns the trips it was given.
+type fakeTripRepository struct {
+	cargonaut.TripRepository
+
+	trips []*cargonaut.Trip
+}
+
+func (f *fakeTripRepository) GetTrip(_ context.Context, id uuid.UUID) (*cargonaut.Trip, error) {
+	for _, trip := range f.trips {
+		if uuid.Equal(trip.ID, id) {
+			return trip, nil
+		}
+	}
+	return nil, cargonaut.ErrTripNotFound
+}
+
+// fakeVehicleRepository returns the vehicles it was given.
+type fakeVehicleRepository struct {
+	cargonaut.VehicleRepository
+
+	vehicles []*cargonaut.Vehicle
+}
+
+func (f *fakeVehicleRepository) GetVehicle(_ context.Context, id uuid.UUID) (*cargonaut.Vehicle, error) {
+	for _, vehicle := range f.vehicles {
+		if uuid.Equal(vehicle.ID, id) {
+			return vehicle, nil
+		}
+	}
+	return nil, cargonaut.ErrVehicleNotFound
+}
+
+func TestWebhookDispatcherDispatch(t *testing.T) {
+	driverID, riderID, organizationID := uuid.NewV4(), uuid.NewV4(), uuid.NewV4()
+	fleetVehicle := &cargonaut.Vehicle{ID: uuid.NewV4(), UserID: driverID, OrganizationID: &organizationID}
+	personalVehicle := &cargonaut.Vehicle{ID: uuid.NewV4(), UserID: driverID}
+	dispatched := &cargonaut.Trip{ID: uuid.NewV4(), UserID: driverID, VehicleID: fleetVehicle.ID, RiderID: &riderID}
+	personal := &cargonaut.Trip{ID: uuid.NewV4(), UserID: driverID, VehicleID: personalVehicle.ID}
+
+	subscribed := cargonaut.EventTypes{cargonaut.EventTypeTripBooked, cargonaut.EventTypeTripCancelled}
+	driverHook := &cargonaut.Webhook{ID: uuid.NewV4(), UserID: &driverID, Events: subscribed}
+	riderHook := &cargonaut.Webhook{ID: uuid.NewV4(), UserID: &riderID, Events: subscribed}
+	organizationHook := &cargonaut.Webhook{ID: uuid.NewV4(), OrganizationID: &organizationID, Events: subscribed}
+
+	tests := []struct {
+		name  string
+		event *cargonaut.Event
+		want  []*cargonaut.Webhook
+	}{
+		{
+			name:  "driver of organization vehicle",
+			event: cargonaut.NewEvent(cargonaut.EventTypeTripBooked, driverID, dispatched.ID),
+			want:  []*cargonaut.Webhook{driverHook, organizationHook},
+		},
+		{
+			name:  "rider of organization vehicle",
+			event: cargonaut.NewEvent(cargonaut.EventTypeTripCancelled, riderID, dispatched.ID),
+			want:  []*cargonaut.Webhook{riderHook},
+		},
+		{
+			name:  "driver of personal vehicle",
+			event: cargonaut.NewEvent(cargonaut.EventTypeTripBooked, driverID, personal.ID),
+			want:  []*cargonaut.Webhook{driverHook},
+		},
+		{
+			name:  "not subscribed",
+			event: cargonaut.NewEvent(cargonaut.EventTypeTripCompleted, driverID, dispatched.ID),
+		},
+		{
+			name:  "deleted trip",
+			event: cargonaut.NewEvent(cargonaut.EventTypeTripBooked, driverID, uuid.NewV4()),
+			want:  []*cargonaut.Webhook{driverHook},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := &fakeWebhookRepository{webhooks: []*cargonaut.Webhook{driverHook, riderHook, organizationHook}}
+			trips := &fakeTripRepository{trips: []*cargonaut.Trip{dispatched, personal}}
+			vehicles := &fakeVehicleRepository{vehicles: []*cargonaut.Vehicle{fleetVehicle, personalVehicle}}
+			d := NewWebhookDispatcher(log.New(ioutil.Discard, "", 0), webhooks, trips, vehicles)
+
+			require.NoError(t, d.Dispatch(context.Background(), tt.event))
+
+			var got []*cargonaut.Webhook
+			for _, delivery := range webhooks.deliveries {
+				assert.Equal(t, tt.event.ID, delivery.EventID)
+				for _, webhook := range webhooks.webhooks {
+					if uuid.Equal(webhook.ID, delivery.WebhookID) {
+						got = append(got, webhook)
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
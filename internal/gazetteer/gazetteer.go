@@ -0,0 +1,144 @@
+package gazetteer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/geo"
+)
+
+var _ cargonaut.DistanceEstimator = (*Gazetteer)(nil)
+
+// detourFactor is the average ratio of the distance by road to the
+// great-circle distance between two places.
+const detourFactor = 1.3
+
+// Gazetteer estimates distances between places it knows by name. It knows
+// the larger cities of Germany and its neighbouring countries.
+type Gazetteer struct {
+	places map[string]geo.Point
+}
+
+// New returns a new Gazetteer.
+func New() *Gazetteer {
+	g := &Gazetteer{places: make(map[string]geo.Point, len(places)+len(aliases))}
+	for name, p := range places {
+		g.places[normalize(name)] = p
+	}
+	for alias, name := range aliases {
+		g.places[normalize(alias)] = places[name]
+	}
+	return g
+}
+
+// EstimateDistance returns the estimated distance by road between the start
+// and the destination in km. Places are looked up by the name of the city,
+// ignoring case, umlauts and everything following the first comma, so
+// "Köln, Hauptbahnhof" and "koeln" are the same place.
+func (g *Gazetteer) EstimateDistance(ctx context.Context, start, destination string) (float64, error) {
+	a, ok := g.places[normalize(start)]
+	if !ok {
+		return 0, cargonaut.ErrLocationNotFound
+	}
+	b, ok := g.places[normalize(destination)]
+	if !ok {
+		return 0, cargonaut.ErrLocationNotFound
+	}
+	return geo.Distance(a, b) * detourFactor, nil
+}
+
+var umlauts = strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+
+// normalize returns the lowercase name of the city of a location.
+func normalize(location string) string {
+	if i := strings.IndexByte(location, ','); i >= 0 {
+		location = location[:i]
+	}
+	return umlauts.Replace(strings.ToLower(strings.TrimSpace(location)))
+}
+
+// aliases maps alternative names of cities to their names in places.
+var aliases = map[string]string{
+	"Cologne":    "Köln",
+	"Munich":     "München",
+	"Nuremberg":  "Nürnberg",
+	"Hanover":    "Hannover",
+	"Frankfurt":  "Frankfurt am Main",
+	"Vienna":     "Wien",
+	"Prague":     "Praha",
+	"Warsaw":     "Warszawa",
+	"Copenhagen": "København",
+	"Brussels":   "Bruxelles",
+	"Zurich":     "Zürich",
+	"Luxemburg":  "Luxembourg",
+	"Basle":      "Basel",
+}
+
+// places are the coordinates of the known cities.
+var places = map[string]geo.Point{
+	"Aachen":            {Lat: 50.7753, Lon: 6.0839},
+	"Augsburg":          {Lat: 48.3705, Lon: 10.8978},
+	"Berlin":            {Lat: 52.5200, Lon: 13.4050},
+	"Bielefeld":         {Lat: 52.0302, Lon: 8.5325},
+	"Bochum":            {Lat: 51.4818, Lon: 7.2162},
+	"Bonn":              {Lat: 50.7374, Lon: 7.0982},
+	"Braunschweig":      {Lat: 52.2689, Lon: 10.5268},
+	"Bremen":            {Lat: 53.0793, Lon: 8.8017},
+	"Chemnitz":          {Lat: 50.8278, Lon: 12.9214},
+	"Darmstadt":         {Lat: 49.8728, Lon: 8.6512},
+	"Dortmund":          {Lat: 51.5136, Lon: 7.4653},
+	"Dresden":           {Lat: 51.0504, Lon: 13.7373},
+	"Duisburg":          {Lat: 51.4344, Lon: 6.7623},
+	"Düsseldorf":        {Lat: 51.2277, Lon: 6.7735},
+	"Erfurt":            {Lat: 50.9848, Lon: 11.0299},
+	"Essen":             {Lat: 51.4556, Lon: 7.0116},
+	"Frankfurt am Main": {Lat: 50.1109, Lon: 8.6821},
+	"Freiburg":          {Lat: 47.9990, Lon: 7.8421},
+	"Gelsenkirchen":     {Lat: 51.5177, Lon: 7.0857},
+	"Göttingen":         {Lat: 51.5413, Lon: 9.9158},
+	"Halle":             {Lat: 51.4969, Lon: 11.9688},
+	"Hamburg":           {Lat: 53.5511, Lon: 9.9937},
+	"Hannover":          {Lat: 52.3759, Lon: 9.7320},
+	"Heidelberg":        {Lat: 49.3988, Lon: 8.6724},
+	"Karlsruhe":         {Lat: 49.0069, Lon: 8.4037},
+	"Kassel":            {Lat: 51.3127, Lon: 9.4797},
+	"Kiel":              {Lat: 54.3233, Lon: 10.1228},
+	"Koblenz":           {Lat: 50.3569, Lon: 7.5890},
+	"Köln":              {Lat: 50.9375, Lon: 6.9603},
+	"Leipzig":           {Lat: 51.3397, Lon: 12.3731},
+	"Lübeck":            {Lat: 53.8655, Lon: 10.6866},
+	"Magdeburg":         {Lat: 52.1205, Lon: 11.6276},
+	"Mainz":             {Lat: 49.9929, Lon: 8.2473},
+	"Mannheim":          {Lat: 49.4875, Lon: 8.4660},
+	"Münster":           {Lat: 51.9607, Lon: 7.6261},
+	"München":           {Lat: 48.1351, Lon: 11.5820},
+	"Nürnberg":          {Lat: 49.4521, Lon: 11.0767},
+	"Oldenburg":         {Lat: 53.1435, Lon: 8.2146},
+	"Osnabrück":         {Lat: 52.2799, Lon: 8.0472},
+	"Potsdam":           {Lat: 52.3906, Lon: 13.0645},
+	"Regensburg":        {Lat: 49.0134, Lon: 12.1016},
+	"Rostock":           {Lat: 54.0924, Lon: 12.0991},
+	"Saarbrücken":       {Lat: 49.2402, Lon: 6.9969},
+	"Schwerin":          {Lat: 53.6355, Lon: 11.4012},
+	"Stuttgart":         {Lat: 48.7758, Lon: 9.1829},
+	"Trier":             {Lat: 49.7490, Lon: 6.6371},
+	"Ulm":               {Lat: 48.4011, Lon: 9.9876},
+	"Wiesbaden":         {Lat: 50.0782, Lon: 8.2398},
+	"Wuppertal":         {Lat: 51.2562, Lon: 7.1508},
+	"Würzburg":          {Lat: 49.7913, Lon: 9.9534},
+
+	"Amsterdam":  {Lat: 52.3676, Lon: 4.9041},
+	"Basel":      {Lat: 47.5596, Lon: 7.5886},
+	"Bruxelles":  {Lat: 50.8503, Lon: 4.3517},
+	"København":  {Lat: 55.6761, Lon: 12.5683},
+	"Luxembourg": {Lat: 49.6116, Lon: 6.1319},
+	"Paris":      {Lat: 48.8566, Lon: 2.3522},
+	"Praha":      {Lat: 50.0755, Lon: 14.4378},
+	"Rotterdam":  {Lat: 51.9244, Lon: 4.4777},
+	"Salzburg":   {Lat: 47.8095, Lon: 13.0550},
+	"Strasbourg": {Lat: 48.5734, Lon: 7.7521},
+	"Warszawa":   {Lat: 52.2297, Lon: 21.0122},
+	"Wien":       {Lat: 48.2082, Lon: 16.3738},
+	"Zürich":     {Lat: 47.3769, Lon: 8.5417},
+}
@@ -1,20 +1,16 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/base64"
 	"encoding/json"
-	"image"
-	_ "image/jpeg" // JPEG format
-	"image/png"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/render"
-	"github.com/nfnt/resize"
+	uuid "github.com/satori/go.uuid"
 
 	"github.com/my-cargonaut/cargonaut"
 	"github.com/my-cargonaut/cargonaut/internal/jwt"
+	"github.com/my-cargonaut/cargonaut/internal/upload"
 	"github.com/my-cargonaut/cargonaut/pkg/password"
 )
 
@@ -41,12 +37,17 @@ type logoutRequest struct {
 	Token string `json:"token"`
 }
 
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
 type registerRequest struct {
-	Email       string    `json:"email"`
-	Password    string    `json:"password"`
-	DisplayName string    `json:"display_name"`
-	Birthday    time.Time `json:"birthday"`
-	Avatar      string    `json:"avatar"`
+	Email        string    `json:"email"`
+	Password     string    `json:"password"`
+	DisplayName  string    `json:"display_name"`
+	Birthday     time.Time `json:"birthday"`
+	Avatar       string    `json:"avatar"`
+	ReferralCode string    `json:"referral_code"`
 }
 
 func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
@@ -218,38 +219,39 @@ func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Base64 decode avatar request data.
-	imgSrc, err := base64.StdEncoding.DecodeString(req.Avatar)
+	// Decode the avatar. It is stored in all sizes once the user is created.
+	avatar, err := decodeAvatar(req.Avatar)
 	if err != nil {
-		h.renderError(w, r, http.StatusInternalServerError, err)
-		return
-	}
-
-	// Decode image data.
-	buf := bytes.NewBuffer(imgSrc)
-	img, _, err := image.Decode(buf)
-	if err != nil {
-		h.renderError(w, r, http.StatusInternalServerError, err)
+		verr := make(validationError)
+		if err == upload.ErrImageTooLarge {
+			verr.add("avatar", "must not have more than %d megapixels", upload.MaxImagePixels/1000/1000)
+		} else {
+			verr.add("avatar", "must be a base64 encoded PNG or JPEG image")
+		}
+		h.renderError(w, r, http.StatusUnprocessableEntity, verr.err())
 		return
 	}
 
-	// Resize to a reasonable size.
-	img = resize.Resize(250, 0, img, resize.Lanczos3)
-
-	// Encode resized image as PNG and base64 encode.
-	buf.Reset()
-	if err := png.Encode(buf, img); err != nil {
-		h.renderError(w, r, http.StatusInternalServerError, err)
-		return
-	}
-	req.Avatar = base64.StdEncoding.EncodeToString(buf.Bytes())
-
 	user := &cargonaut.User{
 		Email:       req.Email,
 		Password:    req.Password,
 		DisplayName: req.DisplayName,
 		Birthday:    req.Birthday,
-		Avatar:      req.Avatar,
+	}
+
+	// Remember who referred the user, so both are credited once the user
+	// completes his first trip.
+	if req.ReferralCode != "" {
+		referrerID, err := h.PromotionRepository.GetReferrer(r.Context(), req.ReferralCode)
+		if err == cargonaut.ErrReferralCodeNotFound {
+			verr := validationError{"referral_code": "must be a valid referral code"}
+			h.renderError(w, r, http.StatusUnprocessableEntity, verr)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		user.ReferredBy = &referrerID
 	}
 
 	if err := h.UserRepository.CreateUser(r.Context(), user); err == cargonaut.ErrUserExists {
@@ -260,5 +262,35 @@ func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The avatar can only be stored once the user has his ID. The user is
+	// registered anyway if that fails, he can upload his avatar again later.
+	if err := h.putAvatar(r.Context(), user.ID, avatar); err != nil {
+		h.log.Printf("[%s %s]: store avatar of user %q: %s", r.Method, requestURI(r), user.ID, err)
+	}
+
 	render.NoContent(w, r)
 }
+
+func (h *Handler) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req verifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := uuid.FromString(req.Token)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if user, err := h.UserRepository.VerifyEmail(r.Context(), id); err == cargonaut.ErrEmailVerificationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err == cargonaut.ErrUserExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, user)
+	}
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/nfnt/resize"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/upload"
+	"github.com/my-cargonaut/cargonaut/pkg/identicon"
+)
+
+// avatarSizes are the widths in pixels avatars are pre-rendered in, ascending.
+// Avatars are only rendered as PNG images. WebP images would be smaller, but
+// there is no WebP encoder written in pure Go.
+var avatarSizes = []int{48, 128, 256}
+
+// avatarMaxAge is the duration clients may use a cached avatar before they
+// have to revalidate it.
+const avatarMaxAge = 5 * time.Minute
+
+// avatarKey returns the key the variant of the avatar of the user identified
+// by the ID in the given size is stored under in the blob store. Variants are
+// rendered from the avatar itself, which is stored under cargonaut.AvatarKey.
+func avatarKey(userID uuid.UUID, size int) string {
+	return path.Join(path.Dir(cargonaut.AvatarKey(userID)), fmt.Sprintf("avatar-%d.png", size))
+}
+
+// avatarSize returns the smallest avatar size which is at least as large as
+// the requested size. Larger sizes are served in the largest size and missing
+// or invalid sizes in the largest size, too.
+func avatarSize(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return avatarSizes[len(avatarSizes)-1]
+	}
+	for _, size := range avatarSizes {
+		if size >= n {
+			return size
+		}
+	}
+	return avatarSizes[len(avatarSizes)-1]
+}
+
+// decodeAvatar decodes the base64 encoded PNG or JPEG avatar image. Like all
+// uploaded images, it must not exceed upload.MaxImagePixels.
+func decodeAvatar(avatar string) (image.Image, error) {
+	imgSrc, err := base64.StdEncoding.DecodeString(avatar)
+	if err != nil {
+		return nil, err
+	}
+	return upload.DecodeImage(imgSrc)
+}
+
+// encodeAvatar resizes the avatar image to the width, if it is wider, and
+// encodes it as PNG. Smaller images are never scaled up.
+func encodeAvatar(img image.Image, size int) ([]byte, error) {
+	if img.Bounds().Dx() > size {
+		img = resize.Resize(uint(size), 0, img, resize.Lanczos3)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// putAvatar stores the avatar image, scaled down to the largest size, and its
+// variants in all sizes in the blob store, replacing the current avatar of the
+// user identified by the ID.
+func (h *Handler) putAvatar(ctx context.Context, userID uuid.UUID, img image.Image) error {
+	avatar, err := encodeAvatar(img, avatarSizes[len(avatarSizes)-1])
+	if err != nil {
+		return fmt.Errorf("encode avatar: %w", err)
+	}
+	if err = h.BlobStore.PutBlob(ctx, cargonaut.AvatarKey(userID), bytes.NewReader(avatar), int64(len(avatar)), "image/png"); err != nil {
+		return err
+	}
+
+	for _, size := range avatarSizes {
+		if avatar, err = encodeAvatar(img, size); err != nil {
+			return fmt.Errorf("encode avatar: %w", err)
+		}
+		if err = h.BlobStore.PutBlob(ctx, avatarKey(userID, size), bytes.NewReader(avatar), int64(len(avatar)), "image/png"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAvatar returns the PNG encoded avatar of the user identified by the ID in
+// the given size. Variants of avatars which predate them, like the ones moved
+// from the database, are rendered from the avatar on first use.
+func (h *Handler) getAvatar(ctx context.Context, userID uuid.UUID, size int) ([]byte, error) {
+	key := avatarKey(userID, size)
+	avatar, err := h.readBlob(ctx, key)
+	if err != cargonaut.ErrBlobNotFound {
+		return avatar, err
+	}
+
+	if avatar, err = h.readBlob(ctx, cargonaut.AvatarKey(userID)); err != nil {
+		return nil, err
+	}
+	img, err := upload.DecodeImage(avatar)
+	if err != nil {
+		return nil, fmt.Errorf("decode avatar: %w", err)
+	}
+	if avatar, err = encodeAvatar(img, size); err != nil {
+		return nil, fmt.Errorf("encode avatar: %w", err)
+	}
+
+	// The variant is served, even if it can't be stored for later.
+	if err = h.BlobStore.PutBlob(ctx, key, bytes.NewReader(avatar), int64(len(avatar)), "image/png"); err != nil {
+		h.log.Printf("store avatar variant %q: %s", key, err)
+	}
+	return avatar, nil
+}
+
+// deleteAvatar deletes the avatar of the user identified by the ID along with
+// its variants in all sizes.
+func (h *Handler) deleteAvatar(ctx context.Context, userID uuid.UUID) error {
+	if err := h.BlobStore.DeleteBlob(ctx, cargonaut.AvatarKey(userID)); err != nil {
+		return err
+	}
+	for _, size := range avatarSizes {
+		if err := h.BlobStore.DeleteBlob(ctx, avatarKey(userID, size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBlob returns the whole content stored under the key in the blob store.
+func (h *Handler) readBlob(ctx context.Context, key string) ([]byte, error) {
+	content, err := h.BlobStore.GetBlob(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return ioutil.ReadAll(content)
+}
+
+// getUserAvatar serves the avatar of a user in the size given by the "size"
+// query parameter. An identicon is served instead, if the user has no avatar
+// or it can't be loaded.
+func (h *Handler) getUserAvatar(w http.ResponseWriter, r *http.Request) {
+	size := avatarSize(r.URL.Query().Get("size"))
+
+	param := chi.URLParam(r, "id")
+	id, err := uuid.FromString(param)
+	if err != nil {
+		h.writeIdenticon(w, r, param, size, true)
+		return
+	}
+
+	avatar, err := h.getAvatar(r.Context(), id, size)
+	if err == cargonaut.ErrBlobNotFound {
+		h.writeIdenticon(w, r, id.String(), size, true)
+		return
+	} else if err != nil {
+		// The identicon must not be cached for long, the avatar is served
+		// again as soon as it can be loaded.
+		h.log.Printf("[%s %s]: load avatar of user %q: %s", r.Method, requestURI(r), id, err)
+		h.writeIdenticon(w, r, id.String(), size, false)
+		return
+	}
+
+	h.writeAvatar(w, r, avatar, true)
+}
+
+// writeIdenticon writes a PNG encoded identicon for the seed in the given size
+// to the response.
+func (h *Handler) writeIdenticon(w http.ResponseWriter, r *http.Request, seed string, size int, cacheable bool) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, identicon.Generate([]byte(seed), size)); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	h.writeAvatar(w, r, buf.Bytes(), cacheable)
+}
+
+// writeAvatar writes the PNG encoded avatar to the response. It is identified
+// by a strong ETag derived from its content, so clients can revalidate cached
+// avatars. Avatars which are not cacheable must be revalidated on every use.
+func (h *Handler) writeAvatar(w http.ResponseWriter, r *http.Request, avatar []byte, cacheable bool) {
+	sum := sha256.Sum256(avatar)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if cacheable {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(avatarMaxAge.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Content-Length", strconv.Itoa(len(avatar)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := w.Write(avatar); err != nil {
+		h.log.Printf("[%s %s]: write avatar: %s", r.Method, requestURI(r), err)
+	}
+}
+
+// etagMatches returns true if the value of an If-None-Match header matches the
+// ETag. As required for If-None-Match, weak ETags of the header match as well.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// driverTrip returns the trip identified by the id URL parameter. It renders
+// an error and returns false, if the trip does not exist or the authenticated
+// user is not its driver.
+func (h *Handler) driverTrip(w http.ResponseWriter, r *http.Request, authUserID uuid.UUID) (*cargonaut.Trip, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	} else if !uuid.Equal(trip.UserID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not manage bookings of trip of another user")
+		return nil, false
+	}
+	return trip, true
+}
+
+// tripBooking returns the booking identified by the bid URL parameter, which
+// must belong to the given trip. It renders an error and returns false
+// otherwise.
+func (h *Handler) tripBooking(w http.ResponseWriter, r *http.Request, trip *cargonaut.Trip) (*cargonaut.Booking, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "bid"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	booking, err := h.BookingRepository.GetBooking(r.Context(), id)
+	if err == cargonaut.ErrBookingNotFound || (err == nil && !uuid.Equal(booking.TripID, trip.ID)) {
+		h.renderError(w, r, http.StatusNotFound, cargonaut.ErrBookingNotFound)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return booking, true
+}
+
+func (h *Handler) listTripBookings(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.driverTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+
+	if bookings, err := h.BookingRepository.ListBookings(r.Context(), trip.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, bookings)
+	}
+}
+
+func (h *Handler) acceptTripBooking(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.driverTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+	booking, ok := h.tripBooking(w, r, trip)
+	if !ok {
+		return
+	}
+
+	event := cargonaut.NewEvent(cargonaut.EventTypeBookingAccepted, booking.UserID, trip.ID)
+	if err := h.BookingRepository.AcceptBooking(r.Context(), booking.ID, event); err == cargonaut.ErrBookingNotPending || err == cargonaut.ErrTripNotAvailable {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) declineTripBooking(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.driverTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+	booking, ok := h.tripBooking(w, r, trip)
+	if !ok {
+		return
+	}
+
+	event := cargonaut.NewEvent(cargonaut.EventTypeBookingDeclined, booking.UserID, trip.ID)
+	if err := h.BookingRepository.DeclineBooking(r.Context(), booking.ID, event); err == cargonaut.ErrBookingNotPending {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/jwtauth"
+)
+
+// eventHeartbeatInterval is the interval in which a comment is sent to keep
+// idle event streams open.
+const eventHeartbeatInterval = 15 * time.Second
+
+// streamEvents streams the events addressed to the authenticated user as
+// Server-Sent Events.
+func (h *Handler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.renderErrorf(w, r, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	// End the stream once the access token expires. The client reconnects
+	// with a refreshed one.
+	ctx := r.Context()
+	if _, claims, err := jwtauth.FromContext(ctx); err == nil {
+		if exp, ok := claims["exp"].(float64); ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, time.Unix(int64(exp), 0))
+			defer cancel()
+		}
+	}
+
+	events, err := h.EventBroker.Subscribe(ctx, authUserID)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.log.Printf("[%s %s]: encode event: %s", r.Method, requestURI(r), err)
+				return
+			}
+			if _, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,65 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/memory"
+)
+
+func TestStreamEventsTokenExpiry(t *testing.T) {
+	h := newTestHandler(t)
+	broker := memory.NewEventBroker()
+	h.EventBroker = broker
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	userID := uuid.NewV4()
+	token := func(exp time.Time) string {
+		token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, jwtgo.MapClaims{
+			"exp":  exp.Unix(),
+			"user": map[string]interface{}{"id": userID.String()},
+		}).SignedString(testSecret)
+		require.NoError(t, err)
+		return token
+	}
+
+	// Expired tokens are refused.
+	resp, err := client.Get(srv.URL + "/api/v1/events?jwt=" + token(time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	exp := time.Now().Add(2 * time.Second)
+	resp, err = client.Get(srv.URL + "/api/v1/events?jwt=" + token(exp))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The headers are only sent after subscribing, so the event is not missed.
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripBooked, userID, uuid.NewV4())
+	require.NoError(t, broker.Publish(context.Background(), event))
+
+	// The stream ends when the token expires, instead of outliving it.
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.False(t, time.Now().Before(time.Unix(exp.Unix(), 0)), "stream ended before the token expired")
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data), string(body))
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/my-cargonaut/cargonaut"
 	_ "github.com/my-cargonaut/cargonaut/internal/ui" // UI
+	"github.com/my-cargonaut/cargonaut/pkg/mergepatch"
 	"github.com/my-cargonaut/cargonaut/pkg/version"
 )
 
@@ -30,10 +31,31 @@ type Handler struct {
 
 	secret []byte
 
-	TripRepository    cargonaut.TripRepository
-	UserRepository    cargonaut.UserRepository
-	VehicleRepository cargonaut.VehicleRepository
-	TokenBlacklist    cargonaut.TokenBlacklist
+	BookingRepository      cargonaut.BookingRepository
+	JobRepository          cargonaut.JobRepository
+	MessageRepository      cargonaut.MessageRepository
+	NotificationRepository cargonaut.NotificationRepository
+	OrganizationRepository cargonaut.OrganizationRepository
+	PaymentRepository      cargonaut.PaymentRepository
+	PromotionRepository    cargonaut.PromotionRepository
+	RatingRepository       cargonaut.RatingRepository
+	ReceiptRepository      cargonaut.ReceiptRepository
+	TripRepository         cargonaut.TripRepository
+	UserRepository         cargonaut.UserRepository
+	VehicleRepository      cargonaut.VehicleRepository
+	WaitlistRepository     cargonaut.WaitlistRepository
+	WalletRepository       cargonaut.WalletRepository
+	WebhookRepository      cargonaut.WebhookRepository
+	TokenBlacklist         cargonaut.TokenBlacklist
+	BlobStore              cargonaut.BlobStore
+	EventBroker            cargonaut.EventBroker
+	Notifier               cargonaut.Notifier
+	PaymentProvider        cargonaut.PaymentProvider
+	DistanceEstimator      cargonaut.DistanceEstimator
+	CancellationPolicy     cargonaut.CancellationPolicy
+	PricingPolicy          cargonaut.PricingPolicy
+	BookingDeadline        time.Duration
+	VATRate                int64
 }
 
 // NewHandler creates a new set of handlers.
@@ -64,19 +86,19 @@ func NewHandler(log *log.Logger, secret []byte) (*Handler, error) {
 		MaxAge:         300,
 	})
 
-	// Base middleware stack: CORS, compression, panic recoverer, request
-	// timeout.
+	// Base middleware stack: CORS, compression, panic recoverer. The request
+	// timeout is applied to all routes but the event stream.
 	h.router.Use(cors.Handler)
 	h.router.Use(middleware.Compress(5))
 	h.router.Use(middleware.Recoverer)
-	h.router.Use(middleware.Timeout(30 * time.Second))
+	timeout := middleware.Timeout(30 * time.Second)
 
 	// Serve user interface.
 	ui, err := fs.NewWithNamespace("ui")
 	if err != nil {
 		return nil, fmt.Errorf("create web ui file system: %w", err)
 	}
-	h.router.Handle("/*", http.FileServer(ui))
+	h.router.With(timeout).Handle("/*", http.FileServer(ui))
 
 	// Serve API.
 	h.router.Route("/api/v1", func(api chi.Router) {
@@ -90,15 +112,32 @@ func NewHandler(log *log.Logger, secret []byte) (*Handler, error) {
 			h.renderError(w, r, code, errors.New(http.StatusText(code)))
 		})
 
-		// API middleware (JSON content type & renderer).
-		api.Use(middleware.AllowContentType("application/json"))
+		// API middleware (JSON content type & renderer). JSON Merge Patch
+		// documents and multipart file uploads are accepted as well.
+		api.Use(middleware.AllowContentType("application/json", mergepatch.ContentType, "multipart/form-data"))
 		api.Use(render.SetContentType(render.ContentTypeJSON))
 
+		// Event stream. Browsers can't set headers on event stream requests,
+		// so the token can be passed as query parameter as well. This is the
+		// only route accepting it, since query parameters end up in logs.
+		api.Group(func(r chi.Router) {
+			r.Use(jwtauth.Verify(jwtauth.New("HS256", secret, nil), jwtauth.TokenFromQuery, jwtauth.TokenFromHeader, jwtauth.TokenFromCookie))
+			r.Use(jwtauth.Authenticator)
+
+			r.Get("/events", h.streamEvents)
+		})
+
+		api = api.With(timeout)
+
 		// Authentication routes.
 		api.Post("/auth/login", h.login)
 		api.Patch("/auth/refresh", h.refresh)
 		api.Post("/auth/logout", h.logout)
 		api.Post("/auth/register", h.register)
+		api.Post("/auth/verify-email", h.verifyEmail)
+
+		// Payment provider webhooks, authenticated by their signature.
+		api.Post("/payments/webhook", h.receivePaymentWebhook)
 
 		// Special user profile picture route.
 		api.Get("/users/{id}/avatar", h.getUserAvatar)
@@ -111,20 +150,116 @@ func NewHandler(log *log.Logger, secret []byte) (*Handler, error) {
 
 			// Trip API.
 			r.Get("/trips", h.listTrips)
+			r.Get("/trips/price-suggestion", h.getTripPriceSuggestion)
 			r.Get("/trips/{id}", h.getTrip)
 			r.Post("/trips", h.createTrip)
 			r.Put("/trips/{id}", h.updateTrip)
+			r.Patch("/trips/{id}", h.patchTrip)
 			r.Delete("/trips/{id}", h.deleteTrip)
-			r.Get("/trips/{id}/ratings", h.getTripRating)
+			r.Get("/trips/{id}/ratings", h.listTripRatings)
+			r.Post("/trips/{id}/start", h.startTrip)
+			r.Post("/trips/{id}/finish", h.finishTrip)
+			r.Post("/trips/{id}/cancel", h.cancelTripAsDriver)
+			r.Get("/trips/{id}/bookings", h.listTripBookings)
+			r.Post("/trips/{id}/bookings/{bid}/accept", h.acceptTripBooking)
+			r.Post("/trips/{id}/bookings/{bid}/decline", h.declineTripBooking)
+			r.Get("/trips/{id}/waitlist", h.listTripWaitlist)
+			r.Post("/trips/{id}/waitlist", h.joinTripWaitlist)
+			r.Delete("/trips/{id}/waitlist", h.leaveTripWaitlist)
+			r.Post("/trips/{id}/waitlist/confirm", h.confirmTripWaitlistOffer)
 			r.Post("/trips/{id}/ratings", h.createTripRating)
+			r.Get("/trips/{id}/messages", h.listTripMessages)
+			r.Post("/trips/{id}/messages", h.createTripMessage)
+
+			// Booking API.
+			r.Get("/bookings/{id}/receipt.pdf", h.getBookingReceipt)
+
+			// Rating API.
+			r.Post("/ratings/{id}/reply", h.replyToRating)
+			r.Post("/ratings/{id}/reports", h.reportRating)
+
+			// Moderation API.
+			r.Route("/moderation", func(r chi.Router) {
+				r.Use(h.requireRole(cargonaut.UserRoleModerator, cargonaut.UserRoleAdmin))
+
+				r.Get("/ratings", h.listReportedRatings)
+				r.Post("/ratings/{id}/approve", h.moderateRating(cargonaut.ModerationActionApprove))
+				r.Post("/ratings/{id}/hide", h.moderateRating(cargonaut.ModerationActionHide))
+				r.Post("/ratings/{id}/delete", h.moderateRating(cargonaut.ModerationActionDelete))
+			})
+
+			// Promotion API.
+			r.Route("/promo-codes", func(r chi.Router) {
+				r.Use(h.requireRole(cargonaut.UserRoleAdmin))
+
+				r.Get("/", h.listPromoCodes)
+				r.Post("/", h.createPromoCode)
+				r.Get("/{id}", h.getPromoCode)
+				r.Patch("/{id}", h.patchPromoCode)
+				r.Get("/{id}/redemptions", h.listPromoRedemptions)
+			})
+
+			// Job API.
+			r.With(h.requireRole(cargonaut.UserRoleAdmin)).Get("/jobs", h.listJobs)
+
+			// Wallet API.
+			r.With(h.requireRole(cargonaut.UserRoleAdmin)).Post("/users/{id}/wallet/deposits", h.depositToUserWallet)
+
+			// Payment API.
+			r.With(h.requireRole(cargonaut.UserRoleAdmin)).Post("/payments/{id}/refunds", h.refundPayment)
+
+			// Organization API.
+			r.Get("/organizations", h.listOrganizations)
+			r.Post("/organizations", h.createOrganization)
+			r.Route("/organizations/{id}", func(r chi.Router) {
+				r.Use(h.requireOrganizationRole())
+
+				r.Get("/", h.getOrganization)
+				r.With(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner)).Patch("/", h.patchOrganization)
+				r.With(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner)).Delete("/", h.deleteOrganization)
+				r.Get("/members", h.listOrganizationMembers)
+				r.With(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner)).Put("/members/{user_id}", h.saveOrganizationMember)
+				r.Delete("/members/{user_id}", h.removeOrganizationMember)
+				r.Get("/vehicles", h.listOrganizationVehicles)
+				r.With(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner, cargonaut.OrganizationRoleDispatcher)).Post("/vehicles", h.createOrganizationVehicle)
+				r.Get("/trips", h.listOrganizationTrips)
+				r.With(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner, cargonaut.OrganizationRoleDispatcher)).Post("/trips", h.createOrganizationTrip)
+				r.Group(func(r chi.Router) {
+					r.Use(h.requireOrganizationRole(cargonaut.OrganizationRoleOwner, cargonaut.OrganizationRoleDispatcher))
+
+					r.Get("/webhooks", h.listOrganizationWebhooks)
+					r.Post("/webhooks", h.createOrganizationWebhook)
+					r.Get("/webhooks/{webhook_id}", h.getWebhook(h.organizationWebhook))
+					r.Delete("/webhooks/{webhook_id}", h.deleteWebhook(h.organizationWebhook))
+					r.Get("/webhooks/{webhook_id}/deliveries", h.listWebhookDeliveries(h.organizationWebhook))
+					r.Post("/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", h.redeliverWebhookDelivery(h.organizationWebhook))
+				})
+			})
 
 			// User API.
 			// r.Get("/users", h.listUsers)
+			r.Get("/users/me", h.getCurrentUser)
+			r.Patch("/users/me", h.patchCurrentUser)
+			r.Delete("/users/me", h.deleteCurrentUser)
+			r.Get("/users/me/export", h.exportCurrentUser)
+			r.Get("/users/me/conversations", h.listCurrentUserConversations)
+			r.Get("/users/me/notifications", h.listCurrentUserNotifications)
+			r.Post("/users/me/notifications/read", h.markAllCurrentUserNotificationsRead)
+			r.Post("/users/me/notifications/{id}/read", h.markCurrentUserNotificationRead)
+			r.Get("/users/me/wallet", h.getCurrentUserWallet)
+			r.Get("/users/me/wallet/postings", h.listCurrentUserWalletPostings)
+			r.Post("/users/me/wallet/top-ups", h.createCurrentUserPayment(cargonaut.PaymentTypeTopUp))
+			r.Post("/users/me/wallet/payouts", h.createCurrentUserPayment(cargonaut.PaymentTypePayout))
+			r.Get("/users/me/payments", h.listCurrentUserPayments)
+			r.Post("/users/me/payments/{id}/authorize", h.approveCurrentUserPayment(true))
+			r.Post("/users/me/payments/{id}/decline", h.approveCurrentUserPayment(false))
+			r.Get("/users/me/referral-code", h.getCurrentUserReferralCode)
 			r.Get("/users/{id}", h.getUser)
 			// r.Post("/users", h.createUser)
 			// r.Put("/users/{id}", h.updateUser)
 			// r.Delete("/users/{id}", h.deleteUser)
 			r.Get("/users/{id}/ratings", h.listUserRatings)
+			r.Get("/users/{id}/ratings/summary", h.listUserRatingSummaries)
 			r.Get("/users/{id}/vehicles", h.listUserVehicles)
 			r.Post("/users/{user_id}/trips/{trip_id}", h.bookTrip)
 			r.Put("/users/{user_id}/trips/{trip_id}", h.cancelTrip)
@@ -134,7 +269,24 @@ func NewHandler(log *log.Logger, secret []byte) (*Handler, error) {
 			r.Get("/vehicles/{id}", h.getVehicle)
 			r.Post("/vehicles", h.createVehicle)
 			r.Put("/vehicles/{id}", h.updateVehicle)
+			r.Patch("/vehicles/{id}", h.patchVehicle)
 			r.Delete("/vehicles/{id}", h.deleteVehicle)
+			r.Get("/vehicles/{id}/photos", h.listVehicleFiles(cargonaut.VehicleFileKindPhoto))
+			r.Post("/vehicles/{id}/photos", h.createVehicleFile(cargonaut.VehicleFileKindPhoto))
+			r.Get("/vehicles/{id}/photos/{file_id}", h.getVehicleFile(cargonaut.VehicleFileKindPhoto))
+			r.Delete("/vehicles/{id}/photos/{file_id}", h.deleteVehicleFile(cargonaut.VehicleFileKindPhoto))
+			r.Get("/vehicles/{id}/documents", h.listVehicleFiles(cargonaut.VehicleFileKindDocument))
+			r.Post("/vehicles/{id}/documents", h.createVehicleFile(cargonaut.VehicleFileKindDocument))
+			r.Get("/vehicles/{id}/documents/{file_id}", h.getVehicleFile(cargonaut.VehicleFileKindDocument))
+			r.Delete("/vehicles/{id}/documents/{file_id}", h.deleteVehicleFile(cargonaut.VehicleFileKindDocument))
+
+			// Webhook API.
+			r.Get("/webhooks", h.listWebhooks)
+			r.Get("/webhooks/{id}", h.getWebhook(h.ownWebhook))
+			r.Post("/webhooks", h.createWebhook)
+			r.Delete("/webhooks/{id}", h.deleteWebhook(h.ownWebhook))
+			r.Get("/webhooks/{id}/deliveries", h.listWebhookDeliveries(h.ownWebhook))
+			r.Post("/webhooks/{id}/deliveries/{delivery_id}/redeliver", h.redeliverWebhookDelivery(h.ownWebhook))
 		})
 	})
 
@@ -157,12 +309,29 @@ func (h *Handler) renderOK(w http.ResponseWriter, r *http.Request, data interfac
 }
 
 func (h *Handler) renderError(w http.ResponseWriter, r *http.Request, code int, err error) {
-	h.log.Printf("[%s %s]: %s", r.Method, r.RequestURI, err)
+	h.log.Printf("[%s %s]: %s", r.Method, requestURI(r), err)
 
-	h.render(w, r, code, map[string]string{
+	resp := map[string]interface{}{
 		"status": http.StatusText(code),
 		"error":  err.Error(),
-	})
+	}
+	if verr, ok := err.(validationError); ok {
+		resp["fields"] = verr
+	}
+	h.render(w, r, code, resp)
+}
+
+// requestURI returns the URI of the request for logging. Access tokens passed
+// as query parameter to the event stream are redacted.
+func requestURI(r *http.Request) string {
+	q := r.URL.Query()
+	if _, ok := q["jwt"]; !ok {
+		return r.RequestURI
+	}
+	q.Set("jwt", "REDACTED")
+	u := *r.URL
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
 }
 
 func (h *Handler) renderErrorf(w http.ResponseWriter, r *http.Request, code int, format string, a ...interface{}) {
@@ -170,6 +339,36 @@ func (h *Handler) renderErrorf(w http.ResponseWriter, r *http.Request, code int,
 	h.renderError(w, r, code, err)
 }
 
+// requireRole is a middleware which only lets authenticated users with one of
+// the given roles pass.
+func (h *Handler) requireRole(roles ...cargonaut.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+			if !ok {
+				return
+			}
+
+			user, err := h.UserRepository.GetUser(r.Context(), authUserID)
+			if err == cargonaut.ErrUserNotFound {
+				h.renderError(w, r, http.StatusUnauthorized, err)
+				return
+			} else if err != nil {
+				h.renderError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+
+			for _, role := range roles {
+				if user.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			h.renderErrorf(w, r, http.StatusForbidden, "insufficient permissions")
+		})
+	}
+}
+
 func (h *Handler) userIDFromRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
 	_, claims, err := jwtauth.FromContext(ctx)
 	if err != nil {
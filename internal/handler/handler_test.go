@@ -0,0 +1,339 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/handler"
+	"github.com/my-cargonaut/cargonaut/internal/jwt"
+)
+
+// testSecret is the secret tokens are signed with and passwords are peppered
+// with. Peppers must be 16, 24 or 32 byte broad.
+var testSecret = []byte("0123456789abcdef")
+
+// newTestHandler returns a handler without any repositories. Tests set the
+// ones used by the routes they call.
+func newTestHandler(t *testing.T) *Handler {
+	h, err := NewHandler(log.New(ioutil.Discard, "", 0), testSecret)
+	require.NoError(t, err)
+	return h
+}
+
+// serve serves a request with the JSON encoded body, authenticated as the user
+// identified by the unique ID, and returns the recorded response.
+func serve(t *testing.T, h http.Handler, userID uuid.UUID, method, target string, body interface{}) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		r = bytes.NewReader(b)
+	}
+	req := httptest.NewRequest(method, target, r)
+	req.Header.Set("Content-Type", "application/json")
+
+	token, err := jwt.NewToken(testSecret, &cargonaut.User{ID: userID})
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// fakeUserRepository keeps users and their resources in memory and records
+// the users deleted. Methods not needed by the tests are not implemented.
+type fakeUserRepository struct {
+	cargonaut.UserRepository
+
+	users     []*cargonaut.User
+	stats     *cargonaut.UserStatistics
+	vehicles  []*cargonaut.Vehicle
+	trips     []*cargonaut.Trip
+	ratings   []*cargonaut.Rating
+	tokens    []*cargonaut.Token
+	deleteErr error
+	deleted   []uuid.UUID
+}
+
+func (f *fakeUserRepository) GetUser(_ context.Context, userID uuid.UUID) (*cargonaut.User, error) {
+	for _, user := range f.users {
+		if uuid.Equal(user.ID, userID) {
+			return user, nil
+		}
+	}
+	return nil, cargonaut.ErrUserNotFound
+}
+
+func (f *fakeUserRepository) GetUserStatistics(context.Context, uuid.UUID) (*cargonaut.UserStatistics, error) {
+	if f.stats == nil {
+		return new(cargonaut.UserStatistics), nil
+	}
+	return f.stats, nil
+}
+
+func (f *fakeUserRepository) DeleteUser(_ context.Context, userID uuid.UUID) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, userID)
+	return nil
+}
+
+func (f *fakeUserRepository) ListTokens(_ context.Context, userID uuid.UUID) ([]*cargonaut.Token, error) {
+	tokens := make([]*cargonaut.Token, 0)
+	for _, token := range f.tokens {
+		if uuid.Equal(token.UserID, userID) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (f *fakeUserRepository) ListRatings(_ context.Context, userID uuid.UUID) ([]*cargonaut.Rating, error) {
+	ratings := make([]*cargonaut.Rating, 0)
+	for _, rating := range f.ratings {
+		if uuid.Equal(rating.UserID, userID) {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+func (f *fakeUserRepository) ListAuthoredRatings(_ context.Context, userID uuid.UUID) ([]*cargonaut.Rating, error) {
+	ratings := make([]*cargonaut.Rating, 0)
+	for _, rating := range f.ratings {
+		if uuid.Equal(rating.AuthorID, userID) {
+			ratings = append(ratings, rating)
+		}
+	}
+	return ratings, nil
+}
+
+func (f *fakeUserRepository) ListTrips(_ context.Context, userID uuid.UUID) ([]*cargonaut.Trip, error) {
+	trips := make([]*cargonaut.Trip, 0)
+	for _, trip := range f.trips {
+		if uuid.Equal(trip.UserID, userID) {
+			trips = append(trips, trip)
+		}
+	}
+	return trips, nil
+}
+
+func (f *fakeUserRepository) ListRides(_ context.Context, userID uuid.UUID) ([]*cargonaut.Trip, error) {
+	trips := make([]*cargonaut.Trip, 0)
+	for _, trip := range f.trips {
+		if trip.RiderID != nil && uuid.Equal(*trip.RiderID, userID) {
+			trips = append(trips, trip)
+		}
+	}
+	return trips, nil
+}
+
+func (f *fakeUserRepository) ListVehicles(_ context.Context, userID uuid.UUID) ([]*cargonaut.Vehicle, error) {
+	vehicles := make([]*cargonaut.Vehicle, 0)
+	for _, vehicle := range f.vehicles {
+		if uuid.Equal(vehicle.UserID, userID) && vehicle.OrganizationID == nil {
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+	return vehicles, nil
+}
+
+// fakeOrganizationRepository keeps organizations and their vehicles in memory.
+// All users are members of all organizations.
+type fakeOrganizationRepository struct {
+	cargonaut.OrganizationRepository
+
+	organizations []*cargonaut.Organization
+	vehicles      []*cargonaut.Vehicle
+}
+
+func (f *fakeOrganizationRepository) ListOrganizations(context.Context, uuid.UUID) ([]*cargonaut.Organization, error) {
+	return f.organizations, nil
+}
+
+func (f *fakeOrganizationRepository) ListVehicles(_ context.Context, organizationID uuid.UUID) ([]*cargonaut.Vehicle, error) {
+	vehicles := make([]*cargonaut.Vehicle, 0)
+	for _, vehicle := range f.vehicles {
+		if vehicle.OrganizationID != nil && uuid.Equal(*vehicle.OrganizationID, organizationID) {
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+	return vehicles, nil
+}
+
+// fakeVehicleRepository keeps vehicles and their files in memory.
+type fakeVehicleRepository struct {
+	cargonaut.VehicleRepository
+
+	vehicles []*cargonaut.Vehicle
+	files    []*cargonaut.VehicleFile
+}
+
+func (f *fakeVehicleRepository) CreateVehicle(_ context.Context, vehicle *cargonaut.Vehicle) error {
+	vehicle.ID = uuid.NewV4()
+	f.vehicles = append(f.vehicles, vehicle)
+	return nil
+}
+
+func (f *fakeVehicleRepository) ListFiles(_ context.Context, vehicleID uuid.UUID, kind cargonaut.VehicleFileKind) ([]*cargonaut.VehicleFile, error) {
+	files := make([]*cargonaut.VehicleFile, 0)
+	for _, file := range f.files {
+		if uuid.Equal(file.VehicleID, vehicleID) && file.Kind == kind {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// fakeNotificationRepository keeps notifications in memory.
+type fakeNotificationRepository struct {
+	cargonaut.NotificationRepository
+
+	notifications []*cargonaut.Notification
+}
+
+func (f *fakeNotificationRepository) ListNotifications(_ context.Context, userID uuid.UUID) ([]*cargonaut.Notification, error) {
+	notifications := make([]*cargonaut.Notification, 0)
+	for _, notification := range f.notifications {
+		if uuid.Equal(notification.UserID, userID) {
+			notifications = append(notifications, notification)
+		}
+	}
+	return notifications, nil
+}
+
+// fakeWalletRepository keeps wallets in memory. Users without a wallet have an
+// empty one.
+type fakeWalletRepository struct {
+	cargonaut.WalletRepository
+
+	wallets []*cargonaut.Wallet
+}
+
+func (f *fakeWalletRepository) GetWallet(_ context.Context, userID uuid.UUID) (*cargonaut.Wallet, error) {
+	for _, wallet := range f.wallets {
+		if uuid.Equal(wallet.UserID, userID) {
+			return wallet, nil
+		}
+	}
+	return &cargonaut.Wallet{UserID: userID}, nil
+}
+
+// fakeTokenBlacklist records the blacklisted tokens.
+type fakeTokenBlacklist struct {
+	blacklisted []*cargonaut.Token
+}
+
+func (f *fakeTokenBlacklist) IsTokenBlacklisted(_ context.Context, tokenID uuid.UUID) (bool, error) {
+	for _, token := range f.blacklisted {
+		if uuid.Equal(token.ID, tokenID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeTokenBlacklist) BlacklistToken(_ context.Context, tokens ...*cargonaut.Token) error {
+	f.blacklisted = append(f.blacklisted, tokens...)
+	return nil
+}
+
+// fakeBlobStore keeps blobs in memory.
+type fakeBlobStore map[string][]byte
+
+func (f fakeBlobStore) PutBlob(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f[key] = content
+	return nil
+}
+
+func (f fakeBlobStore) GetBlob(_ context.Context, key string) (io.ReadCloser, error) {
+	content, ok := f[key]
+	if !ok {
+		return nil, cargonaut.ErrBlobNotFound
+	}
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (f fakeBlobStore) DeleteBlob(_ context.Context, key string) error {
+	delete(f, key)
+	return nil
+}
+
+// fakeTripRepository keeps trips in memory and records the ratings created
+// along with their events.
+type fakeTripRepository struct {
+	cargonaut.TripRepository
+
+	trips     []*cargonaut.Trip
+	ratings   []*cargonaut.Rating
+	events    []*cargonaut.Event
+	createErr error
+}
+
+func (f *fakeTripRepository) GetTrip(_ context.Context, id uuid.UUID) (*cargonaut.Trip, error) {
+	for _, trip := range f.trips {
+		if uuid.Equal(trip.ID, id) {
+			return trip, nil
+		}
+	}
+	return nil, cargonaut.ErrTripNotFound
+}
+
+func (f *fakeTripRepository) CreateRating(_ context.Context, rating *cargonaut.Rating, events ...*cargonaut.Event) error {
+	if f.createErr != nil {
+		return f.createErr
+	}
+	rating.ID = uuid.NewV4()
+	f.ratings = append(f.ratings, rating)
+	f.events = append(f.events, events...)
+	return nil
+}
+
+// fakeMessageRepository keeps messages in memory and records who marked them
+// read and the events created along with them.
+type fakeMessageRepository struct {
+	cargonaut.MessageRepository
+
+	messages []*cargonaut.Message
+	readBy   []uuid.UUID
+	events   []*cargonaut.Event
+}
+
+func (f *fakeMessageRepository) ListMessages(_ context.Context, tripID uuid.UUID) ([]*cargonaut.Message, error) {
+	messages := make([]*cargonaut.Message, 0)
+	for _, message := range f.messages {
+		if uuid.Equal(message.TripID, tripID) {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+func (f *fakeMessageRepository) CreateMessage(_ context.Context, message *cargonaut.Message, events ...*cargonaut.Event) error {
+	message.ID = uuid.NewV4()
+	f.messages = append(f.messages, message)
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func (f *fakeMessageRepository) MarkRead(_ context.Context, _, userID uuid.UUID) error {
+	f.readBy = append(f.readBy, userID)
+	return nil
+}
@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"net/http"
+)
+
+func (h *Handler) listJobs(w http.ResponseWriter, r *http.Request) {
+	if jobs, err := h.JobRepository.ListJobs(r.Context()); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, jobs)
+	}
+}
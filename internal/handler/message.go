@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// conversationTrip returns the trip identified by the id URL parameter if the
+// authenticated user participates in its conversation, which are the driver
+// and the booked rider. Otherwise an error is rendered.
+func (h *Handler) conversationTrip(w http.ResponseWriter, r *http.Request, authUserID uuid.UUID) (*cargonaut.Trip, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if !uuid.Equal(trip.UserID, authUserID) && (trip.RiderID == nil || !uuid.Equal(*trip.RiderID, authUserID)) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not access conversation of trip taken by other users")
+		return nil, false
+	}
+	return trip, true
+}
+
+func (h *Handler) listTripMessages(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.conversationTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+
+	// Opening the conversation marks all of its messages as read.
+	if messages, err := h.MessageRepository.ListMessages(r.Context(), trip.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if err = h.MessageRepository.MarkRead(r.Context(), trip.ID, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, messages)
+	}
+}
+
+func (h *Handler) createTripMessage(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.conversationTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+
+	var message cargonaut.Message
+	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if message.Body == "" {
+		verr.add("body", "must not be empty")
+	} else if len(message.Body) > 1024 {
+		verr.add("body", "must not be longer than 1024 characters")
+	}
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// Notify the other participant of the conversation, if any.
+	var events []*cargonaut.Event
+	if !uuid.Equal(trip.UserID, authUserID) {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeMessageCreated, trip.UserID, trip.ID))
+	} else if trip.RiderID != nil {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeMessageCreated, *trip.RiderID, trip.ID))
+	}
+
+	message.TripID = trip.ID
+	message.AuthorID = authUserID
+	if err := h.MessageRepository.CreateMessage(r.Context(), &message, events...); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) listCurrentUserConversations(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if conversations, err := h.MessageRepository.ListConversations(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, conversations)
+	}
+}
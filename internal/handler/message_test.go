@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+func TestTripMessages(t *testing.T) {
+	driverID, riderID := uuid.NewV4(), uuid.NewV4()
+
+	tests := []struct {
+		name        string
+		unbooked    bool
+		userID      uuid.UUID
+		code        int
+		recipientID *uuid.UUID
+	}{
+		{name: "driver", userID: driverID, code: http.StatusOK, recipientID: &riderID},
+		{name: "rider", userID: riderID, code: http.StatusOK, recipientID: &driverID},
+		{name: "driver of unbooked trip", unbooked: true, userID: driverID, code: http.StatusOK},
+		{name: "other user", userID: uuid.NewV4(), code: http.StatusForbidden},
+		{name: "previous rider", unbooked: true, userID: riderID, code: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trip := &cargonaut.Trip{ID: uuid.NewV4(), UserID: driverID}
+			if !tt.unbooked {
+				rider := riderID
+				trip.RiderID = &rider
+			}
+			messages := &fakeMessageRepository{messages: []*cargonaut.Message{
+				{ID: uuid.NewV4(), TripID: trip.ID, AuthorID: driverID, Body: "Hello"},
+			}}
+			h := newTestHandler(t)
+			h.TripRepository = &fakeTripRepository{trips: []*cargonaut.Trip{trip}}
+			h.MessageRepository = messages
+			target := "/api/v1/trips/" + trip.ID.String() + "/messages"
+
+			// Listing the messages marks them read by the participant.
+			rec := serve(t, h, tt.userID, http.MethodGet, target, nil)
+			require.Equal(t, tt.code, rec.Code, rec.Body.String())
+			if tt.code == http.StatusOK {
+				assert.Equal(t, []uuid.UUID{tt.userID}, messages.readBy)
+				assert.Contains(t, rec.Body.String(), "Hello")
+			} else {
+				assert.Empty(t, messages.readBy)
+			}
+
+			// Writing a message notifies the other participant, if any.
+			rec = serve(t, h, tt.userID, http.MethodPost, target, map[string]string{"body": "Message"})
+			if tt.code != http.StatusOK {
+				require.Equal(t, tt.code, rec.Code, rec.Body.String())
+				assert.Len(t, messages.messages, 1)
+				return
+			}
+			require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+			if assert.Len(t, messages.messages, 2) {
+				assert.Equal(t, tt.userID, messages.messages[1].AuthorID)
+				assert.Equal(t, trip.ID, messages.messages[1].TripID)
+			}
+			if tt.recipientID == nil {
+				assert.Empty(t, messages.events)
+			} else if assert.Len(t, messages.events, 1) {
+				assert.Equal(t, cargonaut.EventTypeMessageCreated, messages.events[0].Type)
+				assert.Equal(t, *tt.recipientID, messages.events[0].UserID)
+			}
+		})
+	}
+}
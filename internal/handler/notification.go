@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+func (h *Handler) listCurrentUserNotifications(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if notifications, err := h.NotificationRepository.ListNotifications(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, notifications)
+	}
+}
+
+func (h *Handler) markCurrentUserNotificationRead(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if err = h.NotificationRepository.MarkNotificationRead(r.Context(), id, authUserID); err == cargonaut.ErrNotificationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) markAllCurrentUserNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.NotificationRepository.MarkAllNotificationsRead(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
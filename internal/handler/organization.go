@@ -0,0 +1,467 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// saveOrganizationMemberRequest is the request to add a member to an
+// organization or to change the role of a member.
+type saveOrganizationMemberRequest struct {
+	Role cargonaut.OrganizationRole `json:"role"`
+}
+
+// validateOrganization validates an organization.
+func validateOrganization(verr validationError, organization *cargonaut.Organization) {
+	if name := strings.TrimSpace(organization.Name); name == "" {
+		verr.add("name", "must not be empty")
+	} else if len(name) > 128 {
+		verr.add("name", "must not be longer than 128 characters")
+	}
+}
+
+// requireOrganizationRole is a middleware which only lets authenticated
+// members of the organization identified by the id URL parameter pass, who
+// have one of the given roles. Without roles, all members pass.
+func (h *Handler) requireOrganizationRole(roles ...cargonaut.OrganizationRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+			if !ok {
+				return
+			}
+
+			id, err := uuid.FromString(chi.URLParam(r, "id"))
+			if err != nil {
+				h.renderError(w, r, http.StatusBadRequest, err)
+				return
+			}
+
+			member, err := h.OrganizationRepository.GetMember(r.Context(), id, authUserID)
+			if err == cargonaut.ErrOrganizationMemberNotFound {
+				// Organizations are not revealed to users outside of them.
+				h.renderError(w, r, http.StatusNotFound, cargonaut.ErrOrganizationNotFound)
+				return
+			} else if err != nil {
+				h.renderError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+
+			if len(roles) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			for _, role := range roles {
+				if member.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			h.renderErrorf(w, r, http.StatusForbidden, "insufficient permissions")
+		})
+	}
+}
+
+// organizationScope returns the unique ID of the organization a listing is
+// scoped to by the organization query parameter, which is nil if there is
+// none. Only members of the organization can scope listings to it. If false
+// is returned, an error has been rendered.
+func (h *Handler) organizationScope(w http.ResponseWriter, r *http.Request) (*uuid.UUID, bool) {
+	param := r.URL.Query().Get("organization")
+	if param == "" {
+		return nil, true
+	}
+
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.FromString(param)
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	if _, err = h.OrganizationRepository.GetMember(r.Context(), id, authUserID); err == cargonaut.ErrOrganizationMemberNotFound {
+		// Organizations are not revealed to users outside of them.
+		h.renderError(w, r, http.StatusNotFound, cargonaut.ErrOrganizationNotFound)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return &id, true
+}
+
+// mayManageVehicle returns true if the user identified by his unique ID
+// registered the vehicle or, if it is owned by an organization, dispatches for
+// it. Members who registered a vehicle of an organization lose access to it
+// when they leave the organization or stop dispatching for it.
+func (h *Handler) mayManageVehicle(ctx context.Context, vehicle *cargonaut.Vehicle, userID uuid.UUID) (bool, error) {
+	if vehicle.OrganizationID == nil {
+		return uuid.Equal(vehicle.UserID, userID), nil
+	}
+
+	member, err := h.OrganizationRepository.GetMember(ctx, *vehicle.OrganizationID, userID)
+	if err == cargonaut.ErrOrganizationMemberNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return member.Role.CanDispatch(), nil
+}
+
+// mayManageTrip returns true if the user identified by his unique ID drives
+// the trip or dispatches for the organization owning its vehicle.
+func (h *Handler) mayManageTrip(ctx context.Context, trip *cargonaut.Trip, userID uuid.UUID) (bool, error) {
+	if uuid.Equal(trip.UserID, userID) {
+		return true, nil
+	}
+
+	vehicle, err := h.VehicleRepository.GetVehicle(ctx, trip.VehicleID)
+	if err == cargonaut.ErrVehicleNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else if vehicle.OrganizationID == nil {
+		return false, nil
+	}
+	return h.mayManageVehicle(ctx, vehicle, userID)
+}
+
+func (h *Handler) listOrganizations(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if organizations, err := h.OrganizationRepository.ListOrganizations(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, organizations)
+	}
+}
+
+func (h *Handler) getOrganization(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if organization, err := h.OrganizationRepository.GetOrganization(r.Context(), id); err == cargonaut.ErrOrganizationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, organization)
+	}
+}
+
+func (h *Handler) createOrganization(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	var organization cargonaut.Organization
+	if err := json.NewDecoder(r.Body).Decode(&organization); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	validateOrganization(verr, &organization)
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// The user creating the organization becomes its first owner.
+	organization.Name = strings.TrimSpace(organization.Name)
+	if err := h.OrganizationRepository.CreateOrganization(r.Context(), &organization, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.render(w, r, http.StatusCreated, organization)
+	}
+}
+
+func (h *Handler) patchOrganization(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	organization, err := h.OrganizationRepository.GetOrganization(r.Context(), id)
+	if err == cargonaut.ErrOrganizationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	var patched cargonaut.Organization
+	if err = applyMergePatch(r, organization, &patched); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	validateOrganization(verr, &patched)
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	patched.ID = organization.ID
+	patched.Name = strings.TrimSpace(patched.Name)
+	if err = h.OrganizationRepository.UpdateOrganization(r.Context(), &patched); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if organization, err = h.OrganizationRepository.GetOrganization(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, organization)
+	}
+}
+
+func (h *Handler) deleteOrganization(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if err := h.OrganizationRepository.DeleteOrganization(r.Context(), id); err == cargonaut.ErrOrganizationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err == cargonaut.ErrVehicleExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) listOrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if members, err := h.OrganizationRepository.ListMembers(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, members)
+	}
+}
+
+func (h *Handler) saveOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.FromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req saveOrganizationMemberRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	switch req.Role {
+	case cargonaut.OrganizationRoleOwner, cargonaut.OrganizationRoleDispatcher, cargonaut.OrganizationRoleDriver:
+	default:
+		verr.add("role", "must be one of owner, dispatcher or driver")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if _, err = h.UserRepository.GetUser(r.Context(), userID); err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	member := &cargonaut.OrganizationMember{
+		OrganizationID: id,
+		UserID:         userID,
+		Role:           req.Role,
+	}
+	if err = h.OrganizationRepository.SaveMember(r.Context(), member); err == cargonaut.ErrOrganizationNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err == cargonaut.ErrOrganizationOwnerRequired {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, member)
+	}
+}
+
+func (h *Handler) removeOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID, err := uuid.FromString(chi.URLParam(r, "user_id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Members can leave the organization on their own, but only owners can
+	// remove other members.
+	if !uuid.Equal(userID, authUserID) {
+		if member, err := h.OrganizationRepository.GetMember(r.Context(), id, authUserID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if member.Role != cargonaut.OrganizationRoleOwner {
+			h.renderErrorf(w, r, http.StatusForbidden, "can not remove other members without being owner")
+			return
+		}
+	}
+
+	if err = h.OrganizationRepository.RemoveMember(r.Context(), id, userID); err == cargonaut.ErrOrganizationNotFound || err == cargonaut.ErrOrganizationMemberNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err == cargonaut.ErrOrganizationOwnerRequired {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) listOrganizationVehicles(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if vehicles, err := h.OrganizationRepository.ListVehicles(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, vehicles)
+	}
+}
+
+func (h *Handler) createOrganizationVehicle(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var vehicle cargonaut.Vehicle
+	if err = json.NewDecoder(r.Body).Decode(&vehicle); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	vehicle.LicensePlate = normalizeLicensePlate(vehicle.LicensePlate)
+	verr := make(validationError)
+	validateVehicle(verr, &vehicle)
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	vehicle.UserID = authUserID
+	vehicle.OrganizationID = &id
+	if err = h.VehicleRepository.CreateVehicle(r.Context(), &vehicle); err == cargonaut.ErrVehicleExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.render(w, r, http.StatusCreated, vehicle)
+	}
+}
+
+func (h *Handler) listOrganizationTrips(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if trips, err := h.OrganizationRepository.ListTrips(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, trips)
+	}
+}
+
+func (h *Handler) createOrganizationTrip(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var trip cargonaut.Trip
+	if err = json.NewDecoder(r.Body).Decode(&trip); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Dispatchers create trips on behalf of the members driving them. Without
+	// a driver, the dispatcher drives the trip.
+	if uuid.Equal(trip.UserID, uuid.Nil) {
+		trip.UserID = authUserID
+	}
+
+	verr := make(validationError)
+	validatePlannedDepature(verr, nil, &trip, time.Now().UTC())
+	if _, err = h.OrganizationRepository.GetMember(r.Context(), id, trip.UserID); err == cargonaut.ErrOrganizationMemberNotFound {
+		verr.add("user_id", "must be a member of the organization")
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), trip.VehicleID); err == cargonaut.ErrVehicleNotFound {
+		verr.add("vehicle_id", "must be a vehicle of the organization")
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if vehicle.OrganizationID == nil || !uuid.Equal(*vehicle.OrganizationID, id) {
+		verr.add("vehicle_id", "must be a vehicle of the organization")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	trip.ID = uuid.NewV4()
+	resetTripProgress(&trip)
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripCreated, trip.UserID, trip.ID)
+	if err = h.TripRepository.CreateTrip(r.Context(), &trip, event); err == cargonaut.ErrTripExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.warnFarPrice(w, r, &trip)
+		render.NoContent(w, r)
+	}
+}
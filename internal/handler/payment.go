@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// maxPaymentWebhookSize is the maximum size of a webhook payload accepted
+// from the payment provider.
+const maxPaymentWebhookSize = 1 << 20
+
+type paymentRequest struct {
+	Amount int64 `json:"amount"`
+}
+
+// paymentApprover is implemented by payment providers which let users approve
+// or reject their top-ups through the API instead of at the provider, like the
+// fake payment provider used for development.
+type paymentApprover interface {
+	Authorize(reference string) error
+	Decline(reference string) error
+}
+
+func (h *Handler) listCurrentUserPayments(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if payments, err := h.PaymentRepository.ListPayments(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, payments)
+	}
+}
+
+// createCurrentUserPayment returns a handler which creates a payment of the
+// given type for the current user and an intent for it at the payment
+// provider. The payment fails if the intent can not be created.
+func (h *Handler) createCurrentUserPayment(typ cargonaut.PaymentType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+		if !ok {
+			return
+		}
+
+		var req paymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		verr := make(validationError)
+		if req.Amount <= 0 {
+			verr.add("amount", "must be positive")
+		}
+		if err := verr.err(); err != nil {
+			h.renderError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		payment := &cargonaut.Payment{
+			UserID: authUserID,
+			Type:   typ,
+			Amount: req.Amount,
+		}
+		if err := h.PaymentRepository.CreatePayment(r.Context(), payment); err == cargonaut.ErrInsufficientFunds {
+			h.renderError(w, r, http.StatusPaymentRequired, err)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		intent, err := h.PaymentProvider.CreateIntent(r.Context(), payment)
+		if err != nil {
+			if ferr := h.PaymentRepository.FailPayment(r.Context(), payment.ID); ferr != nil {
+				h.log.Printf("fail payment %q: %s", payment.ID, ferr)
+			}
+			h.renderErrorf(w, r, http.StatusBadGateway, "create payment intent: %w", err)
+			return
+		}
+		if err = h.PaymentRepository.SetPaymentIntent(r.Context(), payment.ID, intent); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		payment.Reference = &intent.Reference
+		if intent.RedirectURL != "" {
+			payment.RedirectURL = &intent.RedirectURL
+		}
+
+		h.render(w, r, http.StatusCreated, payment)
+	}
+}
+
+// approveCurrentUserPayment returns a handler which authorizes or declines a
+// pending top-up of the current user at the payment provider, if it supports
+// approving payments through the API.
+func (h *Handler) approveCurrentUserPayment(authorize bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+		if !ok {
+			return
+		}
+
+		approver, ok := h.PaymentProvider.(paymentApprover)
+		if !ok {
+			h.renderErrorf(w, r, http.StatusNotFound, "payment provider does not support approving payments")
+			return
+		}
+
+		id, err := uuid.FromString(chi.URLParam(r, "id"))
+		if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		// Payments of other users are not revealed.
+		payment, err := h.PaymentRepository.GetPayment(r.Context(), id)
+		if err == cargonaut.ErrPaymentNotFound || (err == nil && !uuid.Equal(payment.UserID, authUserID)) {
+			h.renderError(w, r, http.StatusNotFound, cargonaut.ErrPaymentNotFound)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if payment.Type != cargonaut.PaymentTypeTopUp || payment.Status != cargonaut.PaymentStatusPending || payment.Reference == nil {
+			h.renderErrorf(w, r, http.StatusConflict, "can not approve payment which is not a pending top-up")
+			return
+		}
+
+		if authorize {
+			err = approver.Authorize(*payment.Reference)
+		} else {
+			err = approver.Decline(*payment.Reference)
+		}
+		if err != nil {
+			h.renderErrorf(w, r, http.StatusBadGateway, "approve payment: %w", err)
+			return
+		}
+
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) refundPayment(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req paymentRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if req.Amount <= 0 {
+		verr.add("amount", "must be positive")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	payment, err := h.PaymentRepository.GetPayment(r.Context(), id)
+	if err == cargonaut.ErrPaymentNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if payment.Reference == nil {
+		h.renderError(w, r, http.StatusConflict, cargonaut.ErrPaymentNotRefundable)
+		return
+	}
+
+	if err = h.PaymentRepository.RefundPayment(r.Context(), id, req.Amount); err == cargonaut.ErrPaymentNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err == cargonaut.ErrPaymentNotRefundable {
+		h.renderError(w, r, http.StatusConflict, err)
+		return
+	} else if err == cargonaut.ErrInsufficientFunds {
+		h.renderError(w, r, http.StatusPaymentRequired, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.PaymentProvider.Refund(r.Context(), *payment.Reference, req.Amount); err != nil {
+		if ferr := h.PaymentRepository.FailRefund(r.Context(), id, req.Amount); ferr != nil {
+			h.log.Printf("fail refund of payment %q: %s", id, ferr)
+		}
+		h.renderErrorf(w, r, http.StatusBadGateway, "refund payment: %w", err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (h *Handler) receivePaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := ioutil.ReadAll(io.LimitReader(r.Body, maxPaymentWebhookSize))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.HandlePaymentWebhook(r.Context(), payload, r.Header); err == cargonaut.ErrInvalidPaymentWebhook {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if err == cargonaut.ErrPaymentNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+// HandlePaymentWebhook verifies a webhook delivered by the payment provider
+// and reconciles the payment it refers to with the event it carries.
+// Authorized top-ups are captured before the event is processed, so a failed
+// capture is retried with the next delivery. Events which have been processed
+// before are acknowledged without further ado.
+func (h *Handler) HandlePaymentWebhook(ctx context.Context, payload []byte, header http.Header) error {
+	event, err := h.PaymentProvider.VerifyWebhook(payload, header)
+	if err != nil {
+		return err
+	}
+
+	if event.Type == cargonaut.PaymentEventTypeAuthorized {
+		payment, err := h.PaymentRepository.GetPaymentByReference(ctx, event.Reference)
+		if err != nil {
+			return err
+		}
+		if payment.Status == cargonaut.PaymentStatusPending || payment.Status == cargonaut.PaymentStatusAuthorized {
+			if err = h.PaymentProvider.Capture(ctx, event.Reference); err != nil {
+				return fmt.Errorf("capture payment %q: %w", payment.ID, err)
+			}
+		}
+	}
+
+	if err = h.PaymentRepository.ProcessPaymentEvent(ctx, event); err == cargonaut.ErrPaymentEventProcessed {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/go-chi/chi"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// promoCodePattern matches valid promo codes.
+var promoCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{3,32}$`)
+
+// referralCode is the representation of the referral code of a user along
+// with the link to share it.
+type referralCode struct {
+	*cargonaut.ReferralCode
+	Link string `json:"link"`
+}
+
+// validatePromoCode validates a promo code.
+func validatePromoCode(verr validationError, code *cargonaut.PromoCode) {
+	if !promoCodePattern.MatchString(code.Code) {
+		verr.add("code", "must consist of 3 to 32 letters, digits, dashes or underscores")
+	}
+	switch code.Type {
+	case cargonaut.PromoCodeTypePercentage:
+		if code.Value <= 0 || code.Value > 10000 {
+			verr.add("value", "must be between 1 and 10000 basis points")
+		}
+	case cargonaut.PromoCodeTypeFixed:
+		if code.Value <= 0 {
+			verr.add("value", "must be positive")
+		}
+	default:
+		verr.add("type", "must be one of percentage or fixed")
+	}
+	if code.MaxRedemptions != nil && *code.MaxRedemptions <= 0 {
+		verr.add("max_redemptions", "must be positive")
+	}
+	if code.MaxRedemptionsPerUser <= 0 {
+		verr.add("max_redemptions_per_user", "must be positive")
+	}
+	if code.ValidFrom != nil && code.ValidUntil != nil && !code.ValidUntil.After(*code.ValidFrom) {
+		verr.add("valid_until", "must be after valid_from")
+	}
+}
+
+func (h *Handler) listPromoCodes(w http.ResponseWriter, r *http.Request) {
+	if codes, err := h.PromotionRepository.ListPromoCodes(r.Context()); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, codes)
+	}
+}
+
+func (h *Handler) getPromoCode(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if code, err := h.PromotionRepository.GetPromoCode(r.Context(), id); err == cargonaut.ErrPromoCodeNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, code)
+	}
+}
+
+func (h *Handler) createPromoCode(w http.ResponseWriter, r *http.Request) {
+	// Promo codes can be redeemed once per user unless stated otherwise.
+	code := cargonaut.PromoCode{MaxRedemptionsPerUser: 1}
+	if err := json.NewDecoder(r.Body).Decode(&code); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	validatePromoCode(verr, &code)
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	code.Redemptions = 0
+	if err := h.PromotionRepository.CreatePromoCode(r.Context(), &code); err == cargonaut.ErrPromoCodeExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.render(w, r, http.StatusCreated, code)
+	}
+}
+
+func (h *Handler) patchPromoCode(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	code, err := h.PromotionRepository.GetPromoCode(r.Context(), id)
+	if err == cargonaut.ErrPromoCodeNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	var patched cargonaut.PromoCode
+	if err = applyMergePatch(r, code, &patched); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	validatePromoCode(verr, &patched)
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	patched.ID = code.ID
+	if err = h.PromotionRepository.UpdatePromoCode(r.Context(), &patched); err == cargonaut.ErrPromoCodeExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if patched, err := h.PromotionRepository.GetPromoCode(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, patched)
+	}
+}
+
+func (h *Handler) listPromoRedemptions(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if _, err := h.PromotionRepository.GetPromoCode(r.Context(), id); err == cargonaut.ErrPromoCodeNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if redemptions, err := h.PromotionRepository.ListPromoRedemptions(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, redemptions)
+	}
+}
+
+func (h *Handler) getCurrentUserReferralCode(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if code, err := h.PromotionRepository.GetReferralCode(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		link := "/#/register?" + url.Values{"referral_code": {code.Code}}.Encode()
+		h.renderOK(w, r, referralCode{ReferralCode: code, Link: link})
+	}
+}
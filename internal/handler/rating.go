@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+type ratingReplyRequest struct {
+	Reply string `json:"reply"`
+}
+
+type ratingReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+type moderationQueueEntry struct {
+	Rating  *cargonaut.Rating         `json:"rating"`
+	Reports []*cargonaut.RatingReport `json:"reports"`
+}
+
+func (h *Handler) replyToRating(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req ratingReplyRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if req.Reply == "" {
+		verr.add("reply", "must not be empty")
+	} else if len(req.Reply) > 512 {
+		verr.add("reply", "must not be longer than 512 characters")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// Only the rated user can reply to a rating.
+	if rating, err := h.RatingRepository.GetRating(r.Context(), id); err == cargonaut.ErrRatingNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if !uuid.Equal(rating.UserID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not reply to rating given to other user")
+	} else if err = h.RatingRepository.ReplyToRating(r.Context(), id, req.Reply); err == cargonaut.ErrRatingReplyExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) reportRating(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req ratingReportRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if req.Reason == "" {
+		verr.add("reason", "must not be empty")
+	} else if len(req.Reason) > 512 {
+		verr.add("reason", "must not be longer than 512 characters")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	report := &cargonaut.RatingReport{
+		RatingID:   id,
+		ReporterID: authUserID,
+		Reason:     req.Reason,
+	}
+	if _, err := h.RatingRepository.GetRating(r.Context(), id); err == cargonaut.ErrRatingNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if err = h.RatingRepository.CreateReport(r.Context(), report); err == cargonaut.ErrRatingReportExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) listReportedRatings(w http.ResponseWriter, r *http.Request) {
+	ratings, err := h.RatingRepository.ListReportedRatings(r.Context())
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	queue := make([]*moderationQueueEntry, 0, len(ratings))
+	for _, rating := range ratings {
+		reports, err := h.RatingRepository.ListReports(r.Context(), rating.ID)
+		if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		queue = append(queue, &moderationQueueEntry{
+			Rating:  rating,
+			Reports: reports,
+		})
+	}
+
+	h.renderOK(w, r, queue)
+}
+
+func (h *Handler) moderateRating(action cargonaut.ModerationAction) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+		if !ok {
+			return
+		}
+
+		if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+		} else if err = h.RatingRepository.ModerateRating(r.Context(), id, authUserID, action); err == cargonaut.ErrRatingNotFound {
+			h.renderError(w, r, http.StatusNotFound, err)
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+		} else {
+			render.NoContent(w, r)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/receipt"
+)
+
+func (h *Handler) getBookingReceipt(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	booking, err := h.BookingRepository.GetBooking(r.Context(), id)
+	if err == cargonaut.ErrBookingNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Only the rider and the driver get to see the receipt.
+	if !uuid.Equal(booking.UserID, authUserID) {
+		if trip, err := h.TripRepository.GetTrip(r.Context(), booking.TripID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if !uuid.Equal(trip.UserID, authUserID) {
+			h.renderErrorf(w, r, http.StatusForbidden, "can not access receipt of booking of another user")
+			return
+		}
+	}
+
+	rcpt, err := h.ReceiptRepository.IssueReceipt(r.Context(), booking.ID, h.VATRate)
+	if err == cargonaut.ErrReceiptNotAvailable {
+		h.renderError(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = receipt.Render(&buf, rcpt); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=\"receipt-%s.pdf\"", rcpt.Number()))
+	if _, err = buf.WriteTo(w); err != nil {
+		h.log.Printf("[%s %s]: write receipt: %s", r.Method, requestURI(r), err)
+	}
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
@@ -11,8 +14,38 @@ import (
 	"github.com/my-cargonaut/cargonaut"
 )
 
+const (
+	// priceHistory is how far back the prices of comparable trips are taken
+	// into account when suggesting prices.
+	priceHistory = 365 * 24 * time.Hour
+	// earlyStart is how long before its planned depature a trip can be
+	// started.
+	earlyStart = time.Hour
+)
+
+type tripCancellationRequest struct {
+	Reason string `json:"reason"`
+}
+
 func (h *Handler) listTrips(w http.ResponseWriter, r *http.Request) {
-	if trips, err := h.TripRepository.ListTrips(r.Context()); err != nil {
+	filter := &cargonaut.TripFilter{
+		Sort: cargonaut.TripSortUpdated,
+	}
+	if sort := r.URL.Query().Get("sort"); sort != "" {
+		switch filter.Sort = cargonaut.TripSort(sort); filter.Sort {
+		case cargonaut.TripSortUpdated, cargonaut.TripSortDriverScore:
+		default:
+			h.renderErrorf(w, r, http.StatusBadRequest, "invalid sort order %q", sort)
+			return
+		}
+	}
+
+	var ok bool
+	if filter.OrganizationID, ok = h.organizationScope(w, r); !ok {
+		return
+	}
+
+	if trips, err := h.TripRepository.ListTrips(r.Context(), filter); err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 	} else {
 		h.renderOK(w, r, trips)
@@ -43,12 +76,23 @@ func (h *Handler) createTrip(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	verr := make(validationError)
+	validatePlannedDepature(verr, nil, &trip, time.Now().UTC())
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	trip.ID = uuid.NewV4()
 	trip.UserID = authUserID
-	if err := h.TripRepository.CreateTrip(r.Context(), &trip); err == cargonaut.ErrTripExists {
+	resetTripProgress(&trip)
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripCreated, trip.UserID, trip.ID)
+	if err := h.TripRepository.CreateTrip(r.Context(), &trip, event); err == cargonaut.ErrTripExists {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 	} else {
+		h.warnFarPrice(w, r, &trip)
 		render.NoContent(w, r)
 	}
 }
@@ -72,21 +116,268 @@ func (h *Handler) updateTrip(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Make sure we can not update a trip of another users by making sure the
-	// user ID of the stored trip and the authenticated user match.
-	if trip, err := h.TripRepository.GetTrip(r.Context(), id); err == cargonaut.ErrTripNotFound {
+	// authenticated user drives the stored trip or dispatches it.
+	stored, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 		return
-	} else if !uuid.Equal(trip.UserID, authUserID) {
+	} else if ok, err := h.mayManageTrip(r.Context(), stored, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
 		h.renderErrorf(w, r, http.StatusForbidden, "can not update trip of another user")
 		return
+	} else if stored.Cancelled() {
+		h.renderErrorf(w, r, http.StatusConflict, "can not update cancelled trip")
+		return
 	}
 
-	trip.UserID = authUserID
+	if !h.validateTripUpdate(w, r, stored, &trip) {
+		return
+	}
+
+	// The driver and bookings can not be changed by updating the trip.
+	trip.UserID = stored.UserID
 	trip.ID = uuid.FromStringOrNil(chi.URLParam(r, "id"))
-	if err := h.TripRepository.UpdateTrip(r.Context(), &trip); err == cargonaut.ErrTripExists {
+	trip.RiderID = stored.RiderID
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripUpdated, trip.UserID, trip.ID)
+	if err := h.TripRepository.UpdateTrip(r.Context(), &trip, event); err == cargonaut.ErrTripExists || err == cargonaut.ErrTripBooked {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.warnFarPrice(w, r, &trip)
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) patchTrip(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Make sure we can not update a trip of another users by making sure the
+	// authenticated user drives the stored trip or dispatches it.
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if ok, err := h.mayManageTrip(r.Context(), trip, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not update trip of another user")
+		return
+	} else if trip.Cancelled() {
+		h.renderErrorf(w, r, http.StatusConflict, "can not update cancelled trip")
+		return
+	}
+
+	var patched cargonaut.Trip
+	if err = applyMergePatch(r, trip, &patched); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !h.validateTripUpdate(w, r, trip, &patched) {
+		return
+	}
+
+	// Ownership and booking can not be changed by patching the trip.
+	patched.ID = trip.ID
+	patched.UserID = trip.UserID
+	patched.RiderID = trip.RiderID
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripUpdated, patched.UserID, patched.ID)
+	if err = h.TripRepository.UpdateTrip(r.Context(), &patched, event); err == cargonaut.ErrTripExists || err == cargonaut.ErrTripBooked {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if patched, err := h.TripRepository.GetTrip(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.warnFarPrice(w, r, patched)
+		h.renderOK(w, r, patched)
+	}
+}
+
+func (h *Handler) getTripPriceSuggestion(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, destination := query.Get("start"), query.Get("destination")
+
+	verr := make(validationError)
+	if start == "" {
+		verr.add("start", "must not be empty")
+	}
+	if destination == "" {
+		verr.add("destination", "must not be empty")
+	}
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	typ := cargonaut.VehicleTypeCar
+	if v := query.Get("vehicle_id"); v != "" {
+		vehicleID, err := uuid.FromString(v)
+		if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), vehicleID)
+		if err == cargonaut.ErrVehicleNotFound {
+			h.renderError(w, r, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		typ = vehicle.Type
+	}
+
+	if suggestion, err := h.suggestPrice(r.Context(), start, destination, typ); err == cargonaut.ErrLocationNotFound {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, suggestion)
+	}
+}
+
+// suggestPrice suggests a price for a trip from the start to the destination
+// with a vehicle of the given type. Unknown locations are tolerated as long as
+// there are enough comparable trips. Otherwise ErrLocationNotFound is returned.
+func (h *Handler) suggestPrice(ctx context.Context, start, destination string, typ cargonaut.VehicleType) (*cargonaut.PriceSuggestion, error) {
+	distance, err := h.DistanceEstimator.EstimateDistance(ctx, start, destination)
+	if err != nil && err != cargonaut.ErrLocationNotFound {
+		return nil, err
+	}
+
+	since := time.Now().UTC().Add(-priceHistory)
+	prices, err := h.TripRepository.ListRoutePrices(ctx, start, destination, typ, since)
+	if err != nil {
+		return nil, err
+	}
+
+	if suggestion, ok := h.PricingPolicy.Suggest(distance, typ, prices); ok {
+		return suggestion, nil
+	}
+	return nil, cargonaut.ErrLocationNotFound
+}
+
+// warnFarPrice sets a warning header on the response if the price of the trip
+// is far outside of the suggested range. The price is only a hint, so failing
+// to suggest one never fails the request.
+func (h *Handler) warnFarPrice(w http.ResponseWriter, r *http.Request, trip *cargonaut.Trip) {
+	typ := cargonaut.VehicleTypeCar
+	if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), trip.VehicleID); err == nil {
+		typ = vehicle.Type
+	} else if err != cargonaut.ErrVehicleNotFound {
+		h.log.Printf("[%s %s]: get vehicle of trip %q: %s", r.Method, requestURI(r), trip.ID, err)
+		return
+	}
+
+	suggestion, err := h.suggestPrice(r.Context(), trip.Start, trip.Destination, typ)
+	if err == cargonaut.ErrLocationNotFound {
+		return
+	} else if err != nil {
+		h.log.Printf("[%s %s]: suggest price of trip %q: %s", r.Method, requestURI(r), trip.ID, err)
+		return
+	}
+
+	if suggestion.Far(trip.Price) {
+		w.Header().Set("Warning", fmt.Sprintf(`299 - "price far outside of suggested range %.2f to %.2f"`, suggestion.MinPrice, suggestion.MaxPrice))
+	}
+}
+
+// resetTripProgress clears the rider of a new trip along with the times it
+// started, finished, completed or was cancelled. Riders only get to a trip by
+// booking it and the times are only recorded as the trip progresses.
+func resetTripProgress(trip *cargonaut.Trip) {
+	trip.RiderID = nil
+	trip.Depature = time.Time{}
+	trip.Arrival = time.Time{}
+	trip.CompletedAt = nil
+	trip.CancelledAt = nil
+	trip.CancellationReason = nil
+}
+
+// validatePlannedDepature validates the planned depature of a created trip or
+// of an update to the stored trip. Every new trip must plan its depature.
+func validatePlannedDepature(verr validationError, stored, trip *cargonaut.Trip, now time.Time) {
+	if trip.PlannedDepature == nil {
+		if stored == nil || stored.PlannedDepature != nil {
+			verr.add("planned_depature", "must not be empty")
+		}
+		return
+	}
+
+	// An unchanged planned depature is accepted even when it has passed, so
+	// the driver can still start and finish the trip.
+	changed := stored == nil || stored.PlannedDepature == nil || !stored.PlannedDepature.Equal(*trip.PlannedDepature)
+	if changed && !trip.PlannedDepature.After(now) {
+		verr.add("planned_depature", "must be in the future")
+	}
+}
+
+// validateTripUpdate validates an update to the stored trip and renders the
+// error if it is invalid. The depature and arrival of a trip are only recorded
+// by starting and finishing it and booked trips keep their price and vehicle,
+// so riders pay what they booked and are picked up by the vehicle they chose.
+func (h *Handler) validateTripUpdate(w http.ResponseWriter, r *http.Request, stored, trip *cargonaut.Trip) bool {
+	verr := make(validationError)
+	validatePlannedDepature(verr, stored, trip, time.Now().UTC())
+	if !trip.Depature.Equal(stored.Depature) {
+		verr.add("depature", "can only be set by starting the trip")
+	}
+	if !trip.Arrival.Equal(stored.Arrival) {
+		verr.add("arrival", "can only be set by finishing the trip")
+	}
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return false
+	}
+
+	if stored.RiderID != nil && (trip.Price != stored.Price || !uuid.Equal(trip.VehicleID, stored.VehicleID)) {
+		h.renderErrorf(w, r, http.StatusConflict, "can not change price or vehicle of booked trip")
+		return false
+	}
+	return true
+}
+
+// startTrip records the depature of a trip on behalf of its driver. Trips can
+// be started no earlier than earlyStart before their planned depature.
+func (h *Handler) startTrip(w http.ResponseWriter, r *http.Request) {
+	trip, ok := h.managedTrip(w, r, "start")
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	if trip.PlannedDepature != nil && now.Before(trip.PlannedDepature.Add(-earlyStart)) {
+		h.renderErrorf(w, r, http.StatusConflict, "can not start trip more than %s before its planned depature", earlyStart)
+		return
+	}
+
+	events := []*cargonaut.Event{
+		cargonaut.NewEvent(cargonaut.EventTypeTripUpdated, trip.UserID, trip.ID),
+	}
+	if trip.RiderID != nil {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeTripStarted, *trip.RiderID, trip.ID))
+	}
+	if err := h.TripRepository.StartTrip(r.Context(), trip.ID, now, events...); err == cargonaut.ErrTripNotStartable {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
@@ -95,6 +386,61 @@ func (h *Handler) updateTrip(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// finishTrip records the arrival of a started trip on behalf of its driver.
+// Finished trips are completed and paid for by the completion job.
+func (h *Handler) finishTrip(w http.ResponseWriter, r *http.Request) {
+	trip, ok := h.managedTrip(w, r, "finish")
+	if !ok {
+		return
+	}
+
+	events := []*cargonaut.Event{
+		cargonaut.NewEvent(cargonaut.EventTypeTripUpdated, trip.UserID, trip.ID),
+	}
+	if trip.RiderID != nil {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeTripFinished, *trip.RiderID, trip.ID))
+	}
+	if err := h.TripRepository.FinishTrip(r.Context(), trip.ID, time.Now().UTC(), events...); err == cargonaut.ErrTripNotFinishable {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+// managedTrip returns the trip identified by the "id" URL parameter, if the
+// authenticated user drives or dispatches it. Otherwise the error is rendered,
+// naming the action which is not allowed.
+func (h *Handler) managedTrip(w http.ResponseWriter, r *http.Request, action string) (*cargonaut.Trip, bool) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return nil, false
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	} else if ok, err := h.mayManageTrip(r.Context(), trip, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	} else if !ok {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not %s trip of another user", action)
+		return nil, false
+	}
+	return trip, true
+}
+
 func (h *Handler) deleteTrip(w http.ResponseWriter, r *http.Request) {
 	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
 	if !ok {
@@ -107,17 +453,25 @@ func (h *Handler) deleteTrip(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Make sure we can not delete a trip of other users by making sure the user
-	// ID of the stored trip and the authenticated user match.
+	// Make sure we can not delete a trip of other users by making sure the
+	// authenticated user drives the stored trip or dispatches it.
 	if trip, err := h.TripRepository.GetTrip(r.Context(), id); err == cargonaut.ErrTripNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 		return
-	} else if !uuid.Equal(trip.UserID, authUserID) {
+	} else if ok, err := h.mayManageTrip(r.Context(), trip, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
 		h.renderErrorf(w, r, http.StatusForbidden, "can not delete trip of another user")
 		return
+	} else if trip.RiderID != nil {
+		// Deleting a booked trip would delete the booking and ratings along
+		// with it. It has to be cancelled instead.
+		h.renderErrorf(w, r, http.StatusConflict, "can not delete booked trip")
+		return
 	}
 
 	if err := h.TripRepository.DeleteTrip(r.Context(), id); err != nil {
@@ -127,15 +481,87 @@ func (h *Handler) deleteTrip(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) getTripRating(w http.ResponseWriter, r *http.Request) {
+// cancelTripAsDriver cancels a trip on behalf of its driver. Unlike deleting
+// the trip, the trip and its booking are kept and the rider is notified.
+func (h *Handler) cancelTripAsDriver(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var req tripCancellationRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if req.Reason == "" {
+		verr.add("reason", "must not be empty")
+	} else if len(req.Reason) > 512 {
+		verr.add("reason", "must not be longer than 512 characters")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if ok, err := h.mayManageTrip(r.Context(), trip, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not cancel trip of another user")
+		return
+	}
+
+	// Cancelling an unbooked trip affects no one, so it is never late. Trips
+	// cancelled by their dispatcher are cancelled on behalf of their driver.
+	cancellation := &cargonaut.TripCancellation{
+		TripID: trip.ID,
+		UserID: trip.UserID,
+		Role:   cargonaut.RatingRoleDriver,
+		Reason: req.Reason,
+		Late:   trip.RiderID != nil && h.CancellationPolicy.Late(trip, time.Now().UTC()),
+	}
+	events := []*cargonaut.Event{
+		cargonaut.NewEvent(cargonaut.EventTypeTripUpdated, trip.UserID, trip.ID),
+	}
+	if trip.RiderID != nil {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeTripCancelledByDriver, *trip.RiderID, trip.ID))
+	}
+	if err = h.TripRepository.CancelTrip(r.Context(), cancellation, events...); err == cargonaut.ErrTripNotCancellable {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) listTripRatings(w http.ResponseWriter, r *http.Request) {
 	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
 		h.renderError(w, r, http.StatusBadRequest, err)
-	} else if rating, err := h.TripRepository.GetTrip(r.Context(), id); err == cargonaut.ErrRatingNotFound {
+	} else if _, err := h.TripRepository.GetTrip(r.Context(), id); err == cargonaut.ErrTripNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if ratings, err := h.TripRepository.ListRatings(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
 	} else {
-		h.renderOK(w, r, rating)
+		h.renderOK(w, r, ratings)
 	}
 }
 
@@ -157,6 +583,18 @@ func (h *Handler) createTripRating(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	verr := make(validationError)
+	if rating.Value < 1 || rating.Value > 5 {
+		verr.add("value", "must be between 1 and 5")
+	}
+	if len(rating.Comment) > 128 {
+		verr.add("comment", "must not be longer than 128 characters")
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
 	trip, err := h.TripRepository.GetTrip(r.Context(), tripID)
 	if err == cargonaut.ErrTripNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
@@ -164,18 +602,33 @@ func (h *Handler) createTripRating(w http.ResponseWriter, r *http.Request) {
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 		return
-	} else if trip.RiderID != nil && !uuid.Equal(*trip.RiderID, authUserID) {
-		h.renderErrorf(w, r, http.StatusForbidden, "can not rate trip taken by another user")
+	} else if !trip.Completed() {
+		h.renderErrorf(w, r, http.StatusConflict, "can not rate trip which is not completed")
 		return
 	}
 
 	// Author is the user who sent the request. User is the user the rating will
-	// be given to.
-	rating.UserID = trip.UserID
+	// be given to. Driver and rider of the trip rate each other.
+	switch {
+	case uuid.Equal(trip.UserID, authUserID):
+		rating.UserID = *trip.RiderID
+		rating.Role = cargonaut.RatingRoleRider
+	case uuid.Equal(*trip.RiderID, authUserID):
+		rating.UserID = trip.UserID
+		rating.Role = cargonaut.RatingRoleDriver
+	default:
+		h.renderErrorf(w, r, http.StatusForbidden, "can not rate trip taken by other users")
+		return
+	}
+	if uuid.Equal(rating.UserID, uuid.Nil) {
+		h.renderErrorf(w, r, http.StatusConflict, "can not rate deleted user")
+		return
+	}
 	rating.AuthorID = authUserID
 	rating.TripID = tripID
 
-	if err := h.TripRepository.CreateRating(r.Context(), &rating); err == cargonaut.ErrRatingExists {
+	event := cargonaut.NewEvent(cargonaut.EventTypeRatingCreated, rating.UserID, tripID)
+	if err := h.TripRepository.CreateRating(r.Context(), &rating, event); err == cargonaut.ErrRatingExists {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
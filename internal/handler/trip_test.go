@@ -0,0 +1,77 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+func TestCreateTripRating(t *testing.T) {
+	driverID, riderID := uuid.NewV4(), uuid.NewV4()
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name      string
+		trip      func(*cargonaut.Trip)
+		authorID  uuid.UUID
+		value     float32
+		createErr error
+		code      int
+		userID    uuid.UUID
+		role      cargonaut.RatingRole
+	}{
+		{name: "driver rates rider", authorID: driverID, value: 4, code: http.StatusNoContent, userID: riderID, role: cargonaut.RatingRoleRider},
+		{name: "rider rates driver", authorID: riderID, value: 5, code: http.StatusNoContent, userID: driverID, role: cargonaut.RatingRoleDriver},
+		{name: "other user", authorID: uuid.NewV4(), value: 5, code: http.StatusForbidden},
+		{name: "not completed", trip: func(trip *cargonaut.Trip) { trip.CompletedAt = nil }, authorID: riderID, value: 5, code: http.StatusConflict},
+		{name: "not booked", trip: func(trip *cargonaut.Trip) { trip.RiderID = nil }, authorID: driverID, value: 5, code: http.StatusConflict},
+		{name: "deleted driver", trip: func(trip *cargonaut.Trip) { trip.UserID = uuid.Nil }, authorID: riderID, value: 5, code: http.StatusConflict},
+		{name: "rated twice", authorID: riderID, value: 5, createErr: cargonaut.ErrRatingExists, code: http.StatusConflict},
+		{name: "value too low", authorID: riderID, value: 0, code: http.StatusUnprocessableEntity},
+		{name: "value too high", authorID: riderID, value: 6, code: http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rider := riderID
+			trip := &cargonaut.Trip{ID: uuid.NewV4(), UserID: driverID, RiderID: &rider, CompletedAt: &now}
+			if tt.trip != nil {
+				tt.trip(trip)
+			}
+			trips := &fakeTripRepository{trips: []*cargonaut.Trip{trip}, createErr: tt.createErr}
+			h := newTestHandler(t)
+			h.TripRepository = trips
+
+			rec := serve(t, h, tt.authorID, http.MethodPost, "/api/v1/trips/"+trip.ID.String()+"/ratings", map[string]interface{}{
+				"value":   tt.value,
+				"comment": "Comment",
+				// The rated user and the role are derived from the author.
+				"user_id": tt.authorID,
+				"role":    cargonaut.RatingRoleDriver,
+			})
+			require.Equal(t, tt.code, rec.Code, rec.Body.String())
+
+			if tt.code != http.StatusNoContent {
+				assert.Empty(t, trips.ratings)
+				return
+			}
+			if assert.Len(t, trips.ratings, 1) {
+				rating := trips.ratings[0]
+				assert.Equal(t, tt.userID, rating.UserID)
+				assert.Equal(t, tt.authorID, rating.AuthorID)
+				assert.Equal(t, trip.ID, rating.TripID)
+				assert.Equal(t, tt.role, rating.Role)
+				assert.Equal(t, tt.value, rating.Value)
+			}
+			if assert.Len(t, trips.events, 1) {
+				assert.Equal(t, cargonaut.EventTypeRatingCreated, trips.events[0].Type)
+				assert.Equal(t, tt.userID, trips.events[0].UserID)
+			}
+		})
+	}
+}
@@ -1,18 +1,152 @@
 package handler
 
 import (
-	"bytes"
-	"encoding/base64"
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/notify"
+	"github.com/my-cargonaut/cargonaut/internal/upload"
+	"github.com/my-cargonaut/cargonaut/pkg/password"
 )
 
+// emailVerificationExpiration is the duration a pending E-Mail address change
+// can be verified.
+const emailVerificationExpiration = time.Hour * 24
+
+// profile is the part of a user resource the user is allowed to change. The
+// avatar is only present if it should be replaced.
+type profile struct {
+	Email         string                         `json:"email"`
+	DisplayName   string                         `json:"display_name"`
+	Birthday      time.Time                      `json:"birthday"`
+	Avatar        string                         `json:"avatar,omitempty"`
+	Privacy       cargonaut.PrivacySettings      `json:"privacy"`
+	Notifications cargonaut.NotificationSettings `json:"notifications"`
+}
+
+// privateProfile is the projection of a user visible to the user himself. It
+// contains all user details.
+type privateProfile struct {
+	*cargonaut.User
+	PendingEmail string                    `json:"pending_email,omitempty"`
+	AvatarURL    string                    `json:"avatar_url"`
+	AgeBracket   string                    `json:"age_bracket"`
+	Statistics   *cargonaut.UserStatistics `json:"statistics"`
+}
+
+// publicProfile is the projection of a user visible to other users. Optional
+// fields are only present if the users privacy settings allow it.
+type publicProfile struct {
+	ID               uuid.UUID                  `json:"id"`
+	DisplayName      string                     `json:"display_name"`
+	AvatarURL        string                     `json:"avatar_url"`
+	MemberSince      time.Time                  `json:"member_since"`
+	CancellationRate float64                    `json:"cancellation_rate"`
+	Email            string                     `json:"email,omitempty"`
+	Birthday         *time.Time                 `json:"birthday,omitempty"`
+	AgeBracket       string                     `json:"age_bracket,omitempty"`
+	RatingCount      *int                       `json:"rating_count,omitempty"`
+	RatingAverage    *float64                   `json:"rating_average,omitempty"`
+	Ratings          []*cargonaut.RatingSummary `json:"ratings,omitempty"`
+	TripCount        *int                       `json:"trip_count,omitempty"`
+}
+
+func newPrivateProfile(user *cargonaut.User, stats *cargonaut.UserStatistics) *privateProfile {
+	return &privateProfile{
+		User:       user,
+		AvatarURL:  avatarURL(user.ID),
+		AgeBracket: ageBracket(user.Birthday, time.Now()),
+		Statistics: stats,
+	}
+}
+
+func newPublicProfile(user *cargonaut.User, stats *cargonaut.UserStatistics) *publicProfile {
+	p := &publicProfile{
+		ID:               user.ID,
+		DisplayName:      user.DisplayName,
+		AvatarURL:        avatarURL(user.ID),
+		MemberSince:      user.CreatedAt,
+		CancellationRate: stats.CancellationRate,
+	}
+	if user.Privacy.ShowEmail {
+		p.Email = user.Email
+	}
+	if user.Privacy.ShowBirthday {
+		p.Birthday = &user.Birthday
+	}
+	if user.Privacy.ShowAgeBracket {
+		p.AgeBracket = ageBracket(user.Birthday, time.Now())
+	}
+	if user.Privacy.ShowRating {
+		p.RatingCount = &stats.RatingCount
+		p.RatingAverage = &stats.RatingAverage
+		p.Ratings = stats.Ratings
+	}
+	if user.Privacy.ShowTripCount {
+		p.TripCount = &stats.TripCount
+	}
+	return p
+}
+
+// avatarURL returns the URL of the avatar of the user identified by his
+// unique ID.
+func avatarURL(userID uuid.UUID) string {
+	return fmt.Sprintf("/api/v1/users/%s/avatar", userID)
+}
+
+// ageBracket returns the age bracket a person born on the given birthday
+// belongs to at the given time.
+func ageBracket(birthday, now time.Time) string {
+	age := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		age--
+	}
+
+	switch {
+	case age < 18:
+		return "under 18"
+	case age < 25:
+		return "18-24"
+	case age < 35:
+		return "25-34"
+	case age < 45:
+		return "35-44"
+	case age < 55:
+		return "45-54"
+	case age < 65:
+		return "55-64"
+	default:
+		return "65+"
+	}
+}
+
+type deleteUserRequest struct {
+	Password string `json:"password"`
+}
+
+type bookTripRequest struct {
+	PromoCode string `json:"promo_code"`
+}
+
+// exportSession is the representation of an authentication token in a data
+// export. The token itself is never exported.
+type exportSession struct {
+	ID        uuid.UUID `json:"id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // func (h *Handler) listUsers(w http.ResponseWriter, r *http.Request) {
 // 	if users, err := h.UserRepository.ListUsers(r.Context()); err != nil {
 // 		h.renderError(w, r, http.StatusInternalServerError, err)
@@ -22,14 +156,45 @@ import (
 // }
 
 func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
-	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
 		h.renderError(w, r, http.StatusBadRequest, err)
-	} else if user, err := h.UserRepository.GetUser(r.Context(), id); err == cargonaut.ErrUserNotFound {
+		return
+	}
+
+	// Users requesting their own profile get the private projection.
+	if user, err := h.UserRepository.GetUser(r.Context(), id); err == cargonaut.ErrUserNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if stats, err := h.UserRepository.GetUserStatistics(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if uuid.Equal(user.ID, authUserID) {
+		h.renderOK(w, r, newPrivateProfile(user, stats))
 	} else {
-		h.renderOK(w, r, user)
+		h.renderOK(w, r, newPublicProfile(user, stats))
+	}
+}
+
+func (h *Handler) getCurrentUser(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if user, err := h.UserRepository.GetUser(r.Context(), authUserID); err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if stats, err := h.UserRepository.GetUserStatistics(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, newPrivateProfile(user, stats))
 	}
 }
 
@@ -109,6 +274,330 @@ func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
 // 	}
 // }
 
+func (h *Handler) patchCurrentUser(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.UserRepository.GetUser(r.Context(), authUserID)
+	if err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	var patched profile
+	if err = applyMergePatch(r, &profile{
+		Email:         user.Email,
+		DisplayName:   user.DisplayName,
+		Birthday:      user.Birthday,
+		Privacy:       user.Privacy,
+		Notifications: user.Notifications,
+	}, &patched); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	validateEmail(verr, "email", patched.Email)
+	validateName(verr, "display_name", patched.DisplayName)
+	if patched.Birthday.IsZero() {
+		verr.add("birthday", "must not be empty")
+	} else if patched.Birthday.After(time.Now()) {
+		verr.add("birthday", "must not be in the future")
+	}
+	if !notify.SupportsLanguage(patched.Notifications.Language) {
+		verr.add("notifications.language", "must be a supported language")
+	}
+	var avatar image.Image
+	if patched.Avatar != "" {
+		if avatar, err = decodeAvatar(patched.Avatar); err == upload.ErrImageTooLarge {
+			verr.add("avatar", "must not have more than %d megapixels", upload.MaxImagePixels/1000/1000)
+		} else if err != nil {
+			verr.add("avatar", "must be a base64 encoded PNG or JPEG image")
+		}
+	}
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// A changed E-Mail address is not applied immediately. Instead, the user
+	// needs to verify the ownership of the new address first.
+	var verification *cargonaut.EmailVerification
+	if patched.Email != user.Email {
+		if _, err = h.UserRepository.GetUserByEmail(r.Context(), patched.Email); err == nil {
+			h.renderError(w, r, http.StatusConflict, cargonaut.ErrUserExists)
+			return
+		} else if err != cargonaut.ErrUserNotFound {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		verification = &cargonaut.EmailVerification{
+			ID:        uuid.NewV4(),
+			UserID:    user.ID,
+			Email:     patched.Email,
+			ExpiresAt: time.Now().UTC().Add(emailVerificationExpiration),
+		}
+		if err = h.UserRepository.CreateEmailVerification(r.Context(), verification); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if err = h.Notifier.NotifyEmailVerification(r.Context(), user, verification); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	user.DisplayName = patched.DisplayName
+	user.Birthday = patched.Birthday
+	user.Privacy = patched.Privacy
+	user.Notifications = patched.Notifications
+	if avatar != nil {
+		if err = h.putAvatar(r.Context(), user.ID, avatar); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	if err = h.UserRepository.UpdateUser(r.Context(), user); err == cargonaut.ErrUserExists {
+		h.renderError(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if user, err = h.UserRepository.GetUser(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	stats, err := h.UserRepository.GetUserStatistics(r.Context(), user.ID)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := newPrivateProfile(user, stats)
+	if verification != nil {
+		resp.PendingEmail = verification.Email
+	}
+	h.renderOK(w, r, resp)
+}
+
+// registeredVehicles lists the personal vehicles of the user identified by
+// their unique ID and the vehicles they registered for their organizations.
+func (h *Handler) registeredVehicles(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Vehicle, error) {
+	vehicles, err := h.UserRepository.ListVehicles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations, err := h.OrganizationRepository.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, organization := range organizations {
+		organizationVehicles, err := h.OrganizationRepository.ListVehicles(ctx, organization.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, vehicle := range organizationVehicles {
+			if uuid.Equal(vehicle.UserID, userID) {
+				vehicles = append(vehicles, vehicle)
+			}
+		}
+	}
+	return vehicles, nil
+}
+
+func (h *Handler) exportCurrentUser(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.UserRepository.GetUser(r.Context(), authUserID)
+	if err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Users whose avatar couldn't be stored have none.
+	avatar, err := h.readBlob(r.Context(), cargonaut.AvatarKey(user.ID))
+	if err != nil && err != cargonaut.ErrBlobNotFound {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// Gather all personal data before the response is written. Errors can't
+	// be rendered once the archive is streamed to the client.
+	files := make(map[string]interface{})
+	files["profile.json"] = user
+	if files["vehicles.json"], err = h.registeredVehicles(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if files["trips_as_driver.json"], err = h.UserRepository.ListTrips(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if files["trips_as_rider.json"], err = h.UserRepository.ListRides(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if files["ratings_received.json"], err = h.UserRepository.ListRatings(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if files["ratings_given.json"], err = h.UserRepository.ListAuthoredRatings(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if files["notifications.json"], err = h.NotificationRepository.ListNotifications(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	tokens, err := h.UserRepository.ListTokens(r.Context(), user.ID)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	sessions := make([]*exportSession, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, &exportSession{
+			ID:        token.ID,
+			ExpiresAt: token.ExpiresAt,
+			CreatedAt: token.CreatedAt,
+		})
+	}
+	files["sessions.json"] = sessions
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"cargonaut-export-%s.zip\"", user.ID))
+
+	zw := zip.NewWriter(w)
+	for name, data := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			h.log.Printf("[%s %s]: create %s: %s", r.Method, requestURI(r), name, err)
+			return
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(data); err != nil {
+			h.log.Printf("[%s %s]: write %s: %s", r.Method, requestURI(r), name, err)
+			return
+		}
+	}
+	if avatar != nil {
+		if f, err := zw.Create("avatar.png"); err != nil {
+			h.log.Printf("[%s %s]: create avatar.png: %s", r.Method, requestURI(r), err)
+			return
+		} else if _, err = f.Write(avatar); err != nil {
+			h.log.Printf("[%s %s]: write avatar.png: %s", r.Method, requestURI(r), err)
+			return
+		}
+	}
+	if err = zw.Close(); err != nil {
+		h.log.Printf("[%s %s]: close archive: %s", r.Method, requestURI(r), err)
+	}
+}
+
+func (h *Handler) deleteCurrentUser(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	var req deleteUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	user, err := h.UserRepository.GetUser(r.Context(), authUserID)
+	if err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The deletion must be confirmed with the users password.
+	if err = password.Compare(h.secret, req.Password, user.Password); err == password.ErrPasswordMismatch {
+		h.renderErrorf(w, r, http.StatusUnauthorized, "invalid credentials")
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The balance of the wallet must be withdrawn and the bookings holding
+	// it cancelled first, so no money is left behind with the account.
+	if wallet, err := h.WalletRepository.GetWallet(r.Context(), user.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if wallet.Balance != 0 || wallet.Held != 0 {
+		h.renderError(w, r, http.StatusConflict, cargonaut.ErrWalletNotEmpty)
+		return
+	}
+
+	// The authentication tokens of the user are deleted along with the
+	// account, they are invalidated once it is gone.
+	tokens, err := h.UserRepository.ListTokens(r.Context(), user.ID)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The personal vehicles of the user are deleted along with the account,
+	// the content of their files has to be removed from the blob store
+	// afterwards.
+	vehicles, err := h.UserRepository.ListVehicles(r.Context(), user.ID)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	vehicleIDs := make([]uuid.UUID, len(vehicles))
+	for i, vehicle := range vehicles {
+		vehicleIDs[i] = vehicle.ID
+	}
+	files, err := h.vehicleFiles(r.Context(), vehicleIDs...)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.UserRepository.DeleteUser(r.Context(), user.ID); err == cargonaut.ErrWalletNotEmpty || err == cargonaut.ErrOrganizationOwnerRequired {
+		h.renderError(w, r, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.TokenBlacklist.BlacklistToken(r.Context(), tokens...); err != nil {
+		h.log.Printf("[%s %s]: blacklist tokens of user %q: %s", r.Method, requestURI(r), user.ID, err)
+	}
+
+	// The user is gone, so left over vehicle files and avatars are never
+	// served again.
+	h.deleteVehicleFileContents(r, files...)
+	if err = h.deleteAvatar(r.Context(), user.ID); err != nil {
+		h.log.Printf("[%s %s]: delete avatar of user %q: %s", r.Method, requestURI(r), user.ID, err)
+	}
+
+	render.NoContent(w, r)
+}
+
 func (h *Handler) listUserRatings(w http.ResponseWriter, r *http.Request) {
 	if userID, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
 		h.renderError(w, r, http.StatusBadRequest, err)
@@ -119,6 +608,32 @@ func (h *Handler) listUserRatings(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) listUserRatingSummaries(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Respect the privacy settings of the rated user.
+	if user, err := h.UserRepository.GetUser(r.Context(), userID); err == cargonaut.ErrUserNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if !user.Privacy.ShowRating && !uuid.Equal(user.ID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "ratings of user are private")
+	} else if summaries, err := h.UserRepository.ListRatingSummaries(r.Context(), userID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, summaries)
+	}
+}
+
 func (h *Handler) listUserVehicles(w http.ResponseWriter, r *http.Request) {
 	if userID, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
 		h.renderError(w, r, http.StatusBadRequest, err)
@@ -154,6 +669,13 @@ func (h *Handler) bookTrip(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The request body is optional.
+	var req bookTripRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
 	trip, err := h.TripRepository.GetTrip(r.Context(), tripID)
 	if err == cargonaut.ErrTripNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
@@ -163,11 +685,47 @@ func (h *Handler) bookTrip(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	trip.RiderID = &userID
-	if err := h.TripRepository.UpdateTrip(r.Context(), trip); err == cargonaut.ErrTripExists {
+	if uuid.Equal(trip.UserID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not book own trip")
+		return
+	} else if trip.Cancelled() {
+		h.renderErrorf(w, r, http.StatusConflict, "can not book cancelled trip")
+		return
+	}
+
+	// Trips which don't require approval are booked instantly. Otherwise the
+	// booking is pending until the driver decides or it expires, at the
+	// latest on departure.
+	now := time.Now().UTC()
+	booking := &cargonaut.Booking{
+		TripID:    trip.ID,
+		UserID:    userID,
+		Status:    cargonaut.BookingStatusAccepted,
+		ExpiresAt: now,
+		DecidedAt: &now,
+		PromoCode: req.PromoCode,
+	}
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripBooked, trip.UserID, trip.ID)
+	if trip.ApprovalRequired {
+		booking.Status = cargonaut.BookingStatusPending
+		booking.DecidedAt = nil
+		booking.ExpiresAt = now.Add(h.BookingDeadline)
+		if depature := trip.PlannedDepature; depature != nil && depature.After(now) && depature.Before(booking.ExpiresAt) {
+			booking.ExpiresAt = *depature
+		}
+		event = cargonaut.NewEvent(cargonaut.EventTypeBookingRequested, trip.UserID, trip.ID)
+	}
+
+	if err := h.BookingRepository.CreateBooking(r.Context(), booking, event); err == cargonaut.ErrTripNotAvailable || err == cargonaut.ErrBookingExists {
 		h.renderError(w, r, http.StatusConflict, err)
+	} else if err == cargonaut.ErrInsufficientFunds {
+		h.renderError(w, r, http.StatusPaymentRequired, err)
+	} else if err == cargonaut.ErrPromoCodeNotFound || err == cargonaut.ErrPromoCodeNotRedeemable {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if booking.Status == cargonaut.BookingStatusPending {
+		h.render(w, r, http.StatusAccepted, booking)
 	} else {
 		render.NoContent(w, r)
 	}
@@ -208,13 +766,22 @@ func (h *Handler) cancelTrip(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Make sure we can not cancel a trip we aren't assigned to
-	if trip.RiderID != nil && !uuid.Equal(*trip.RiderID, authUserID) {
+	if trip.RiderID == nil {
+		h.renderErrorf(w, r, http.StatusConflict, "can not cancel trip which is not booked")
+		return
+	} else if !uuid.Equal(*trip.RiderID, authUserID) {
 		h.renderErrorf(w, r, http.StatusForbidden, "can not cancel trip in behalf of another user")
 		return
 	}
 
-	trip.RiderID = nil
-	if err := h.TripRepository.UpdateTrip(r.Context(), trip); err == cargonaut.ErrTripExists {
+	cancellation := &cargonaut.TripCancellation{
+		TripID: trip.ID,
+		UserID: authUserID,
+		Role:   cargonaut.RatingRoleRider,
+		Late:   h.CancellationPolicy.Late(trip, time.Now().UTC()),
+	}
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripCancelled, trip.UserID, trip.ID)
+	if err := h.TripRepository.CancelTrip(r.Context(), cancellation, event); err == cargonaut.ErrTripNotCancellable {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
@@ -222,21 +789,3 @@ func (h *Handler) cancelTrip(w http.ResponseWriter, r *http.Request) {
 		render.NoContent(w, r)
 	}
 }
-
-func (h *Handler) getUserAvatar(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "image/png")
-
-	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
-		h.renderError(w, r, http.StatusBadRequest, err)
-	} else if user, err := h.UserRepository.GetUser(r.Context(), id); err == cargonaut.ErrUserNotFound {
-		h.renderError(w, r, http.StatusNotFound, err)
-	} else if err != nil {
-		h.renderError(w, r, http.StatusInternalServerError, err)
-	} else {
-		if imgSrc, err := base64.StdEncoding.DecodeString(user.Avatar); err != nil {
-			h.renderError(w, r, http.StatusInternalServerError, err)
-		} else if _, err = io.Copy(w, bytes.NewReader(imgSrc)); err != nil {
-			h.renderError(w, r, http.StatusInternalServerError, err)
-		}
-	}
-}
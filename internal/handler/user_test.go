@@ -0,0 +1,256 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/handler"
+	"github.com/my-cargonaut/cargonaut/pkg/password"
+)
+
+// userTest provides a handler with in-memory repositories around a user
+// with a personal vehicle, a vehicle registered for an organization and an
+// avatar.
+type userTest struct {
+	h             *Handler
+	user          *cargonaut.User
+	users         *fakeUserRepository
+	organizations *fakeOrganizationRepository
+	vehicles      *fakeVehicleRepository
+	wallets       *fakeWalletRepository
+	blacklist     *fakeTokenBlacklist
+	blobs         fakeBlobStore
+}
+
+func newUserTest(t *testing.T) *userTest {
+	hash, err := password.Generate(testSecret, "password", bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &cargonaut.User{ID: uuid.NewV4(), Email: "user@example.com", Password: hash, DisplayName: "User"}
+	other := uuid.NewV4()
+
+	organizationID := uuid.NewV4()
+	personal := &cargonaut.Vehicle{ID: uuid.NewV4(), UserID: user.ID}
+	registered := &cargonaut.Vehicle{ID: uuid.NewV4(), UserID: user.ID, OrganizationID: &organizationID}
+	foreign := &cargonaut.Vehicle{ID: uuid.NewV4(), UserID: other, OrganizationID: &organizationID}
+	personalFile := &cargonaut.VehicleFile{ID: uuid.NewV4(), VehicleID: personal.ID, Kind: cargonaut.VehicleFileKindPhoto}
+	registeredFile := &cargonaut.VehicleFile{ID: uuid.NewV4(), VehicleID: registered.ID, Kind: cargonaut.VehicleFileKindDocument}
+
+	u := &userTest{
+		h:    newTestHandler(t),
+		user: user,
+		users: &fakeUserRepository{
+			users:    []*cargonaut.User{user},
+			vehicles: []*cargonaut.Vehicle{personal, registered, foreign},
+			trips: []*cargonaut.Trip{
+				{ID: uuid.NewV4(), UserID: user.ID, VehicleID: personal.ID, RiderID: &other},
+				{ID: uuid.NewV4(), UserID: other, VehicleID: foreign.ID, RiderID: &user.ID},
+			},
+			ratings: []*cargonaut.Rating{
+				{ID: uuid.NewV4(), UserID: user.ID, AuthorID: other, Role: cargonaut.RatingRoleDriver},
+				{ID: uuid.NewV4(), UserID: other, AuthorID: user.ID, Role: cargonaut.RatingRoleRider},
+			},
+			tokens: []*cargonaut.Token{
+				{ID: uuid.NewV4(), UserID: user.ID, Token: "secret token"},
+				{ID: uuid.NewV4(), UserID: other, Token: "other token"},
+			},
+		},
+		organizations: &fakeOrganizationRepository{
+			organizations: []*cargonaut.Organization{{ID: organizationID}},
+			vehicles:      []*cargonaut.Vehicle{registered, foreign},
+		},
+		vehicles:  &fakeVehicleRepository{files: []*cargonaut.VehicleFile{personalFile, registeredFile}},
+		wallets:   &fakeWalletRepository{},
+		blacklist: &fakeTokenBlacklist{},
+		blobs: fakeBlobStore{
+			cargonaut.AvatarKey(user.ID): []byte("avatar"),
+			personalFile.Key():           []byte("photo"),
+			registeredFile.Key():         []byte("document"),
+		},
+	}
+	u.h.UserRepository = u.users
+	u.h.OrganizationRepository = u.organizations
+	u.h.VehicleRepository = u.vehicles
+	u.h.WalletRepository = u.wallets
+	u.h.NotificationRepository = &fakeNotificationRepository{notifications: []*cargonaut.Notification{
+		{ID: uuid.NewV4(), UserID: user.ID, Title: "Notification"},
+	}}
+	u.h.TokenBlacklist = u.blacklist
+	u.h.BlobStore = u.blobs
+	return u
+}
+
+func TestExportCurrentUser(t *testing.T) {
+	u := newUserTest(t)
+
+	rec := serve(t, u.h, u.user.ID, http.MethodGet, "/api/v1/users/me/export", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = ioutil.ReadAll(r)
+		require.NoError(t, err)
+		_ = r.Close()
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"profile.json", "vehicles.json", "trips_as_driver.json", "trips_as_rider.json",
+		"ratings_received.json", "ratings_given.json", "notifications.json", "sessions.json",
+		"avatar.png",
+	}, names)
+	assert.Equal(t, []byte("avatar"), files["avatar.png"])
+
+	// The vehicles the user registered for the organization are exported
+	// along with the personal ones, those of other members are not.
+	var vehicles []*cargonaut.Vehicle
+	require.NoError(t, json.Unmarshal(files["vehicles.json"], &vehicles))
+	if assert.Len(t, vehicles, 2) {
+		assert.Nil(t, vehicles[0].OrganizationID)
+		assert.NotNil(t, vehicles[1].OrganizationID)
+	}
+
+	for name, n := range map[string]int{
+		"trips_as_driver.json":  1,
+		"trips_as_rider.json":   1,
+		"ratings_received.json": 1,
+		"ratings_given.json":    1,
+		"notifications.json":    1,
+		"sessions.json":         1,
+	} {
+		var entries []map[string]interface{}
+		require.NoError(t, json.Unmarshal(files[name], &entries), name)
+		assert.Len(t, entries, n, name)
+	}
+
+	// Neither the token itself nor the password hash leave the server.
+	assert.NotContains(t, string(files["sessions.json"]), "secret token")
+	assert.NotContains(t, string(files["profile.json"]), u.user.Password)
+}
+
+func TestDeleteCurrentUser(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		wallet    *cargonaut.Wallet
+		deleteErr error
+		code      int
+	}{
+		{name: "deleted", password: "password", code: http.StatusNoContent},
+		{name: "wrong password", password: "wrong", code: http.StatusUnauthorized},
+		{name: "balance left", password: "password", wallet: &cargonaut.Wallet{Balance: 100}, code: http.StatusConflict},
+		{name: "balance held", password: "password", wallet: &cargonaut.Wallet{Held: 100}, code: http.StatusConflict},
+		{name: "sole owner", password: "password", deleteErr: cargonaut.ErrOrganizationOwnerRequired, code: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newUserTest(t)
+			if tt.wallet != nil {
+				tt.wallet.UserID = u.user.ID
+				u.wallets.wallets = append(u.wallets.wallets, tt.wallet)
+			}
+			u.users.deleteErr = tt.deleteErr
+
+			rec := serve(t, u.h, u.user.ID, http.MethodDelete, "/api/v1/users/me", map[string]string{"password": tt.password})
+			require.Equal(t, tt.code, rec.Code, rec.Body.String())
+
+			if tt.code != http.StatusNoContent {
+				// Nothing is invalidated or removed unless the user is gone.
+				assert.Empty(t, u.users.deleted)
+				assert.Empty(t, u.blacklist.blacklisted)
+				assert.Len(t, u.blobs, 3)
+				return
+			}
+
+			assert.Equal(t, []uuid.UUID{u.user.ID}, u.users.deleted)
+			if assert.Len(t, u.blacklist.blacklisted, 1) {
+				assert.Equal(t, u.user.ID, u.blacklist.blacklisted[0].UserID)
+			}
+
+			// The avatar and the files of the personal vehicle are removed,
+			// the vehicle registered for the organization is handed over
+			// with its files.
+			assert.Len(t, u.blobs, 1)
+			for _, content := range u.blobs {
+				assert.Equal(t, []byte("document"), content)
+			}
+		})
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	birthday := time.Date(1990, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := &cargonaut.UserStatistics{RatingCount: 2, RatingAverage: 4.5, TripCount: 3}
+	optional := []string{"email", "birthday", "age_bracket", "rating_count", "rating_average", "trip_count"}
+
+	tests := []struct {
+		name    string
+		own     bool
+		privacy cargonaut.PrivacySettings
+		want    []string
+	}{
+		{name: "nothing shown"},
+		{name: "email", privacy: cargonaut.PrivacySettings{ShowEmail: true}, want: []string{"email"}},
+		{name: "birthday", privacy: cargonaut.PrivacySettings{ShowBirthday: true}, want: []string{"birthday"}},
+		{name: "age bracket", privacy: cargonaut.PrivacySettings{ShowAgeBracket: true}, want: []string{"age_bracket"}},
+		{name: "rating", privacy: cargonaut.PrivacySettings{ShowRating: true}, want: []string{"rating_count", "rating_average"}},
+		{name: "trip count", privacy: cargonaut.PrivacySettings{ShowTripCount: true}, want: []string{"trip_count"}},
+		{name: "own profile", own: true, want: []string{"email", "birthday", "age_bracket"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &cargonaut.User{ID: uuid.NewV4(), Email: "user@example.com", Password: "hash", DisplayName: "User", Birthday: birthday, Privacy: tt.privacy}
+			h := newTestHandler(t)
+			h.UserRepository = &fakeUserRepository{users: []*cargonaut.User{user}, stats: stats}
+
+			viewerID := uuid.NewV4()
+			if tt.own {
+				viewerID = user.ID
+			}
+			rec := serve(t, h, viewerID, http.MethodGet, "/api/v1/users/"+user.ID.String(), nil)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			var profile map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &profile))
+			assert.Equal(t, "User", profile["display_name"])
+			assert.NotContains(t, rec.Body.String(), "hash")
+			for _, field := range optional {
+				assert.Equal(t, contains(tt.want, field), profile[field] != nil, field)
+			}
+			if tt.own {
+				// The private projection contains the statistics and
+				// settings regardless of the privacy settings.
+				assert.Contains(t, profile, "statistics")
+				assert.Contains(t, profile, "privacy")
+			}
+		})
+	}
+}
+
+// contains reports whether the string is in the slice.
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
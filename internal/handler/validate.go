@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/my-cargonaut/cargonaut/pkg/mergepatch"
+)
+
+// validationError describes field level validation failures of a request. It
+// maps the JSON field names to the reason the field was rejected.
+type validationError map[string]string
+
+// Error implements the error interface.
+func (e validationError) Error() string {
+	fields := make([]string, 0, len(e))
+	for field := range e {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	msgs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", field, e[field]))
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// add records a validation failure for the given field. Only the first
+// failure of a field is kept.
+func (e validationError) add(field, format string, a ...interface{}) {
+	if _, ok := e[field]; !ok {
+		e[field] = fmt.Sprintf(format, a...)
+	}
+}
+
+// err returns the validation error or nil, if no failures were recorded.
+func (e validationError) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// validateEmail validates an E-Mail address.
+func validateEmail(verr validationError, field, email string) {
+	if email == "" {
+		verr.add(field, "must not be empty")
+	} else if len(email) > 128 {
+		verr.add(field, "must not be longer than 128 characters")
+	} else if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		verr.add(field, "must be a valid e-mail address")
+	}
+}
+
+// validateName validates a required, human readable name.
+func validateName(verr validationError, field, name string) {
+	if strings.TrimSpace(name) == "" {
+		verr.add(field, "must not be empty")
+	} else if len(name) > 128 {
+		verr.add(field, "must not be longer than 128 characters")
+	}
+}
+
+// applyMergePatch applies the JSON Merge Patch read from the request body to
+// the JSON representation of src and decodes the result into dst. The dst
+// value should be a pointer to a zero value, because members removed by the
+// patch are not touched while decoding.
+func applyMergePatch(r *http.Request, src, dst interface{}) error {
+	patch, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("read patch: %w", err)
+	}
+
+	doc, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("encode resource: %w", err)
+	}
+
+	if doc, err = mergepatch.Apply(doc, patch); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(dst); err != nil {
+		return fmt.Errorf("decode patched resource: %w", err)
+	}
+	return nil
+}
@@ -1,18 +1,114 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
+	"github.com/nfnt/resize"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/upload"
 )
 
+// licensePlatePattern matches valid license plates. They consist of groups of
+// letters and digits separated by single spaces or dashes.
+var licensePlatePattern = regexp.MustCompile(`^[A-ZÄÖÜ0-9]+([ -][A-ZÄÖÜ0-9]+)*$`)
+
+// normalizeLicensePlate returns the license plate in upper case with repeated
+// spaces removed, so the same plate is always written the same way.
+func normalizeLicensePlate(plate string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(plate), " "))
+}
+
+// validateVehicle validates a vehicle. The license plate is expected to be
+// normalized.
+func validateVehicle(verr validationError, vehicle *cargonaut.Vehicle) {
+	if n := len([]rune(vehicle.LicensePlate)); n == 0 {
+		verr.add("license_plate", "must not be empty")
+	} else if n > 16 {
+		verr.add("license_plate", "must not be longer than 16 characters")
+	} else if !licensePlatePattern.MatchString(vehicle.LicensePlate) {
+		verr.add("license_plate", "must consist of letters and digits separated by spaces or dashes")
+	}
+	switch vehicle.Type {
+	case cargonaut.VehicleTypeCar, cargonaut.VehicleTypeVan, cargonaut.VehicleTypeTruck:
+	case cargonaut.VehicleTypeTrailer:
+		if vehicle.Passengers > 0 {
+			verr.add("passengers", "must be zero for trailers")
+		}
+	default:
+		verr.add("type", "must be one of car, van, truck or trailer")
+	}
+	if strings.TrimSpace(vehicle.Brand) == "" {
+		verr.add("brand", "must not be empty")
+	} else if len(vehicle.Brand) > 128 {
+		verr.add("brand", "must not be longer than 128 characters")
+	}
+	if strings.TrimSpace(vehicle.Model) == "" {
+		verr.add("model", "must not be empty")
+	} else if len(vehicle.Model) > 128 {
+		verr.add("model", "must not be longer than 128 characters")
+	}
+	if len(vehicle.Color) > 32 {
+		verr.add("color", "must not be longer than 32 characters")
+	}
+	if vehicle.MaxPayload < 0 {
+		verr.add("max_payload", "must not be negative")
+	}
+	if vehicle.LoadingAreaLength < 0 {
+		verr.add("loading_area_length", "must not be negative")
+	}
+	if vehicle.LoadingAreaWidth < 0 {
+		verr.add("loading_area_width", "must not be negative")
+	}
+	if vehicle.LoadingAreaHeight < 0 {
+		verr.add("loading_area_height", "must not be negative")
+	}
+	// The volume can not exceed the cuboid spanned by the loading area, which
+	// is given in cm. The cuboid is rounded to the precision of the volume, so
+	// a volume matching it exactly is accepted.
+	if vehicle.LoadingVolume < 0 {
+		verr.add("loading_volume", "must not be negative")
+	} else if vehicle.LoadingAreaHeight > 0 && vehicle.LoadingVolume > float32(float64(vehicle.LoadingAreaLength)*float64(vehicle.LoadingAreaWidth)*float64(vehicle.LoadingAreaHeight)/1e6) {
+		verr.add("loading_volume", "must not exceed the dimensions of the loading area")
+	}
+	seen := make(map[cargonaut.VehicleAmenity]bool, len(vehicle.Amenities))
+	for _, amenity := range vehicle.Amenities {
+		switch amenity {
+		case cargonaut.VehicleAmenityAirConditioning, cargonaut.VehicleAmenityCooling,
+			cargonaut.VehicleAmenityHandTruck, cargonaut.VehicleAmenityMovingBlankets,
+			cargonaut.VehicleAmenityRamp, cargonaut.VehicleAmenityTailLift,
+			cargonaut.VehicleAmenityTieDowns:
+		default:
+			verr.add("amenities", "must only contain supported amenities")
+		}
+		if seen[amenity] {
+			verr.add("amenities", "must not contain duplicates")
+		}
+		seen[amenity] = true
+	}
+}
+
 func (h *Handler) listVehicles(w http.ResponseWriter, r *http.Request) {
-	if vehicles, err := h.VehicleRepository.ListVehicles(r.Context()); err != nil {
+	organizationID, ok := h.organizationScope(w, r)
+	if !ok {
+		return
+	}
+
+	if vehicles, err := h.VehicleRepository.ListVehicles(r.Context(), organizationID); err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 	} else {
 		h.renderOK(w, r, vehicles)
@@ -43,7 +139,17 @@ func (h *Handler) createVehicle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	vehicle.LicensePlate = normalizeLicensePlate(vehicle.LicensePlate)
+	verr := make(validationError)
+	validateVehicle(verr, &vehicle)
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// Vehicles of organizations are created through the organization.
 	vehicle.UserID = authUserID
+	vehicle.OrganizationID = nil
 	if err := h.VehicleRepository.CreateVehicle(r.Context(), &vehicle); err == cargonaut.ErrVehicleExists {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
@@ -71,21 +177,35 @@ func (h *Handler) updateVehicle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	vehicle.LicensePlate = normalizeLicensePlate(vehicle.LicensePlate)
+	verr := make(validationError)
+	validateVehicle(verr, &vehicle)
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
 	// Make sure we can not update a vehicle of another users by making sure the
-	// user ID of the stored vehicle and the authenticated user match.
-	if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), id); err == cargonaut.ErrVehicleNotFound {
+	// authenticated user registered the stored vehicle or dispatches for its
+	// organization.
+	stored, err := h.VehicleRepository.GetVehicle(r.Context(), id)
+	if err == cargonaut.ErrVehicleNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 		return
-	} else if !uuid.Equal(vehicle.UserID, authUserID) {
+	} else if ok, err := h.mayManageVehicle(r.Context(), stored, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
 		h.renderErrorf(w, r, http.StatusForbidden, "can not update vehicle of another user")
 		return
 	}
 
 	vehicle.ID = id
-	vehicle.UserID = authUserID
+	vehicle.UserID = stored.UserID
+	vehicle.OrganizationID = stored.OrganizationID
 	if err := h.VehicleRepository.UpdateVehicle(r.Context(), &vehicle); err == cargonaut.ErrVehicleExists {
 		h.renderError(w, r, http.StatusConflict, err)
 	} else if err != nil {
@@ -95,6 +215,65 @@ func (h *Handler) updateVehicle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handler) patchVehicle(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	// Make sure we can not update a vehicle of another users by making sure the
+	// authenticated user registered the stored vehicle or dispatches for its
+	// organization.
+	vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), id)
+	if err == cargonaut.ErrVehicleNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if ok, err := h.mayManageVehicle(r.Context(), vehicle, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not update vehicle of another user")
+		return
+	}
+
+	var patched cargonaut.Vehicle
+	if err = applyMergePatch(r, vehicle, &patched); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	patched.LicensePlate = normalizeLicensePlate(patched.LicensePlate)
+	verr := make(validationError)
+	validateVehicle(verr, &patched)
+	if err = verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	// Ownership can not be changed by patching the vehicle.
+	patched.ID = vehicle.ID
+	patched.UserID = vehicle.UserID
+	patched.OrganizationID = vehicle.OrganizationID
+	if err = h.VehicleRepository.UpdateVehicle(r.Context(), &patched); err == cargonaut.ErrVehicleExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else if vehicle, err = h.VehicleRepository.GetVehicle(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, vehicle)
+	}
+}
+
 func (h *Handler) deleteVehicle(w http.ResponseWriter, r *http.Request) {
 	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
 	if !ok {
@@ -107,22 +286,323 @@ func (h *Handler) deleteVehicle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Make sure we can not delete a vehicle of other users by making sure the user
-	// ID of the stored vehicle and the authenticated user match.
+	// Make sure we can not delete a vehicle of other users by making sure the
+	// authenticated user registered the stored vehicle or dispatches for its
+	// organization.
 	if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), id); err == cargonaut.ErrVehicleNotFound {
 		h.renderError(w, r, http.StatusNotFound, err)
 		return
 	} else if err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 		return
-	} else if !uuid.Equal(vehicle.UserID, authUserID) {
+	} else if ok, err := h.mayManageVehicle(r.Context(), vehicle, authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
 		h.renderErrorf(w, r, http.StatusForbidden, "can not delete vehicle of another user")
 		return
 	}
 
-	if err := h.VehicleRepository.DeleteVehicle(r.Context(), id); err != nil {
+	// The files of the vehicle are deleted along with it, their content has to
+	// be removed from the blob store afterwards.
+	files, err := h.vehicleFiles(r.Context(), id)
+	if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err = h.VehicleRepository.DeleteVehicle(r.Context(), id); err != nil {
 		h.renderError(w, r, http.StatusInternalServerError, err)
 	} else {
+		h.deleteVehicleFileContents(r, files...)
 		render.NoContent(w, r)
 	}
 }
+
+const (
+	// maxVehicleFileSize is the maximum size of uploaded photos and
+	// documents of vehicles.
+	maxVehicleFileSize = 10 << 20
+	// vehiclePhotoWidth is the width photos of vehicles are resized to, if
+	// they are wider.
+	vehiclePhotoWidth = 1280
+)
+
+// vehicleFileContentTypes are the sniffed content types accepted for each kind
+// of vehicle file. Documents may be scans as well.
+var vehicleFileContentTypes = map[cargonaut.VehicleFileKind][]string{
+	cargonaut.VehicleFileKindPhoto:    {"image/jpeg", "image/png"},
+	cargonaut.VehicleFileKindDocument: {"application/pdf", "image/jpeg", "image/png"},
+}
+
+// vehicleFile is the representation of a photo or document of a vehicle along
+// with the URL of its content.
+type vehicleFile struct {
+	*cargonaut.VehicleFile
+	URL string `json:"url"`
+}
+
+// newVehicleFile returns the representation of a vehicle file.
+func newVehicleFile(file *cargonaut.VehicleFile) vehicleFile {
+	return vehicleFile{
+		VehicleFile: file,
+		URL:         fmt.Sprintf("/api/v1/vehicles/%s/%ss/%s", file.VehicleID, file.Kind, file.ID),
+	}
+}
+
+// resizePhoto decodes the photo, resizes it to a reasonable width and returns
+// it as JPEG image.
+func resizePhoto(photo []byte) ([]byte, error) {
+	img, err := upload.DecodeImage(photo)
+	if err != nil {
+		return nil, err
+	}
+
+	if img.Bounds().Dx() > vehiclePhotoWidth {
+		img = resize.Resize(vehiclePhotoWidth, 0, img, resize.Lanczos3)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// vehicleFileFromRequest returns the file of the given kind identified by the
+// file_id URL parameter, which must belong to the vehicle identified by the id
+// URL parameter. Errors are rendered and false is returned.
+func (h *Handler) vehicleFileFromRequest(w http.ResponseWriter, r *http.Request, kind cargonaut.VehicleFileKind) (*cargonaut.VehicleFile, bool) {
+	vehicleID, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	id, err := uuid.FromString(chi.URLParam(r, "file_id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	file, err := h.VehicleRepository.GetFile(r.Context(), id)
+	if err == cargonaut.ErrVehicleFileNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	} else if !uuid.Equal(file.VehicleID, vehicleID) || file.Kind != kind {
+		h.renderError(w, r, http.StatusNotFound, cargonaut.ErrVehicleFileNotFound)
+		return nil, false
+	}
+	return file, true
+}
+
+func (h *Handler) listVehicleFiles(kind cargonaut.VehicleFileKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := uuid.FromString(chi.URLParam(r, "id"))
+		if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		if _, err = h.VehicleRepository.GetVehicle(r.Context(), id); err == cargonaut.ErrVehicleNotFound {
+			h.renderError(w, r, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		files, err := h.VehicleRepository.ListFiles(r.Context(), id, kind)
+		if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		resp := make([]vehicleFile, len(files))
+		for i, file := range files {
+			resp[i] = newVehicleFile(file)
+		}
+		h.renderOK(w, r, resp)
+	}
+}
+
+func (h *Handler) getVehicleFile(kind cargonaut.VehicleFileKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		file, ok := h.vehicleFileFromRequest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		content, err := h.BlobStore.GetBlob(r.Context(), file.Key())
+		if err == cargonaut.ErrBlobNotFound {
+			h.renderError(w, r, http.StatusNotFound, cargonaut.ErrVehicleFileNotFound)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		defer content.Close()
+
+		// Photos are shown in place, documents are downloaded. The content of
+		// a file never changes, so it can be cached for long.
+		disposition := "inline"
+		if kind == cargonaut.VehicleFileKindDocument {
+			disposition = "attachment"
+		}
+		w.Header().Set("Content-Type", file.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.Name}))
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if _, err = io.Copy(w, content); err != nil {
+			h.log.Printf("[%s %s]: write vehicle file %q: %s", r.Method, requestURI(r), file.ID, err)
+		}
+	}
+}
+
+func (h *Handler) createVehicleFile(kind cargonaut.VehicleFileKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+		if !ok {
+			return
+		}
+
+		id, err := uuid.FromString(chi.URLParam(r, "id"))
+		if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		// Make sure we can not add files to a vehicle of another user.
+		if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), id); err == cargonaut.ErrVehicleNotFound {
+			h.renderError(w, r, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if ok, err := h.mayManageVehicle(r.Context(), vehicle, authUserID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if !ok {
+			h.renderErrorf(w, r, http.StatusForbidden, "can not add %s to vehicle of another user", kind)
+			return
+		}
+
+		verr := make(validationError)
+		uploaded, err := upload.ReadFile(w, r, "file", maxVehicleFileSize, vehicleFileContentTypes[kind]...)
+		if err == upload.ErrTooLarge {
+			h.renderErrorf(w, r, http.StatusRequestEntityTooLarge, "%s must not be larger than %d MiB", kind, maxVehicleFileSize>>20)
+			return
+		} else if err == upload.ErrContentType {
+			verr.add("file", "must be one of %s", strings.Join(vehicleFileContentTypes[kind], ", "))
+			h.renderError(w, r, http.StatusUnprocessableEntity, verr.err())
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		file := &cargonaut.VehicleFile{
+			ID:          uuid.NewV4(),
+			VehicleID:   id,
+			Kind:        kind,
+			Name:        uploaded.Name,
+			ContentType: uploaded.ContentType,
+		}
+		content := uploaded.Content
+		if kind == cargonaut.VehicleFileKindPhoto {
+			if content, err = resizePhoto(content); err == upload.ErrImageTooLarge {
+				verr.add("file", "must not have more than %d megapixels", upload.MaxImagePixels/1000/1000)
+			} else if err != nil {
+				verr.add("file", "must be a valid PNG or JPEG image")
+			}
+			file.ContentType = "image/jpeg"
+			file.Name = strings.TrimSuffix(file.Name, filepath.Ext(file.Name)) + ".jpg"
+		}
+		if len(file.Name) > 256 || file.Name == "." || file.Name == string(filepath.Separator) {
+			file.Name = string(kind)
+		}
+		if err = verr.err(); err != nil {
+			h.renderError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		file.Size = int64(len(content))
+
+		// The content is stored first, so files are never listed without it.
+		// Should creating the file fail, the content is removed again.
+		if err = h.BlobStore.PutBlob(r.Context(), file.Key(), bytes.NewReader(content), file.Size, file.ContentType); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if err = h.VehicleRepository.CreateFile(r.Context(), file); err != nil {
+			if err := h.BlobStore.DeleteBlob(r.Context(), file.Key()); err != nil {
+				h.log.Printf("[%s %s]: delete content of vehicle file %q: %s", r.Method, requestURI(r), file.ID, err)
+			}
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		h.render(w, r, http.StatusCreated, newVehicleFile(file))
+	}
+}
+
+func (h *Handler) deleteVehicleFile(kind cargonaut.VehicleFileKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+		if !ok {
+			return
+		}
+
+		file, ok := h.vehicleFileFromRequest(w, r, kind)
+		if !ok {
+			return
+		}
+
+		// Make sure we can not delete files of a vehicle of another user.
+		if vehicle, err := h.VehicleRepository.GetVehicle(r.Context(), file.VehicleID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if ok, err := h.mayManageVehicle(r.Context(), vehicle, authUserID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if !ok {
+			h.renderErrorf(w, r, http.StatusForbidden, "can not delete %s of vehicle of another user", kind)
+			return
+		}
+
+		if err := h.VehicleRepository.DeleteFile(r.Context(), file.ID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		h.deleteVehicleFileContents(r, file)
+		render.NoContent(w, r)
+	}
+}
+
+// vehicleFiles lists the photos and documents of the vehicles identified by
+// their unique IDs.
+func (h *Handler) vehicleFiles(ctx context.Context, vehicleIDs ...uuid.UUID) ([]*cargonaut.VehicleFile, error) {
+	var files []*cargonaut.VehicleFile
+	for _, vehicleID := range vehicleIDs {
+		for _, kind := range []cargonaut.VehicleFileKind{cargonaut.VehicleFileKindPhoto, cargonaut.VehicleFileKindDocument} {
+			kindFiles, err := h.VehicleRepository.ListFiles(ctx, vehicleID, kind)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, kindFiles...)
+		}
+	}
+	return files, nil
+}
+
+// deleteVehicleFileContents deletes the content of the files from the blob
+// store. The files are gone already, so failures are only logged.
+func (h *Handler) deleteVehicleFileContents(r *http.Request, files ...*cargonaut.VehicleFile) {
+	for _, file := range files {
+		if err := h.BlobStore.DeleteBlob(r.Context(), file.Key()); err != nil {
+			h.log.Printf("[%s %s]: delete content of vehicle file %q: %s", r.Method, requestURI(r), file.ID, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+func TestCreateVehicleValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		vehicle func(*cargonaut.Vehicle)
+		fields  map[string]string
+		plate   string
+	}{
+		{name: "valid", plate: "B-CN 1234"},
+		{name: "normalized plate", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "  b-cn   1234 " }, plate: "B-CN 1234"},
+		{name: "umlaut plate", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "mü ab 12" }, plate: "MÜ AB 12"},
+		{name: "empty plate", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "  " }, fields: map[string]string{
+			"license_plate": "must not be empty",
+		}},
+		{name: "long plate", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "B-CN 123456789012" }, fields: map[string]string{
+			"license_plate": "must not be longer than 16 characters",
+		}},
+		{name: "repeated separator", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "B--CN 1234" }, fields: map[string]string{
+			"license_plate": "must consist of letters and digits separated by spaces or dashes",
+		}},
+		{name: "trailing separator", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "B-CN-" }, fields: map[string]string{
+			"license_plate": "must consist of letters and digits separated by spaces or dashes",
+		}},
+		{name: "invalid character", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate = "B_CN 1234" }, fields: map[string]string{
+			"license_plate": "must consist of letters and digits separated by spaces or dashes",
+		}},
+		{name: "trailer", vehicle: func(v *cargonaut.Vehicle) { v.Type, v.Passengers = cargonaut.VehicleTypeTrailer, 0 }, plate: "B-CN 1234"},
+		{name: "trailer with passengers", vehicle: func(v *cargonaut.Vehicle) { v.Type = cargonaut.VehicleTypeTrailer }, fields: map[string]string{
+			"passengers": "must be zero for trailers",
+		}},
+		{name: "unknown type", vehicle: func(v *cargonaut.Vehicle) { v.Type = "bicycle" }, fields: map[string]string{
+			"type": "must be one of car, van, truck or trailer",
+		}},
+		{name: "volume of the cuboid", vehicle: func(v *cargonaut.Vehicle) { v.LoadingVolume = 2.4 }, plate: "B-CN 1234"},
+		{name: "volume exceeds the cuboid", vehicle: func(v *cargonaut.Vehicle) { v.LoadingVolume = 2.5 }, fields: map[string]string{
+			"loading_volume": "must not exceed the dimensions of the loading area",
+		}},
+		{name: "volume without height", vehicle: func(v *cargonaut.Vehicle) { v.LoadingAreaHeight, v.LoadingVolume = 0, 10 }, plate: "B-CN 1234"},
+		{name: "negative volume", vehicle: func(v *cargonaut.Vehicle) { v.LoadingVolume = -1 }, fields: map[string]string{
+			"loading_volume": "must not be negative",
+		}},
+		{name: "unsupported amenity", vehicle: func(v *cargonaut.Vehicle) { v.Amenities = append(v.Amenities, "jacuzzi") }, fields: map[string]string{
+			"amenities": "must only contain supported amenities",
+		}},
+		{name: "duplicate amenity", vehicle: func(v *cargonaut.Vehicle) { v.Amenities = append(v.Amenities, cargonaut.VehicleAmenityRamp) }, fields: map[string]string{
+			"amenities": "must not contain duplicates",
+		}},
+		{name: "several fields", vehicle: func(v *cargonaut.Vehicle) { v.LicensePlate, v.Brand, v.MaxPayload = "", "", -1 }, fields: map[string]string{
+			"license_plate": "must not be empty",
+			"brand":         "must not be empty",
+			"max_payload":   "must not be negative",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The loading area spans 200 x 120 x 100 cm, that is 2.4 m³.
+			vehicle := &cargonaut.Vehicle{
+				LicensePlate:      "B-CN 1234",
+				Type:              cargonaut.VehicleTypeVan,
+				Brand:             "Brand",
+				Model:             "Model",
+				Passengers:        2,
+				MaxPayload:        1000,
+				LoadingAreaLength: 200,
+				LoadingAreaWidth:  120,
+				LoadingAreaHeight: 100,
+				LoadingVolume:     2,
+				Amenities:         cargonaut.VehicleAmenities{cargonaut.VehicleAmenityRamp, cargonaut.VehicleAmenityTieDowns},
+			}
+			if tt.vehicle != nil {
+				tt.vehicle(vehicle)
+			}
+			vehicles := &fakeVehicleRepository{}
+			h := newTestHandler(t)
+			h.VehicleRepository = vehicles
+
+			userID := uuid.NewV4()
+			rec := serve(t, h, userID, http.MethodPost, "/api/v1/vehicles", vehicle)
+
+			if tt.fields != nil {
+				require.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+				var resp struct {
+					Fields map[string]string `json:"fields"`
+				}
+				require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+				assert.Equal(t, tt.fields, resp.Fields)
+				assert.Empty(t, vehicles.vehicles)
+				return
+			}
+			require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+			if assert.Len(t, vehicles.vehicles, 1) {
+				assert.Equal(t, tt.plate, vehicles.vehicles[0].LicensePlate)
+				assert.Equal(t, userID, vehicles.vehicles[0].UserID)
+				assert.Nil(t, vehicles.vehicles[0].OrganizationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// waitlistTrip returns the trip identified by the id URL parameter. It renders
+// an error and returns false, if the trip does not exist.
+func (h *Handler) waitlistTrip(w http.ResponseWriter, r *http.Request) (*cargonaut.Trip, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	trip, err := h.TripRepository.GetTrip(r.Context(), id)
+	if err == cargonaut.ErrTripNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return trip, true
+}
+
+func (h *Handler) listTripWaitlist(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.driverTrip(w, r, authUserID)
+	if !ok {
+		return
+	}
+
+	if entries, err := h.WaitlistRepository.ListWaitlist(r.Context(), trip.ID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, entries)
+	}
+}
+
+func (h *Handler) joinTripWaitlist(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.waitlistTrip(w, r)
+	if !ok {
+		return
+	}
+
+	if uuid.Equal(trip.UserID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not join waitlist of own trip")
+		return
+	} else if trip.RiderID != nil && uuid.Equal(*trip.RiderID, authUserID) {
+		h.renderErrorf(w, r, http.StatusConflict, "can not join waitlist of booked trip")
+		return
+	}
+
+	entry := &cargonaut.WaitlistEntry{
+		TripID: trip.ID,
+		UserID: authUserID,
+	}
+	if err := h.WaitlistRepository.JoinWaitlist(r.Context(), entry); err == cargonaut.ErrTripNotAvailable || err == cargonaut.ErrTripNotFull || err == cargonaut.ErrWaitlistEntryExists {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.render(w, r, http.StatusCreated, entry)
+	}
+}
+
+func (h *Handler) leaveTripWaitlist(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if err = h.WaitlistRepository.LeaveWaitlist(r.Context(), id, authUserID); err == cargonaut.ErrWaitlistEntryNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
+
+func (h *Handler) confirmTripWaitlistOffer(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	trip, ok := h.waitlistTrip(w, r)
+	if !ok {
+		return
+	}
+
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripBooked, trip.UserID, trip.ID)
+	if err := h.WaitlistRepository.ConfirmWaitlistOffer(r.Context(), trip.ID, authUserID, event); err == cargonaut.ErrWaitlistOfferNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+	} else if err == cargonaut.ErrTripNotAvailable {
+		h.renderError(w, r, http.StatusConflict, err)
+	} else if err == cargonaut.ErrInsufficientFunds {
+		h.renderError(w, r, http.StatusPaymentRequired, err)
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		render.NoContent(w, r)
+	}
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/webhook"
+)
+
+// webhookEventTypes are the event types webhooks can subscribe to.
+var webhookEventTypes = cargonaut.EventTypes{
+	cargonaut.EventTypeTripCreated,
+	cargonaut.EventTypeTripUpdated,
+	cargonaut.EventTypeTripBooked,
+	cargonaut.EventTypeTripCancelled,
+	cargonaut.EventTypeTripCancelledByDriver,
+	cargonaut.EventTypeTripCompleted,
+	cargonaut.EventTypeBookingRequested,
+	cargonaut.EventTypeBookingAccepted,
+	cargonaut.EventTypeBookingDeclined,
+	cargonaut.EventTypeBookingExpired,
+	cargonaut.EventTypeWaitlistOffered,
+	cargonaut.EventTypeWaitlistOfferExpired,
+	cargonaut.EventTypeRatingCreated,
+}
+
+type createWebhookRequest struct {
+	URL    string               `json:"url"`
+	Events cargonaut.EventTypes `json:"events"`
+}
+
+// createdWebhook is the representation of a newly created webhook. It is the
+// only one which contains the secret.
+type createdWebhook struct {
+	*cargonaut.Webhook
+	Secret string `json:"secret"`
+}
+
+// webhookLookup returns the webhook a request is about, if the authenticated
+// user may manage it. Otherwise an error is rendered.
+type webhookLookup func(w http.ResponseWriter, r *http.Request) (*cargonaut.Webhook, bool)
+
+// webhookFromRequest returns the webhook identified by the URL parameter.
+// Otherwise an error is rendered.
+func (h *Handler) webhookFromRequest(w http.ResponseWriter, r *http.Request, param string) (*cargonaut.Webhook, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, param))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	webhook, err := h.WebhookRepository.GetWebhook(r.Context(), id)
+	if err == cargonaut.ErrWebhookNotFound {
+		h.renderError(w, r, http.StatusNotFound, err)
+		return nil, false
+	} else if err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return webhook, true
+}
+
+// ownWebhook returns the webhook identified by the id URL parameter if it
+// belongs to the authenticated user. Otherwise an error is rendered.
+func (h *Handler) ownWebhook(w http.ResponseWriter, r *http.Request) (*cargonaut.Webhook, bool) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return nil, false
+	}
+
+	webhook, ok := h.webhookFromRequest(w, r, "id")
+	if !ok {
+		return nil, false
+	} else if webhook.UserID == nil || !uuid.Equal(*webhook.UserID, authUserID) {
+		h.renderErrorf(w, r, http.StatusForbidden, "can not access webhook of another user")
+		return nil, false
+	}
+	return webhook, true
+}
+
+// organizationWebhook returns the webhook identified by the webhook_id URL
+// parameter if it belongs to the organization identified by the id URL
+// parameter. Otherwise an error is rendered. The membership of the
+// authenticated user is checked by the requireOrganizationRole middleware.
+func (h *Handler) organizationWebhook(w http.ResponseWriter, r *http.Request) (*cargonaut.Webhook, bool) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return nil, false
+	}
+
+	webhook, ok := h.webhookFromRequest(w, r, "webhook_id")
+	if !ok {
+		return nil, false
+	} else if webhook.OrganizationID == nil || !uuid.Equal(*webhook.OrganizationID, id) {
+		h.renderError(w, r, http.StatusNotFound, cargonaut.ErrWebhookNotFound)
+		return nil, false
+	}
+	return webhook, true
+}
+
+func (h *Handler) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	if webhooks, err := h.WebhookRepository.ListWebhooks(r.Context(), authUserID); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, webhooks)
+	}
+}
+
+func (h *Handler) listOrganizationWebhooks(w http.ResponseWriter, r *http.Request) {
+	if id, err := uuid.FromString(chi.URLParam(r, "id")); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+	} else if webhooks, err := h.WebhookRepository.ListOrganizationWebhooks(r.Context(), id); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.renderOK(w, r, webhooks)
+	}
+}
+
+func (h *Handler) getWebhook(lookup webhookLookup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if webhook, ok := lookup(w, r); ok {
+			h.renderOK(w, r, webhook)
+		}
+	}
+}
+
+func (h *Handler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	authUserID, ok := h.userIDFromRequest(r.Context(), w, r)
+	if !ok {
+		return
+	}
+
+	h.createOwnedWebhook(w, r, &cargonaut.Webhook{UserID: &authUserID})
+}
+
+func (h *Handler) createOrganizationWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.FromString(chi.URLParam(r, "id"))
+	if err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.createOwnedWebhook(w, r, &cargonaut.Webhook{OrganizationID: &id})
+}
+
+// createOwnedWebhook creates the webhook requested for the owner set on the
+// given webhook.
+func (h *Handler) createOwnedWebhook(w http.ResponseWriter, r *http.Request, hook *cargonaut.Webhook) {
+	var req createWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.renderError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verr := make(validationError)
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		verr.add("url", "must be an absolute http or https URL")
+	} else if len(req.URL) > 2048 {
+		verr.add("url", "must not be longer than 2048 characters")
+	} else if err = webhook.CheckTarget(r.Context(), net.DefaultResolver, req.URL); err == webhook.ErrForbiddenTarget {
+		verr.add("url", "must not point to a loopback, private or link-local address")
+	} else if err != nil {
+		verr.add("url", "must have a resolvable host")
+	}
+	if len(req.Events) == 0 {
+		verr.add("events", "must not be empty")
+	}
+	for _, typ := range req.Events {
+		if !webhookEventTypes.Contains(typ) {
+			verr.add("events", "must only contain supported event types")
+			break
+		}
+	}
+	if err := verr.err(); err != nil {
+		h.renderError(w, r, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	hook.URL = req.URL
+	hook.Secret = hex.EncodeToString(secret)
+	hook.Events = req.Events
+	if err := h.WebhookRepository.CreateWebhook(r.Context(), hook); err != nil {
+		h.renderError(w, r, http.StatusInternalServerError, err)
+	} else {
+		h.render(w, r, http.StatusCreated, &createdWebhook{
+			Webhook: hook,
+			Secret:  hook.Secret,
+		})
+	}
+}
+
+func (h *Handler) deleteWebhook(lookup webhookLookup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhook, ok := lookup(w, r)
+		if !ok {
+			return
+		}
+
+		if err := h.WebhookRepository.DeleteWebhook(r.Context(), webhook.ID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+		} else {
+			render.NoContent(w, r)
+		}
+	}
+}
+
+func (h *Handler) listWebhookDeliveries(lookup webhookLookup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhook, ok := lookup(w, r)
+		if !ok {
+			return
+		}
+
+		if deliveries, err := h.WebhookRepository.ListDeliveries(r.Context(), webhook.ID); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+		} else {
+			h.renderOK(w, r, deliveries)
+		}
+	}
+}
+
+func (h *Handler) redeliverWebhookDelivery(lookup webhookLookup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhook, ok := lookup(w, r)
+		if !ok {
+			return
+		}
+
+		deliveryID, err := uuid.FromString(chi.URLParam(r, "delivery_id"))
+		if err != nil {
+			h.renderError(w, r, http.StatusBadRequest, err)
+			return
+		}
+
+		delivery, err := h.WebhookRepository.GetDelivery(r.Context(), deliveryID)
+		if err == cargonaut.ErrWebhookDeliveryNotFound {
+			h.renderError(w, r, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+			return
+		} else if !uuid.Equal(delivery.WebhookID, webhook.ID) {
+			h.renderError(w, r, http.StatusNotFound, cargonaut.ErrWebhookDeliveryNotFound)
+			return
+		}
+
+		// A redelivery is a new delivery of the same payload which is
+		// attempted as soon as possible.
+		redelivery := &cargonaut.WebhookDelivery{
+			WebhookID:  delivery.WebhookID,
+			EventID:    delivery.EventID,
+			EventType:  delivery.EventType,
+			Payload:    delivery.Payload,
+			Redelivery: true,
+		}
+		if err = h.WebhookRepository.CreateDelivery(r.Context(), redelivery); err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, err)
+		} else {
+			h.render(w, r, http.StatusAccepted, redelivery)
+		}
+	}
+}
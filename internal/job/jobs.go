@@ -0,0 +1,64 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// DeleteExpiredTokens returns a job which deletes expired authentication
+// tokens.
+func DeleteExpiredTokens(users cargonaut.UserRepository) Func {
+	return func(ctx context.Context) error {
+		_, err := users.DeleteExpiredTokens(ctx, time.Now().UTC())
+		return err
+	}
+}
+
+// PruneOutbox returns a job which deletes events published to all subscribers
+// longer than the retention ago.
+func PruneOutbox(outbox cargonaut.OutboxRepository, retention time.Duration) Func {
+	return func(ctx context.Context) error {
+		_, err := outbox.PrunePublishedEvents(ctx, time.Now().UTC().Add(-retention))
+		return err
+	}
+}
+
+// RemindTrips returns a job which reminds drivers and riders of trips
+// departing within the given duration.
+func RemindTrips(trips cargonaut.TripRepository, ahead time.Duration) Func {
+	return func(ctx context.Context) error {
+		_, err := trips.RemindTrips(ctx, time.Now().UTC().Add(ahead))
+		return err
+	}
+}
+
+// CompleteTrips returns a job which completes trips whose arrival passed and
+// settles them to their drivers, withholding the platform fee. Referrals are
+// credited according to the referral policy.
+func CompleteTrips(trips cargonaut.TripRepository, fees cargonaut.FeePolicy, referrals cargonaut.ReferralPolicy) Func {
+	return func(ctx context.Context) error {
+		_, err := trips.CompleteTrips(ctx, time.Now().UTC(), fees, referrals)
+		return err
+	}
+}
+
+// ExpireBookings returns a job which expires bookings the driver did not
+// decide on in time.
+func ExpireBookings(bookings cargonaut.BookingRepository) Func {
+	return func(ctx context.Context) error {
+		_, err := bookings.ExpireBookings(ctx, time.Now().UTC())
+		return err
+	}
+}
+
+// AdvanceWaitlists returns a job which passes the offers of trips on along
+// their waitlists. Offered trips are held for the given duration.
+func AdvanceWaitlists(waitlists cargonaut.WaitlistRepository, hold time.Duration) Func {
+	return func(ctx context.Context) error {
+		now := time.Now().UTC()
+		_, err := waitlists.AdvanceWaitlists(ctx, now, now.Add(hold))
+		return err
+	}
+}
@@ -0,0 +1,145 @@
+package job
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/cron"
+)
+
+// pollInterval is the interval in which due jobs are checked.
+const pollInterval = 10 * time.Second
+
+// releaseTimeout is the time granted to release the lease of a job after it
+// ran. The lease is released even if the scheduler is shutting down.
+const releaseTimeout = 5 * time.Second
+
+// Func is the function run by a job. It must return once the context is done.
+type Func func(ctx context.Context) error
+
+// entry is a job registered with the scheduler.
+type entry struct {
+	name     string
+	schedule *cron.Schedule
+	timeout  time.Duration
+	run      Func
+}
+
+// Scheduler runs jobs on their schedules. Jobs are coordinated across server
+// instances through leases, so each scheduled run happens on one instance
+// only. Schedules are evaluated in UTC.
+type Scheduler struct {
+	log   *log.Logger
+	jobs  cargonaut.JobRepository
+	owner string
+
+	entries []*entry
+	running map[string]bool
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+}
+
+// NewScheduler returns a new Scheduler. The owner identifies the server
+// instance holding the lease of a job.
+func NewScheduler(log *log.Logger, jobs cargonaut.JobRepository, owner string) *Scheduler {
+	return &Scheduler{
+		log:     log,
+		jobs:    jobs,
+		owner:   owner,
+		running: make(map[string]bool),
+	}
+}
+
+// Register registers a job under a unique name. A run of the job is cancelled
+// after the timeout, which is the duration of its lease as well. Register must
+// not be called after Run.
+func (s *Scheduler) Register(name string, schedule *cron.Schedule, timeout time.Duration, run Func) {
+	s.entries = append(s.entries, &entry{
+		name:     name,
+		schedule: schedule,
+		timeout:  timeout,
+		run:      run,
+	})
+}
+
+// Run runs the registered jobs until the context is done. Running jobs are
+// cancelled and waited for before Run returns.
+func (s *Scheduler) Run(ctx context.Context) error {
+	defer s.wg.Wait()
+
+	now := time.Now().UTC()
+	for _, e := range s.entries {
+		if err := s.jobs.RegisterJob(ctx, &cargonaut.Job{
+			Name:      e.name,
+			Schedule:  e.schedule.String(),
+			NextRunAt: e.schedule.Next(now),
+		}); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, e := range s.entries {
+			s.start(ctx, e)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// start runs the job in the background, if it is not already running on this
+// instance and its lease can be acquired.
+func (s *Scheduler) start(ctx context.Context, e *entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[e.name] {
+		return
+	}
+
+	if ok, err := s.jobs.AcquireJob(ctx, e.name, s.owner, e.timeout); err != nil {
+		s.log.Printf("acquire job %s: %s", e.name, err)
+		return
+	} else if !ok {
+		return
+	}
+
+	s.running[e.name] = true
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx, e)
+
+		s.mu.Lock()
+		delete(s.running, e.name)
+		s.mu.Unlock()
+	}()
+}
+
+// run runs the job and releases its lease afterwards.
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	runCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	runErr := e.run(runCtx)
+	if runErr != nil {
+		s.log.Printf("run job %s: %s", e.name, runErr)
+	}
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	next := e.schedule.Next(time.Now().UTC())
+	if err := s.jobs.ReleaseJob(releaseCtx, e.name, s.owner, next, runErr); err != nil {
+		s.log.Printf("release job %s: %s", e.name, err)
+	}
+}
@@ -5,41 +5,64 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/SermoDigital/jose/crypto"
-	"github.com/SermoDigital/jose/jws"
+	jwtgo "github.com/dgrijalva/jwt-go"
 	uuid "github.com/satori/go.uuid"
 
 	"github.com/my-cargonaut/cargonaut"
 )
 
-const expiration = time.Hour * 24
+const (
+	expiration = time.Hour * 24
+	// leeway is how long after their expiration tokens are still accepted by
+	// UserFromToken, so they can be refreshed.
+	leeway = expiration
+
+	issuer   = "my-cargonaut.com"
+	subject  = "authentication"
+	audience = "my-cargonaut.com"
+)
+
+// claims are the claims of an authentication token.
+type claims struct {
+	jwtgo.StandardClaims
+	User userClaims `json:"user"`
+}
+
+// userClaims are the custom claims identifying the user of an authentication
+// token.
+type userClaims struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
 
 // NewToken creates an authentication token for the specified user resource.
 func NewToken(secret []byte, user *cargonaut.User) (token *cargonaut.Token, err error) {
 	// Create unique JWT ID.
 	id := uuid.NewV4()
 
-	// Setup the standard claims.
+	// Setup the standard and the custom user claims.
 	now := time.Now().UTC()
 	exp := now.Add(expiration)
-	claims := jws.Claims{}
-	claims.SetIssuer("my-cargonaut.com")
-	claims.SetSubject("authentication")
-	claims.SetAudience("my-cargonaut.com")
-	claims.SetExpiration(exp)
-	claims.SetNotBefore(now)
-	claims.SetIssuedAt(now)
-	claims.SetJWTID(id.String())
-
-	// Setup the custom user claims.
-	claims.Set("user", map[string]interface{}{
-		"id":    user.ID,
-		"email": user.Email,
-		"name":  user.DisplayName,
-	})
+	c := &claims{
+		StandardClaims: jwtgo.StandardClaims{
+			Id:        id.String(),
+			Issuer:    issuer,
+			Subject:   subject,
+			Audience:  audience,
+			ExpiresAt: exp.Unix(),
+			NotBefore: now.Unix(),
+			IssuedAt:  now.Unix(),
+		},
+		User: userClaims{
+			ID:    user.ID.String(),
+			Email: user.Email,
+			Name:  user.DisplayName,
+		},
+	}
 
 	// Create and serialize JWT.
-	tokenStr, err := jws.NewJWT(claims, crypto.SigningMethodHS256).Serialize(secret)
+	tokenStr, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, c).SignedString(secret)
 	if err != nil {
 		return nil, fmt.Errorf("serialize token: %w", err)
 	}
@@ -47,9 +70,9 @@ func NewToken(secret []byte, user *cargonaut.User) (token *cargonaut.Token, err
 	token = &cargonaut.Token{
 		ID:        id,
 		UserID:    user.ID,
-		Token:     string(tokenStr),
-		ExpiresAt: exp,
-		CreatedAt: now,
+		Token:     tokenStr,
+		ExpiresAt: time.Unix(exp.Unix(), 0).UTC(),
+		CreatedAt: time.Unix(now.Unix(), 0).UTC(),
 	}
 	return token, nil
 }
@@ -58,71 +81,49 @@ func NewToken(secret []byte, user *cargonaut.User) (token *cargonaut.Token, err
 // user which is associated with it from the token claims. The provided token is
 // updated.
 func UserFromToken(secret []byte, token *cargonaut.Token) (user *cargonaut.User, err error) {
-	// Parse the token string.
-	jwt, err := jws.ParseJWT([]byte(token.Token))
-	if err != nil {
+	// Parse the token string and validate its signature. The times are
+	// validated below to allow for the leeway.
+	c := new(claims)
+	parser := &jwtgo.Parser{
+		ValidMethods:         []string{jwtgo.SigningMethodHS256.Alg()},
+		SkipClaimsValidation: true,
+	}
+	if _, err = parser.ParseWithClaims(token.Token, c, func(*jwtgo.Token) (interface{}, error) {
+		return secret, nil
+	}); err != nil {
 		return nil, fmt.Errorf("parse jwt: %w", err)
 	}
 
-	// Setup the standard claims which are expected to match.
-	claims := jws.Claims{}
-	claims.SetIssuer("my-cargonaut.com")
-	claims.SetSubject("authentication")
-	claims.SetAudience("my-cargonaut.com")
-
-	// Setup custom validator function to check the user claims.
-	val := func(c jws.Claims) error {
-		var ok bool
-
-		// Get standard claims.
-		tokenID, ok := c.JWTID()
-		if !ok {
-			return errors.New("missing token id")
-		} else if token.ID, err = uuid.FromString(tokenID); err != nil {
-			return fmt.Errorf("parse token id: %w", err)
-		}
-
-		expiresAt, ok := c.Expiration()
-		if !ok {
-			return errors.New("missing expiration time")
-		}
-		token.ExpiresAt = expiresAt.UTC()
-
-		createdAt, ok := c.IssuedAt()
-		if !ok {
-			return errors.New("missing creation time")
-		}
-		token.CreatedAt = createdAt.UTC()
-
-		// Get user claims.
-		uClaims, ok := c.Get("user").(map[string]interface{})
-		if !ok {
-			return errors.New("could not find user claims")
-		}
-		user = new(cargonaut.User)
-
-		userID, ok := uClaims["id"].(string)
-		if !ok {
-			return errors.New("missing user id")
-		} else if user.ID, err = uuid.FromString(userID); err != nil {
-			return fmt.Errorf("parse user id: %w", err)
-		}
-
-		if user.Email, ok = uClaims["email"].(string); !ok {
-			return errors.New("missing user email")
-		}
-
-		if user.DisplayName, ok = uClaims["name"].(string); !ok {
-			return errors.New("missing user name")
-		}
-
-		return nil
+	// Validate the standard claims which are expected to match and the times.
+	now := time.Now().UTC()
+	if c.Issuer != issuer || c.Subject != subject || c.Audience != audience {
+		return nil, errors.New("validate token: unexpected issuer, subject or audience")
+	} else if !c.VerifyExpiresAt(now.Add(-leeway).Unix(), true) {
+		return nil, errors.New("validate token: token is expired")
+	} else if !c.VerifyNotBefore(now.Unix(), false) || !c.VerifyIssuedAt(now.Unix(), false) {
+		return nil, errors.New("validate token: token is not valid yet")
 	}
 
-	// Setup validator and validate the token.
-	v := jws.NewValidator(claims, expiration, 0, val)
-	if err = jwt.Validate(secret, crypto.SigningMethodHS256, v); err != nil {
-		return nil, fmt.Errorf("validate token: %w", err)
+	// Get standard claims.
+	if c.Id == "" {
+		return nil, errors.New("missing token id")
+	} else if token.ID, err = uuid.FromString(c.Id); err != nil {
+		return nil, fmt.Errorf("parse token id: %w", err)
+	}
+	token.ExpiresAt = time.Unix(c.ExpiresAt, 0).UTC()
+	if c.IssuedAt == 0 {
+		return nil, errors.New("missing creation time")
+	}
+	token.CreatedAt = time.Unix(c.IssuedAt, 0).UTC()
+
+	// Get user claims.
+	user = new(cargonaut.User)
+	if c.User.ID == "" {
+		return nil, errors.New("missing user id")
+	} else if user.ID, err = uuid.FromString(c.User.ID); err != nil {
+		return nil, fmt.Errorf("parse user id: %w", err)
 	}
+	user.Email = c.User.Email
+	user.DisplayName = c.User.Name
 	return user, nil
 }
@@ -0,0 +1,88 @@
+package jwt_test
+
+import (
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/jwt"
+)
+
+var secret = []byte("secret")
+
+func TestToken(t *testing.T) {
+	user := &cargonaut.User{
+		ID:          uuid.NewV4(),
+		Email:       "user@example.com",
+		DisplayName: "User",
+	}
+	token, err := NewToken(secret, user)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, token.UserID)
+	assert.Equal(t, 24*time.Hour, token.ExpiresAt.Sub(token.CreatedAt))
+
+	parsed := &cargonaut.Token{Token: token.Token}
+	got, err := UserFromToken(secret, parsed)
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+	assert.Equal(t, token.ID, parsed.ID)
+	assert.Equal(t, token.ExpiresAt, parsed.ExpiresAt)
+	assert.Equal(t, token.CreatedAt, parsed.CreatedAt)
+
+	_, err = UserFromToken([]byte("other secret"), &cargonaut.Token{Token: token.Token})
+	assert.Error(t, err)
+}
+
+func TestUserFromToken(t *testing.T) {
+	now := time.Now()
+	sign := func(mutate func(claims jwtgo.MapClaims)) string {
+		claims := jwtgo.MapClaims{
+			"jti":  uuid.NewV4().String(),
+			"iss":  "my-cargonaut.com",
+			"sub":  "authentication",
+			"aud":  "my-cargonaut.com",
+			"exp":  now.Add(time.Hour).Unix(),
+			"nbf":  now.Unix(),
+			"iat":  now.Unix(),
+			"user": map[string]interface{}{"id": uuid.NewV4().String(), "email": "user@example.com", "name": "User"},
+		}
+		mutate(claims)
+		token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, claims).SignedString(secret)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(claims jwtgo.MapClaims)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(jwtgo.MapClaims) {}},
+		{name: "expired within leeway", mutate: func(c jwtgo.MapClaims) { c["exp"] = now.Add(-23 * time.Hour).Unix() }},
+		{name: "expired", mutate: func(c jwtgo.MapClaims) { c["exp"] = now.Add(-25 * time.Hour).Unix() }, wantErr: true},
+		{name: "not yet valid", mutate: func(c jwtgo.MapClaims) { c["nbf"] = now.Add(time.Hour).Unix() }, wantErr: true},
+		{name: "other audience", mutate: func(c jwtgo.MapClaims) { c["aud"] = "example.com" }, wantErr: true},
+		{name: "missing token id", mutate: func(c jwtgo.MapClaims) { delete(c, "jti") }, wantErr: true},
+		{name: "missing user", mutate: func(c jwtgo.MapClaims) { delete(c, "user") }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UserFromToken(secret, &cargonaut.Token{Token: sign(tt.mutate)})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	none, err := jwtgo.NewWithClaims(jwtgo.SigningMethodNone, jwtgo.MapClaims{}).SignedString(jwtgo.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	_, err = UserFromToken(secret, &cargonaut.Token{Token: none})
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+var (
+	_ cargonaut.Mailer = (*SMTPMailer)(nil)
+	_ cargonaut.Mailer = (*LogMailer)(nil)
+)
+
+// SMTPMailer sends E-Mails through an SMTP server.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer returns a new SMTPMailer which sends E-Mails from the given
+// address through the SMTP server listening on addr. If a username is set, the
+// mailer authenticates using PLAIN authentication, which requires TLS unless
+// the server is running on localhost.
+func NewSMTPMailer(addr, username, password, from string) (*SMTPMailer, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse smtp address: %w", err)
+	}
+
+	m := &SMTPMailer{
+		addr: addr,
+		from: from,
+	}
+	if username != "" {
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m, nil
+}
+
+// SendMail sends a plain text E-Mail to the given address.
+func (m *SMTPMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{to}, message(m.from, to, subject, body)); err != nil {
+		return fmt.Errorf("send mail to %q: %w", to, err)
+	}
+	return nil
+}
+
+// LogMailer logs E-Mails instead of sending them. It is used if no SMTP server
+// is configured.
+type LogMailer struct {
+	log *log.Logger
+}
+
+// NewLogMailer returns a new LogMailer which writes E-Mails to the given
+// logger.
+func NewLogMailer(log *log.Logger) *LogMailer {
+	return &LogMailer{log}
+}
+
+// SendMail logs a plain text E-Mail to the given address.
+func (m *LogMailer) SendMail(ctx context.Context, to, subject, body string) error {
+	m.log.Printf("E-Mail to %q: %s\n%s", to, subject, body)
+	return nil
+}
+
+// message returns a plain text, UTF-8 encoded E-Mail message.
+func message(from, to, subject, body string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprint(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprint(&buf, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprint(&buf, "Content-Transfer-Encoding: 8bit\r\n")
+	fmt.Fprint(&buf, "\r\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+var _ cargonaut.EventBroker = (*EventBroker)(nil)
+
+// subscriptionBuffer is the number of events buffered per subscription. Events
+// are dropped for subscribers which do not keep up.
+const subscriptionBuffer = 16
+
+// EventBroker is an in-process event broker. It only distributes events to
+// subscribers of the same process.
+type EventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan *cargonaut.Event]struct{}
+}
+
+// NewEventBroker returns a new in-process event broker.
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscribers: make(map[uuid.UUID]map[chan *cargonaut.Event]struct{}),
+	}
+}
+
+// Publish publishes events to the users they are addressed to.
+func (b *EventBroker) Publish(ctx context.Context, events ...*cargonaut.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, event := range events {
+		for ch := range b.subscribers[event.UserID] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe subscribes to the events addressed to the user identified by his
+// unique ID. The returned channel is closed once the context is done.
+func (b *EventBroker) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan *cargonaut.Event, error) {
+	ch := make(chan *cargonaut.Event, subscriptionBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[chan *cargonaut.Event]struct{})
+	}
+	b.subscribers[userID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers[userID], ch)
+		if len(b.subscribers[userID]) == 0 {
+			delete(b.subscribers, userID)
+		}
+		b.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,94 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/memory"
+)
+
+// receive returns the events buffered in the channel without waiting for more.
+func receive(ch <-chan *cargonaut.Event) []*cargonaut.Event {
+	var events []*cargonaut.Event
+	for {
+		select {
+		case event := <-ch:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestEventBrokerFanOut(t *testing.T) {
+	b := NewEventBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	userID, otherID := uuid.NewV4(), uuid.NewV4()
+	first, err := b.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	second, err := b.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	other, err := b.Subscribe(ctx, otherID)
+	require.NoError(t, err)
+
+	// Every subscription of the user gets the events addressed to them, the
+	// subscriptions of other users don't.
+	event := cargonaut.NewEvent(cargonaut.EventTypeTripBooked, userID, uuid.NewV4())
+	unsubscribed := cargonaut.NewEvent(cargonaut.EventTypeTripBooked, uuid.NewV4(), uuid.NewV4())
+	require.NoError(t, b.Publish(ctx, event, unsubscribed))
+	assert.Equal(t, []*cargonaut.Event{event}, receive(first))
+	assert.Equal(t, []*cargonaut.Event{event}, receive(second))
+	assert.Empty(t, receive(other))
+}
+
+func TestEventBrokerSlowSubscriber(t *testing.T) {
+	b := NewEventBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	userID := uuid.NewV4()
+	slow, err := b.Subscribe(ctx, userID)
+	require.NoError(t, err)
+
+	// Publishing doesn't block on a subscriber which doesn't keep up. Events
+	// exceeding its buffer of 16 events are dropped for it.
+	events := make([]*cargonaut.Event, 20)
+	for i := range events {
+		events[i] = cargonaut.NewEvent(cargonaut.EventTypeMessageCreated, userID, uuid.NewV4())
+		require.NoError(t, b.Publish(ctx, events[i]))
+	}
+	assert.Equal(t, events[:16], receive(slow))
+
+	// Once it caught up, it gets new events again.
+	event := cargonaut.NewEvent(cargonaut.EventTypeMessageCreated, userID, uuid.NewV4())
+	require.NoError(t, b.Publish(ctx, event))
+	assert.Equal(t, []*cargonaut.Event{event}, receive(slow))
+}
+
+func TestEventBrokerUnsubscribe(t *testing.T) {
+	b := NewEventBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	userID := uuid.NewV4()
+	ch, err := b.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	cancel()
+
+	// The channel is closed once the context is done and publishing to the
+	// user afterwards is no error.
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed")
+	}
+	assert.NoError(t, b.Publish(context.Background(), cargonaut.NewEvent(cargonaut.EventTypeTripBooked, userID, uuid.NewV4())))
+}
@@ -0,0 +1,9 @@
+package notify
+
+// Exported for the tests of the template lookup.
+var (
+	DefaultLanguage = defaultLanguage
+	TemplateSources = templateSources
+	Templates       = templates
+	LookupTemplate  = lookupTemplate
+)
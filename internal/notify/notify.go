@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+var _ cargonaut.Notifier = (*Notifier)(nil)
+
+// Notifier notifies users about events through the in-app inbox and E-Mail,
+// depending on their notification settings. Users are only notified about
+// events a template exists for.
+type Notifier struct {
+	users         cargonaut.UserRepository
+	trips         cargonaut.TripRepository
+	notifications cargonaut.NotificationRepository
+	mailer        cargonaut.Mailer
+}
+
+// NewNotifier returns a new Notifier which stores in-app notifications in the
+// notification repository and sends E-Mails using the mailer.
+func NewNotifier(users cargonaut.UserRepository, trips cargonaut.TripRepository, notifications cargonaut.NotificationRepository, mailer cargonaut.Mailer) *Notifier {
+	return &Notifier{
+		users:         users,
+		trips:         trips,
+		notifications: notifications,
+		mailer:        mailer,
+	}
+}
+
+// Notify notifies the users the events are addressed to.
+func (n *Notifier) Notify(ctx context.Context, events ...*cargonaut.Event) error {
+	for _, event := range events {
+		if err := n.notify(ctx, event); err != nil {
+			return fmt.Errorf("notify user %q about %s event: %w", event.UserID, event.Type, err)
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) notify(ctx context.Context, event *cargonaut.Event) error {
+	if _, ok := lookupTemplate(defaultLanguage, string(event.Type)); !ok {
+		return nil
+	}
+
+	// There is no one to notify about deleted trips or deleted users.
+	user, err := n.users.GetUser(ctx, event.UserID)
+	if err == cargonaut.ErrUserNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	trip, err := n.trips.GetTrip(ctx, event.TripID)
+	if err == cargonaut.ErrTripNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	tmpl, _ := lookupTemplate(user.Notifications.Language, string(event.Type))
+	subject, body, err := render(tmpl, &templateData{
+		User: user,
+		Trip: trip,
+	})
+	if err != nil {
+		return err
+	}
+
+	if user.Notifications.Inbox {
+		if err = n.notifications.CreateNotification(ctx, &cargonaut.Notification{
+			UserID:  user.ID,
+			TripID:  trip.ID,
+			EventID: &event.ID,
+			Type:    event.Type,
+			Title:   subject,
+		}); err != nil {
+			return err
+		}
+	}
+	if user.Notifications.Email {
+		if err = n.mailer.SendMail(ctx, user.Email, subject, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NotifyEmailVerification sends the verification token of a pending E-Mail
+// address change to the new address.
+func (n *Notifier) NotifyEmailVerification(ctx context.Context, user *cargonaut.User, verification *cargonaut.EmailVerification) error {
+	tmpl, _ := lookupTemplate(user.Notifications.Language, emailVerificationTemplate)
+	subject, body, err := render(tmpl, &templateData{
+		User:         user,
+		Verification: verification,
+	})
+	if err != nil {
+		return err
+	}
+	return n.mailer.SendMail(ctx, verification.Email, subject, body)
+}
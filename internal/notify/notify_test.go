@@ -0,0 +1,141 @@
+package notify_test
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/notify"
+)
+
+// fakeUserRepository returns the users it was given.
+type fakeUserRepository struct {
+	cargonaut.UserRepository
+
+	users []*cargonaut.User
+}
+
+func (f *fakeUserRepository) GetUser(_ context.Context, id uuid.UUID) (*cargonaut.User, error) {
+	for _, user := range f.users {
+		if uuid.Equal(user.ID, id) {
+			return user, nil
+		}
+	}
+	return nil, cargonaut.ErrUserNotFound
+}
+
+// fakeTripRepository returns the trips it was given.
+type fakeTripRepository struct {
+	cargonaut.TripRepository
+
+	trips []*cargonaut.Trip
+}
+
+func (f *fakeTripRepository) GetTrip(_ context.Context, id uuid.UUID) (*cargonaut.Trip, error) {
+	for _, trip := range f.trips {
+		if uuid.Equal(trip.ID, id) {
+			return trip, nil
+		}
+	}
+	return nil, cargonaut.ErrTripNotFound
+}
+
+// fakeNotificationRepository records the notifications created.
+type fakeNotificationRepository struct {
+	cargonaut.NotificationRepository
+
+	notifications []*cargonaut.Notification
+}
+
+func (f *fakeNotificationRepository) CreateNotification(_ context.Context, notification *cargonaut.Notification) error {
+	f.notifications = append(f.notifications, notification)
+	return nil
+}
+
+// mail is an E-Mail sent by the fakeMailer.
+type mail struct {
+	to, subject, body string
+}
+
+// fakeMailer records the E-Mails sent.
+type fakeMailer struct {
+	mails []mail
+}
+
+func (f *fakeMailer) SendMail(_ context.Context, to, subject, body string) error {
+	f.mails = append(f.mails, mail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func TestNotifierNotify(t *testing.T) {
+	trip := &cargonaut.Trip{ID: uuid.NewV4(), Start: "Berlin", Destination: "Hamburg"}
+	const (
+		english = "Your trip from Berlin to Hamburg has been booked"
+		german  = "Deine Fahrt von Berlin nach Hamburg wurde gebucht"
+	)
+
+	tests := []struct {
+		name     string
+		settings cargonaut.NotificationSettings
+		event    cargonaut.EventType
+		deleted  bool
+		trip     *cargonaut.Trip
+		inbox    bool
+		email    bool
+		subject  string
+	}{
+		{name: "inbox", settings: cargonaut.NotificationSettings{Inbox: true}, inbox: true, subject: english},
+		{name: "email", settings: cargonaut.NotificationSettings{Email: true}, email: true, subject: english},
+		{name: "inbox and email", settings: cargonaut.NotificationSettings{Inbox: true, Email: true}, inbox: true, email: true, subject: english},
+		{name: "no channel", settings: cargonaut.NotificationSettings{}},
+		{name: "language", settings: cargonaut.NotificationSettings{Inbox: true, Email: true, Language: "de"}, inbox: true, email: true, subject: german},
+		{name: "unsupported language", settings: cargonaut.NotificationSettings{Inbox: true, Language: "xx"}, inbox: true, subject: english},
+		{name: "no template", settings: cargonaut.NotificationSettings{Inbox: true, Email: true}, event: cargonaut.EventTypeMessageCreated},
+		{name: "deleted user", settings: cargonaut.NotificationSettings{Inbox: true, Email: true}, deleted: true},
+		{name: "deleted trip", settings: cargonaut.NotificationSettings{Inbox: true, Email: true}, trip: &cargonaut.Trip{ID: uuid.NewV4()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &cargonaut.User{ID: uuid.NewV4(), Email: "user@example.com", DisplayName: "User", Notifications: tt.settings}
+			users := &fakeUserRepository{users: []*cargonaut.User{user}}
+			if tt.deleted {
+				users.users = nil
+			}
+			notifications := &fakeNotificationRepository{}
+			mailer := &fakeMailer{}
+			n := NewNotifier(users, &fakeTripRepository{trips: []*cargonaut.Trip{trip}}, notifications, mailer)
+
+			typ, tripID := tt.event, trip.ID
+			if typ == "" {
+				typ = cargonaut.EventTypeTripBooked
+			}
+			if tt.trip != nil {
+				tripID = tt.trip.ID
+			}
+			event := cargonaut.NewEvent(typ, user.ID, tripID)
+			require.NoError(t, n.Notify(context.Background(), event))
+
+			if tt.inbox && assert.Len(t, notifications.notifications, 1) {
+				notification := notifications.notifications[0]
+				assert.Equal(t, user.ID, notification.UserID)
+				assert.Equal(t, trip.ID, notification.TripID)
+				assert.Equal(t, &event.ID, notification.EventID)
+				assert.Equal(t, event.Type, notification.Type)
+				assert.Equal(t, tt.subject, notification.Title)
+			} else if !tt.inbox {
+				assert.Empty(t, notifications.notifications)
+			}
+			if tt.email && assert.Len(t, mailer.mails, 1) {
+				assert.Equal(t, user.Email, mailer.mails[0].to)
+				assert.Equal(t, tt.subject, mailer.mails[0].subject)
+				assert.Contains(t, mailer.mails[0].body, user.DisplayName)
+			} else if !tt.email {
+				assert.Empty(t, mailer.mails)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// defaultLanguage is used for users whose language has no templates.
+const defaultLanguage = "en"
+
+// emailVerificationTemplate is the name of the E-Mail verification template.
+const emailVerificationTemplate = "email_verification"
+
+// templateSources are the localized notification templates. Each template
+// defines a subject and a body. The subject is used as title of in-app
+// notifications as well.
+var templateSources = map[string]map[string]string{
+	"en": {
+		string(cargonaut.EventTypeTripBooked): `
+{{- define "subject"}}Your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been booked{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been booked.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeTripCancelled): `
+{{- define "subject"}}Your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been cancelled{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+the booking of your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been cancelled by the rider. The trip is available again.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeBookingRequested): `
+{{- define "subject"}}New booking request for your trip from {{.Trip.Start}} to {{.Trip.Destination}}{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+a rider requested to book your trip from {{.Trip.Start}} to {{.Trip.Destination}}. Please accept or decline the request before it expires.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeBookingAccepted): `
+{{- define "subject"}}Your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}} has been accepted{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+the driver accepted your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}}.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeBookingDeclined): `
+{{- define "subject"}}Your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}} has been declined{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+unfortunately, your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}} has been declined.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeBookingExpired): `
+{{- define "subject"}}Your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}} has expired{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+the driver did not respond to your booking of the trip from {{.Trip.Start}} to {{.Trip.Destination}} in time. The booking has expired.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeWaitlistOffered): `
+{{- define "subject"}}A spot on the trip from {{.Trip.Start}} to {{.Trip.Destination}} is available{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+a spot on the trip from {{.Trip.Start}} to {{.Trip.Destination}} you are waiting for became available. It is held for you for a limited time, so please confirm soon to book the trip.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeWaitlistOfferExpired): `
+{{- define "subject"}}Your offer for the trip from {{.Trip.Start}} to {{.Trip.Destination}} has expired{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+you did not confirm the spot on the trip from {{.Trip.Start}} to {{.Trip.Destination}} in time. It has been offered to the next rider on the waitlist.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeTripCancelledByDriver): `
+{{- define "subject"}}Your ride from {{.Trip.Start}} to {{.Trip.Destination}} has been cancelled{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+the driver cancelled your ride from {{.Trip.Start}} to {{.Trip.Destination}}.
+{{- with .Trip.CancellationReason}}
+
+Reason: {{.}}
+{{- end}}
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeTripReminder): `
+{{- define "subject"}}Your trip from {{.Trip.Start}} to {{.Trip.Destination}} departs soon{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+your trip from {{.Trip.Start}} to {{.Trip.Destination}} departs at {{.Trip.PlannedDepature.Format "2006-01-02 15:04 MST"}}.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeTripCompleted): `
+{{- define "subject"}}Your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been completed{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+your trip from {{.Trip.Start}} to {{.Trip.Destination}} has been completed. Don't forget to rate your trip.
+
+Your Cargonaut team
+{{end}}`,
+		string(cargonaut.EventTypeRatingCreated): `
+{{- define "subject"}}You have been rated for your trip from {{.Trip.Start}} to {{.Trip.Destination}}{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+you have received a new rating for your trip from {{.Trip.Start}} to {{.Trip.Destination}}.
+
+Your Cargonaut team
+{{end}}`,
+		emailVerificationTemplate: `
+{{- define "subject"}}Verify your E-Mail address{{end}}
+{{- define "body"}}Hello {{.User.DisplayName}},
+
+please verify your new E-Mail address {{.Verification.Email}} using the following token:
+
+{{.Verification.ID}}
+
+The token expires at {{.Verification.ExpiresAt.Format "2006-01-02 15:04 MST"}}.
+
+Your Cargonaut team
+{{end}}`,
+	},
+	"de": {
+		string(cargonaut.EventTypeTripBooked): `
+{{- define "subject"}}Deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde gebucht{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde gebucht.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeTripCancelled): `
+{{- define "subject"}}Deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde storniert{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+die Buchung deiner Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde vom Mitfahrer storniert. Die Fahrt ist wieder verfügbar.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeBookingRequested): `
+{{- define "subject"}}Neue Buchungsanfrage für deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}}{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+ein Mitfahrer möchte deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} buchen. Bitte nimm die Anfrage an oder lehne sie ab, bevor sie abläuft.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeBookingAccepted): `
+{{- define "subject"}}Deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde angenommen{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+der Fahrer hat deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} angenommen.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeBookingDeclined): `
+{{- define "subject"}}Deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde abgelehnt{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+leider wurde deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} abgelehnt.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeBookingExpired): `
+{{- define "subject"}}Deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} ist abgelaufen{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+der Fahrer hat nicht rechtzeitig auf deine Buchung der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} reagiert. Die Buchung ist abgelaufen.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeWaitlistOffered): `
+{{- define "subject"}}Ein Platz auf der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} ist frei{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+auf der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}}, auf die du wartest, ist ein Platz frei geworden. Er wird nur für kurze Zeit für dich freigehalten, bitte bestätige ihn also bald, um die Fahrt zu buchen.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeWaitlistOfferExpired): `
+{{- define "subject"}}Dein Angebot für die Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} ist abgelaufen{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+du hast den Platz auf der Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} nicht rechtzeitig bestätigt. Er wurde dem nächsten Mitfahrer auf der Warteliste angeboten.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeTripCancelledByDriver): `
+{{- define "subject"}}Deine Mitfahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde abgesagt{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+der Fahrer hat deine Mitfahrt von {{.Trip.Start}} nach {{.Trip.Destination}} abgesagt.
+{{- with .Trip.CancellationReason}}
+
+Grund: {{.}}
+{{- end}}
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeTripReminder): `
+{{- define "subject"}}Deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} startet bald{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} startet am {{.Trip.PlannedDepature.Format "02.01.2006 um 15:04 MST"}}.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeTripCompleted): `
+{{- define "subject"}}Deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde abgeschlossen{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} wurde abgeschlossen. Vergiss nicht, deine Fahrt zu bewerten.
+
+Dein Cargonaut-Team
+{{end}}`,
+		string(cargonaut.EventTypeRatingCreated): `
+{{- define "subject"}}Du wurdest für deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} bewertet{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+du hast eine neue Bewertung für deine Fahrt von {{.Trip.Start}} nach {{.Trip.Destination}} erhalten.
+
+Dein Cargonaut-Team
+{{end}}`,
+		emailVerificationTemplate: `
+{{- define "subject"}}Bestätige deine E-Mail-Adresse{{end}}
+{{- define "body"}}Hallo {{.User.DisplayName}},
+
+bitte bestätige deine neue E-Mail-Adresse {{.Verification.Email}} mit dem folgenden Token:
+
+{{.Verification.ID}}
+
+Das Token ist gültig bis {{.Verification.ExpiresAt.Format "02.01.2006 15:04 MST"}}.
+
+Dein Cargonaut-Team
+{{end}}`,
+	},
+}
+
+// templates are the parsed notification templates by language and name.
+var templates = parseTemplates()
+
+// SupportsLanguage returns true if notification templates exist for the
+// language.
+func SupportsLanguage(language string) bool {
+	_, ok := templates[language]
+	return ok
+}
+
+// templateData is passed to the notification templates.
+type templateData struct {
+	User         *cargonaut.User
+	Trip         *cargonaut.Trip
+	Verification *cargonaut.EmailVerification
+}
+
+func parseTemplates() map[string]map[string]*template.Template {
+	parsed := make(map[string]map[string]*template.Template, len(templateSources))
+	for language, sources := range templateSources {
+		parsed[language] = make(map[string]*template.Template, len(sources))
+		for name, src := range sources {
+			parsed[language][name] = template.Must(template.New(name).Parse(src))
+		}
+	}
+	return parsed
+}
+
+// lookupTemplate returns the template with the given name in the given
+// language. If the language is not supported or has no such template, the
+// template in the default language is returned.
+func lookupTemplate(language, name string) (*template.Template, bool) {
+	if tmpl, ok := templates[language][name]; ok {
+		return tmpl, true
+	}
+	tmpl, ok := templates[defaultLanguage][name]
+	return tmpl, ok
+}
+
+// render renders the subject and body of a template.
+func render(tmpl *template.Template, data *templateData) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err = tmpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", fmt.Errorf("render %s subject: %w", tmpl.Name(), err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err = tmpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", fmt.Errorf("render %s body: %w", tmpl.Name(), err)
+	}
+	return subject, buf.String(), nil
+}
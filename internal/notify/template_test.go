@@ -0,0 +1,46 @@
+package notify_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/notify"
+)
+
+func TestTemplateSources(t *testing.T) {
+	for language, sources := range TemplateSources {
+		for name := range TemplateSources[DefaultLanguage] {
+			assert.Contains(t, sources, name, "language %q", language)
+		}
+		for name := range sources {
+			assert.Contains(t, TemplateSources[DefaultLanguage], name, "language %q", language)
+		}
+	}
+}
+
+func TestLookupTemplate(t *testing.T) {
+	name := string(cargonaut.EventTypeTripBooked)
+
+	tmpl, ok := LookupTemplate("de", name)
+	if assert.True(t, ok) {
+		assert.Equal(t, Templates["de"][name], tmpl)
+	}
+	tmpl, ok = LookupTemplate("xx", name)
+	if assert.True(t, ok) {
+		assert.Equal(t, Templates[DefaultLanguage][name], tmpl)
+	}
+	_, ok = LookupTemplate("de", "unknown")
+	assert.False(t, ok)
+
+	// A template missing in a supported language falls back to the default
+	// language as well.
+	localized := Templates["de"][name]
+	delete(Templates["de"], name)
+	defer func() { Templates["de"][name] = localized }()
+	tmpl, ok = LookupTemplate("de", name)
+	if assert.True(t, ok) {
+		assert.Equal(t, Templates[DefaultLanguage][name], tmpl)
+	}
+}
@@ -0,0 +1,222 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/backoff"
+	"github.com/my-cargonaut/cargonaut/pkg/webhook"
+)
+
+var _ cargonaut.PaymentProvider = (*FakeProvider)(nil)
+
+// FakeSignatureHeader is the HTTP header carrying the signature of webhooks
+// delivered by the fake payment provider.
+const FakeSignatureHeader = "X-Fake-Payment-Signature"
+
+const (
+	// deliveryAttempts is the maximum number of attempts to deliver a
+	// webhook.
+	deliveryAttempts = 8
+	// deliveryTimeout is the timeout of a single delivery attempt.
+	deliveryTimeout = 30 * time.Second
+
+	// backoffBase and backoffMax bound the delay between attempts to deliver
+	// a webhook.
+	backoffBase = 100 * time.Millisecond
+	backoffMax  = 30 * time.Second
+)
+
+// ErrIntentNotFound is raised when an intent does not exist.
+var ErrIntentNotFound = errors.New("intent not found")
+
+// intent is an intent created by the fake payment provider.
+type intent struct {
+	typ        cargonaut.PaymentType
+	amount     int64
+	refunded   int64
+	authorized bool
+	captured   bool
+	failed     bool
+}
+
+// FakeProvider is a payment provider which keeps all intents in memory and
+// moves no real money. Webhooks are signed like a real provider would do and
+// delivered asynchronously, retrying failed deliveries. Top-ups have to be
+// authorized or declined explicitly, as if the user approved or rejected them.
+// It is meant for development and tests.
+type FakeProvider struct {
+	// Deliver delivers a webhook. Webhooks are dropped if it is not set.
+	Deliver func(ctx context.Context, payload []byte, header http.Header) error
+
+	log    *log.Logger
+	secret []byte
+
+	mu      sync.Mutex
+	intents map[string]*intent
+}
+
+// NewFakeProvider returns a new FakeProvider which signs its webhooks with the
+// secret.
+func NewFakeProvider(log *log.Logger, secret []byte) *FakeProvider {
+	return &FakeProvider{
+		log:     log,
+		secret:  secret,
+		intents: make(map[string]*intent),
+	}
+}
+
+// CreateIntent creates an intent to move the amount of the payment. Payouts
+// are paid out right away.
+func (p *FakeProvider) CreateIntent(ctx context.Context, payment *cargonaut.Payment) (*cargonaut.PaymentIntent, error) {
+	reference := "fake_" + uuid.NewV4().String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.intents[reference] = &intent{
+		typ:    payment.Type,
+		amount: payment.Amount,
+	}
+	if payment.Type == cargonaut.PaymentTypePayout {
+		p.deliver(cargonaut.PaymentEventTypePayoutPaid, reference, payment.Amount)
+	}
+
+	return &cargonaut.PaymentIntent{Reference: reference}, nil
+}
+
+// Authorize authorizes the top-up identified by its reference, as if the user
+// approved it.
+func (p *FakeProvider) Authorize(reference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.intents[reference]
+	if !ok || i.typ != cargonaut.PaymentTypeTopUp {
+		return ErrIntentNotFound
+	} else if i.failed {
+		return fmt.Errorf("authorize intent %q: intent failed", reference)
+	}
+	i.authorized = true
+	p.deliver(cargonaut.PaymentEventTypeAuthorized, reference, i.amount)
+	return nil
+}
+
+// Decline declines the top-up identified by its reference, as if the user
+// rejected it.
+func (p *FakeProvider) Decline(reference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.intents[reference]
+	if !ok || i.typ != cargonaut.PaymentTypeTopUp {
+		return ErrIntentNotFound
+	} else if i.captured {
+		return fmt.Errorf("decline intent %q: intent captured", reference)
+	}
+	i.failed = true
+	p.deliver(cargonaut.PaymentEventTypeFailed, reference, i.amount)
+	return nil
+}
+
+// Capture captures the authorized intent identified by its reference.
+// Capturing a captured intent is a no-op.
+func (p *FakeProvider) Capture(ctx context.Context, reference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.intents[reference]
+	if !ok {
+		return ErrIntentNotFound
+	} else if i.captured {
+		return nil
+	} else if !i.authorized || i.failed {
+		return fmt.Errorf("capture intent %q: intent not authorized", reference)
+	}
+	i.captured = true
+	p.deliver(cargonaut.PaymentEventTypeCaptured, reference, i.amount)
+	return nil
+}
+
+// Refund refunds the amount of the captured intent identified by its
+// reference.
+func (p *FakeProvider) Refund(ctx context.Context, reference string, amount int64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.intents[reference]
+	if !ok {
+		return ErrIntentNotFound
+	} else if !i.captured {
+		return fmt.Errorf("refund intent %q: intent not captured", reference)
+	} else if amount <= 0 || amount > i.amount-i.refunded {
+		return fmt.Errorf("refund intent %q: invalid amount %d", reference, amount)
+	}
+	i.refunded += amount
+	p.deliver(cargonaut.PaymentEventTypeRefunded, reference, amount)
+	return nil
+}
+
+// VerifyWebhook verifies the signature of a webhook delivered by the fake
+// payment provider and returns the event it carries.
+func (p *FakeProvider) VerifyWebhook(payload []byte, header http.Header) (*cargonaut.PaymentEvent, error) {
+	if !webhook.Verify(p.secret, payload, header.Get(FakeSignatureHeader)) {
+		return nil, cargonaut.ErrInvalidPaymentWebhook
+	}
+
+	event := new(cargonaut.PaymentEvent)
+	if err := json.Unmarshal(payload, event); err != nil || event.IdempotencyKey == "" || event.Reference == "" {
+		return nil, cargonaut.ErrInvalidPaymentWebhook
+	}
+	return event, nil
+}
+
+// deliver signs and delivers a webhook carrying an event of the given type in
+// the background. Delivery is retried with exponential backoff until it
+// succeeds or the maximum number of attempts is reached.
+func (p *FakeProvider) deliver(typ cargonaut.PaymentEventType, reference string, amount int64) {
+	event := &cargonaut.PaymentEvent{
+		IdempotencyKey: uuid.NewV4().String(),
+		Type:           typ,
+		Reference:      reference,
+		Amount:         amount,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.log.Printf("marshal payment event %q: %s", event.IdempotencyKey, err)
+		return
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set(FakeSignatureHeader, webhook.Sign(p.secret, payload))
+
+	deliver := p.Deliver
+	if deliver == nil {
+		p.log.Printf("drop payment event %q: no delivery configured", event.IdempotencyKey)
+		return
+	}
+
+	go func() {
+		for attempt := 1; ; attempt++ {
+			ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			err := deliver(ctx, payload, header)
+			cancel()
+			if err == nil {
+				return
+			} else if attempt == deliveryAttempts {
+				p.log.Printf("deliver payment event %q: giving up after %d attempts: %s", event.IdempotencyKey, attempt, err)
+				return
+			}
+			time.Sleep(backoff.Exponential(attempt, backoffBase, backoffMax))
+		}
+	}()
+}
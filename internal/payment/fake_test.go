@@ -0,0 +1,159 @@
+package payment_test
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/payment"
+)
+
+var secret = []byte("0123456789abcdef0123456789abcdef")
+
+// newFakeProvider returns a FakeProvider which delivers the events of its
+// webhooks to the returned channel, after verifying them.
+func newFakeProvider(t *testing.T) (*FakeProvider, <-chan *cargonaut.PaymentEvent) {
+	events := make(chan *cargonaut.PaymentEvent, 16)
+	p := NewFakeProvider(log.New(ioutil.Discard, "", 0), secret)
+	p.Deliver = func(ctx context.Context, payload []byte, header http.Header) error {
+		event, err := p.VerifyWebhook(payload, header)
+		if assert.NoError(t, err) {
+			events <- event
+		}
+		return nil
+	}
+	return p, events
+}
+
+// expectEvent waits for the next event delivered and asserts its type.
+func expectEvent(t *testing.T, events <-chan *cargonaut.PaymentEvent, typ cargonaut.PaymentEventType, amount int64) *cargonaut.PaymentEvent {
+	select {
+	case event := <-events:
+		assert.Equal(t, typ, event.Type)
+		assert.Equal(t, amount, event.Amount)
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no %s event delivered", typ)
+		return nil
+	}
+}
+
+// expectNoEvent asserts that no event is delivered.
+func expectNoEvent(t *testing.T, events <-chan *cargonaut.PaymentEvent) {
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected %s event delivered", event.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestFakeProviderTopUp(t *testing.T) {
+	p, events := newFakeProvider(t)
+	ctx := context.Background()
+
+	intent, err := p.CreateIntent(ctx, &cargonaut.Payment{Type: cargonaut.PaymentTypeTopUp, Amount: 1000})
+	require.NoError(t, err)
+	expectNoEvent(t, events)
+
+	// Top-ups can't be captured until the user authorized them.
+	assert.Error(t, p.Capture(ctx, intent.Reference))
+
+	require.NoError(t, p.Authorize(intent.Reference))
+	authorized := expectEvent(t, events, cargonaut.PaymentEventTypeAuthorized, 1000)
+	assert.Equal(t, intent.Reference, authorized.Reference)
+
+	require.NoError(t, p.Capture(ctx, intent.Reference))
+	captured := expectEvent(t, events, cargonaut.PaymentEventTypeCaptured, 1000)
+	assert.NotEqual(t, authorized.IdempotencyKey, captured.IdempotencyKey)
+
+	// Capturing again is a no-op and a captured top-up can't be declined.
+	require.NoError(t, p.Capture(ctx, intent.Reference))
+	assert.Error(t, p.Decline(intent.Reference))
+	expectNoEvent(t, events)
+
+	require.NoError(t, p.Refund(ctx, intent.Reference, 400))
+	expectEvent(t, events, cargonaut.PaymentEventTypeRefunded, 400)
+	assert.Error(t, p.Refund(ctx, intent.Reference, 601))
+	assert.Error(t, p.Refund(ctx, intent.Reference, 0))
+}
+
+func TestFakeProviderDecline(t *testing.T) {
+	p, events := newFakeProvider(t)
+	ctx := context.Background()
+
+	intent, err := p.CreateIntent(ctx, &cargonaut.Payment{Type: cargonaut.PaymentTypeTopUp, Amount: 1000})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Decline(intent.Reference))
+	expectEvent(t, events, cargonaut.PaymentEventTypeFailed, 1000)
+	assert.Error(t, p.Authorize(intent.Reference))
+	assert.Error(t, p.Capture(ctx, intent.Reference))
+	expectNoEvent(t, events)
+}
+
+func TestFakeProviderPayout(t *testing.T) {
+	p, events := newFakeProvider(t)
+
+	intent, err := p.CreateIntent(context.Background(), &cargonaut.Payment{Type: cargonaut.PaymentTypePayout, Amount: 500})
+	require.NoError(t, err)
+	expectEvent(t, events, cargonaut.PaymentEventTypePayoutPaid, 500)
+
+	// Payouts are neither authorized nor declined by the user.
+	assert.Equal(t, ErrIntentNotFound, p.Authorize(intent.Reference))
+	assert.Equal(t, ErrIntentNotFound, p.Decline(intent.Reference))
+}
+
+func TestFakeProviderUnknownIntent(t *testing.T) {
+	p, _ := newFakeProvider(t)
+	ctx := context.Background()
+
+	assert.Equal(t, ErrIntentNotFound, p.Authorize("fake_unknown"))
+	assert.Equal(t, ErrIntentNotFound, p.Decline("fake_unknown"))
+	assert.Equal(t, ErrIntentNotFound, p.Capture(ctx, "fake_unknown"))
+	assert.Equal(t, ErrIntentNotFound, p.Refund(ctx, "fake_unknown", 100))
+}
+
+func TestFakeProviderVerifyWebhook(t *testing.T) {
+	var (
+		payload []byte
+		header  http.Header
+	)
+	p := NewFakeProvider(log.New(ioutil.Discard, "", 0), secret)
+	delivered := make(chan struct{})
+	p.Deliver = func(ctx context.Context, pl []byte, h http.Header) error {
+		payload, header = pl, h
+		close(delivered)
+		return nil
+	}
+	_, err := p.CreateIntent(context.Background(), &cargonaut.Payment{Type: cargonaut.PaymentTypePayout, Amount: 500})
+	require.NoError(t, err)
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook delivered")
+	}
+
+	event, err := p.VerifyWebhook(payload, header)
+	require.NoError(t, err)
+	assert.Equal(t, cargonaut.PaymentEventTypePayoutPaid, event.Type)
+
+	// Webhooks signed with another secret or tampered with are rejected.
+	other := NewFakeProvider(log.New(ioutil.Discard, "", 0), []byte("fedcba9876543210fedcba9876543210"))
+	_, err = other.VerifyWebhook(payload, header)
+	assert.Equal(t, cargonaut.ErrInvalidPaymentWebhook, err)
+
+	tampered := append([]byte(nil), payload...)
+	tampered[len(tampered)-2]++
+	_, err = p.VerifyWebhook(tampered, header)
+	assert.Equal(t, cargonaut.ErrInvalidPaymentWebhook, err)
+
+	_, err = p.VerifyWebhook(payload, http.Header{})
+	assert.Equal(t, cargonaut.ErrInvalidPaymentWebhook, err)
+}
@@ -0,0 +1,104 @@
+package receipt
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/pdf"
+)
+
+const (
+	// margin is the margin of the page in points.
+	margin = 56.0
+	// lineHeight is the distance between two lines of body text in points.
+	lineHeight = 16.0
+	// valueOffset is the offset of values from the left margin in points.
+	valueOffset = 110.0
+
+	// dateLayout and timeLayout format dates and times on receipts. All
+	// times are in UTC.
+	dateLayout = "2006-01-02"
+	timeLayout = "2006-01-02 15:04 MST"
+)
+
+// Render renders the receipt as a single A4 page PDF document and writes it to
+// w.
+func Render(w io.Writer, receipt *cargonaut.Receipt) error {
+	doc := pdf.New()
+	doc.Title = "Receipt " + receipt.Number()
+	page := doc.AddPage(pdf.A4Width, pdf.A4Height)
+	right := pdf.A4Width - margin
+
+	y := pdf.A4Height - margin - 24
+	page.Text(margin, y, pdf.HelveticaBold, 24, "Cargonaut")
+	page.TextRight(right, y, pdf.HelveticaBold, 16, "Receipt")
+
+	y -= 2 * lineHeight
+	page.TextRight(right, y, pdf.Helvetica, 10, "No. "+receipt.Number())
+	y -= lineHeight
+	page.TextRight(right, y, pdf.Helvetica, 10, "Issued "+receipt.CreatedAt.UTC().Format(dateLayout))
+
+	y -= 2 * lineHeight
+	for _, field := range []struct{ label, value string }{
+		{"Driver", receipt.DriverName},
+		{"Rider", receipt.RiderName},
+		{"From", receipt.Start},
+		{"To", receipt.Destination},
+		{"Departure", receipt.Depature.UTC().Format(timeLayout)},
+		{"Booking", receipt.BookingID.String()},
+	} {
+		page.Text(margin, y, pdf.HelveticaBold, 10, field.label)
+		page.Text(margin+valueOffset, y, pdf.Helvetica, 10, field.value)
+		y -= lineHeight
+	}
+
+	y -= lineHeight
+	page.Text(margin, y, pdf.HelveticaBold, 10, "Description")
+	page.TextRight(right, y, pdf.HelveticaBold, 10, "Amount")
+	y -= 6
+	page.Line(margin, y, right, y, 0.5)
+
+	vat := receipt.VAT()
+	y -= lineHeight
+	page.Text(margin, y, pdf.Helvetica, 10, "Trip fare")
+	page.TextRight(right, y, pdf.Helvetica, 10, amount(receipt.Amount))
+	if receipt.Discount != 0 {
+		y -= lineHeight
+		page.Text(margin, y, pdf.Helvetica, 10, "Discount")
+		page.TextRight(right, y, pdf.Helvetica, 10, amount(-receipt.Discount))
+	}
+	y -= lineHeight
+	page.Text(margin, y, pdf.Helvetica, 10, "Total, net")
+	page.TextRight(right, y, pdf.Helvetica, 10, amount(receipt.Total()-vat))
+	y -= lineHeight
+	page.Text(margin, y, pdf.Helvetica, 10, "VAT "+rate(receipt.VATRate))
+	page.TextRight(right, y, pdf.Helvetica, 10, amount(vat))
+	y -= 6
+	page.Line(margin, y, right, y, 0.5)
+	y -= lineHeight
+	page.Text(margin, y, pdf.HelveticaBold, 10, "Total")
+	page.TextRight(right, y, pdf.HelveticaBold, 10, amount(receipt.Total()))
+
+	page.Text(margin, margin, pdf.Helvetica, 8, "This receipt has been issued electronically and is valid without a signature.")
+
+	if _, err := doc.WriteTo(w); err != nil {
+		return fmt.Errorf("write receipt %s: %w", receipt.Number(), err)
+	}
+	return nil
+}
+
+// amount formats an amount in cents.
+func amount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d EUR", sign, cents/100, cents%100)
+}
+
+// rate formats a rate given in basis points as percentage.
+func rate(bps int64) string {
+	return strconv.FormatFloat(float64(bps)/100, 'f', -1, 64) + "%"
+}
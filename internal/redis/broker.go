@@ -0,0 +1,187 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/backoff"
+)
+
+var _ cargonaut.EventBroker = (*EventBroker)(nil)
+
+const (
+	// eventChannel is the Redis pub/sub channel events are published to.
+	eventChannel = "cargonaut:events"
+
+	// reconnectBase and reconnectMax bound the delay between attempts to
+	// reconnect the receiving connection.
+	reconnectBase = time.Second
+	reconnectMax  = time.Minute
+
+	// publishTimeout is the timeout of publishing an event.
+	publishTimeout = 5 * time.Second
+)
+
+// eventEnvelope wraps an event together with the user it is addressed to which
+// is not part of the events JSON representation.
+type eventEnvelope struct {
+	UserID uuid.UUID        `json:"user_id"`
+	Event  *cargonaut.Event `json:"event"`
+}
+
+// EventBroker is a Redis pub/sub based event broker. Every server instance
+// receives all events from Redis and distributes them to its own subscribers
+// using a local event broker. Broken connections are replaced by new ones.
+type EventBroker struct {
+	log  *log.Logger
+	dial func() (redis.Conn, error)
+
+	mu   sync.Mutex
+	conn redis.Conn
+
+	local cargonaut.EventBroker
+}
+
+// NewEventBroker returns a new event broker which opens connections to Redis
+// with the dial function. Received events are distributed by the local event
+// broker. The connections must not have a read timeout set, events are
+// published with a timeout of their own.
+func NewEventBroker(log *log.Logger, dial func() (redis.Conn, error), local cargonaut.EventBroker) *EventBroker {
+	return &EventBroker{
+		log:   log,
+		dial:  dial,
+		local: local,
+	}
+}
+
+// Run receives events from Redis and distributes them until the context is
+// done. If the connection fails, it reconnects with an exponential backoff.
+// Events published while it is disconnected are lost.
+func (b *EventBroker) Run(ctx context.Context) error {
+	var attempts int
+	for {
+		subscribed, err := b.receive(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if subscribed {
+			attempts = 0
+		}
+		attempts++
+
+		delay := backoff.Exponential(attempts, reconnectBase, reconnectMax)
+		b.log.Printf("receive events: %s, reconnecting in %s", err, delay)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// receive opens a connection, subscribes to the event channel and distributes
+// the received events until the context is done or the connection fails. It
+// reports whether the subscription succeeded.
+func (b *EventBroker) receive(ctx context.Context) (bool, error) {
+	conn, err := b.dial()
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	sub := redis.PubSubConn{Conn: conn}
+	defer sub.Close()
+
+	if err = sub.Subscribe(eventChannel); err != nil {
+		return false, fmt.Errorf("subscribe to event channel: %w", err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = sub.Unsubscribe()
+		case <-done:
+		}
+	}()
+
+	var subscribed bool
+	for {
+		switch v := sub.Receive().(type) {
+		case redis.Message:
+			var envelope eventEnvelope
+			if err := json.Unmarshal(v.Data, &envelope); err != nil || envelope.Event == nil {
+				b.log.Printf("decode event: %v", err)
+				continue
+			}
+			envelope.Event.UserID = envelope.UserID
+			if err := b.local.Publish(ctx, envelope.Event); err != nil {
+				b.log.Printf("distribute event %q: %s", envelope.Event.ID, err)
+			}
+		case redis.Subscription:
+			if v.Count == 0 {
+				return subscribed, nil
+			}
+			subscribed = true
+		case error:
+			return subscribed, fmt.Errorf("receive event: %w", v)
+		}
+	}
+}
+
+// Publish publishes events to the users they are addressed to. A broken
+// connection is replaced on the next call.
+func (b *EventBroker) Publish(ctx context.Context, events ...*cargonaut.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		conn, err := b.dial()
+		if err != nil {
+			return fmt.Errorf("connect: %w", err)
+		}
+		b.conn = conn
+	}
+
+	for _, event := range events {
+		data, err := json.Marshal(&eventEnvelope{
+			UserID: event.UserID,
+			Event:  event,
+		})
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+		if _, err = redis.DoWithTimeout(b.conn, publishTimeout, "PUBLISH", eventChannel, data); err != nil {
+			_ = b.conn.Close()
+			b.conn = nil
+			return fmt.Errorf("publish event: %w", err)
+		}
+	}
+	return nil
+}
+
+// Subscribe subscribes to the events addressed to the user identified by his
+// unique ID. The returned channel is closed once the context is done.
+func (b *EventBroker) Subscribe(ctx context.Context, userID uuid.UUID) (<-chan *cargonaut.Event, error) {
+	return b.local.Subscribe(ctx, userID)
+}
+
+// Close closes the connection used for publishing events.
+func (b *EventBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
@@ -0,0 +1,7 @@
+package relay
+
+// Exported for the tests of relaying single events.
+var (
+	MaxAttempts = maxAttempts
+	RelayEvent  = (*Relay).relay
+)
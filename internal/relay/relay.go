@@ -0,0 +1,139 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/backoff"
+)
+
+const (
+	// pollInterval is the interval in which pending events are claimed.
+	pollInterval = time.Second
+	// claimLimit is the maximum number of events claimed at once.
+	claimLimit = 32
+	// claimLease is the duration claimed events are hidden from other relays.
+	// It must exceed the time needed to relay all claimed events.
+	claimLease = 5 * time.Minute
+
+	// backoffBase and backoffMax bound the delay between attempts to publish
+	// an event. Events are retried until all subscribers handled them or the
+	// maximum number of attempts is reached, which takes about a day.
+	backoffBase = 5 * time.Second
+	backoffMax  = time.Hour
+	maxAttempts = 32
+)
+
+// subscriber is an event handler registered under a unique name.
+type subscriber struct {
+	name   string
+	handle cargonaut.EventHandler
+}
+
+// Relay publishes the events written to the outbox to all subscribers. Each
+// successful delivery to a subscriber is recorded, so an event is delivered to
+// every subscriber at least once, even if the relay crashes in between.
+type Relay struct {
+	log    *log.Logger
+	outbox cargonaut.OutboxRepository
+
+	subscribers []subscriber
+}
+
+// NewRelay returns a new Relay which publishes the events of the outbox.
+func NewRelay(log *log.Logger, outbox cargonaut.OutboxRepository) *Relay {
+	return &Relay{
+		log:    log,
+		outbox: outbox,
+	}
+}
+
+// Subscribe registers an event handler. The name identifies the subscriber in
+// the outbox and must not change between releases. Subscribe must not be
+// called after Run.
+func (r *Relay) Subscribe(name string, handle cargonaut.EventHandler) {
+	r.subscribers = append(r.subscribers, subscriber{name, handle})
+}
+
+// Run relays pending events until the context is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		events, err := r.outbox.ClaimPendingEvents(ctx, claimLimit, claimLease)
+		if err != nil {
+			r.log.Printf("claim pending events: %s", err)
+			continue
+		}
+		for _, event := range events {
+			if err = r.relay(ctx, event); err != nil {
+				r.log.Printf("relay %s event %q: %s", event.Type, event.ID, err)
+			}
+		}
+	}
+}
+
+// relay delivers an event to all subscribers which have not handled it yet.
+// If a subscriber fails, the event is retried later. Events which failed for
+// good or too often are dead and not retried anymore.
+func (r *Relay) relay(ctx context.Context, event *cargonaut.OutboxEvent) error {
+	names, err := r.outbox.ListEventSubscribers(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	delivered := make(map[string]bool, len(names))
+	for _, name := range names {
+		delivered[name] = true
+	}
+
+	var (
+		failed    error
+		permanent bool
+	)
+	for _, sub := range r.subscribers {
+		if delivered[sub.name] {
+			continue
+		}
+		if err = sub.handle(ctx, &event.Event); err != nil {
+			failed = fmt.Errorf("deliver to %s: %w", sub.name, err)
+			permanent = permanent || isPermanent(err)
+			continue
+		}
+		if err = r.outbox.MarkEventDelivered(ctx, event.ID, sub.name); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case failed == nil:
+		return r.outbox.MarkEventPublished(ctx, event.ID)
+	case permanent || event.Attempts+1 >= maxAttempts:
+		if err = r.outbox.MarkEventDead(ctx, event.ID, failed.Error()); err != nil {
+			return err
+		}
+		return fmt.Errorf("give up: %w", failed)
+	default:
+		next := time.Now().UTC().Add(backoff.Exponential(event.Attempts+1, backoffBase, backoffMax))
+		if err = r.outbox.RetryEvent(ctx, event.ID, next, failed.Error()); err != nil {
+			return err
+		}
+		return failed
+	}
+}
+
+// isPermanent returns true if the error of a subscriber won't go away by
+// retrying, because the trip or user the event is about has been deleted.
+func isPermanent(err error) bool {
+	return errors.Is(err, cargonaut.ErrTripNotFound) || errors.Is(err, cargonaut.ErrUserNotFound)
+}
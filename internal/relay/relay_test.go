@@ -0,0 +1,152 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/relay"
+)
+
+// fakeOutboxRepository records the state of the events relayed. Claiming and
+// pruning events is not implemented.
+type fakeOutboxRepository struct {
+	cargonaut.OutboxRepository
+
+	delivered map[uuid.UUID][]string
+	published []uuid.UUID
+	retried   []uuid.UUID
+	next      time.Time
+	dead      []uuid.UUID
+	reason    string
+}
+
+func (f *fakeOutboxRepository) ListEventSubscribers(_ context.Context, eventID uuid.UUID) ([]string, error) {
+	return f.delivered[eventID], nil
+}
+
+func (f *fakeOutboxRepository) MarkEventDelivered(_ context.Context, eventID uuid.UUID, subscriber string) error {
+	f.delivered[eventID] = append(f.delivered[eventID], subscriber)
+	return nil
+}
+
+func (f *fakeOutboxRepository) MarkEventPublished(_ context.Context, eventID uuid.UUID) error {
+	f.published = append(f.published, eventID)
+	return nil
+}
+
+func (f *fakeOutboxRepository) RetryEvent(_ context.Context, eventID uuid.UUID, next time.Time, reason string) error {
+	f.retried = append(f.retried, eventID)
+	f.next, f.reason = next, reason
+	return nil
+}
+
+func (f *fakeOutboxRepository) MarkEventDead(_ context.Context, eventID uuid.UUID, reason string) error {
+	f.dead = append(f.dead, eventID)
+	f.reason = reason
+	return nil
+}
+
+func TestRelay(t *testing.T) {
+	transient := errors.New("connection refused")
+	permanent := fmt.Errorf("get trip: %w", cargonaut.ErrTripNotFound)
+
+	tests := []struct {
+		name      string
+		attempts  int
+		delivered []string
+		errs      map[string]error
+		calls     []string
+		want      []string
+		published bool
+		retried   bool
+		dead      bool
+	}{
+		{
+			name:      "delivered",
+			calls:     []string{"notifier", "webhooks"},
+			want:      []string{"notifier", "webhooks"},
+			published: true,
+		},
+		{
+			name:      "delivered before",
+			delivered: []string{"notifier"},
+			calls:     []string{"webhooks"},
+			want:      []string{"notifier", "webhooks"},
+			published: true,
+		},
+		{
+			name:    "transient error",
+			errs:    map[string]error{"notifier": transient},
+			calls:   []string{"notifier", "webhooks"},
+			want:    []string{"webhooks"},
+			retried: true,
+		},
+		{
+			name:     "transient error before last attempt",
+			attempts: MaxAttempts - 2,
+			errs:     map[string]error{"webhooks": transient},
+			calls:    []string{"notifier", "webhooks"},
+			want:     []string{"notifier"},
+			retried:  true,
+		},
+		{
+			name:     "transient error on last attempt",
+			attempts: MaxAttempts - 1,
+			errs:     map[string]error{"webhooks": transient},
+			calls:    []string{"notifier", "webhooks"},
+			want:     []string{"notifier"},
+			dead:     true,
+		},
+		{
+			name:  "permanent error",
+			errs:  map[string]error{"notifier": permanent, "webhooks": transient},
+			calls: []string{"notifier", "webhooks"},
+			dead:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &cargonaut.OutboxEvent{
+				Event:    *cargonaut.NewEvent(cargonaut.EventTypeTripBooked, uuid.NewV4(), uuid.NewV4()),
+				Attempts: tt.attempts,
+			}
+			outbox := &fakeOutboxRepository{delivered: map[uuid.UUID][]string{event.ID: tt.delivered}}
+			r := NewRelay(log.New(ioutil.Discard, "", 0), outbox)
+
+			var calls []string
+			for _, name := range []string{"notifier", "webhooks"} {
+				name := name
+				r.Subscribe(name, func(_ context.Context, events ...*cargonaut.Event) error {
+					assert.Equal(t, []*cargonaut.Event{&event.Event}, events)
+					calls = append(calls, name)
+					return tt.errs[name]
+				})
+			}
+
+			err := RelayEvent(r, context.Background(), event)
+			assert.Equal(t, tt.published, err == nil, "error: %v", err)
+			assert.Equal(t, tt.calls, calls, "calls")
+			assert.Equal(t, tt.want, outbox.delivered[event.ID], "delivered")
+
+			// An event is only published once all subscribers handled it.
+			assert.Equal(t, tt.published, len(outbox.published) == 1, "published")
+			assert.Equal(t, tt.retried, len(outbox.retried) == 1, "retried")
+			assert.Equal(t, tt.dead, len(outbox.dead) == 1, "dead")
+			if tt.retried {
+				assert.True(t, outbox.next.After(time.Now()), "next attempt in the future")
+			}
+			if tt.retried || tt.dead {
+				assert.Contains(t, outbox.reason, "deliver to")
+			}
+		})
+	}
+}
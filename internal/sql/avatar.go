@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+const (
+	listStoredAvatarsSQL = "SELECT id, avatar FROM user_account WHERE avatar IS NOT NULL LIMIT 100"
+	clearStoredAvatarSQL = "UPDATE user_account SET avatar = NULL WHERE id = $1"
+)
+
+// storedAvatar is an avatar which is still stored in the database.
+type storedAvatar struct {
+	UserID uuid.UUID `db:"id"`
+	Avatar []byte    `db:"avatar"`
+}
+
+// MigrateAvatars moves the avatars of users, which used to be stored in the
+// database as base64 encoded PNG images, to the blob store and returns the
+// number of moved avatars. Avatars which can't be decoded could never be served
+// and are dropped. It is safe to run it repeatedly or after an interruption.
+func MigrateAvatars(ctx context.Context, db *sqlx.DB, store cargonaut.BlobStore) (int, error) {
+	var n int
+	for {
+		var avatars []*storedAvatar
+		if err := db.SelectContext(ctx, &avatars, listStoredAvatarsSQL); err != nil {
+			return n, fmt.Errorf("list stored avatars from database: %w", err)
+		} else if len(avatars) == 0 {
+			return n, nil
+		}
+
+		for _, avatar := range avatars {
+			if img, err := base64.StdEncoding.DecodeString(string(avatar.Avatar)); err == nil && len(img) > 0 {
+				key := cargonaut.AvatarKey(avatar.UserID)
+				if err = store.PutBlob(ctx, key, bytes.NewReader(img), int64(len(img)), "image/png"); err != nil {
+					return n, fmt.Errorf("move avatar of user %q: %w", avatar.UserID, err)
+				}
+				n++
+			}
+			if _, err := db.ExecContext(ctx, clearStoredAvatarSQL, avatar.UserID); err != nil {
+				return n, fmt.Errorf("clear avatar of user %q in database: %w", avatar.UserID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/internal/blob"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestMigrateAvatars(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "cargonaut-avatars-")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	store, err := blob.NewFileStore(dir)
+	require.NoError(t, err)
+
+	// stored creates a user with the avatar stored in the database.
+	stored := func(avatar string) *cargonaut.User {
+		user := createTestUser(t, db)
+		_, err := db.Exec("UPDATE user_account SET avatar = $2 WHERE id = $1", user.ID, []byte(avatar))
+		require.NoError(t, err)
+		return user
+	}
+	content := []byte("\x89PNG\r\n\x1a\navatar")
+	valid := stored(base64.StdEncoding.EncodeToString(content))
+	undecodable := stored("not base64!")
+	empty := stored("")
+
+	// An earlier run was interrupted after the avatar was stored, but before
+	// it was cleared in the database.
+	interrupted := stored(base64.StdEncoding.EncodeToString(content))
+	require.NoError(t, store.PutBlob(ctx, cargonaut.AvatarKey(interrupted.ID), bytes.NewReader([]byte("partial")), 7, "image/png"))
+
+	n, err := MigrateAvatars(ctx, db, store)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, n, 2)
+
+	for _, user := range []*cargonaut.User{valid, undecodable, empty, interrupted} {
+		var cleared bool
+		require.NoError(t, db.Get(&cleared, "SELECT avatar IS NULL FROM user_account WHERE id = $1", user.ID))
+		assert.True(t, cleared, user.ID.String())
+	}
+
+	assertAvatar := func(user *cargonaut.User, want []byte) {
+		r, err := store.GetBlob(ctx, cargonaut.AvatarKey(user.ID))
+		if want == nil {
+			assert.Equal(t, cargonaut.ErrBlobNotFound, err)
+			return
+		}
+		require.NoError(t, err)
+		defer r.Close()
+		got, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	assertAvatar(valid, content)
+	assertAvatar(interrupted, content)
+	assertAvatar(undecodable, nil)
+	assertAvatar(empty, nil)
+
+	// Migrated avatars are not moved again.
+	require.NoError(t, store.PutBlob(ctx, cargonaut.AvatarKey(valid.ID), bytes.NewReader([]byte("replaced")), 8, "image/png"))
+	n, err = MigrateAvatars(ctx, db, store)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assertAvatar(valid, []byte("replaced"))
+}
@@ -0,0 +1,409 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.BookingRepository = (*BookingRepository)(nil)
+
+const (
+	listBookingsSQL           = "SELECT id, trip_id, user_id, status, expires_at, decided_at, created_at FROM booking WHERE trip_id = $1 ORDER BY created_at"
+	getBookingSQL             = "SELECT id, trip_id, user_id, status, expires_at, decided_at, created_at FROM booking WHERE id = $1 LIMIT 1"
+	lockBookingSQL            = "SELECT id, trip_id, user_id, status, expires_at, decided_at, created_at FROM booking WHERE id = $1 FOR UPDATE"
+	createBookingSQL          = "INSERT INTO booking (trip_id, user_id, status, expires_at, decided_at) VALUES (:trip_id, :user_id, :status, :expires_at, :decided_at) RETURNING id, created_at"
+	decideBookingSQL          = "UPDATE booking SET status = $2, decided_at = (now() at time zone 'utc') WHERE id = $1"
+	declinePendingBookingsSQL = "UPDATE booking SET status = 'declined', decided_at = (now() at time zone 'utc') WHERE trip_id = $1 AND status = 'pending' RETURNING id, trip_id, user_id"
+	expireBookingsSQL         = "UPDATE booking SET status = 'expired', decided_at = (now() at time zone 'utc') WHERE status = 'pending' AND expires_at <= $1 RETURNING id, trip_id, user_id"
+	lockAvailableTripSQL      = "SELECT id FROM trip WHERE id = $1 AND rider_id IS NULL AND cancelled_at IS NULL AND completed_at IS NULL AND depature <= 'epoch' AND NOT EXISTS (SELECT 1 FROM waitlist_entry WHERE trip_id = trip.id AND status IN ('waiting', 'offered')) FOR UPDATE"
+	bookTripSQL               = "UPDATE trip SET rider_id = $2, updated_at = (now() at time zone 'utc') WHERE id = $1"
+	lockPromoCodeSQL          = "SELECT id, code, type, value, max_redemptions, max_redemptions_per_user, first_trip_only, valid_from, valid_until, created_at, updated_at FROM promo_code WHERE upper(code) = upper($1) FOR UPDATE"
+	countPromoRedemptionsSQL  = "SELECT count(*) AS total, count(*) FILTER (WHERE b.user_id = $2) AS per_user FROM promo_redemption r JOIN booking b ON b.id = r.booking_id WHERE r.promo_code_id = $1 AND b.status IN ('pending', 'accepted')"
+	hasCompletedRidesSQL      = "SELECT EXISTS (SELECT 1 FROM trip WHERE rider_id = $1 AND completed_at IS NOT NULL)"
+	createPromoRedemptionSQL  = "INSERT INTO promo_redemption (promo_code_id, booking_id, amount) VALUES ($1, $2, $3)"
+)
+
+// BookingRepository provides access to the booking resource backed by a
+// Postgres SQL database.
+type BookingRepository struct {
+	db *sqlx.DB
+
+	listStmt              *sqlx.Stmt
+	getStmt               *sqlx.Stmt
+	lockStmt              *sqlx.Stmt
+	createStmt            *sqlx.NamedStmt
+	decideStmt            *sqlx.Stmt
+	declinePendingStmt    *sqlx.Stmt
+	expireStmt            *sqlx.Stmt
+	lockAvailableTripStmt *sqlx.Stmt
+	bookTripStmt          *sqlx.Stmt
+	lockPromoCodeStmt     *sqlx.Stmt
+	countRedemptionsStmt  *sqlx.Stmt
+	hasCompletedRidesStmt *sqlx.Stmt
+	createRedemptionStmt  *sqlx.Stmt
+	createEventStmt       *sqlx.NamedStmt
+	ledgerStmts           *ledgerStatements
+}
+
+// NewBookingRepository returns a new BookingRepository based on top of the
+// provided database connection.
+func NewBookingRepository(ctx context.Context, db *sqlx.DB) (*BookingRepository, error) {
+	s := &BookingRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listBookingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list bookings statement: %w", err)
+	}
+	if s.getStmt, err = db.PreparexContext(ctx, getBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare get booking statement: %w", err)
+	}
+	if s.lockStmt, err = db.PreparexContext(ctx, lockBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock booking statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare create booking statement: %w", err)
+	}
+	if s.decideStmt, err = db.PreparexContext(ctx, decideBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare decide booking statement: %w", err)
+	}
+	if s.declinePendingStmt, err = db.PreparexContext(ctx, declinePendingBookingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare decline pending bookings statement: %w", err)
+	}
+	if s.expireStmt, err = db.PreparexContext(ctx, expireBookingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare expire bookings statement: %w", err)
+	}
+	if s.lockAvailableTripStmt, err = db.PreparexContext(ctx, lockAvailableTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock available trip statement: %w", err)
+	}
+	if s.bookTripStmt, err = db.PreparexContext(ctx, bookTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare book trip statement: %w", err)
+	}
+	if s.lockPromoCodeStmt, err = db.PreparexContext(ctx, lockPromoCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock promo code statement: %w", err)
+	}
+	if s.countRedemptionsStmt, err = db.PreparexContext(ctx, countPromoRedemptionsSQL); err != nil {
+		return nil, fmt.Errorf("prepare count promo redemptions statement: %w", err)
+	}
+	if s.hasCompletedRidesStmt, err = db.PreparexContext(ctx, hasCompletedRidesSQL); err != nil {
+		return nil, fmt.Errorf("prepare has completed rides statement: %w", err)
+	}
+	if s.createRedemptionStmt, err = db.PreparexContext(ctx, createPromoRedemptionSQL); err != nil {
+		return nil, fmt.Errorf("prepare create promo redemption statement: %w", err)
+	}
+	if s.createEventStmt, err = db.PrepareNamedContext(ctx, createEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare create event statement: %w", err)
+	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *BookingRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list bookings statement: %w", err)
+	}
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get booking statement: %w", err)
+	}
+	if err := s.lockStmt.Close(); err != nil {
+		return fmt.Errorf("close lock booking statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create booking statement: %w", err)
+	}
+	if err := s.decideStmt.Close(); err != nil {
+		return fmt.Errorf("close decide booking statement: %w", err)
+	}
+	if err := s.declinePendingStmt.Close(); err != nil {
+		return fmt.Errorf("close decline pending bookings statement: %w", err)
+	}
+	if err := s.expireStmt.Close(); err != nil {
+		return fmt.Errorf("close expire bookings statement: %w", err)
+	}
+	if err := s.lockAvailableTripStmt.Close(); err != nil {
+		return fmt.Errorf("close lock available trip statement: %w", err)
+	}
+	if err := s.bookTripStmt.Close(); err != nil {
+		return fmt.Errorf("close book trip statement: %w", err)
+	}
+	if err := s.lockPromoCodeStmt.Close(); err != nil {
+		return fmt.Errorf("close lock promo code statement: %w", err)
+	}
+	if err := s.countRedemptionsStmt.Close(); err != nil {
+		return fmt.Errorf("close count promo redemptions statement: %w", err)
+	}
+	if err := s.hasCompletedRidesStmt.Close(); err != nil {
+		return fmt.Errorf("close has completed rides statement: %w", err)
+	}
+	if err := s.createRedemptionStmt.Close(); err != nil {
+		return fmt.Errorf("close create promo redemption statement: %w", err)
+	}
+	if err := s.createEventStmt.Close(); err != nil {
+		return fmt.Errorf("close create event statement: %w", err)
+	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListBookings lists all bookings of the trip identified by its unique ID.
+func (s *BookingRepository) ListBookings(ctx context.Context, tripID uuid.UUID) ([]*cargonaut.Booking, error) {
+	bookings := make([]*cargonaut.Booking, 0)
+	if err := s.listStmt.SelectContext(ctx, &bookings, tripID); err != nil {
+		return nil, fmt.Errorf("select bookings of trip %q from database: %w", tripID, err)
+	}
+	return bookings, nil
+}
+
+// GetBooking returns a booking identified by its unique ID.
+func (s *BookingRepository) GetBooking(ctx context.Context, id uuid.UUID) (*cargonaut.Booking, error) {
+	booking := new(cargonaut.Booking)
+	if err := s.getStmt.GetContext(ctx, booking, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrBookingNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get booking %q from database: %w", id, err)
+	}
+	return booking, nil
+}
+
+// CreateBooking creates a new booking. An accepted booking books the trip for
+// the rider right away, while a pending booking waits for the driver to decide.
+// Either way, the price of the trip is held on the wallet of the rider, less
+// the discount of the promo code of the booking, if any. The promo code is
+// redeemed and the events are written to the outbox in the same transaction.
+func (s *BookingRepository) CreateBooking(ctx context.Context, booking *cargonaut.Booking, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = s.lockAvailableTrip(ctx, tx, booking.TripID); err != nil {
+		return err
+	}
+	if booking.Status == cargonaut.BookingStatusAccepted {
+		if _, err = tx.StmtxContext(ctx, s.bookTripStmt).ExecContext(ctx, booking.TripID, booking.UserID); err != nil {
+			return fmt.Errorf("book trip %q in database: %w", booking.TripID, err)
+		}
+	}
+	if err = tx.NamedStmtContext(ctx, s.createStmt).GetContext(ctx, booking, booking); isAlreadyExistsError(err) {
+		return cargonaut.ErrBookingExists
+	} else if err != nil {
+		return fmt.Errorf("create booking of trip %q in database: %w", booking.TripID, err)
+	}
+	if booking.PromoCode != "" {
+		if booking.Discount, err = s.redeem(ctx, tx, booking); err != nil {
+			return err
+		}
+	}
+	if err = s.ledgerStmts.hold(ctx, tx, booking.TripID, booking.UserID, booking.Discount); err != nil {
+		return err
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AcceptBooking accepts the pending booking identified by its unique ID and
+// books the trip for its rider. Other pending bookings of the trip are
+// declined and the balance held for them is released. The events, along with
+// booking declined events for the declined bookings, are written to the outbox
+// in the same transaction.
+func (s *BookingRepository) AcceptBooking(ctx context.Context, id uuid.UUID, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The trip is locked before the booking, like bookings are created and
+	// cancelled, so concurrent transactions can not deadlock.
+	booking := new(cargonaut.Booking)
+	if err = tx.StmtxContext(ctx, s.getStmt).GetContext(ctx, booking, id); err == sql.ErrNoRows {
+		return cargonaut.ErrBookingNotFound
+	} else if err != nil {
+		return fmt.Errorf("get booking %q from database: %w", id, err)
+	}
+	if err = s.lockAvailableTrip(ctx, tx, booking.TripID); err != nil {
+		return err
+	}
+	if booking, err = s.lockPendingBooking(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.bookTripStmt).ExecContext(ctx, booking.TripID, booking.UserID); err != nil {
+		return fmt.Errorf("book trip %q in database: %w", booking.TripID, err)
+	}
+	if _, err = tx.StmtxContext(ctx, s.decideStmt).ExecContext(ctx, id, cargonaut.BookingStatusAccepted); err != nil {
+		return fmt.Errorf("accept booking %q in database: %w", id, err)
+	}
+
+	declined := make([]*cargonaut.Booking, 0)
+	if err = tx.StmtxContext(ctx, s.declinePendingStmt).SelectContext(ctx, &declined, booking.TripID); err != nil {
+		return fmt.Errorf("decline pending bookings of trip %q in database: %w", booking.TripID, err)
+	}
+	for _, booking := range declined {
+		if err = s.ledgerStmts.release(ctx, tx, booking.TripID, booking.UserID); err != nil {
+			return err
+		}
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeBookingDeclined, booking.UserID, booking.TripID))
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeclineBooking declines the pending booking identified by its unique ID and
+// releases the balance held for it. The events are written to the outbox in
+// the same transaction.
+func (s *BookingRepository) DeclineBooking(ctx context.Context, id uuid.UUID, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	booking, err := s.lockPendingBooking(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.decideStmt).ExecContext(ctx, id, cargonaut.BookingStatusDeclined); err != nil {
+		return fmt.Errorf("decline booking %q in database: %w", id, err)
+	}
+	if err = s.ledgerStmts.release(ctx, tx, booking.TripID, booking.UserID); err != nil {
+		return err
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ExpireBookings expires bookings still pending at the given time and releases
+// the balance held for them. Booking expired events for the riders are written
+// to the outbox in the same transaction.
+func (s *BookingRepository) ExpireBookings(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	expired := make([]*cargonaut.Booking, 0)
+	if err = tx.StmtxContext(ctx, s.expireStmt).SelectContext(ctx, &expired, before); err != nil {
+		return 0, fmt.Errorf("expire bookings in database: %w", err)
+	}
+	events := make([]*cargonaut.Event, 0, len(expired))
+	for _, booking := range expired {
+		if err = s.ledgerStmts.release(ctx, tx, booking.TripID, booking.UserID); err != nil {
+			return 0, err
+		}
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeBookingExpired, booking.UserID, booking.TripID))
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return int64(len(expired)), nil
+}
+
+// redeem redeems the promo code of the booking and returns the discount it
+// grants on the price of the trip. The promo code is locked for the rest of the
+// transaction, so its limits can not be exceeded by concurrent redemptions.
+func (s *BookingRepository) redeem(ctx context.Context, tx *sqlx.Tx, booking *cargonaut.Booking) (int64, error) {
+	code := new(cargonaut.PromoCode)
+	if err := tx.StmtxContext(ctx, s.lockPromoCodeStmt).GetContext(ctx, code, booking.PromoCode); err == sql.ErrNoRows {
+		return 0, cargonaut.ErrPromoCodeNotFound
+	} else if err != nil {
+		return 0, fmt.Errorf("lock promo code %q in database: %w", booking.PromoCode, err)
+	} else if !code.Valid(booking.CreatedAt) {
+		return 0, cargonaut.ErrPromoCodeNotRedeemable
+	}
+
+	var counts struct {
+		Total   int `db:"total"`
+		PerUser int `db:"per_user"`
+	}
+	if err := tx.StmtxContext(ctx, s.countRedemptionsStmt).GetContext(ctx, &counts, code.ID, booking.UserID); err != nil {
+		return 0, fmt.Errorf("count redemptions of promo code %q in database: %w", code.ID, err)
+	} else if code.MaxRedemptions != nil && counts.Total >= *code.MaxRedemptions {
+		return 0, cargonaut.ErrPromoCodeNotRedeemable
+	} else if counts.PerUser >= code.MaxRedemptionsPerUser {
+		return 0, cargonaut.ErrPromoCodeNotRedeemable
+	}
+	if code.FirstTripOnly {
+		var completed bool
+		if err := tx.StmtxContext(ctx, s.hasCompletedRidesStmt).GetContext(ctx, &completed, booking.UserID); err != nil {
+			return 0, fmt.Errorf("check completed rides of user %q in database: %w", booking.UserID, err)
+		} else if completed {
+			return 0, cargonaut.ErrPromoCodeNotRedeemable
+		}
+	}
+
+	price, err := s.ledgerStmts.price(ctx, tx, booking.TripID)
+	if err != nil {
+		return 0, err
+	}
+	discount := code.Discount(price)
+	if _, err = tx.StmtxContext(ctx, s.createRedemptionStmt).ExecContext(ctx, code.ID, booking.ID, discount); err != nil {
+		return 0, fmt.Errorf("create redemption of promo code %q in database: %w", code.ID, err)
+	}
+	return discount, nil
+}
+
+// lockPendingBooking locks the booking identified by its unique ID for the
+// rest of the transaction. It must still be pending and not have expired.
+func (s *BookingRepository) lockPendingBooking(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*cargonaut.Booking, error) {
+	booking := new(cargonaut.Booking)
+	if err := tx.StmtxContext(ctx, s.lockStmt).GetContext(ctx, booking, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrBookingNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("lock booking %q in database: %w", id, err)
+	}
+	if booking.Status != cargonaut.BookingStatusPending || !booking.ExpiresAt.After(time.Now().UTC()) {
+		return nil, cargonaut.ErrBookingNotPending
+	}
+	return booking, nil
+}
+
+// lockAvailableTrip locks the trip identified by its unique ID for the rest of
+// the transaction. It must neither be booked, cancelled nor completed, nor be
+// held for riders on its waitlist.
+func (s *BookingRepository) lockAvailableTrip(ctx context.Context, tx *sqlx.Tx, tripID uuid.UUID) error {
+	var id uuid.UUID
+	if err := tx.StmtxContext(ctx, s.lockAvailableTripStmt).GetContext(ctx, &id, tripID); err == sql.ErrNoRows {
+		return cargonaut.ErrTripNotAvailable
+	} else if err != nil {
+		return fmt.Errorf("lock trip %q in database: %w", tripID, err)
+	}
+	return nil
+}
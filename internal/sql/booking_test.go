@@ -0,0 +1,105 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+)
+
+// request creates a pending booking of the trip for the rider, which expires at
+// the given time.
+func (l *ledgerTest) request(t *testing.T, tripID, riderID uuid.UUID, expiresAt time.Time) *cargonaut.Booking {
+	booking := &cargonaut.Booking{
+		TripID:    tripID,
+		UserID:    riderID,
+		Status:    cargonaut.BookingStatusPending,
+		ExpiresAt: expiresAt,
+	}
+	require.NoError(t, l.bookings.CreateBooking(context.Background(), booking))
+	return booking
+}
+
+// assertBookingStatus asserts the status of the booking.
+func (l *ledgerTest) assertBookingStatus(t *testing.T, booking *cargonaut.Booking, status cargonaut.BookingStatus) {
+	got, err := l.bookings.GetBooking(context.Background(), booking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, status, got.Status)
+}
+
+func TestBookingRepositoryAcceptDeclineBooking(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+	expiresAt := time.Now().UTC().Add(time.Hour)
+
+	trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = 20 })
+	acceptedID, declinedID, outbidID := l.rider(t, 5000), l.rider(t, 5000), l.rider(t, 5000)
+	accepted := l.request(t, trip.ID, acceptedID, expiresAt)
+	declined := l.request(t, trip.ID, declinedID, expiresAt)
+	outbid := l.request(t, trip.ID, outbidID, expiresAt)
+	for _, riderID := range []uuid.UUID{acceptedID, declinedID, outbidID} {
+		l.assertWallet(t, riderID, 3000, 2000)
+	}
+
+	// Declining a booking releases its hold.
+	require.NoError(t, l.bookings.DeclineBooking(ctx, declined.ID))
+	l.assertBookingStatus(t, declined, cargonaut.BookingStatusDeclined)
+	l.assertWallet(t, declinedID, 5000, 0)
+	assert.Equal(t, cargonaut.ErrBookingNotPending, l.bookings.DeclineBooking(ctx, declined.ID))
+	assert.Equal(t, cargonaut.ErrBookingNotPending, l.bookings.AcceptBooking(ctx, declined.ID))
+
+	// Accepting a booking books the trip and keeps its hold, while the other
+	// pending bookings are declined and their holds released.
+	require.NoError(t, l.bookings.AcceptBooking(ctx, accepted.ID))
+	l.assertBookingStatus(t, accepted, cargonaut.BookingStatusAccepted)
+	l.assertBookingStatus(t, outbid, cargonaut.BookingStatusDeclined)
+	l.assertWallet(t, acceptedID, 3000, 2000)
+	l.assertWallet(t, outbidID, 5000, 0)
+	got, err := l.trips.GetTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &acceptedID, got.RiderID)
+
+	// The booked trip can neither be accepted again nor requested anymore.
+	assert.Equal(t, cargonaut.ErrTripNotAvailable, l.bookings.AcceptBooking(ctx, accepted.ID))
+	assert.Equal(t, cargonaut.ErrBookingNotFound, l.bookings.AcceptBooking(ctx, uuid.NewV4()))
+	err = l.bookings.CreateBooking(ctx, &cargonaut.Booking{
+		TripID:    trip.ID,
+		UserID:    l.rider(t, 5000),
+		Status:    cargonaut.BookingStatusPending,
+		ExpiresAt: expiresAt,
+	})
+	assert.Equal(t, cargonaut.ErrTripNotAvailable, err)
+	l.assertBalanced(t, trip.ID)
+}
+
+func TestBookingRepositoryExpireBookings(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = 20 })
+	expiredID, pendingID := l.rider(t, 5000), l.rider(t, 5000)
+	expired := l.request(t, trip.ID, expiredID, now.Add(-time.Minute))
+	pending := l.request(t, trip.ID, pendingID, now.Add(time.Hour))
+
+	// Bookings which expired can't be accepted anymore, even before they are
+	// expired, and expiring them releases their holds.
+	assert.Equal(t, cargonaut.ErrBookingNotPending, l.bookings.AcceptBooking(ctx, expired.ID))
+	n, err := l.bookings.ExpireBookings(ctx, now)
+	require.NoError(t, err)
+	assert.True(t, n >= 1, "expired bookings")
+	l.assertBookingStatus(t, expired, cargonaut.BookingStatusExpired)
+	l.assertBookingStatus(t, pending, cargonaut.BookingStatusPending)
+	l.assertWallet(t, expiredID, 5000, 0)
+	l.assertWallet(t, pendingID, 3000, 2000)
+
+	// The trip is still available for the pending booking.
+	require.NoError(t, l.bookings.AcceptBooking(ctx, pending.ID))
+	l.assertWallet(t, pendingID, 3000, 2000)
+	l.assertBalanced(t, trip.ID)
+}
@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.JobRepository = (*JobRepository)(nil)
+
+const (
+	listJobsSQL    = "SELECT name, schedule, locked_by, locked_until, last_run_at, last_finished_at, last_error, next_run_at FROM job ORDER BY name"
+	registerJobSQL = "INSERT INTO job (name, schedule, next_run_at) VALUES (:name, :schedule, :next_run_at) ON CONFLICT (name) DO UPDATE SET next_run_at = CASE WHEN job.schedule = excluded.schedule THEN job.next_run_at ELSE excluded.next_run_at END, schedule = excluded.schedule"
+	acquireJobSQL  = "UPDATE job SET locked_by = $2, locked_until = (now() at time zone 'utc') + make_interval(secs => $3), last_run_at = (now() at time zone 'utc') WHERE name = $1 AND next_run_at <= (now() at time zone 'utc') AND (locked_until IS NULL OR locked_until < (now() at time zone 'utc'))"
+	releaseJobSQL  = "UPDATE job SET locked_by = NULL, locked_until = NULL, last_finished_at = (now() at time zone 'utc'), last_error = $3, next_run_at = $4 WHERE name = $1 AND locked_by = $2"
+)
+
+// JobRepository provides access to the background jobs and their leases backed
+// by a Postgres SQL database.
+type JobRepository struct {
+	db *sqlx.DB
+
+	listStmt     *sqlx.Stmt
+	registerStmt *sqlx.NamedStmt
+	acquireStmt  *sqlx.Stmt
+	releaseStmt  *sqlx.Stmt
+}
+
+// NewJobRepository returns a new JobRepository based on top of the provided
+// database connection.
+func NewJobRepository(ctx context.Context, db *sqlx.DB) (*JobRepository, error) {
+	s := &JobRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listJobsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list jobs statement: %w", err)
+	}
+	if s.registerStmt, err = db.PrepareNamedContext(ctx, registerJobSQL); err != nil {
+		return nil, fmt.Errorf("prepare register job statement: %w", err)
+	}
+	if s.acquireStmt, err = db.PreparexContext(ctx, acquireJobSQL); err != nil {
+		return nil, fmt.Errorf("prepare acquire job statement: %w", err)
+	}
+	if s.releaseStmt, err = db.PreparexContext(ctx, releaseJobSQL); err != nil {
+		return nil, fmt.Errorf("prepare release job statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *JobRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list jobs statement: %w", err)
+	}
+	if err := s.registerStmt.Close(); err != nil {
+		return fmt.Errorf("close register job statement: %w", err)
+	}
+	if err := s.acquireStmt.Close(); err != nil {
+		return fmt.Errorf("close acquire job statement: %w", err)
+	}
+	if err := s.releaseStmt.Close(); err != nil {
+		return fmt.Errorf("close release job statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListJobs lists all jobs.
+func (s *JobRepository) ListJobs(ctx context.Context) ([]*cargonaut.Job, error) {
+	jobs := make([]*cargonaut.Job, 0)
+	if err := s.listStmt.SelectContext(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("select jobs from database: %w", err)
+	}
+	return jobs, nil
+}
+
+// RegisterJob creates the job or updates its schedule. The next run of an
+// existing job is only replaced, if the schedule changed.
+func (s *JobRepository) RegisterJob(ctx context.Context, job *cargonaut.Job) error {
+	if _, err := s.registerStmt.ExecContext(ctx, job); err != nil {
+		return fmt.Errorf("register job %q in database: %w", job.Name, err)
+	}
+	return nil
+}
+
+// AcquireJob takes the lease of the job identified by its unique name for the
+// given owner and duration. It returns false, if the job is not due or its
+// lease is held by another owner.
+func (s *JobRepository) AcquireJob(ctx context.Context, name, owner string, lease time.Duration) (bool, error) {
+	res, err := s.acquireStmt.ExecContext(ctx, name, owner, lease.Seconds())
+	if err != nil {
+		return false, fmt.Errorf("acquire job %q in database: %w", name, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("acquire job %q in database: %w", name, err)
+	}
+	return n == 1, nil
+}
+
+// ReleaseJob releases the lease of the job identified by its unique name held
+// by the given owner and records the result of the run.
+func (s *JobRepository) ReleaseJob(ctx context.Context, name, owner string, next time.Time, runErr error) error {
+	var lastError sql.NullString
+	if runErr != nil {
+		lastError = sql.NullString{String: runErr.Error(), Valid: true}
+	}
+	if _, err := s.releaseStmt.ExecContext(ctx, name, owner, lastError, next); err != nil {
+		return fmt.Errorf("release job %q in database: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	"github.com/my-cargonaut/cargonaut/pkg/ledger"
+)
+
+const (
+	userLedgerAccountSQL     = "INSERT INTO ledger_account (user_id, type) VALUES ($1, $2) ON CONFLICT (user_id, type) WHERE user_id <> '00000000-0000-0000-0000-000000000000' DO UPDATE SET type = excluded.type RETURNING id"
+	platformLedgerAccountSQL = "SELECT id FROM ledger_account WHERE user_id IS NULL AND type = $1"
+	ledgerBalanceSQL         = "SELECT coalesce(sum(amount), 0) FROM ledger_posting WHERE account_id = $1"
+	ledgerTripBalanceSQL     = "SELECT coalesce(sum(p.amount), 0) FROM ledger_posting p JOIN ledger_entry e ON e.id = p.entry_id WHERE p.account_id = $1 AND e.trip_id = $2"
+	ledgerTripSubsidySQL     = "SELECT coalesce(-sum(p.amount), 0) FROM ledger_posting p JOIN ledger_entry e ON e.id = p.entry_id WHERE p.account_id = $1 AND e.trip_id = $2 AND EXISTS (SELECT 1 FROM ledger_posting h WHERE h.entry_id = e.id AND h.account_id = $3)"
+	ledgerTripPriceSQL       = "SELECT price FROM trip WHERE id = $1"
+	createLedgerEntrySQL     = "INSERT INTO ledger_entry (type, trip_id, payment_id) VALUES ($1, $2, $3) RETURNING id"
+	createLedgerPostingSQL   = "INSERT INTO ledger_posting (entry_id, account_id, amount) VALUES ($1, $2, $3)"
+)
+
+// ledgerStatements posts journal entries to the wallet ledger within the
+// transactions of the repositories which move money. Looking up the account
+// of a user locks it for the rest of the transaction, so balances can not
+// change between checking and posting.
+type ledgerStatements struct {
+	userAccountStmt     *sqlx.Stmt
+	platformAccountStmt *sqlx.Stmt
+	balanceStmt         *sqlx.Stmt
+	tripBalanceStmt     *sqlx.Stmt
+	tripSubsidyStmt     *sqlx.Stmt
+	tripPriceStmt       *sqlx.Stmt
+	createEntryStmt     *sqlx.Stmt
+	createPostingStmt   *sqlx.Stmt
+}
+
+// prepareLedgerStatements prepares the statements posting to the wallet
+// ledger.
+func prepareLedgerStatements(ctx context.Context, db *sqlx.DB) (*ledgerStatements, error) {
+	l := new(ledgerStatements)
+
+	var err error
+	if l.userAccountStmt, err = db.PreparexContext(ctx, userLedgerAccountSQL); err != nil {
+		return nil, fmt.Errorf("prepare user ledger account statement: %w", err)
+	}
+	if l.platformAccountStmt, err = db.PreparexContext(ctx, platformLedgerAccountSQL); err != nil {
+		return nil, fmt.Errorf("prepare platform ledger account statement: %w", err)
+	}
+	if l.balanceStmt, err = db.PreparexContext(ctx, ledgerBalanceSQL); err != nil {
+		return nil, fmt.Errorf("prepare ledger balance statement: %w", err)
+	}
+	if l.tripBalanceStmt, err = db.PreparexContext(ctx, ledgerTripBalanceSQL); err != nil {
+		return nil, fmt.Errorf("prepare ledger trip balance statement: %w", err)
+	}
+	if l.tripSubsidyStmt, err = db.PreparexContext(ctx, ledgerTripSubsidySQL); err != nil {
+		return nil, fmt.Errorf("prepare ledger trip subsidy statement: %w", err)
+	}
+	if l.tripPriceStmt, err = db.PreparexContext(ctx, ledgerTripPriceSQL); err != nil {
+		return nil, fmt.Errorf("prepare ledger trip price statement: %w", err)
+	}
+	if l.createEntryStmt, err = db.PreparexContext(ctx, createLedgerEntrySQL); err != nil {
+		return nil, fmt.Errorf("prepare create ledger entry statement: %w", err)
+	}
+	if l.createPostingStmt, err = db.PreparexContext(ctx, createLedgerPostingSQL); err != nil {
+		return nil, fmt.Errorf("prepare create ledger posting statement: %w", err)
+	}
+
+	return l, nil
+}
+
+// Close all prepared statements.
+func (l *ledgerStatements) Close() error {
+	if err := l.userAccountStmt.Close(); err != nil {
+		return fmt.Errorf("close user ledger account statement: %w", err)
+	}
+	if err := l.platformAccountStmt.Close(); err != nil {
+		return fmt.Errorf("close platform ledger account statement: %w", err)
+	}
+	if err := l.balanceStmt.Close(); err != nil {
+		return fmt.Errorf("close ledger balance statement: %w", err)
+	}
+	if err := l.tripBalanceStmt.Close(); err != nil {
+		return fmt.Errorf("close ledger trip balance statement: %w", err)
+	}
+	if err := l.tripSubsidyStmt.Close(); err != nil {
+		return fmt.Errorf("close ledger trip subsidy statement: %w", err)
+	}
+	if err := l.tripPriceStmt.Close(); err != nil {
+		return fmt.Errorf("close ledger trip price statement: %w", err)
+	}
+	if err := l.createEntryStmt.Close(); err != nil {
+		return fmt.Errorf("close create ledger entry statement: %w", err)
+	}
+	if err := l.createPostingStmt.Close(); err != nil {
+		return fmt.Errorf("close create ledger posting statement: %w", err)
+	}
+
+	return nil
+}
+
+// deposit credits the amount to the wallet of the user from the funding
+// account of the platform. The payment ID is optional.
+func (l *ledgerStatements) deposit(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, paymentID *uuid.UUID, amount int64) error {
+	return l.credit(ctx, tx, cargonaut.LedgerEntryTypeDeposit, userID, paymentID, amount)
+}
+
+// reverse credits the amount of a failed withdrawal back to the wallet of the
+// user.
+func (l *ledgerStatements) reverse(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, paymentID *uuid.UUID, amount int64) error {
+	return l.credit(ctx, tx, cargonaut.LedgerEntryTypeReversal, userID, paymentID, amount)
+}
+
+// credit moves the amount from the funding account of the platform to the
+// wallet of the user.
+func (l *ledgerStatements) credit(ctx context.Context, tx *sqlx.Tx, typ cargonaut.LedgerEntryType, userID uuid.UUID, paymentID *uuid.UUID, amount int64) error {
+	funding, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypeFunding)
+	if err != nil {
+		return err
+	}
+	wallet, err := l.userAccount(ctx, tx, userID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return err
+	}
+	return l.post(ctx, tx, typ, nil, paymentID, ledger.Transfer(funding, wallet, amount))
+}
+
+// withdraw moves the amount from the wallet of the user to the funding account
+// of the platform. It returns cargonaut.ErrInsufficientFunds if the available
+// balance does not cover the amount.
+func (l *ledgerStatements) withdraw(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, paymentID *uuid.UUID, amount int64) error {
+	wallet, err := l.userAccount(ctx, tx, userID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return err
+	}
+	if err = l.cover(ctx, tx, wallet, amount); err != nil {
+		return err
+	}
+	funding, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypeFunding)
+	if err != nil {
+		return err
+	}
+	return l.post(ctx, tx, cargonaut.LedgerEntryTypeWithdrawal, nil, paymentID, ledger.Transfer(wallet, funding, amount))
+}
+
+// hold moves the price of the trip to the hold account of the rider. The
+// discount, which must not exceed the price, is paid from the promotions
+// account and the rest from the wallet of the rider. It returns
+// cargonaut.ErrInsufficientFunds if the available balance does not cover the
+// rest. Nothing is held for free trips.
+func (l *ledgerStatements) hold(ctx context.Context, tx *sqlx.Tx, tripID, riderID uuid.UUID, discount int64) error {
+	amount, err := l.price(ctx, tx, tripID)
+	if err != nil || amount <= 0 {
+		return err
+	}
+
+	wallet, err := l.userAccount(ctx, tx, riderID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return err
+	}
+	if err = l.cover(ctx, tx, wallet, amount-discount); err != nil {
+		return err
+	}
+	hold, err := l.userAccount(ctx, tx, riderID, cargonaut.LedgerAccountTypeHold)
+	if err != nil {
+		return err
+	}
+	promotions, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypePromotions)
+	if err != nil {
+		return err
+	}
+	return l.post(ctx, tx, cargonaut.LedgerEntryTypeHold, &tripID, nil, ledger.Subsidize(wallet, hold, promotions, amount, discount))
+}
+
+// release moves the balance held for the trip back to the wallet of the
+// rider. A discount paid from the promotions account is returned to it
+// instead. Nothing is posted if no balance is held.
+func (l *ledgerStatements) release(ctx context.Context, tx *sqlx.Tx, tripID, riderID uuid.UUID) error {
+	hold, held, err := l.held(ctx, tx, tripID, riderID)
+	if err != nil || held == 0 {
+		return err
+	}
+	wallet, err := l.userAccount(ctx, tx, riderID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return err
+	}
+	promotions, subsidy, err := l.subsidy(ctx, tx, tripID, hold)
+	if err != nil {
+		return err
+	}
+	return l.post(ctx, tx, cargonaut.LedgerEntryTypeRelease, &tripID, nil, ledger.Settle(hold, wallet, promotions, held, subsidy))
+}
+
+// reward moves the amount from the promotions account of the platform to the
+// wallet of the user.
+func (l *ledgerStatements) reward(ctx context.Context, tx *sqlx.Tx, typ cargonaut.LedgerEntryType, userID uuid.UUID, tripID *uuid.UUID, amount int64) error {
+	promotions, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypePromotions)
+	if err != nil {
+		return err
+	}
+	wallet, err := l.userAccount(ctx, tx, userID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return err
+	}
+	return l.post(ctx, tx, typ, tripID, nil, ledger.Transfer(promotions, wallet, amount))
+}
+
+// settle moves the balance held for the trip to the wallet of the driver,
+// withholding the platform fee. It returns the part of the settled balance the
+// rider paid from their wallet. Nothing is posted if no balance is held.
+func (l *ledgerStatements) settle(ctx context.Context, tx *sqlx.Tx, tripID, riderID, driverID uuid.UUID, fees cargonaut.FeePolicy) (int64, error) {
+	hold, held, err := l.held(ctx, tx, tripID, riderID)
+	if err != nil || held == 0 {
+		return 0, err
+	}
+	_, subsidy, err := l.subsidy(ctx, tx, tripID, hold)
+	if err != nil {
+		return 0, err
+	}
+	wallet, err := l.userAccount(ctx, tx, driverID, cargonaut.LedgerAccountTypeWallet)
+	if err != nil {
+		return 0, err
+	}
+	feeAccount, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypeFees)
+	if err != nil {
+		return 0, err
+	}
+	if err = l.post(ctx, tx, cargonaut.LedgerEntryTypeSettle, &tripID, nil, ledger.Settle(hold, wallet, feeAccount, held, fees.Fee(held))); err != nil {
+		return 0, err
+	}
+	return held - subsidy, nil
+}
+
+// price returns the price of the trip in cents.
+func (l *ledgerStatements) price(ctx context.Context, tx *sqlx.Tx, tripID uuid.UUID) (int64, error) {
+	var price float64
+	if err := tx.StmtxContext(ctx, l.tripPriceStmt).GetContext(ctx, &price, tripID); err != nil {
+		return 0, fmt.Errorf("get price of trip %q from database: %w", tripID, err)
+	}
+	return ledger.Cents(price), nil
+}
+
+// cover returns cargonaut.ErrInsufficientFunds if the balance of the account
+// does not cover the amount.
+func (l *ledgerStatements) cover(ctx context.Context, tx *sqlx.Tx, account string, amount int64) error {
+	var balance int64
+	if err := tx.StmtxContext(ctx, l.balanceStmt).GetContext(ctx, &balance, account); err != nil {
+		return fmt.Errorf("get balance of ledger account %q from database: %w", account, err)
+	} else if balance < amount {
+		return cargonaut.ErrInsufficientFunds
+	}
+	return nil
+}
+
+// held returns the hold account of the rider and the balance held on it for
+// the trip.
+func (l *ledgerStatements) held(ctx context.Context, tx *sqlx.Tx, tripID, riderID uuid.UUID) (string, int64, error) {
+	hold, err := l.userAccount(ctx, tx, riderID, cargonaut.LedgerAccountTypeHold)
+	if err != nil {
+		return "", 0, err
+	}
+	var held int64
+	if err = tx.StmtxContext(ctx, l.tripBalanceStmt).GetContext(ctx, &held, hold, tripID); err != nil {
+		return "", 0, fmt.Errorf("get balance of ledger account %q for trip %q from database: %w", hold, tripID, err)
+	}
+	return hold, held, nil
+}
+
+// subsidy returns the promotions account of the platform and the part of the
+// balance held on the hold account for the trip which has been paid from it.
+func (l *ledgerStatements) subsidy(ctx context.Context, tx *sqlx.Tx, tripID uuid.UUID, hold string) (string, int64, error) {
+	promotions, err := l.platformAccount(ctx, tx, cargonaut.LedgerAccountTypePromotions)
+	if err != nil {
+		return "", 0, err
+	}
+	var subsidy int64
+	if err = tx.StmtxContext(ctx, l.tripSubsidyStmt).GetContext(ctx, &subsidy, promotions, tripID, hold); err != nil {
+		return "", 0, fmt.Errorf("get subsidy of ledger account %q for trip %q from database: %w", hold, tripID, err)
+	}
+	return promotions, subsidy, nil
+}
+
+// empty returns cargonaut.ErrWalletNotEmpty if the wallet or the hold account
+// of the user has a balance.
+func (l *ledgerStatements) empty(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID) error {
+	for _, typ := range []cargonaut.LedgerAccountType{cargonaut.LedgerAccountTypeWallet, cargonaut.LedgerAccountTypeHold} {
+		account, err := l.userAccount(ctx, tx, userID, typ)
+		if err != nil {
+			return err
+		}
+		var balance int64
+		if err = tx.StmtxContext(ctx, l.balanceStmt).GetContext(ctx, &balance, account); err != nil {
+			return fmt.Errorf("get balance of ledger account %q from database: %w", account, err)
+		} else if balance != 0 {
+			return cargonaut.ErrWalletNotEmpty
+		}
+	}
+	return nil
+}
+
+// userAccount returns the ID of the account of the user, creating it if it
+// does not exist yet, and locks it for the rest of the transaction.
+func (l *ledgerStatements) userAccount(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, typ cargonaut.LedgerAccountType) (string, error) {
+	var id string
+	if err := tx.StmtxContext(ctx, l.userAccountStmt).GetContext(ctx, &id, userID, typ); err != nil {
+		return "", fmt.Errorf("get %s ledger account of user %q from database: %w", typ, userID, err)
+	}
+	return id, nil
+}
+
+// platformAccount returns the ID of the account of the platform.
+func (l *ledgerStatements) platformAccount(ctx context.Context, tx *sqlx.Tx, typ cargonaut.LedgerAccountType) (string, error) {
+	var id string
+	if err := tx.StmtxContext(ctx, l.platformAccountStmt).GetContext(ctx, &id, typ); err != nil {
+		return "", fmt.Errorf("get %s ledger account from database: %w", typ, err)
+	}
+	return id, nil
+}
+
+// post validates and writes the entry along with its postings. The accounts
+// of the postings are the IDs of ledger accounts. The trip and payment the
+// entry belongs to are optional.
+func (l *ledgerStatements) post(ctx context.Context, tx *sqlx.Tx, typ cargonaut.LedgerEntryType, tripID, paymentID *uuid.UUID, entry ledger.Entry) error {
+	if err := entry.Validate(); err != nil {
+		return fmt.Errorf("post %s ledger entry: %w", typ, err)
+	}
+
+	var id uuid.UUID
+	if err := tx.StmtxContext(ctx, l.createEntryStmt).GetContext(ctx, &id, typ, tripID, paymentID); err != nil {
+		return fmt.Errorf("create %s ledger entry in database: %w", typ, err)
+	}
+	for _, p := range entry {
+		if _, err := tx.StmtxContext(ctx, l.createPostingStmt).ExecContext(ctx, id, p.Account, p.Amount); err != nil {
+			return fmt.Errorf("create posting of ledger entry %q in database: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// ledgerTest provides the repositories moving money through the ledger.
+type ledgerTest struct {
+	db       *sqlx.DB
+	trips    *TripRepository
+	bookings *BookingRepository
+	wallets  *WalletRepository
+	users    *UserRepository
+}
+
+func newLedgerTest(t *testing.T) *ledgerTest {
+	db := testDB(t)
+	ctx := context.Background()
+	l := &ledgerTest{db: db}
+
+	var err error
+	l.trips, err = NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.trips.Close() })
+	l.bookings, err = NewBookingRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.bookings.Close() })
+	l.wallets, err = NewWalletRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.wallets.Close() })
+	l.users, err = NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.users.Close() })
+	return l
+}
+
+// rider creates a user with the amount in cents deposited to his wallet.
+func (l *ledgerTest) rider(t *testing.T, amount int64) uuid.UUID {
+	rider := createTestUser(t, l.db)
+	require.NoError(t, l.wallets.Deposit(context.Background(), rider.ID, amount))
+	return rider.ID
+}
+
+// book creates a trip for the price and books it for the rider.
+func (l *ledgerTest) book(t *testing.T, riderID uuid.UUID, price float32) *cargonaut.Trip {
+	trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = price })
+	now := time.Now().UTC()
+	require.NoError(t, l.bookings.CreateBooking(context.Background(), &cargonaut.Booking{
+		TripID:    trip.ID,
+		UserID:    riderID,
+		Status:    cargonaut.BookingStatusAccepted,
+		ExpiresAt: now,
+		DecidedAt: &now,
+	}))
+	trip.RiderID = &riderID
+	return trip
+}
+
+// finish marks the trip as started and finished a minute ago.
+func (l *ledgerTest) finish(t *testing.T, tripID uuid.UUID) {
+	now := time.Now().UTC()
+	_, err := l.db.Exec("UPDATE trip SET depature = $2, arrival = $3 WHERE id = $1", tripID, now.Add(-time.Hour), now.Add(-time.Minute))
+	require.NoError(t, err)
+}
+
+// assertWallet asserts the balance and the held balance of the wallet of the
+// user.
+func (l *ledgerTest) assertWallet(t *testing.T, userID uuid.UUID, balance, held int64) {
+	wallet, err := l.wallets.GetWallet(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, balance, wallet.Balance, "balance")
+	assert.Equal(t, held, wallet.Held, "held")
+}
+
+// assertBalanced asserts that all postings of the trip and of the ledger as a
+// whole sum to zero.
+func (l *ledgerTest) assertBalanced(t *testing.T, tripID uuid.UUID) {
+	var sum int64
+	require.NoError(t, l.db.Get(&sum, "SELECT coalesce(sum(p.amount), 0) FROM ledger_posting p JOIN ledger_entry e ON e.id = p.entry_id WHERE e.trip_id = $1", tripID))
+	assert.Zero(t, sum, "trip postings")
+	require.NoError(t, l.db.Get(&sum, "SELECT coalesce(sum(amount), 0) FROM ledger_posting"))
+	assert.Zero(t, sum, "ledger postings")
+}
+
+func TestLedgerHoldRelease(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	riderID := l.rider(t, 5000)
+	trip := l.book(t, riderID, 20)
+	l.assertWallet(t, riderID, 3000, 2000)
+
+	require.NoError(t, l.trips.CancelTrip(ctx, &cargonaut.TripCancellation{
+		TripID: trip.ID,
+		UserID: riderID,
+		Role:   cargonaut.RatingRoleRider,
+	}))
+	l.assertWallet(t, riderID, 5000, 0)
+	l.assertBalanced(t, trip.ID)
+}
+
+func TestLedgerHoldInsufficientFunds(t *testing.T) {
+	l := newLedgerTest(t)
+
+	riderID := l.rider(t, 1000)
+	trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = 20 })
+	err := l.bookings.CreateBooking(context.Background(), &cargonaut.Booking{
+		TripID:    trip.ID,
+		UserID:    riderID,
+		Status:    cargonaut.BookingStatusPending,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	})
+	assert.Equal(t, cargonaut.ErrInsufficientFunds, err)
+	l.assertWallet(t, riderID, 1000, 0)
+}
+
+func TestLedgerSettle(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	riderID := l.rider(t, 5000)
+	finished := l.book(t, riderID, 20)
+	unstarted := l.book(t, riderID, 10)
+	l.finish(t, finished.ID)
+
+	_, err := l.trips.CompleteTrips(ctx, time.Now().UTC(), cargonaut.FeePolicy{Rate: 1000}, cargonaut.ReferralPolicy{})
+	require.NoError(t, err)
+
+	// The finished trip is paid to its driver less 10% fee, while the price
+	// of the trip which has not been started yet stays held.
+	l.assertWallet(t, riderID, 2000, 1000)
+	l.assertWallet(t, finished.UserID, 1800, 0)
+	l.assertWallet(t, unstarted.UserID, 0, 0)
+	l.assertBalanced(t, finished.ID)
+}
+
+func TestUserRepositoryDeleteUserReleasesHolds(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	riderID := l.rider(t, 5000)
+	trip := l.book(t, riderID, 20)
+
+	// The balance held by the rider for the trip of the deleted driver is
+	// released back to his wallet.
+	require.NoError(t, l.users.DeleteUser(ctx, trip.UserID))
+	l.assertWallet(t, riderID, 5000, 0)
+	l.assertBalanced(t, trip.ID)
+}
+
+func TestUserRepositoryDeleteUserWalletNotEmpty(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	riderID := l.rider(t, 5000)
+	trip := l.book(t, riderID, 50)
+	l.assertWallet(t, riderID, 0, 5000)
+
+	// Deleting the rider would release his hold into a wallet nobody owns.
+	assert.Equal(t, cargonaut.ErrWalletNotEmpty, l.users.DeleteUser(ctx, riderID))
+	l.assertWallet(t, riderID, 0, 5000)
+
+	_, err := l.users.GetUser(ctx, riderID)
+	require.NoError(t, err)
+	got, err := l.trips.GetTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &riderID, got.RiderID)
+}
+
+func TestLedgerSettleOnlyFinishedTrips(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+	fees := cargonaut.FeePolicy{Rate: 1000}
+
+	riderID := l.rider(t, 5000)
+	trip := l.book(t, riderID, 20)
+
+	// Updating the booked trip neither records its progress nor changes its
+	// price, so the hold is not settled by completing trips.
+	updated, err := l.trips.GetTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	updated.Depature, updated.Arrival = now.Add(-time.Hour), now.Add(-time.Minute)
+	require.NoError(t, l.trips.UpdateTrip(ctx, updated))
+	updated.Price = 1
+	assert.Equal(t, cargonaut.ErrTripBooked, l.trips.UpdateTrip(ctx, updated))
+
+	_, err = l.trips.CompleteTrips(ctx, now, fees, cargonaut.ReferralPolicy{})
+	require.NoError(t, err)
+	l.assertWallet(t, riderID, 3000, 2000)
+
+	// A trip is finished after it has been started and started only once.
+	assert.Equal(t, cargonaut.ErrTripNotFinishable, l.trips.FinishTrip(ctx, trip.ID, now.Add(-time.Minute)))
+	require.NoError(t, l.trips.StartTrip(ctx, trip.ID, now.Add(-time.Hour)))
+	assert.Equal(t, cargonaut.ErrTripNotStartable, l.trips.StartTrip(ctx, trip.ID, now.Add(-time.Hour)))
+	assert.Equal(t, cargonaut.ErrTripNotFinishable, l.trips.FinishTrip(ctx, trip.ID, now.Add(-2*time.Hour)))
+	require.NoError(t, l.trips.FinishTrip(ctx, trip.ID, now.Add(-time.Minute)))
+	assert.Equal(t, cargonaut.ErrTripNotFinishable, l.trips.FinishTrip(ctx, trip.ID, now))
+
+	_, err = l.trips.CompleteTrips(ctx, now, fees, cargonaut.ReferralPolicy{})
+	require.NoError(t, err)
+	l.assertWallet(t, riderID, 3000, 0)
+	l.assertWallet(t, trip.UserID, 1800, 0)
+	l.assertBalanced(t, trip.ID)
+}
@@ -0,0 +1,134 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.MessageRepository = (*MessageRepository)(nil)
+
+// messageReadCondition matches messages of the message table aliased m which
+// have been read by another participant of the conversation.
+const messageReadCondition = "EXISTS (SELECT 1 FROM message_receipt mr WHERE mr.trip_id = m.trip_id AND mr.user_id <> m.author_id AND mr.read_at >= m.created_at)"
+
+const (
+	listMessagesSQL      = "SELECT m.id, m.trip_id, m.author_id, m.body, " + messageReadCondition + " AS read, m.created_at FROM message m WHERE m.trip_id = $1 ORDER BY m.created_at"
+	createMessageSQL     = "INSERT INTO message (trip_id, author_id, body) VALUES (:trip_id, :author_id, :body) RETURNING id, created_at"
+	markReadSQL          = "INSERT INTO message_receipt (trip_id, user_id) VALUES ($1, $2) ON CONFLICT (trip_id, user_id) DO UPDATE SET read_at = excluded.read_at"
+	listConversationsSQL = "SELECT t.id AS trip_id, m.id AS \"last_message.id\", m.trip_id AS \"last_message.trip_id\", m.author_id AS \"last_message.author_id\", m.body AS \"last_message.body\", " + messageReadCondition + " AS \"last_message.read\", m.created_at AS \"last_message.created_at\", (SELECT count(*) FROM message u WHERE u.trip_id = t.id AND u.author_id <> $1 AND u.created_at > coalesce(r.read_at, 'epoch')) AS unread_count FROM trip t JOIN LATERAL (SELECT id, trip_id, author_id, body, created_at FROM message WHERE trip_id = t.id ORDER BY created_at DESC LIMIT 1) m ON true LEFT JOIN message_receipt r ON r.trip_id = t.id AND r.user_id = $1 WHERE t.user_id = $1 OR t.rider_id = $1 ORDER BY m.created_at DESC"
+)
+
+// MessageRepository provides access to the message resource backed by a
+// Postgres SQL database.
+type MessageRepository struct {
+	db *sqlx.DB
+
+	listStmt              *sqlx.Stmt
+	createStmt            *sqlx.NamedStmt
+	markReadStmt          *sqlx.Stmt
+	listConversationsStmt *sqlx.Stmt
+	createEventStmt       *sqlx.NamedStmt
+}
+
+// NewMessageRepository returns a new MessageRepository based on top of the
+// provided database connection.
+func NewMessageRepository(ctx context.Context, db *sqlx.DB) (*MessageRepository, error) {
+	s := &MessageRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listMessagesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list messages statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createMessageSQL); err != nil {
+		return nil, fmt.Errorf("prepare create message statement: %w", err)
+	}
+	if s.markReadStmt, err = db.PreparexContext(ctx, markReadSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark messages read statement: %w", err)
+	}
+	if s.listConversationsStmt, err = db.PreparexContext(ctx, listConversationsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list conversations statement: %w", err)
+	}
+	if s.createEventStmt, err = db.PrepareNamedContext(ctx, createEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare create event statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *MessageRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list messages statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create message statement: %w", err)
+	}
+	if err := s.markReadStmt.Close(); err != nil {
+		return fmt.Errorf("close mark messages read statement: %w", err)
+	}
+	if err := s.listConversationsStmt.Close(); err != nil {
+		return fmt.Errorf("close list conversations statement: %w", err)
+	}
+	if err := s.createEventStmt.Close(); err != nil {
+		return fmt.Errorf("close create event statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListMessages lists all messages of the conversation of the trip identified
+// by its unique ID.
+func (s *MessageRepository) ListMessages(ctx context.Context, tripID uuid.UUID) ([]*cargonaut.Message, error) {
+	messages := make([]*cargonaut.Message, 0)
+	if err := s.listStmt.SelectContext(ctx, &messages, tripID); err != nil {
+		return nil, fmt.Errorf("select messages of trip %q from database: %w", tripID, err)
+	}
+	return messages, nil
+}
+
+// CreateMessage creates a new message. The events are written to the outbox in
+// the same transaction.
+func (s *MessageRepository) CreateMessage(ctx context.Context, message *cargonaut.Message, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = tx.NamedStmtContext(ctx, s.createStmt).GetContext(ctx, message, message); err != nil {
+		return fmt.Errorf("create message in database: %w", err)
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// MarkRead marks all messages of the conversation of the trip identified by
+// its unique ID as read by the user identified by his unique ID.
+func (s *MessageRepository) MarkRead(ctx context.Context, tripID, userID uuid.UUID) error {
+	if _, err := s.markReadStmt.ExecContext(ctx, tripID, userID); err != nil {
+		return fmt.Errorf("mark messages of trip %q read by user %q in database: %w", tripID, userID, err)
+	}
+	return nil
+}
+
+// ListConversations lists the conversations of all trips the user identified
+// by his unique ID participates in, most recent first.
+func (s *MessageRepository) ListConversations(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Conversation, error) {
+	conversations := make([]*cargonaut.Conversation, 0)
+	if err := s.listConversationsStmt.SelectContext(ctx, &conversations, userID); err != nil {
+		return nil, fmt.Errorf("select conversations of user %q from database: %w", userID, err)
+	}
+	return conversations, nil
+}
@@ -0,0 +1,76 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestMessageRepositoryUnreadCount(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+	repo, err := NewMessageRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	rider := createTestUser(t, db)
+	trip := createTestTrip(t, db, trips, nil)
+	_, err = db.Exec("UPDATE trip SET rider_id = $2 WHERE id = $1", trip.ID, rider.ID)
+	require.NoError(t, err)
+
+	send := func(authorID uuid.UUID, body string) {
+		require.NoError(t, repo.CreateMessage(ctx, &cargonaut.Message{TripID: trip.ID, AuthorID: authorID, Body: body}))
+	}
+	// assertConversation asserts the unread count and the last message of
+	// the conversation of the trip for the user.
+	assertConversation := func(userID uuid.UUID, unread int, last string, read bool) {
+		conversations, err := repo.ListConversations(ctx, userID)
+		require.NoError(t, err)
+		if assert.Len(t, conversations, 1) {
+			assert.Equal(t, trip.ID, conversations[0].TripID)
+			assert.Equal(t, unread, conversations[0].UnreadCount, "unread count")
+			assert.Equal(t, last, conversations[0].LastMessage.Body)
+			assert.Equal(t, read, conversations[0].LastMessage.Read, "read")
+		}
+	}
+
+	// Own messages are never unread.
+	send(trip.UserID, "Hello")
+	send(trip.UserID, "Where are you?")
+	assertConversation(rider.ID, 2, "Where are you?", false)
+	assertConversation(trip.UserID, 0, "Where are you?", false)
+
+	// Marking the conversation read as rider shows the messages as read to
+	// the driver.
+	require.NoError(t, repo.MarkRead(ctx, trip.ID, rider.ID))
+	assertConversation(rider.ID, 0, "Where are you?", true)
+	messages, err := repo.ListMessages(ctx, trip.ID)
+	require.NoError(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.True(t, messages[0].Read)
+		assert.True(t, messages[1].Read)
+	}
+
+	// Messages written afterwards are unread again.
+	send(rider.ID, "At the station")
+	assertConversation(trip.UserID, 1, "At the station", false)
+	send(trip.UserID, "On my way")
+	assertConversation(rider.ID, 1, "On my way", false)
+	require.NoError(t, repo.MarkRead(ctx, trip.ID, trip.UserID))
+	assertConversation(trip.UserID, 0, "On my way", false)
+	assertConversation(rider.ID, 1, "On my way", false)
+
+	// Users not participating have no conversation.
+	conversations, err := repo.ListConversations(ctx, createTestUser(t, db).ID)
+	require.NoError(t, err)
+	assert.Empty(t, conversations)
+}
@@ -9,6 +9,6 @@ import (
 const Migrations = "migrations" // static asset namespace
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x17\x00	\x000000_database_setup.sqlUT\x05\x00\x01\x80Cm8\xd2\xd5U\xd0\xce\xcdL/J,IU\x08-\xe0r\x0eru\x0cqUp\x8d\x08q\xf5\x0b\xf6\xf4\xf7S\xf0tS\xf0\xf3\x0fQp\x8d\xf0\x0c\x0e	VP*-\xcdL\xd1\xcd/..P\xb2\xe6\xe2B\xd6\xeb\x92_\x9e\xc7\xe5\x12\xe4\x1f\x80\xaa\x17\x9b>@\x00\x00\x00\xff\xffPK\x07\x08N%i\x05R\x00\x00\x00s\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000001_user_account.sqlUT\x05\x00\x01\x80Cm8\xac\x93\xcfn\xe20\x10\xc6\xefy\x8a\xb9\x91h\x97\x03{Z)\xa7@\xbc\xdb\xa8\xc1\xa1\xc1QK/\xd6\x10[\xc4*\xf9#\xc7\x81\xa6O_\x91\x00\x02*\n\x87\xfa8\x9e\xdf\xf7\x8dg\xc6\xc3!\xfc\xca\xd5J\xa3\x91\x90T\xd6$&\x1e#\xc0\xbcqH\xa0\xa9\xa5\xe6\x98\xa6eS\x18\xb0-\x00\x00%\xe0\xe44\x8d\x12@#\x064	C\xf0\xc9?/	Y\x17\xe5+Y\xc8\x9d(\xdf\x8c\xf2\xd4v~w\xb4\xccQ\xad\x8ft\x9a\xa1\xc6\xd4H\x0d\x1b\xd4\xad*V\xf6\xe8\xcf_\xe7\xa8\xd7#\x15\xd6\xf5\xb6\xd4\x82gXg\xf7!B\xd5\xd5\x1a[^`.\xeftY*m2\x81m_\x98Q\xb9\xac\x0d\xe6\x15<\x07\xec!J\x18\xb0`J\xe05\xa2\xe4\x82\xc3\x0d\x1a\xd4\x87\x07\x8d\x17\x8cx\x17\x19\xa9\x96h\xa4\xe0hn)\x1f\xdag\x17\xe5\xd6v\x00M\x97\x0d\x1fe!a\xd0\x98t\xb0obS\x89\x1fV\x9cDt\xceb/\xa0\xecl\xe4\xbcz\x93-\xcc\xe2`\xea\xc5\x0bx$\x0b\xb0\x95\xb8\x81t\x13\xe6;.\xa1\xc1SB\xc0\xee\"\x8e\xe5\xb8\x87\xdd\n\xa8O^\xce)%\xb8\x12\xef\x10\xd1\xf3\x95K\xe6\x01\xfd\x0fK\xa3\xa5\xec\xac\xbf\x93\xe8\x8do\xaa\xf4\xd5\xb8\x96u\xba\xf7~\xb9-,?\x8ef\xd7ks\xaf\xde\x1f\x8d\xf7)_\xbf\x8ek}\x06\x00\x00\xff\xffPK\x07\x08\x18\xfe&bZ\x01\x00\x00e\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x13\x00	\x000002_user_token.sqlUT\x05\x00\x01\x80Cm8\x84\x92\xc1n\xb2@\x14\x85\xf7<\xc5\xd9	\xf9\x7f\x9f\x80\x15\x85\xab\x9d\x14\x07;\x0ci\xed\x86P\x986\x13#\x10\x1c\xa2\xed\xd37\x02Fj\x8a\xce\xf6~\xe7\xdc99w>\xc7\xbf\x9d\xfel2\xa3\x90\xd4\x96/\xc8\x93\x04\xe9=\x84\x84v\xaf\x9a\xd4T[U\xc2\xb6\x00@\x178\xbf\xb6\xd5\x05x$\xc1\x930\xfc\xdfM;\xbcG\xfe\x98\xaac\xad\x1b\xb5O3\x03\xa3wjo\xb2]\x8d\x17&\x1f\xa3DB\xb2\x15\xe1-\xe2\xd4\xb3y\xa32\xa3\x8a;,\x02ZxI(a\x97\xd5\xc1v0\xd0\xf8\xaeJ\x85Yk\xf2\x99\xd3\xdb\xf9\x11\x8f\xa5\xf0\x18\x97\xa3Hi\xbdU_X\x0b\xb6\xf2\xc4\x06O\xb4\x81\xad\x8b\x9b\x82\x8f\x93`\x11	bK\xde\x0b\x86\xc4\x0e\x04-H\x10\xf7)\xee\x05Y\x9eWmi:KD\x1c\x01\x85$	\xbe\x17\xfb^@\xb7\x96\xe8\"=\xadI8{N\xa8\xd3[\x8e{\xae\x85\xf1\x80^\xafh]\x1cO\x1bF]%1\xe3K\xbc\x9bF\xa9\xce`Z>\xfc\xff\x9e\xc79\xe6\xb4\xd1\xa5\xdc{^\x17\xd2q-k|}Au(\xad@D\xeb\xa9\x98\xee\xc4t\x94b\n\xf9\xfd\xbf\x81\xba>r\xd7\xfa	\x00\x00\xff\xffPK\x07\x08J6\x19\x105\x01\x00\x00\x0d\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000003_vehicle.sqlUT\x05\x00\x01\x80Cm8\xb4\x93A\x8f\x9b0\x10\x85\xef\xfc\x8a\xb9-\xa8\xd9\xc3\xf6T)'\n\x93-*k\xb6\xc4\xa8\xdd^\x90\xd7\x9e\x12\xab`\x901I\xb7\xbf\xbe\n	\xdd\x84&=T*G\xfc\xbd\xf7\xc6\xd6\x9b\xdb[x\xd3\xe8\xca\nGPt^\x94c\xc8\x11x\xf8>E\xd8\xd2F\xcb\x9a\xc0\xf7\x00\x00\xb4\x82?\xbea\xd0\nX\xc6\x81\x15i\n1\xae\xc2\"\xe5\xe3\xdf\xb2\"C{\xd7r{\xd7H?X\x8c\x1eCO\xb6\x9c\x19\x9dy\x1c\xb0g+\xcc<Mn\x84\x15\xd2\x91\x85\xad\xb0/\xdaT\xfe\xdd\xdbw\xc1L\xd8\xb4\x8a\xea\x7f\x11v\xa2\xef\xc9Td\xfbWa\xdf\x88\xba\xd6\xc6-`D\xeaV(m\xaaRX\x12eM\xa6r\x1b0CCV\xcbK\xc4N+\xb7\x81\x19!-	G\xaa\x14\xee5\xc6\xe9\x86z'\x9a\x0e>'\xfcCVp\xe0\xc9\x03\xc2\xd7\x8c\xe1\xef\x17\xf5M\xbb\xf3\x03\x10n\xa4\xe1gk\x08n\x06'o\xa6w\xed\xd4\x7f\xf1\x8d2\xb6\xe6y\x980>\x95\xa1\xec\xbe\xd3\x0b<\xe6\xc9C\x98?\xc1G|\x02_\xab\xeb\xf4\xb7=\xbd\xcarL\xee\xd9\x81>V \x80\x1cW\x98#\x8bp}\xa8\x85\x90\xb2\x1d\x8c\x1b\xfd c\x10c\x8a\x1c!\n\xd7Q\x18\xe3\xd5\x04\xad\xca}F\xc1\x92O\x05\x82?Vgq(B\xe0\x05\xcb\xa9\xd1	\x8b\xf1\xcb\xa9H\xab\x1f\xfb\x94\xa9\xe3\xc5:a\xf7\xf0\xec,\xd18\xc1\x15\xe1q\xfa\xbf\xaa\xa7\x1b.=\xeft\xbb\xe2vg\xbc8\xcf\x1e/\xce\xb2\xbctt\x92v<?[\xcc\xa5\xf7+\x00\x00\xff\xffPK\x07\x08\x04\xeeP\xab\x90\x01\x00\x00\xbe\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x000004_trip.sqlUT\x05\x00\x01\x80Cm8\xacTOo\xd30\x14\xbf\xe7S\xbc\xdb\x12\xc1\x0e\x1b\x17\xa4\x9eB\xf2:\"2g\xa4\x8e`\\\"c\x9b\xcebq\"\xd7\xe9\x18\x9f\x1e\xc5q\xd2\x96\xb6hH\xf3\xf1\xbd\xdf\x9f\xa7\xe7\x9f}y	o\x1a\xb56\xccJ\xa8\xba )1\xa6\x084\xfe\x90#X\xa3:\x08\x03\x00\x00%`>}\xaf\x04\x90\x82\x02\xa9\xf2\x1cR\\\xc6UN]\xb5^K-\x07\xa9z{\xd5\xf00z\xeb\xb8\xfdF\x9a\xda\x0b\x1cp\xc7\xf6V>(\xfe(\x1d\xe2D\xdb(1\xd3\x87\xf6X\xddXf\xac\x9f\x87?0\xc3\xb8\x95\x06\xb6\xcc<+\xbd\x0e\xaf\xae\xdfG\x7f\xc9\x08\xb9\xb1J3\xabZ\xfd2Bg\x14\x97\xdeA\xf7\x8d4\x8a\xef\x10\xe05;f{\xe3PV5rcY\xd3\xc1\x97\x8c~,*\n4\xbbE\xf8V\x10\x1c\xf5\x981j\xcb\x1e\xe1E`n$\xb3R\xd4\xcc\xfe\x13</?\xd4\xedS\x18\x01\xb3\x0e\x0d\xbf[-\xe1\xa2\xb7\xfcb\xba\x82N\xbc\xaa^R\x90\x15-\xe3\x8cP\x17\x92\xba\xfb)\x9f\xe1\xae\xccn\xe3\xf2\x1e>\xe1=\x84J\x9c\x81\xfe\x18\xa0\xcb\xa2\xc4\xec\x86\x8cP\x9f\x8f\x08J\\b\x89$\xc1\xd5\x98\x19\xc6y\xdbk\xeb\xc4\xa0 \x90b\x8e\x14!\x89WI\x9c\xe2y\xf9\xfa\xfa\xd0`\x8a\xd0+:\xbc;t\xd8e\xf8\xc0\xc3\x97\xffK^\x89z\xd8PE\xb2\xcf\x15:f\x10-\xa6w\x99\x91\x14\xbf\xce8%~\x0d\xaa\xee\x99V\xab\x8c\xdc\xc0wk\xe4hw\x8a\xe2\x17}\x9e7\xdd\xc4)\xf2\xb4\xc4\xf3\xecy\xcd\x8b \xd8\xffV\xd2\xf6I\x07iY\xdc\x1d\x8f\xbf8\xaa\xef\xcdx\xdc\xdc\x9f\xc1ww?\xd5\"\xf8\x13\x00\x00\xff\xffPK\x07\x08\xc2\xbd\xf1\x82\xad\x01\x00\x00\xcc\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x000005_rating.sqlUT\x05\x00\x01\x80Cm8\xacT\xcdn\x9c0\x10\xbe\xf3\x14s\x0b\xa8\xc9!\xe9\xa5\xd2\x9e(\xcc\xa6\xa8\xc4\xa4^\xa36\xbdX.\xb8\x1b\xab\xc5\xac\x1c{\xd3\xf4\xe9+\xc0l\xa1b\x91*\x85\x13\xf27\xdf\xcf\xcc`\xae\xae\xe0M\xa3\xf6FX	\xe5!H(\xc6\x0c\x81\xc5\xefs\x04#\xac\xd2{\x08\x03\x00\x00U\xc3\xf88\xa7j \x05\x03R\xe69\xa4\xb8\x8d\xcb\x9c\xf5\xa7|/\xb5\xec\xb4\xf8\xf1\xba\xa9\xc2\xe8\xb2\xa7\xba'i\xf8\xc0\x9fQ\x07T8\xfb\xd8\x0e\xf8\x02j\x8d:\x9c\xe7Vm\xd3Hm\xfb\xd7GaDe\xa5\x81\xa30/J\xef\xc3\xeb\x9bw\xd1?\xf5G\xf1\xd3\xc9\xa1	\xed\x1aiT\xf5\xb7\x00\x06E#\x85\x955\x17\x16\xacj\xe4\x93\x15\xcd\x01>g\xecCQ2`\xd9\x1d\xc2\xd7\x82\xe0\xa9\xe9P\xb7\xcfa\x04\xbe\x1a~\xb7Z\xc2\x85\xb3\xd5\x85o=)\xc8\x8e\xd18#\xccO\x93\x1f~\xc8\x17\xb8\xa7\xd9]L\x1f\xe0#>@\xa8\xea\xb3\xc5\xdf\xbb\xe2mA1\xbb%C\xb1\x9fe\x04\x14\xb7H\x91$\xb8\x1b\xe6+\xaa\xaau\xda\xf6rP\x10H1G\x86\x90\xc4\xbb$Nq\xcd\x80\xdf\xcc-N\x0byU\x93\xb7s\x13\xbf\xd7\x99Ew\xf6\x9f\xd2\xaa\xe6\xdd\x88J\x92}*qu\x94\xa7\xae\xb8\xb7\x9e\x11O\xe8\xe5\xf8\xc5EA\xb4\x19\xafCFR\xfc2\xb1T\xf5\xaf.\xa2\xbf\x1f\xe5.#\xb7\xf0\xcd\x1a)\xfb\x04\xcb4\xbf\xb85\xee\xb8\xdbe\x811\xf6\x8a\xc0\x18}\x13\x04\xd3{\x9d\xb6\xcf:Hiq\xbf\xd4\xc8f\x01\x99d]\x82'I<<\xfd_l\x82?\x01\x00\x00\xff\xffPK\x07\x081Un\xa4\x99\x01\x00\x00T\x04\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(N%i\x05R\x00\x00\x00s\x00\x00\x00\x17\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x000000_database_setup.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x18\xfe&bZ\x01\x00\x00e\x03\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa0\x00\x00\x000001_user_account.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(J6\x19\x105\x01\x00\x00\x0d\x03\x00\x00\x13\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81F\x02\x00\x000002_user_token.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x04\xeeP\xab\x90\x01\x00\x00\xbe\x03\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc5\x03\x00\x000003_vehicle.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xc2\xbd\xf1\x82\xad\x01\x00\x00\xcc\x04\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9c\x05\x00\x000004_trip.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(1Un\xa4\x99\x01\x00\x00T\x04\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x8d\x07\x00\x000005_rating.sqlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00\x06\x00\x06\x00\xb5\x01\x00\x00l	\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x17\x00	\x000000_database_setup.sqlUT\x05\x00\x01\x80Cm8\x00s\x00\x8c\xff-- +migrate Up\nCREATE EXTENSION IF NOT EXISTS \"uuid-ossp\";\n\n-- +migrate Down\nDROP EXTENSION IF EXISTS \"uuid-ossp\";\n\x03\x00PK\x07\x08N%i\x05z\x00\x00\x00s\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000001_user_account.sqlUT\x05\x00\x01\x80Cm8\xac\x93M\x8f\xda0\x10\x86\xef\xf9\x15s#Q\xcb\x81\x9e*\xe5\x14\x88\xdbF\x0d\x0e\x0d\x8e\xba\xec\xc5\x1ab\x8bXK>\xe48\xb0\xd9_\xbf\xc2|\x08X\xb1pX\x1f\xc7\xef\xf3\xcexf<\x1c\xc2\xb7R\xad4\x1a	Y\xe3LR\x120\x02,\x18\xc7\x04\xbaVj\x8ey^w\x95\x01\xd7\x01\x00P\x02\xceN\xd7)\x014a@\xb38\x86\x90\xfc\n\xb2\x98\xd9(_\xc9J\xeeL\xf9fT\xe6\xae\xf7\xdd\xd2\xb2D\xb5>\xd1y\x81\x1as#5lP\xf7\xaaZ\xb9\xa3\x1f?\xbd\x93\xdf\x1ei\xb0m\xb7\xb5\x16\xbc\xc0\xb6x\x0c\x11\xaam\xd6\xd8\xf3\nK	\x8f!K\xa5M!\xb0\xdf\x17fT)[\x83e\x03\xff#\xf6'\xc9\x18\xb0hJ\xe09\xa1\xe4\x8a\xc3\x0d\x1a\xd4\xc7\x07\x8d\x17\x8c\x04W\x8a\\K4Rp4\xf7\x9c\x8f\xeds\xabz\xebz\x80\xc6\xaa\xe1\xad\xae$\x0c:\x93\x0f\x0eM\xec\x1a\xf1\xc5\x8e\x93\x84\xceY\x1aD\x94]\x8c\x9c7/\xb2\x87Y\x1aM\x83t\x01\x7f\xc9\x02\\%\xee v\xc2|\xc7e4\xfa\x97\x11pm\xc4s<\xff\xb8[\x11\x0d\xc9\xd3%\xa5\x04W\xe2\x15\x12z\x11\x86l\x1e\xd1\xdf\xb04ZJ\x9b\xfa3\x0b\x9b\xe6\xbe\x8b\x95y\xbe\xe3\x9c\xef}Xo+'L\x93\xd9\xed\xda\xfc\x9b\xf7\xa7\xc4\x07\xc9\xc7\xaf\xe3;\xef\x03\x00PK\x07\x08\x18\xfe&bX\x01\x00\x00e\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x13\x00	\x000002_user_token.sqlUT\x05\x00\x01\x80Cm8\x84\x92Ao\xa30\x14\x84\xef\xfc\x8a\xb9\x05\xb4\x9b_\xc0\x89\x85\x97\xac\xb5\xc4d\x8d\xd1nzA\x14\xbb\x95\x15\x05\x101J\xda__\x05\x88B[\x91p\xe4\xcd|oF~\xcb%~\x1c\xcck[X\x8d\xacqBA\x81$\xc8\xe0WL\xe8\x8e\xba\xcdm\xbd\xd7\x15\\\x07\x00\x8c\xc2\xf5\xeb:\xa3\xc0\x13	\x9e\xc5\xf1\xcf~\xda\xcb\x8d\x9a\x99\xeascZ}\xcc\x0b\x0bk\x0e\xfah\x8bC\x83\x7fL\xfeN2	\xc96\x84\xa7\x84\xd3@*[]X\xad\x1eh\x11\xd1*\xc8b	\xb7\xaaO\xae\x87Q\x8d\xf7\xba\xd2Xt\xb6\\x\x03.Lx*E\xc0\xb8\x9cT\xca\x9b\xbd~\xc3V\xb0M v\xf8C;\xb8F\xdd5\xbc\\\x0c\xabD\x10[\xf3\xc106\xf6 hE\x82xH\xe9\xb0\xa1(\xcb\xba\xabl\x8fD\xc2\x11QL\x92\x10\x06i\x18Dt/\x95Q\xf9eM\xc6\xd9\xdf\x8cz\xbf\xe3\xf9\xd7ga<\xa2\xff\xd3HF\xe5F\x9d/\x1bn?\x91\xa5\x8c\xaf\xf1l[\xad{\xc0\xbc}\xcc\xff\x88q\xad9\x0f\xba=\xee#\xd6M\xe9\xf9\x8e3\xbd\xbe\xa8>UN$\x92\xedw\xfcP\xd3\x9f\x99NZ\xccI>\xe7\x1bU_\x8f\xdcw>\x06\x00PK\x07\x08J6\x19\x108\x01\x00\x00\x0d\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000003_vehicle.sqlUT\x05\x00\x01\x80Cm8\xb4\x93O\x8f\x9b0\x10\xc5\xef|\x8a\xb9-\xa8\xd9\xc3\xf6T)'\n\x93-*k\xb6\xc4\xa8\xdd^,\xaf=%V\xc1 c\x92n?}\x15\xf2\xa7i\x9aT\xbd,G\xfb\xf7\xde\x1b\xac7\xb7\xb7\xf0\xa65\xb5\x93\x9e\xa0\xea\x83\xa4\xc4\x98#\xf0\xf8}\x8e\xb0\xa6\x95Q\x0dA\x18\x00\x00\x18\x0d\x7f}\xe3h4\xb0\x82\x03\xab\xf2\x1cR\\\xc4U\xce\xa7SQ\x93\xa5\xad\xabX\xdf\xb5*\x8cf\x93\xc78\x90\x13F_\xf7\xd8a\xcfN\xda\xf34\xb5\x92N*O\x0e\xd6\xd2\xbd\x18[\x87wo\xdfEg\xc2\xb6\xd3\xd4\x9c\x88\xfe[\xd8\xcba [\x93\x1b~\x0b\x87V6\x8d\xb1~\x06\x13\xd2tR\x1b[\x0b\xe9H\x8a\x86l\xedW`\xc7\x96\x9cQ\x97\x88\x8d\xd1~\x05g\x84r$=i!\xfd1\x05\xbcii\xf0\xb2\xed\xe1s\xc6?\x14\x15\x07\x9e= |-\x18\x1e_4\xb4\xdd&\x8c@\xfa\x89\x86\x9f\x9d%\xb8\x19\xbd\xba9\xbck\xaf_\xc57)\xd8\x92\x97q\xc6\xf8\xa1\x0c\xa2\xffN/\xf0Xf\x0fq\xf9\x04\x1f\xf1	B\xa3\xaf\xd3\xdf\xb6\xf4\xa2(1\xbbg;z_\x81\x08J\\`\x89,\xc1\xe5\xae\x16R\xa9n\xb4~\xf2\x83\x82A\x8a9r\x84$^&q\x8aW\x13\x8c\x16\xdb\x8c\x8ae\x9f*\x84p\xaa\xcelW\x84(\x88\xe6\x87Fg,\xc5/\xc7\xb1\x8c\x16F\xff\xd8\xa6\xecO\xa0Zf\xec\x1e\x9e\xbd#\x9a&\xb8\"\xdcO\xffO\xf5\xe1\x0f\xe7Ap\xba]i\xb7\xb1AZ\x16\x8f\x17g\x99_\xba:I\xdb\xdf\xff\xb1\x98\xf3\xe0\xd7\x00PK\x07\x08\x04\xeeP\xab\x91\x01\x00\x00\xbe\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0d\x00	\x000004_trip.sqlUT\x05\x00\x01\x80Cm8\xacT\xcdn\x9b@\x10\xbe\xf3\x14s\x0b\xa8\xcd!\xe9\xa5\x92O\x14\xc6)*YR\xbc\xa8M/h\xbb\xbbuF\x0d\x0bZ/N\xd3\xa7\xaf\xf8\xb5-\xdbU\"\x85\x1b3\xdf\x1f\xb3\xc3^^\xc2\xbb\x8a\xd6V8\x0dE\xe3E9\x86\x1c\x81\x87\x9fR\x04g\xa9\x01\xdf\x03\x00 \x05\xf3\xd3\xb6\xa4\x80e\x1cX\x91\xa6\x10\xe32,R\xdeW\xcb\xb56\xba\x93*\xb7W\x95\xf4\x83\xf7=\xb7\xddh[\x92:\xe6\x0e\xed\xad~ \xf9\xa8{\xc4\x89\xb6%5\xd3\xbb\xf6@\xda8a\xdd\x98G>\x08+\xa4\xd3\x16\xb6\xc2>\x93Y\xfbW\xd7\x1f\x839\xe1@Pz\xe3\xc8\x08G\xb5y\x19\xa1\xb1$\xf5\xe8`\xdaJ[\x92;\x04\x8c\x9a\x8dp\xad\xedQ\x8e*\xbdq\xa2j\xe0[\xc2?g\x05\x07\x9e\xdc\"\xfc\xc8\x18\x0e\x01\x84\xb5\xb4\x15\x8f\xf0\"\xb0\xb4Z8\xadJ\xe1\xfe\x0b\x9e\x87\xef\x9b\xfa\xc9\x0f@\xb8\x1e\x0d\x7fk\xa3\xe1\xa2u\xf2b:\x82F\xbd\xa9^\x94\xb1\x15\xcf\xc3\x84\xf1~I\xca\xe6\xb7~\x86\xbb<\xb9\x0d\xf3{\xf8\x82\xf7\xe0\x93:\x03\xfd\xd5A\x97Y\x8e\xc9\x0d\x1b\xa0\xe3~\x04\x90\xe3\x12sd\x11\xae\x86\x9d\x11R\xd6\xadq\xbd\x18d\x0cbL\x91#D\xe1*\nc<\x9d\xa4\x93/\xaf\x0f\x0d\xa6\x15zC\x87\x0f\x87\x0e\xbb\x1d>\xf0\x18\xcb\xaf\xfa\x00Re7\xa1\x82%_\x0b\xec\x99^\xb0\x98\xfe\xcb\x84\xc5\xf8}\xc6\x91\xfa\xd3\x85\xee^\xa1X%\xec\x06~:\xab\x07\xbbS\x94q\xd0\xe7y\xd3I\x9c\"OC<\xcf\x9e\xc7\xbc\xf0\xbc\xfdk%\xae\x9f\x8c\x17\xe7\xd9\xdd\xbe\xdc \xb48\xaa\xefe<n\xeeg\x18\xbb\xbb\x9bj\xe1\xfd\x1b\x00PK\x07\x08\xc2\xbd\xf1\x82\xae\x01\x00\x00\xcc\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x000005_rating.sqlUT\x05\x00\x01\x80Cm8\xacT\xc1n\xd3@\x10\xbd\xfb+\xde\xad\xb6h\x0f-\x17\xa4\x9c\x8c=)\x16\xee\xba8kA\xb9\xac\x16{IW\xe0u\xb4]\xa7\x94\xafG\x8e\xd7!\x86$\x12\x129\xee{\xf3\xde\x9b\x99\x8c\xaf\xae\xf0\xaa\xd5k+\x9dB\xb5	\x92\x92bN\xe0\xf1\xdb\x9c`\xa5\xd3f\x8d0\x00\x00\xdd`\xfa\xf5\xbdn\xc0\n\x0eV\xe59RZ\xc6U\xcew\xafb\xad\x8c\x1a\xb4\xc4\xf6\xba\xad\xc3\xe8rW\xda?)+t\xf3W\xe9\x88\xca\xde=v#>\x13\x1eQg\xf5\xe6tm\xdd\xb5\xad2n@\xebGie\xed\x94\xc5V\xda\x17m\xd6\xe1\xf5\xcd\x9b\xe8\x0f\xfeV~\xef\xd5\xc0\x06L\xdf*\xab\xeb\xdf\x04\x8c\x8aVI\xa7\x1a!\x1d\x9cn\xd5\x93\x93\xed\x06\x1f3\xfe\xae\xa88xvG\xf8\\0\xda7\x1d\x9a\xee9\x8c\xe0\xd9\xf8\xd9\x19\x85\x8b\xde\xd5\x17\xbe\xf5\xa4`+^\xc6\x19\xe3~\x9ab\xf3M\xbd\xe0\xbe\xcc\xee\xe2\xf2\x01\xef\xe9\x01\xa1nN\x92\xbf\x0e\xe4eQRv\xcbF\xb2\x9fe\x84\x92\x96T\x12Kh5\xceW\xd6u\xd7\x1b\xb7\x93C\xc1\x90RN\x9c\x90\xc4\xab$N\xe9\x9c\x81\xb8\x99[\xec\x17\xf2_M^\xcfM\xfc^g\x16\xc3\xdb?\xe6\xd7\x8d\x18FT\xb1\xecCEgG\xb9\xefJx\xebY\xe1\x1e\xbd\x9c\xfeqQ\x10-\xa6s\xc8XJ\x9f\xa6\x9d\xe8F\xe8\xe6\xc7\xd0\xbd\xbf\x8fj\x95\xb1[|qV\xa9]\x82\xe3e~q\xe7j\xa7\xdd\x1e\x17\xf0\xb9\xce	L\xd1\x17Apx\xd7i\xf7l\x82\xb4,\xee\xe7\x82c#\x8b#\xc8A\xd6c\xf0A\x12\x0f\x1f~/\x16\xc1\xaf\x01\x00PK\x07\x081Un\xa4\x9e\x01\x00\x00T\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00 \x00	\x000006_user_email_verification.sqlUT\x05\x00\x01\x80Cm8\x8c\x92Ko\xd40\x14\x85\xf7\xf9\x15g\xd7D\xd0\x05\xac\x90\xb2\n\xc9\x9db\x91:\xc5q\x04e\x13\x99\xc4\x1d,\xc8C\x1eg\x1e\xfcz\x94\xc7h\xd0H\xa1\xe3\xad\xcfw\xbe\xab\xab{\x7f\x8f7\x8d\xd9Z\xe54\x8a\xde\x8b\x05E\x92 \xa3\x8f)a\xd8i[\xeaF\x99\xdf\xe5^[\xf3b*\xe5L\xd7\xc2\xf7\x00\xc0\xd48\xbfa05x&\xc1\x8b4};\xfdN\xac\xa9W~\xa7\xd21\x06T?\x95U\x95\xd3\x16{eO\xa6\xdd\xfa\xef\xde\x7f\x08\xae\xf3\xc7\xdeX\xbd+\x95\x833\x8d\xde9\xd5\xf4\xf8\xca\xe4\xa7\xac\x90\x90\xec\x91\xf0=\xe3t\x05UV+\xa7\xeb\xd7\xa0\x846Q\x91J\xf8mw\xf0\x03,i\xfc\xe9Z\x8d\xbb\xc1Uw\xc1\\\x17g<\x97\"b\\\xae-\xa6\xec\x7f\xe9\x13\x9e\x04{\x8c\xc43>\xd33|S\xdfN\xbf\x8c\xf4&\x13\xc4\x1e\xf8L/K\x0c hC\x82xL\xf9\xecVU\xd5\x0d\xad\x9b\xfa\x91q$\x94\x92$\xc4Q\x1eG	\xddl\\\xfa\xcbQ\\p\xf6\xa5\xa0\x8b\xd3\x0b\xc2\xf350\x9e\xd0\xb7\xd5\xb1\x17\xa04\xf5q\x1ce%\x86\"g\xfc\x01?\x9c\xd5\xfa\"	=\xef\xdf\x03L\xbaC\xeb%\"{\xba]\x19\xce\xf9\xff\x1el\xe8\xfd\x1d\x00PK\x07\x08\xab\xb0\x16%B\x01\x00\x00\xe6\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x16\x00	\x000007_user_deletion.sqlUT\x05\x00\x01\x80Cm8\xd4VQs\x9aL\x14}\xe7W\x9c7\xe3|\x901\xc9\xc37\x1d\xdb\xcePYS\xa6\x06Z\x84\xa6}bV\xd9\xc8N\x14\x1cX\x93\xe6\xdfw\x16\x16\x02\x89\x11M\xe2Cutd\xf7\xee=\xe7\xde{\xee]\x0d\x03\xff\xad\xf8\"\xa3\x82!Xk\x86\x01?fH\xf8\x12A`[\xe0\x11K\x04\xbf\xe1,\x07\x85HW\xb3\\\xa4	\xc3&g\x19\xe8|\x9en\x12\x01\x9aD\xb8c1\x9f/\xd9)\xaec\x96\x80\x96\x06<\x97\xfe\"\xb6d\x82E:\xe6\xe9j]\xfc\x84\xc8\xf8:/\xceeT\xf0d\x91\x83f\x0c\xb7l-0\xdb\x08d\x8c\xe69_$\xd22\x85\x88\x99tS\x83\xe7\xe0I.\x18\x8d\x90\xde`\xc6x\xb2\xa8 @\x97i\xb2\xc0=\x17\xb1<U\x908\xd5lgJ<\x1f\xb6\xe3\xbb\xc5JX\xf1>\xe1\x91\x0e\xb6\xa2|\xa9cM\xf3\xfc>\xcd\xa20\xa6y\xac#\xe2\xf9zI\x1f\xc2\x84\xae\x98\x8e\x19\xcfD\x1c\xd1\x07\x1d\xf4\x8e\n\x9a\xf5\xb5\x9f\xe6$ S\x9c\xf4\x06\x83\xf2ml\xf9\xaa^=\x1d=\xc5\xd0\x90\x04\xe4\xb3\xfcX\x8au\xb5v\xf6\xe1\xff\x81183\x06gr\xb7\xd7\x1f\xb6\xa8\xab\x0c\x97\xac\xe5\x91P\xd2\x9fe4\x89t\xac\xd2\x88-_A\xabz\xe8\xa2\xaf\xa8J^\x8a\x87\xa4\xa7\x99\x13\x9fx\xf0\xcd/\x13R\xd4T\x03\x80rq\xe4N\x82+\xa7\"\x8a)\xf1a\x91\xb1\x19L\xfc}Q\x9f;S\xd0\xef\xe6/\xe3\xd1\xdb\xd8Y\x9e\xfb\x1d#\xd7\x99\xfa\x9ei;~\x91\x83\xf0\xe6\x96=tl\x87\xe7]\x06\x17*|\xcb\xda\xea\x1fc\xd7#\xf6\xa5\x83o\xe47NT\x92\xfb\xf0\xc8\x98x\xc4\x19\x91\xe93\xa5\xf7\xe1:\xb0\xc8\x84\xf8\xa4\x19\xeen\x98\xf0\xbc\x0dT%\xec\x08H\x17m\xa4\xc7R\xb7\xb0\xd4\xf2\x0e\x98'\xaa,\xe7\xcb\x91uI7\"N\xdfUH%\xed\x1dRj\x18\x84\xe7;MjraQV\x1e\x85\xb5\xd3'\xeaj\xf8<\xae\xbeZ\xe4\xdbP5\xdb\xd7Hl\xa8\x8d<b\xfa\x04\x81c\xff\x08\x08l\xc7\"\xbf*\xb4\xady\x90~\xca}\x04S\xdb\xb9\xc4Ld\x8c5h\xe8PY\xeb\x179\xbe\xfeJ<\xd2\xa8\xf7\xc7\xcf{\xce\x8b\x9a\xda\x0b\x9cx\xf4\xa7\x93\x8b\x9c\xb8\xcd\xeb\xdaJ\xef\x13\xad\xa8\xfa\xcbN\x87;\x0d\x1a\x8a\x18jj8\x8c=\xf7JY\xaapU\xf9\xf1i\xcfX\xe1z\x8d\x14\xed{jxP\xdf\x16Q\xb5g\xd8\xf6\x8e|n\xf8\x1e\xbdv\xac\xce\x19\x99\xd3\x91i\x91.\x90\xb7w\xcd\x1e@[eR\xb5\xd6\xb6\x0e9\xe8\x0fAG\x01\x1f\xe7\x7f\x97e}\x89wW\xfa\x1f\xb9\x9ew\xd5\xa6\x0e\xe1\xa9\x04\x0e\xbe\x9a\xf7Cy\xd3\xb5\xac \x86Zk\xb6\xb4dYN\x98\x83\xc6\xc4\xdf\x01\x00PK\x07\x08\xfb)\x80\x8a\xb9\x02\x00\x00\xb2\x0c\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000008_user_privacy.sqlUT\x05\x00\x01\x80Cm8t\xce1\n\xc20\x00\x85\xe1=\xa7xt\xe9\xa0\xbd\x80\x9d\xa2\xa9Sl\xa5$sHC\xa8QLJ\x9aZD\xbc\xbb\xa0\x0eRp}\xff\xf0\xbe\xa2\xc0\xea\xea\xfa\xa8\x93\x85\x1c\x08\xe5\xa2j!\xe8\x96W\x98F\x1b\x956&L>\x11\x00\xa0\x8ca\xd7py\xa81Dw\xd3\xe6\x8e\xf3\x18|\x87\xba\x11\xa8%\xe7`\xd5\x9eJ.\x90?\xb2\xf1\x14f\xa5{\xab\xba\xa8\xcd\xc5\xa6l\x83\x14'\xbb\xc6\xa7D\x9d\x9c\xef\x17c\x8anP\xef\xbfox\xe6%!\xbfD\x16f\xff\x17	\xd66\xc7\x85\xb0$\xaf\x01\x00PK\x07\x08uN\x9a\xef\x97\x00\x00\x00\xe2\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x17\x00	\x000009_rating_summary.sqlUT\x05\x00\x01\x80Cm8\x94VMo\xe36\x10\xbd\xebW\xbc\x9b\xa5]G\xbb\xf9\xea%\xeb\x05\x14\x9b\xde\xa8u\xa4@\x92\x1b\xa4\x17\x83\x90h\x99X}\x18$e\xaf\xfb\xeb\x0bR\x1fu\xe2\x14utH\xc63\xef\x8d83o@]\\\xe0s\xc9sA\x15\xc3rkM#\xe2%\x04\x89w\xbf \x10T\xf1*_\xc9\xa6,\xa98\xc0\xb6\x00\xa0\x91L\xacxf\xcc\x86g\x08\xc2\x04\xc1r\xb1\x18\x9b\xa8\xa8\x0b\xa6\xff\x03H7T\xd0T1\x81\x1d\x15\x07^\xe5\xf6\xe5o\xce\x1bxZ7\x95\xd2`\x80W\x8a\xe5L\x0c\x00\xcc\xc8\xdc[.\x12|m\xa1\xaaV\xb40HTM\xc9\x04O\xff\x13*\x15\x15ruyN\xd6\x16zu>\xf4\xfa|\xe8\xcd\xf9\xd0\xdbs\xa0\xcd6\xa3\x8ae+\xaa\xa0x\xc9\xa4\xa2\xe5\x16\xcf~\xf2\x10.\x13$\xfe#\xc1_a@\x06\x96]\xd5{\xdbA\x87\xc6\xdfu\xc50jT:r\xdat\xd30\x88\x93\xc8\xf3\x83\xe4\xcd\xa0W\xdb\x9f\xec\x80\xa7\xc8\x7f\xf4\xa2\x17\xfcA^`wC\x1f\x9b\xf9\xfe/\x7f\xad\xf9\xf30\"\xfe\x8f\xe0\x15\xdfAD\xe6$\"\xc1\x94\xc4\xad\x90h\xdaJ\xc0\xe6\x99\x830\xc0\x8c,HB0\xf5\xe2\xa97#\x96sgY\x17\x17\xb8\xa7\xe9\xcf5/\n\xa8\x0dC+G\xce$\xd6\xa2.\x8d\x8b\xfd\xe2RW\xd0\x1dD\xba\xf0:\x139\xdf\xb1\n\xaa\xd68\x9d\xaa\xdeWL\xa0^\xeb\xdf\x1a\xc32(\xc1\xb7\xe0\x12\xb4\xe7P\x89L\xf0\x1d\x13c\xb0\x1d\x13\x07\xd4j\xa3I\x15\xd3!\xc13&\\\xcb\x0fb\x12%\xf0\x83$<Y\x94W\xed\x1a\xb7*\x1f\xb7\n\x1ew\x13\xbf\xec\x8d\xab\xde\xb8\xee\x8d\x9b\xde\xb8u\xac\x98,\xc84\x81p\xfb\x94V\xb7]S/&x~ \xc1\xbf1L\xa0\x06;\xd1\xa1Q[\xc6\x08d\x11\x13\x8c\xcc\xc9G \xc1\x0c^lF9\xa43G\xb4?u\xa3\xd5\xbalJ[\xb8;Z4\xfd\xbc\x8fP\x98\xfb\x8b\x84D\xb0\x9f\x1fHD \xea\xa6\xca\x064\xbeMp\xf9Q\xce\x04W\x1f\xa7\\\x7f\x9cr\xf3Q\xca\xf7	n\x1dk\x1e\x85\x8f\xbd:\x84\xf5{\xe8\x07\xadj\x94\xd6\xacrM\xef\x85\xab]+\x9eY]W\x86Y|\xfb\x8e\xd1\xd7\xee\xb9x\xe7O\xff\x8c\xac\x1fQ\xb8|\xc2\xfd\x0b.\xc7\xb8j\xb5\x9fh\xc9\xa7\xb5`Z\xa2Z\xb4\xf7\xf4\xc0$\xa7\x15\xe8\x8e	\x9a3\xd4kP\xb3M\xb2;\xa2\x04\xaf\xb4\x9a\xeb\x82\xb9\xf0\x15\xa8\x94M\xc9\xa4\x96\xff\x9a\xef\x18\xb6\x82\xd7b\xc0v\xbbP2Zu>\xed\xa2E\xd1\xe5\xe4\x15\xd4\x86\xaaN\xcb\xfb\x0dO7:\xd3V\xd4\x8a\xa5J\x82\xe6\x94WRa\xcd\xf6`\xbf\x94`%\xeb\xf2H\xb7\xbfO\xfe\xf4\xc9s\xe7\\\xb5\xc5xq\xafm\xd9\xf7i\x98\x8ct_iS\xbaFwGa\xb3I\xf8\x82\xaa)\n\xbe\xb6{\x00\xbe:\xf0bS\xc9\x00\xb6{\xf4g\xdc\xe2\x13rWG\x1d|A\xcf\xd2\x01C3\xe7:zI\xbf\xa7o=W'\x9e\xeb\x13\xcf\xcd\x89\xe7\xf6XC\xc3\x95*[-\xb57k\xd7\x0fS;\xd2\x9a\x16L\xa6\xcc\xd6\x8bh\xeau\x8e\nnJ\xdb\x1c\xde\xd15\x1f\xd7m\x12\xbd\xf3&\xe3\x1f\xd4\xa5_a9\xc8\xb5zs\xd3lL\xba\xae\xb7\xa2\x1b>	f\xf5\xbe\xb2fQ\xf8t:\xc2\xbb\xd6\xff\xde\xa7\xc2\x9d\xf5\xcf\x00PK\x07\x08\xe8\xb25EN\x03\x00\x00W\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x000010_rating_role.sqlUT\x05\x00\x01\x80Cm8l\x8f=k\xc30\x10\x86w\xfd\x8awsK\x89\xa1K\x17\x93A\x8d\xaetP\xe4`\xcbt\x0c\"\x12\xeeA\xeb\x84\xab\x9a\xd2\x7f_\xe4B\xf0\xe0\xed>\x1f\x9ew\xb3\xc1\xc3'\x8f\x12r\xc2pQ\xdaz\xea\xe0\xf5\xb3%H\xc8<\x8d\xd0\xc6`\xd7\xdaa\xef \xe7\x8f\x84\xd3{\x90p\xcaIp\x0d\xf2\xcb\xd3x\xf7\xf8t\xdf\xa8\xe1`\xb4\xbf=	z\xf2\xff\xf7[\xectOx{%\x07\xa9\xbf\xbf\x92\x1c9b\x8b|\xab}YUQ\xf8\x9a\xa4\x02\xd9\x9eP	\xc7\xb9q\x06/]\xbbG\x16\xbe \x17JG\xc8\xf5L\x90\xbaL\x8f\x1c\x9bU\xef9\xca\xd2\xbc(\xb9\xd6\xc3\x0d\xd66J-\xa3\x9b\xf3\xcf\xb4\x061]{X2\x1a\xf57\x00PK\x07\x08\x8d\x1f\x04\x81\xbf\x00\x00\x001\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x000011_rating_moderation.sqlUT\x05\x00\x01\x80Cm8\x9c\x94\xcfr\x9b0\x10\xc6\xef<\xc5\xde\x0cS\xe7\xe0t\xda\x0b'\nr\xca\x14\x8b\x14\x8bi\xd3\x0b\xa3\xc0\xd6\xd5\xd4F\x8c\x10N\xdd\xa7\xef\x00J\x0c\xfe?\xe5\xc6j\xf7\xf7\xad>I{w\x07\xef6b\xa5\xb8FH+\xcb\x8b\x18I\x80y\x9f\"\x02M\x8d*\xe3y.\x9bR\x83\x17\x04\xe0\xc7Q\xba\xa0\xa0\xe4\x1a!\xff\xc5\x15\xcf5*\xd8r\xb5\x13\xe5\xca\x9e}t\x80\xc6\x0ch\x1aE\x10\x90\xb9\x97F\x0c&-d\xe2Z#\xb0\xe2Z\x94+\x0b\x00\x86\xd8Zs\xdd\xd4m\xf0f\xf8V\xd4\xe2y\x8d\x93\xe9!Ka\xb5\xde\xc1\x19\xd6\x87\xd9\xbds\xb2B`\x91q\x0dZl\xb0\xd6|S\xc1\xb7\x90}\x8eS\x06,\\\x10\xf8\x11S\xe2Z\x96\x9f\x10\x8f\x11cQ\xbf\x93La%\x95\x06\xbb\x83\x8a\x02\xf6_\xd3\x88\xe2\xb8\xf16\x9a\xad\xb0\xc4\xd6\xf6l;\xdb\xe4\xb6\xe9\xc8\x00EqTl\xd6;%T]\xc6\xc9u^\xcb\x12.m\xfd\x808\xb0\xfdv\xe3e\x85\xa5q}#\x8bv\x1b\xb2k\xa9\xed\xa8\x0f\xe7\n\xb9\xee\xfd\x84K\x8e\xbe1\xedR\xbe\xd8\x0e\x18\xff\xe1\xaf,\x11&\x8d\xce'\xaf\xce`-\xd7[C\xbc\x00\xec\xb3\xfd\x98.Y\xe2\x85\x94\x8d\xcf(\xab~\xe3\x0e\x1e\x93p\xe1%O\xf0\x85<\x81-\n\xe7J\xcd\xcf\xb6f\x1e'$|\xa0}\x8dY\x17\x85\x03	\x99\x93\x84P\x9f,\x8dRG\x84\x98B@\"\xc2\x08\xf8\xde\xd2\xf7\x82k}\xb5\x1a\xd9\xfd\x81\xca\xfe\xb0G:\xa3\x87\xf9\xffj\xef\xc7j\xc3\x83\xbcYnI\x86\xb7\xf3\xac\xebo~\x99@w\x81\xb3\xd6\xd6\x94\x86_S2pt:\xbc\xe3\x8e\xe5\xb8\xaf/.\xa4\x01\xf9~\xb0\x0f\xf3'\x8aL\x14\x7f\xda\xb6F\xcb\x90.C\xfa\x00\xcfZ!\x0e\x14.#\xfb\xf7p\x03\xafOt\\\xcb\x1a\xce\xd0@\xbe\x94V\x90\xc4\x8f\xd7\xfbu\xcf\xe7\xed\x9b0I'\xc6\x8d{n\xa6v\xd4\xd1P\x9d\x1e\xc5\xbb\x01y:,\xba76\xa6\x8fn\xdc\x88#\xd7\xe8Z\xff\x06\x00PK\x07\x08\x8ao\x85\xd8\x04\x02\x00\x00E\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000012_message.sqlUT\x05\x00\x01\x80Cm8\xb4\x94Ao\x9b@\x10\x85\xef\xfc\x8aw3\xa8\xa6J\xa2\xde|\xa2f\x9d\xa2:\x10\xe1\xb5\xda\xf4\x8260\xb1W\x95Y\xb4,v\xdd__\x81\x17\x1a\xbb\xb4\x89Te\x0f\x1cvf\xde\xcc<>\xad\xef\xe3\xddNn\xb40\x84u\xe5\xccS\x16p\x06\x1e|\\2\xec\xa8\xae\xc5\x86\xe0:\x00 \x0b\xf4\xa7id\x818\xe1\x88\xd7\xcb%B\xb6\x08\xd6K\xde\xddf\x1b*\xa9\x15\xcb\xf6\xd7\xbb\xdc\xf5\xa6]\xa9\xd1\xb2\xcad\xf1G\xe9)*\x1a\xb3U\xba\x8b\x8f\x0bO\xae\xec\xf1G>\xfd\x99\x9c\xc4\x1eUq\xb4S\xe6[\xa1EnHc/\xf4Q\x96\x1b\xf7\xfa\xea\xe6\x83w\xd1=\xd7$\x0c\x15\x9900rG\xb5\x11\xbb\n_\"\xfe)Ys\xf0\xe8\x8e\xe1[\x12\xb3aI\xb7T\x07\xd7\x83\xcd\xc6OU\x12&\x8d\xc9'v\xd5y\x12\xafx\x1aD1\xef\xed\xcb\xaa\xeft\xc4}\x1a\xdd\x05\xe9\x03>\xb3\x07\xb8\xb2\xf8{\xf6S\x9b\xbdHR\x16\xdd\xc6\xa7l\xeb\x9e\x87\x94-X\xca\xe29[\xa1\xbd\xebt\x90\xc4\x08\xd9\x92q\x86y\xb0\x9a\x07!\xfb\xa7rvs\xae=x\x7f\xa6\xde\xd4\xa43\x91\xe7\xaa)\xcde\x97\x15\xe3\xbd\x19\x8e7\xeb\x81\x89\xe2\x90}\x1dv\xb0\x13g\xbf\xbd\xcdd\xf1\xa3\x1d\xd5f`\xbd\x8a\xe2[<\x1aM4,8}\xf6/\xbc\x99\xe3\xf8>\x82\xa1@SN\xb22\xa8\x8d\xd2T\xa3\xa9`\x14\x0e[*!P	md.+Q\x1alE\x0dM\xa2\x80\xd9\x12rU\xeeI\xd7\xc2HU:\xbe\x0f\xf5\x04\x81\xb6\xdb\xfbQ\xd0\xb3\xbe\x89{F\xed\x19\x94\xd3.\xd4\x194\x1ej\x9b\xbf\x04S_\xf1?T\xd9YG\xe8\x1a\xec\xb4S\xbe\xac\xf1v\xcc=\xefp\xc9^?\xdek\xc9\xb3|;\x96\x8d\xe1\xd9\n\xd5\xa1t\xc24\xb9?\x7f\xb6\xfa\xde\xb3S\xec5\x84\xceFdf\xce\xaf\x01\x00PK\x07\x08\xaf\xfcX\xab\xdc\x01\x00\x00#\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000013_notification.sqlUT\x05\x00\x01\x80Cm8\x8cSA\x93\x930\x18\xbd\xf3+\xdep)\x8c\xdd\x83u\xf4PN\x08\xe9\xcaH\xc3\x0e\x0d\xa3\xeb\x85I![\xa3%0i\xd8\xdd\xea\xf8\xdf\x1d(\xbb\x96\xad\xadrb\x92\xf7\xf2\xde\xf7\x92wu\x85W\x95\xdchn\x04\xb2\xc6\xf2cFR0\xff}L\xd0\xee\x84\xceyQ\xd4\xad2\x16\x00\xf8a\x88 \x89\xb3%\x85\xaa\x8d\xbc\x93\x057\xb2V;|\xdb\xd5j\x0d\x9a0\xd0,\x8e\x11\x92\x85\x9f\xc5\x0c\x93\x9f\xb6T\xeb\xfa\xd1\x9e\xc3\xe8VLa\x8b\x8a\xcb\xad=\x87\xd1\xad\x98\xc2\xder\xb5i\xf9F\xd8s\xd8B\xd9\xbf&\x9ee\x05)\xf1\x19\x19,\x1c\xcb\xc0\xe9M\xc8\x12O_\xdb\xca\xf2T\xb5[\xcd7B\x89n\xa6\xfc\xfeuU8\xee\xb4\xa7\xf6\x03\xc9\xf2\x84z\xd85Z6\x17v\xf7\x8d\x18d\x8b\xaf\\\xf3\xc2\x08\x8d{\xae\xf7Rm\x9c73\xf7%\\\x9a\xad8\x07\x9f\xbd}\xf7\x12\xaf\x05/sn\xba_#+\xb13\xbcj\xf0)b\x1f\x92\x8c\x81EK\x82/	%\x87\xb3\x0b-\xb8\x11=\xfc\x02\xf69\x0fG\xd5\x0f\x8e\x8b\x01\x8d\x1f\xb5\x12\x98\xb4\xa6\x98\x0c\xa9\x04	]\xb1\xd4\x8f(\x1b\xc5\x9d7\xdf\xc5\x1e7i\xb4\xf4\xd3[|$\xb7pd\xf9\x0f\xca]GY$)\x89\xae\xe9\x812D\xee\"%\x0b\x92\x12\x1a\x90\xd5\xe8]\xf5\x87\"\xa1\x08IL\x18A\xe0\xaf\x02?$\x97\x9du2\xf9l,4\xdc\xdeH\xa8[;#`\xb9\xde\xd3K\x8bhH>\x8f\xe7\x18\\\xe7\x7f\x92\xcee\xf9\xd8\xd9<\x86![E\xf4\x1ak\xa3\x85x\x9etzt=\xaegY\xc7\xfd\n\xeb\x07e\x85ir\xf3\xff\x9a\xde\x01\x7f\xda\x06\xeflU\xd13\xfe\xd6S\xcf\xfa=\x00PK\x07\x08\x89.\xfb*\xb5\x01\x00\x00\xee\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000014_webhook.sqlUT\x05\x00\x01\x80Cm8\xac\x95]o\x9b0\x18\x85\xef\xf9\x15\xef]@k\xa5\xb6\xab\xaaI\xd1.Xp[\xb4\x14*B\xd4u\xd3\x84\x1cx\x97\xb2&62&\x1f\xfb\xf5S\x82\x0d)\x1f\xdd\xb25W\x919\xe7\xd8\xd8\xcf1\xa7\xa7\xf0n\x99\xce\x05\x95\x08\xd3\xcc\x18\x05\xc4\x0e	\x84\xf6\xa71\x815\xce\x9e8\x7f\x06\xd3\x00\x00H\x13\xd0\xbf\xa2H\x13\xf0\xfc\x10\xbc\xe9x\x0c\x0e\xb9\xb6\xa7\xe3p?\x1a\xcd\x91\xe1.,Z\x9d/c\xd3:\xd9[\x8b\x1cE\x94&-\xabz*\x16:8~\xa2\x82\xc6\x12\x05\xac\xa8\xd8\xa6ln^\x9c]~\xb0\x1a\x86\x1cc\x81\xb2\xc7pu\xd9\x94\xe3\n\x99\xccw\xff\xe0g\xce\xd9\xac\xbd\xf2\xc1\xb7\xef\x832:\x16H%&\x11\x95 \xd3%\xe6\x92.3xp\xc3[\x7f\x1aB\xe8\xde\x11\xf8\xea{\xa4ze\x93\xf1\xb5i\x81R\xc3/\xce\x10\x06\x85\x8c\x07\xfa\xc5\xb3\xe4-\xe3F\xbe7	\x03\xdb\xf5B}6Q\xf6\x8c[\xb8\x0f\xdc;;x\x84\xcf\xe4\x11\xcc4\xe9W\xff\xd8\xa9\xaf\xfd\x80\xb87^\xa9VGcA@\xaeI@\xbc\x11\x99\x94\xc7E\xe3\x98\x17L\xee\xf3\xc0\xf7\xc0!c\x12\x12\x18\xd9\x93\x91\xed\x10\xc3\x1ajV\\\xcf!_\xaa\x19T^\x94&\x9b\x9dK\x0d\xc3t\xe2z70\x93\x02\xb1\x9esht\xf2\x16%\xb8HW(\xb6m\xf0\xfe\x89>\x1d\x9b&\x1d\xfe\x03D*A'\xa5\xa5Dn3\xecC\xf5\xfdE\x93\xbc\x8cn\x17\x9cV\xa9 q#\x1b\x92\\RY\xe4Z\xd0\x05\xf4\xf9U\x1d[\x912\xc8\x90%)\x9b+l\xa9\x94\xb8\xcc\x14\xe4\xbb\xae2\x89s\x14m\xdfY\xa9\x17\x98g\x9c\xe5\x18\xc5<\xc1Z\xaf6C\x08.t\x92Zu\xf9\x84\xe1FFj\xae\xb7\xaa\x88:\xec\xb2s\x00\xafe\xb6*\n\x7f0\xfcO\xb14\x84G6\xac\xb2\xb5\xabVs\xf8\xa2mj\xf8\xd8\xa2U\x13\xd5\xb1Q}{5\xfaW\xa9_\x16\xb1\xb6\x9e\x1clk_\xb5uF\xd4\xc0\xe0\xef\xe6j\x98,x\xb8%\x01\xd1\xfc\x7f\xac\x89\x1e\x1a\xc6\xe1G\xc9\xe1kf8\x81\x7f\x7f\xc4j\x86\xaf\x1az7L\xd9\xba/\xa3\xceLu\x93\xf5\x99\x87\xc6\xef\x01\x00PK\x07\x08\xb3zB\xe2+\x02\x00\x00_\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x000015_outbox.sqlUT\x05\x00\x01\x80Cm8\xb4UAs\xa2L\x10\xbd\xf3+\xfa\x16\xa9OS_\xed\x1e=\xb12I\xa85\x90E\xa8l\xf6B\x8d\xd0\xeaTp\x86\x9a\x194\xec\xaf\xdf\x1ad\x14\x8cer\xd8\xf5\xa4\xf0\xfa\xcd\xeb~\xaf\xc7\xc9\x04\xfe\xdb\xb2\xb5\xa4\x1a!\xad\x9c\xc9\x04\xc8\x0e\xb9V@%\xc2^2\xad\x91\x83\x16\xa07\x08\xa2\xd6K\xf1\x06\x8c\xb7\xbf\x14\xdd\"hI\xb9\xa2\xb9f\x82\x03U\xed\xf3|C\xf9\x1a\xcd\xd7\xc6\xd0\x15\xa8r\xc9\x96x\x0b\xc9\x06AbI\x1b\xa8\xeae\xc9\xd4\x06\xdb\x82\xad\xa1\xa7e	\xaa^\x1e\xa0R\x01\xe5\x05H\xcc\x85,\x14 \xcd7\x86I\xd5y\x8eJ\xad\xea\x12\n,\xd9\x0ee3\x06%\x80\xf6*M\x11\xb2\x1d\x1a\x06@\xd3	P\x0d%R\xa5A\xf0\x1co\x9dYL\xbc\x84@\xe2}\x9b\x13\xdb\xd1\xc8\x01\x00`\x05\x0c>u\xcd\n\x08\xa3\x04\xc2t>\x1f\xb7\x10\xddT\xd8C\x98^%\xcd5J\xd8Q\xd90\xbe\x1e}\xfd\xe2\x9e\xd5\xd4\ne\xc6\x8a\xab\xb4\x92U\x1f@\xa8\xd6\xb8\xad\xb4\xea\x10\xc0\xb8\xc65\xca\xe3Y\xe0\x93;/\x9d'\xf0\xff\xe1T\x8eo:\xeb\x8a2\xaaA\xb3-*M\xb7\x15<\x07\xc9C\x94&\x90\x04\x8f\x04~E!9\x96\x8e\xb8\xd8\x8f\\\xe8\xd0\xf0[p\x84\x9bZ\xe77\xee\x81\xd3\xdaV\x18B\x80k\x9c\x87\x82\\\"\xd5G8\xfc\x15\x11\xb3(\\$\xb1\x17\x84I\xe7^V\xbdb\x03Oq\xf0\xe8\xc5/\xf0\x9d\xbc\xc0\x88\x15\xae\xe3N\xad\xd5A\xe8\x93\x9f\x16|6\x97\x8c\x15o\x10\x85\xdd[H\x17Ax\x0fK-\x11at\x06u\xe1\xf9\x81\xc4d8\x85`\xd1\xa6c\xea\\\xcaUfc\xda\x05\xac\xcd\xa3\xf5\xf9B\xbaz1\xfe\\\xb4:\xfe\xc3\x84\xff\xcdpm\x0b\x17\xa6l\xdb\x19\xf7\x84\x7f\xcc\xb32n\xddE1	\xee\xc3!\x8f\x0b1\xb9#1	gda\x0d1V\x1a\x7f|2'	\x81\x99\xb7\x98y>1\xe6\x9a\x1baq<W\x9953\xb7I\xb7\xf4\x81o\xae#V\xe0\xb6\x12\x1ay\xde\xc0+6\xb7\x8e7OH\xdc\xed>\x17\x9a\xadXN\xdb\xbb\xcb\xf3}\x98E\xf3\xf41<\xb9d\x1c:\x86(\x0d\x83\x1f\xa9\xcdR\xbf4\xb3\xf8\xcc4\x16\x85C\xdeA\xa0,\xd2\xa8\xef+\xd9\xe3r#\xc4\xeb).=5\x12\x8fO\x97B\x94H\xf9\xfb\x95_\xd1R\xe1e\xa5\xe7\xd4\x99}\xc0\x8aw\xc2\xcf\xb1C\xf1\xa7\xc2\xf1qDn\x1b\x9a\xc3Z\x18U'\xb1S\xc7\xe9\xff\xb3\xf8b\xcf\x1d?\x8e\x9e\xba\x01~V\xd6\xf4\xfa\x9cZ\xc6\xce\xb6\xfe\xd9\xbd\x93\xfan\\\xa1\x1e\x98\xd6\xa7\xb5%\x1d\xe9\xc5\xf5\xee\xde}t\xcf\\\xa0\x98:\x7f\x06\x00PK\x07\x08~\x06%\x97\xa6\x02\x00\x00\x80\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0c\x00	\x000016_job.sqlUT\x05\x00\x01\x80Cm8\x94\x94Ao\xdaN\x10\xc5\xef\xfe\x14\xef\x16\xd0?D\xfaWUU\x89\xe6\xe0\x04\xab\xb1J\xec\xc8\x18\xa5\xe9\x05-\xf6\x807\x98]kv\x1c\xa0\x9f\xbe\xb2cZh\x12\x95\xf8f\xcf\xfc\xde\xcc\xee\xbc\xf1`\x80\xff\xd6z\xc9J\x08\xd3\xca\x1b\x0cp\xa5\xb2\xd5\x92mmr<\xda\xb9\x83bBf-\xe7\xda(\xa1\x1c*c\xeb\x1c\x1c\xf1\x131\xb4q\xa2LF\x0eR\xb0\xad\x97\x05JR\x8e\xdc\x05|\xd3\xa8\xed\xe3\xe0\xda8\xa8F\x12\xd6\x94;\xa8\x85\x10C\xd4J\x9b%\xb4\xb8g\xee\x1c\x9bBg\x05h[i&\x07k\xda\x98\xdd\x18\xe8E\xa3'\x05\xfd\xd1\xccX\xb9\x82\xdc\x85w\x9d\x04~\x1a \xf5\xaf\xc6A[\xa2\xe7\x01\x80Qk\xc2\xe1\x93\x15\x8aU\xd6\x14~R\xbc\xd3f\xd9\xfb\xf4\xb1\x8f(N\x11M\xc7\xe3\xf3\x16rYAy]\xd2\xbb\xa0\xd2f+\xcag\xf3\xdd\xdb\xd0\xff\x1f>\xf7\x8f\x92k#\xbal\x93E\xaf\xc9\x89ZW\xb8\x0f\xd3\x9bx\x9a\"\x0do\x03\xfc\x88\xa3\xa0#\x94\x93\x19\xd7f\xa6\x04\xef \x16\xdahWP\xde`\xa7\x11\xc4l\xb9;\x82\xd0V\x9eC\x86\xb6'\x97\xff\xeb6\xaf\xe3h\x92&~\x18\xa5\xcd\\f\xd5\x8av\xb8K\xc2[?y\xc0\xb7\xe0\x01\xbdfF}\xaf?\xf4<\x7f\x9c\x06I7Ca]\xb5\xa5\xfd\xd1\x08\xd7\xf1xz\x1b\x81i\xadM~\xe2i\x0e\xb8\xcc\xae\xab\x92\xe4\x9f\xe0po\xa30\x1a\x05\xdf\xdb\x16f9UJj\xa6\x99\xce\xb7\x88\xa3\xf6#\xa6\x930\xfa\x8a\xb90\x11z\xfb\x8c>\xeeo\x82$8\xea2\x9c\xb4\xbezMX1\xeb'U\xbe\xad\xdb%\xece\x8f\x0e\xf1[\xb7\xd9\x88\x94u\xe5\xba\xb5i!\xca\xa1M\xbb(\x95r\xd2\xedoGc\xa3\xa5\xb0\xb5\xc0X\xd1\x8b\xc6\x97Pfg\x0d]x\xd3\xbbQ\xb3CMw\x98\x04\xe9q\xc5Kt\xfdt\xed\xec\xdf\xbe\\\xa2g\xec\xa6\xd7Gg1\xfc\xb4\x86pVKv\xd6\xcc\xf4\xf0\xf72\xb2\x1b\xe3\x8d\x92\xf8\xee\x8d{\x18\xbe\x08\x1e\xde\xfe\xf0u\x7f\xb4Hg\x90\xc3\x8e\xcf_D\x0f\x06\xd3UJ\xfd\xabq\x80G;\x1fz\xbf\x06\x00PK\x07\x08V\x13\x1bZ#\x02\x00\x00\x07\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1a\x00	\x000017_trip_cancellation.sqlUT\x05\x00\x01\x80Cm8\x8cT]o\x9b0\x14}\xe7W\x9c\xb7$ZS\xa9\x95\xb6\x97<\xb1\xe0vh\x14*\x02\xda\xba\x17\xe4\xe0\x9b\xc6\x1a\xb1\x911\xc9\xb2_?\xf1\x91\xaa)e\x8d\x1f\xeds\xee9\xf7\x1e\xdb\xf39>\xed\xe4\xb3\xe1\x96\x90\x96\x8e\x1b$,F\xe2~\x0d\x18\xac\x91\xa5\x03\x00\xae\xe7a\x19\x05\xe9C\x88\x9c\xab\x9c\x8a\x82D\xc6-\xac\xdcQe\xf9\xae\xc4\x0f?\xf9\x16\xa5	\x12\xff\x81\xe1W\x14\xb2\xab\x11\"\xb7R\xab\xcc\x10\xaf\xb4B\xbe\xe5\x86\xe7\x96\x0c\xf6\xdc\x1c\xa5z\x9e~\xbe\xb9\x9d-\x1cg>\x07\xdb\x939\x9e\xe4Z\x16\xd6Gp\x08#\xf7d\xa0\x0d\x8c\x14d +\x18\xca\xb5\x11$`5r\xbd+kKg\xc4\xa6\\\xd3_u\x8d\x80\xbf9\xab\xb0\xe5eI\x8a\x04\x0e\xd2n\xa5\x82\xdd\x126\x86\xcea8H%\xf4\xa1\xa9\xb4\xa6\x8d6\x04A%7\xb66t\xed,c\xe6&\xec\xd5\xd0\xb23\xea\xb4\x1d\x85\x148\xad\xba\x96\x02a\x94 L\x83\x00\x1e\xbbs\xd3 iw\xb3gR\xd4X\xcd\xf67\xbb|:\xbbj\xa9M\x0e\x99\x14\x03jwZWd\xc6O\x8d.\xa8\x97\x1dN\xfb\xe6\xcb\xec-\xbc\x0b\xe6}x\x13\xce\xd0\xf7d\xd2Q\x8bf\xb4\xddZk]\x10WC\xec\x86\x17\x15u\xf0\xdc\x10\xb7\x1f^\xa3\x97\xe9L\x95>Lg\xe8/\x1d\xfejE\x98\xd46\x9f\xf43ZF\xe1*\x89]?L\x86	d\xe5o:\xe21\xf6\x1f\xdc\xf8	\xdf\xd9\x13\xa6R\\\xc2\xdb4\xbc\xbb(f\xfe}\xd8\xf1\xfa(f\x88\xd9\x1d\x8bY\xb8d\xabV\xaf\xad\x88(\x84\xc7\x02\x960,\xdd\xd5\xd2\xf5\xd8\x05\xde\x1a\x8d\xec\xf6\\\xa5\x8f\xf4L\xa5\xdd\xe3y\xaekeG\xd4\x9c\xd9\xe2t\x17\xfd\xd0c?\xdf\xe9\xa8\xf7\x9fI\xf1\xa7q;\x00 ]\xf9\xe1=\xd6\xb6y\x00/\xdd~X\xb67|a\xd9S{\xddC\x7f\xf9}<}P\x8e\x17G\x8f\x97\xc8,\xfe\x8f\xec\x9d\xbfB\x8e\xbc\xce\xc5\xfb?^K\x19\xff\xb9\xae\xc6@$2n\x17\xce\xbf\x01\x00PK\x07\x08\xe0\xa2t\x8e\x06\x02\x00\x00U\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000018_booking.sqlUT\x05\x00\x01\x80Cm8\x84TAs\xb2H\x10\xbd\xf3+\xdeM\xad\xc5T}{\xd8\x8b\xb5\x07>\x19\x13j\x11\xb2\x08\x95\xcd^\xa8	tt*:\xb0\xc3\xa0q\x7f\xfdW\xa3#\x9a\x18\"'\xaa\xe7\xf5\xeb\xd7=oz<\xc6o\x1b\xb1T\\\x13\xb2\xda\xf1\xc2\x94%H\xbd\x9f!\x83V\xa2\x86\xe7\xfb\x98\xc6a6\x8f\xc0\xebZU[\xbe\xce\x15\xfd\xd7\nE%^\xaajM\\\"\x8aSDY\x18\xc2g3/\x0bS\xbc\xf2uC\x13\xc7\x19\x8f\xf1\xb3\xaa\xde\x84\\6\xa8^\x0f\x8c\x0dv+Q\xac`I:VpE\xa8I\x96B.\xd1J-\xd6\xd0+B\xa9\xc4\x96\x943\x1e\x83\x17\x05\xd5\xbaA\xa5PR\xb1\x16\x92\x1a\x83\xd8\xb8&\xa2W\xb4\x07\xbd\xd7B\xd1\x1d\x02\xd9h.\xb5\x91w,m\xa8\x8f\xe9T\x1a*%\x96+\x0d\xbe\xe3\xfb;g\x9a0/e\xb6e\x9b\x81\xa1\x03\x00\xa2\xc4\xe9k[Q^\xb7i\xa2\xf9\x92$\x99\xf1\xe5\xdb\x1f\x9bb8r\x0f\xa9\xa6\xd3\\\x94W\xa9\xc7\xd3\xb6!\xd5\x7f\xdah\xae\xdb\xc6\x1c\xa2Xq\xc5\x0bM\n[\xae\xf6B.\x87?\xfe\x18u\xf0N\xc7\xc0\xcemp\xa4?\xce\xa1\xc9\xb9\x86\x16\x1bj4\xdf\xd4x\n\xd2\x878K\x91\x06s\x86\x7f\xe3\x88}\xaaZR!J*o$\x1d\xb1\x85\"\xaeob;yCY\xed\x86#Xf\xfc_I\xc2\xa0\xd5\xc5\xc0\x0ek\x1aG\x8b4\xf1\x82(=]@^\xbf\xd1\x1e\x8fI0\xf7\x92g\xfc\xc5\x9e1\x14e?\xfa\xd5\xa0gq\xc2\x82\xfb\xe8\x88\xb6\xf3\x1f!a3\x96\xb0h\xca\x160\xb1\x03\x0f\xe2\x08>\x0bY\xca0\xf5\x16S\xcfg\xbd:\x0cs\xfe\xfbGn{{\x1f\xb8\x0f1^\x14U+uO\x0dg49\x99-\x88|\xf6O\xa7\xdej\xcdE\xf9n\x94\xd90\xb2E\x10\xdd\xe3E+\xa2s?=\x14\xe7\x1b\xff\x96\xe5\x0c\x1b\xe1\xe9\x81%\xec\xe4\xb5?\xcf\x16\xea*dQ\xf0w\xd6\xa7\xd5\xce 7\x83\xbf\xa1\xd9=\xd9\xfd\xbb\x9a\xe6U\xb2w\xd1h\xa3\xd9\x16k\xb0\xe2[\xc2\x0b\x91\x84\xf8\xf4\xa4\xef\x9c Z\xb0$E\x10\xa5qW\xfe\xaa\xa4k\x1bt/\xde\x84{au\xf7\xc2\xca\xa3\x83\x07\x16,d\xd3\x14&U\x89\xd2\x92\x0cN\xcbc\xe0\xa2\xadK\xeb\xfd\xbe\x7f\xcc\x92x\x0e#\xc56|\"B\xb0\xe8\x1e\xdd\xc4q.W\xaf_\xed\xa4\xe3'\xf1\xe3\xedyO\xbe\xc2\x9d\xdb3\x06\xf8\x12bgsq\xfea\xe7M\xaew\xff\x01\xd4\xb7\xfc'\xce\xaf\x01\x00PK\x07\x08I\x8a\x10\xca{\x02\x00\x00;\x06\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x11\x00	\x000019_waitlist.sqlUT\x05\x00\x01\x80Cm8\xacTOs\x9b>\x14\xbc\xf3)\xf6f<\xbf83\xf9\x1dz\xc9\x89\x1a%a\xea\x88\x14\xc3\xa4\xe9\x85\x91\xd1\xc3\xd6\x04\x0bF\x12I\xdcO\xdf\x11&\xff\x9b\xc6\x87r\x93\xde\xee\xbe]=\xa1\xd9\x0c\xffm\xd5\xda\x08G(\xba`6C\xa6$\x19\x8b{\xa1\x1c\x94F\xa34\xa1n\x0d\xea\xbeivX\xb5\xed-I8\xa3:{\x8c\xeb\x0di\x88a\x85\x15U\xed\x96,\xc4\x9dP\x8dX5\xe4\xc5\xc4Z(}\x04\xb7!\xd4\xcaX7\xc8*\xbd\x86\xf1]\xa0,\xda\xba&C\x12\xcaAh\x89M\xdbH\xeb\x17\xbdv\xaa\x19\x88\x03\xc2\x8b\xd1C\xa7\x0c\xd9\xe3`\x9e\xb1(g\xc8\xa3\xaf\x0b6(6\xca\xba\x92\xb43;\x84\x01\x00(\x89\xc7\xaf\xef\x95\x04Os\xf0b\xb1@\xcc\xce\xa2b\x91\x0f\xbb\xe5\x9a4\xf9\xe4\xe5\xdd\xc9\xb6\n\xa7G\x03\xd5\x87)\x95|G\xddW{K\xe6\xe3\xaau\xc2\xf5\xd6\x17Qm\x84\x11\x95#\x83;avJ\xaf\xc3\x93/\xd3\xf7>&\xe3\x81L\xf6\xf2>~9FW[\xb2Nl;\\'\xf9EZ\xe4\xc8\x93K\x86\x9f)g{leH8\x92\xa5p\x7f\xc3>%\x0eu{\x1fN1\xa2\xf1\xab\xd5\x84I\xef\xaa\xc9\x98\xbb\xef\xe4\xbf\x94\x9b\xa7|\x99gQ\xc2\xf37\x13*\xbb[\xda\xe1*K.\xa3\xec\x06\xdf\xd8\x0dB%?%\xd5\x9et\x96f,9\xe7{\xd28\xa7)2v\xc62\xc6\xe7l	\xbf7\xc8!\xe5\x88\xd9\x82\xe5\x0c\xf3h9\x8fb\xf6\x99+\xdf\xa0\xfc\xffu\x8bq\xd8\xafZ\x0c{\xa2\xaa\xda^\xbb\x0fZ\x05\xd3\xd3\xc7+\x9a\xf0\x98\xfdx\x9bet^*\xf9\xe0}\xbe\xae\xa2X&\xfc\x1c+g\x88\x9eB\x1e\xbd\x18\xf6\x14\xd7\x17,c\x8fw-\xe1\x08\x9f/\x11&\xe3\xff4y\xf6P\xf0\xe4{\xf1\x89\x951i\xe9O\xf9PKO\xa7s\xa8\x9f\xe0\xe5c\x13\xb7\xf7:\x88\xb3\xf4\xea`c\xa7\x07\xc0\x95|\x18a\x7fz\x1cN\x83\xdf\x03\x00PK\x07\x08*\xc6\x15\xee\xf6\x01\x00\x00\xef\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0f\x00	\x000020_ledger.sqlUT\x05\x00\x01\x80Cm8\xbcV_o\x9b\xc8\x17}\xe7S\x9c\x87\xfcd[?;j\xf7a_\xdc\xadD\xec\xb1\x83\xea@\x16\xc3\xa6\xdd\x174\x86\x89\x8d\x02\x83w\x18\x9cu?\xfdj` \x06\xd3\xd4i\xa5\xf2\x10\xc5\xcc\xfds\xee=g.w2\xc1\xff\xd3x+\xa8d\xf0\xf7\xc6d\x02r`\xe2\x88\"g\x02;\x9a\x83\xe2\x99&	\x93\xa0a\x98\x15\\b\x97%Q\xcc\xb7\x90;\x06z\xa0qB7	\xc3\x86&\x94\x87\x0c\x94G\xa0\xa5\x8d\x8a\xd5\xe7S[\n\x963q`\x11\x1e3\x81M\x96=\xb1\x08R\xc4\xfb\xfc\x1a\xde\x8ea\x9fP\xf9\x98\x89\xb4\x02\xa1\x82=2\x967\x11\xab<\x8f\x05/\xb1\xe8\xb7c<\xef\xe2p\x874\xe3\xec\x08\xc6%\x13yi\x990z`\xb9J\xaf\x025\xa1\xe5Nd\xc5vw\x0d3U\xee9\xa8`\x889BV\xfe\xe0Q\x0bp\xf6\x08\xca\x1b\x00q\x13./Ru\x16\xcb\x1c\xfb,\x971\xdf\xe6c\xe4\xd9\xa9o\xae\x0ch\x92\xd4\xdey\xe9T\xec!3|e\"\xbb6f.1=\x02\xcf\xbcY\x11$,\xda2\x11hc\x0c\x0d\x00\x88#\xd4OQ\xc4\x11l\xc7\x83\xed\xafV\x98\x93\x85\xe9\xaf\xbc\xf2m\xb0e\x9c)2\x83\xc3\xfb4\x1c\x8e\xc6\xa5\xab\"3\xa8\xfc\x95Q\xe31x\xa7\x9fI\xcf\x9f\xfa\x19T1\xe4q\xcft\xfapG\x05\x0d%\x138Pq\x8c\xf9v\xf8\xfe\xf7Q\x83\xa72\x0f\x05\xa3\x92E\x01\x95\x90q\xcarI\xd3=\x1e,\xef\xd6\xf1=x\xd6\x1d\xc1\xdf\x8eM\x1a$C\x9e=\x0fG\xd0\xd6\xf8\x9aq\x86A!\xc3\x81\xae`\xe6\xd8k\xcf5-\xdb\xeb4'\xd8?\xb1#\xee]\xeb\xcet\xbf\xe0\x13\xf9\x82a\x1c}\xd7\xe9Q9-\x1c\x97XK\xbbr\xd2-\x1a\xc1%\x0b\xe2\x12{F\xd6U\xdb\x1a\x12\xe2h\x04\xc7\xc6\x9c\xac\x88G\xb0&^\x0d\xde\x18Mk\xfa|\xdb\xfa\xd3'\xb0\xec9\xf9\xdc\xcd\xa93\x04\xaa\x91\x81\xca\xef\xd8\x1d\x13\xf8k\xcb^b#\x05c\x0d\xa2q\xd9\xf9Q\xd9\xd4\x87[\xe2\x92\x86\xcd\x0f\x1f/$\xf0\"x\x97\xc2Rv\xa3\x0e\x12k]*qj\x18\x96\xbd&\xae\x07\xcb\xf6\x9cn\x94NA\xf8\xcb\\\xf9d\x8d\xa1\xf2\x1cc\xa0n\xf7`4~\xf9]]\xed\xc1hj\xf4^\x0e\xc6\xa58\xfe\xfc\xd5x\xa3\xac\xd5\x80:\xb9I\xbfH\xebe\xadoSz\xe5r\xaes]@K\xe7\xea]\x9f\xbeU\x0bO\xc4\xddRu\x15_G\x0b\xe2\xe8\xdf\x13\xdd\x94g\x1d\xd5\xe8\xb4\xdf S\xcf\xcd\x9f\xa7\xb3L]\x11\xd4\x1a\x92\xd5i\xad\xf58\xea=-?\x02e\xdeM\xbc\x8d\xb9\xfc\xd5#Mw\xe1mD\xd7N\xe7T\xd7\xbdhq\xdd\xbe=\x17\xc6\x0d~k\x0f\xcb\x97>\xf6\xc5\xd6\xa7\x17\xa1\xaez\x1e\x84;\x16>avKf\x9f0\xd4<|\xf8\x88w\xa3o\xc9\xaf\xf6\xafk\xec(P\x1f\xb75\xd8\xf4\xe3\xf5\x90/\xc5]\x12\xf4\xc5Zi{2\xc1\xbd^\x01\xaa]\"M\x0bI7	\xbb\xc6]\x9cK\xfa\xa46\x18\xc1\x10fB\xb0P\xb2\x08\x9bc\xbd4\x80\xf2L\xee\x98\xa84|m\x9cnfkI%K\x19\x977l\x1b\xf3\x1a\xfe\xc2\xb7g\x9eu\x060h\xb2\x0e\x15=\x9e\xef\xdakH\x11o\xb7L\xc0\\\xe3\xea\xca\xb8!K\xcb.\xa9qMkM@>\xcf\xc8}\x19jP\xc5\xc2\xbe\xb7\x8c\xc1\xd4 \xf6|j\\]ae\xdaK\xdf\\\x12\xec\x93\xfd6\xff'\x99\xf6\x03&<\xaa\xe1z\xae\xb5\\\x12\xf7\x9bhqC\x94\xca\xe0\xdf\xcf\xd5\x80p\xdcz\x16\x9dUX\"_8.\x889\xbb\x85\xeb<\x80|&3\xdf#\xb8w\x9d\x19\x99\xfb.y\xa5'\x15O\xe5\x8eYs\xa5\xb63^5\x1ei\x91\xcb\xcez\x86L\xed\x7fj\x9f\x93\x82\xf2\x9c\x862\xce8\xc2,Mc\x99\xff\x08Se\xa6@/\x87\xd1\x054Y\x0b\x0c\xd7dEf\x9e\x82\xa6\xaf\xc8\x08\x0b\xd7\xb9\xebT\xaa?\xcd\xb5\xd8\xf1\x07l\xf2p\xddh\xbf\xbcV\xf0nIE\xffk\x12(}\xf0?\xb5\xe9\x16\xbc\xc6:\x18\xb7\xe2M\xcb(\xc4\x9e\xc3ZT\xffW\x95\xe8}\xe0\xc7\xc5r22:\xbai\xf7\x0e\xe6\xc2#.\xf4\xde\xd1\xaf\x94\xb9\x1aQn\xb9<X\xb6\xe5Y\xe6j\xf5E\xbf$\xf3\x0bu\xd4elj\xb4J\x98g\xcf\xdc\x98\xbb\xce\xfdw\xd0\x9e\x01\x9cV^\xdd\xab|\x9e\xae/\xf8\xd9\x85\xbf8\xfe\x99g\x93\xa2w\xcc\xb6g\xe2k\x96\xb50N\xecZk\x9b\xb6\xeb\x0bq\xb6R\xf4\xf9\x97F}\xde\xdd=\xf65\x1b\xbd\x89vm[@i\x18f\x05\x97S\xe3\xbf\x01\x00PK\x07\x08I\xc1\x92\xd1\x8c\x04\x00\x00\"\x0f\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000021_payment.sqlUT\x05\x00\x01\x80Cm8\xacUAo\xabF\x10\xbe\xf3+\xe6f\xac\xd6U^\xaa\xd7\x8b\xfb\x9eD\xcd\xe6\x05\xc5\x81\x14\x83\xd2\xf4\x826\xec\xd8^\xd9,hY\xec\xd0__-\xe0\xb5\xb1q^\x0e\xe1`\xc9\xcb|\xdf\xcc\xec|\xdf0\x99\xc0/\x19_I\xaa\x10\xe2\xc2\x9aL\xe0\x89\xd6\x19\nU\x02\x95\x08*/&UQB\xbe\x84=\xddnQ\x1f\x0b\x06\x05\xad\xf3J\x95\xb0\x94y\x06j\x8d\xfaG\xe6\xd5j\xad\xff@\xd12h\xb2B\xe6;\xceP\xfe\x06\xd1\x1aA\xe2\x12%\x8a\x14\x813\x14\x8a/9\x96\x0d\x82\x0b\x85B\xe9,\xf4\x80\x06\xaa\x9aW\x86\xc1\x9a\x85\xc4\x89\x08D\xce_sb\xa2l\x0b\x00\x8038>U\xc5\x19\xf8A\x04~<\x9f\x83K\xee\x9cx\x1e5\xa7\xc9\n\x05\xeaV\x93\xdd\x97,\xb5\xc7\xbf6\xe0\xaaD\x99p\xf6\x1ext\xd3=\x93\x81\x9f\xc33j\xe9T]\xa0\xa9%]SIS\x85\x12vT\xd6\\\xac\xec/\x7f\x8cMq-\x80fy%T\x07x\xe5+.\xd4Y\x84\xc4e%\x18\xb2\xa1\x08\xd3\xe0M\x1b[*\xaa\xaa\xf2C\xe9\x0dtT\xa0`\\\xac\xba\x0e\x8ec\x1a\xec\xe0\xf6\xeb\xd7\xee\xe6$2.1UI%\xb7\xa0\xf0M\xb5\xc7\xa9D\xaa\x90%T7\xa5x\x86\xa5\xa2Y\x01\xcf^t\x1f\xc4\x11D\xde#\x81\x7f\x03\x9f\x98\xfc\xb6\xc8\xf7\xf6\xb8\x998\xcf\x10\xfe\xcb\x05\xc2\xa8R\xe9\xe80\xa1\x82}.\xe1,\xf0\x17Q\xe8x~t\x10RRl\xb0\x86\xa7\xd0{t\xc2\x17x /`sv=z\xa9\xa3\xef\x82\x90x?\xfc6\xbaS\xd1\x18BrGB\xe2\xcf\xc8\xa2U\x16M\xd3f\xbe\x9a\x0f\x02\x1f\\2'\x11\x81\x05\x89\x0e\xfd_\xcd\xd2*#I\xd7\x98n`vOf\x0f`wj\xf9\x0e7\xe0\xf8\xeeQ\x1a\xdf\xbf\x9d\x9f\xfc\xf9\xad\x93\xd6\xd8\x1aO\x0f\xee\xf1|\x97\xfcc\xda\xe8\x8aN8{\xd3\xa5u\xc7\x10/<\xff\x07\xbc*\x89xl\xccP\xc4\xbe\xf7w|\xced4\x93\xe8\x9b\xb9\xc6e\xa2\xc6SK\xaf\x87g|]\xe7\xf9\xa6\xd9/'\x9b\xc3\x98\xbe\xd9A\x85\xccS,Kd\x90kM\x16(\xf5\xfa\xc8\x8a\\\xa1Hk\xd8`=\xbc\x1b\x92}\xcb\x9e\xe0\xeetS\x18dS\xe9\xa5A\xb5\xbc\xcf\xfc\xd7\xb3\xf4\xa0'~\xbf=\xc7\x98V;\xd0\x87\x12\xf5V\xc1\xa5\xd7\x07\xec\xf5I\x0e\x1b0D\xef\xf6\x06\xed\xd1\xbb\xc9Fd\x963\x8fH\xd8\x8da\x8bl\x852A\xa1d\x0d\x8e\xeb\xc2,\x98\xc7\x8fF\x19z\xe5\xeam\xdb6\xd5\xbe7E\x9cb\x1b\xb3%\xb7}\xbb\x1dIz\x8e\xeb\x8e\xc1>\x15l\xab\xf9\x1e\xe5\x11~\xd0\xfe\xe9\xeb\xbe\x01\x8e\xb1\x9dj\xcd\x07\xd3\xcd\xf7\xc2r\xc3\xe0\xe9\xe7)\xa6\xd7\xaf\xa6!\xb8\xb8\x9biK\xdc\xfb\xda\xf5g\xd2E\xbc\xe3\xc3\xc1\x88\xce\xcfmQ\x979\xa6\xd6\xff\x03\x00PK\x07\x08\x8bZ\xef\x04\xd3\x02\x00\x00\x13\x08\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x10\x00	\x000022_receipt.sqlUT\x05\x00\x01\x80Cm8\x8cTMo\xdb:\x10\xbc\xebW\xcc-2\x9e\x1d \xef\xf4\x80\x9c\xfcb\xb5\x15\xea\xc8\xa9#\xa1M/\x02-md\xc2\x16\xa9\x92+\x1b\xea\xaf/\xa8\xaf\xc0n\x9c\xc4\x17\x8b\xdc\x99Y\xeep\x97\xb3\x19\xfe)ea\x04\x13\x92\xca\x9b\xcd\xb0\xa6\x8cd\xc5\x16\xc2\x10T]n\xc8P\x0eK\xbfjR,\xc5~\xdf\xa0\"\x83\x86\x84\xb9F\xbc%d\xbaVL\x06F\x1f\xa1\x9f!\xda\x10\xa4ub{\x9d\xed(\xc7q+\xf7\x04\x01\xd3iCZHkk\xca\xa7\xb0\xbaO\xd2%\x14\xd6\xcaB9\x8a\xe4\xad\xae\x19\x85\xa8\xec\xb5w\xb7\x0e\xe6q\x80x\xfe\xff2\x18T\xd2!\xb1\xef\x01h\x0f\xe4\xfe!\x15SA\x06\xd1*F\x94,\x97\xd36\xdc\x15\x90\xd1\x85\xf0\xdd*z\x8c\xd7\xf30\x8a\xcf\xe5\xd3jG\x0d\x1e\xd6\xe1\xfd|\xfd\x84\xaf\xc1\x13|\x97j\xe2Mn\xbd\x13\xbfvD\x15\x042]5\xce\x07\xde\x12rb!\xf7vXn\xb4\xdeIU@p\xbbdY\x92\xfbh\\\xe5N\xaa\xf3\xa4u\xb5\xdd\x83q|\xe7\x06\x1dHA\x9e\xa8L\xdb\x05\x1bYA\x9b\xf6\xbb\xb6\xbd\x8bN+\xa7=qo0o\xc9Y\xeb\xeeS\xe3Y\x1b\x92\x85\xc2\x8e\x9a\x0b\xc6\xf6\x86\xca\x1c\xe3\xaf\xaee>:\x86E\xf0i\x9e,\xe3v7-H\x91k\x9f\xf4pSf\xfe\xa4s\xbb\xaf4\x95\xf9\x19\xb7\x0b\xbbS\xa7\xbd\xfe+\xe1\xf1*\xdf\xbf\xcd\x8b\x88\xdc\xc8\x03\x99T\x89\x92\x90m\x85\x11\x19\x93\xc1A\x98F\xaa\xc2\xbf\xf9\xf7\xbf\xc9XOw&#\xf3\x01\xff1\x82ea\xb8?\xe5\x87\x089Y\x96J\xb0\xd4\xea\xa3\x84Jpm\xc8\x91]\xb3X\x16e\x85\xefa\xfce\x95\xc4\x88\xc3\xfb\x00?WQp\xc6\x12\xa5\x9b\x0b\xc7\x016\xb2\x90\x8a\xcf\x00\x07\xc1\xa9\xbb\xb1\x8b\x80\xcc\x90`\xcaS\xc1o\xe7\x1d\xfa\xc0W\xfa\xe8O\xda\xb6v-\xfd[+\xc2U\xcd\xd9\xd5\xe4\xe2p\xfd=T2oG\xaa\x9f\xf4$\n\xbf%\x01\xc2h\x11\xfc\x18'\xf2\xa5\xabRG_EC\x04\xc9c\x18}\xc6\x86\x0d\x11\xfc\x17\xd8;z\xae\xcf\xd2\xe1axS\xd2!\xa7\xe3\x1b\xd2O\xfe\xf8p.\xf4Qy\x8b\xf5\xea\xe1=\xfd\xdb\xd7P\xa7U\xf5\x90\x93\x97\xee\xb5\xbd4\xd3\xb5b2\xb7\xde\x9f\x01\x00PK\x07\x08\x8dc	!D\x02\x00\x00\xc3\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x12\x00	\x000023_promotion.sqlUT\x05\x00\x01\x80Cm8\xb4WOo\xdb\xb8\x13\xbd\xfbS\xcc-6~M\xf0k\x17\xd8\x8b\xd1\x02\xaa\xcd4B\x1d9+\xcb\xdb\xed^\x04Z\x1cKD$R \xe9\xfc\xd9O\xbf %[T\xa2\xd81\xd0\xf5%\x009\xef\x91\x9cyoF\xb9\xbc\x84\xffU<W\xd4 \xac\xeb\xd1\xe5%\xdc)YI\xc8$C\x0d\xb9\xa2\xc2\x80\xe2\x0c\x95\x06\n\x8c\xebL\xee\x84\x01)\xc0\x14\x08\xb5\xe2\x19\x82\xdc\x02\x05\xa3x\x0d\x8f\x05\n\xd8Hy\xcfE\x0e\xdc\\9:T\x19\nCs<\xc05P\x85\x90\xf3\x07\x14\xc0\x05l\xa8\xe6\x1aj\xc9\x85\xd1\x1f`\xcb\x9f\x90y\xa1\\\x80\xc5\xeb\xab\xd1,&AB 	\xbe.\x08\xd4\xf6\x96\xa9\xbd%\x8cG\x00\x00\x9c\xc1\xf0o\xb7\xe3\x0c\xa2e\x02\xd1z\xb1\x809\xb9\x0e\xd6\x8b\xc4\xad\xa69\n\xb4/O\x1f>V\xd9x\xf2\xc1\x119\xce\xa1_VPE3\x83\n\x1e\xa8z\xe6\"\x1f\xff\xf6ir`n\xc0\xe6\xb9~/\xf8\xe3\xef/\xc1\x0f\xb4\xdc\x0d\xa27<\xe7\xc2\xbc\x88\xae\xe8S\xaa\x90aU\x1b.\x85\xee\xa2\xb90\x98\xa3\x1a\x8cJkT\xe9N\xa3\xdaG\xbd\xce\xcc\xc7\x06\xb8\xe5J\x9b\xd4\x965\x95\xa2|>\xb0\xc3F\xca\x12\xa9x\x0d\xdc\xd2R\xe3\xe1%\x9c\xa5[%\xab\x03\xac\xf9\x19^\xa16\xb4\xaa\xe1G\x98\xdc,\xd7	$\xe1-\x81\xbf\x97\x11\xf1\x91;axy&2SH\x0d\xb2\x94\x1ax7\xf2 \x87\xb1\x90\x8f\xe3	P\xe3n\x08\xffH\x81p\xb13\xd9E+\x8a]\xcd\xfe;\xf2\xd92Z%q\x10F\x89'\xeb\xb4\xbe\xc7g\xb8\x8b\xc3\xdb \xfe	\xdf\xc9O\x18sv\x14\xe0\xe4\x93f\x05f\xf70\xbb!\xb3\xef0vK\xf0\x05\xfe?\x19M\xa6{\x03\xad\xa3\xf0\x8f5\x810\x9a\x93\xbf|\xbc#\xb1\x87.#o\x19\xd6\xab0\xfa\x06\x1b\xa3\x10a\xbc\xabkTc\x1b9\x99LG\xb6]\xc4\x9e\x04\x9b\xe6@s\xca\x856\xaeC\x94\xbc\xe2F\xdb\x16a\n\xe4\xaa\xe1m,F5\x94R\xe4\xf6\xaf\xdb\xb3d\x87\xde\xa1\xa1F\xc1l\x1b\x91\nh\x96am\x90]ARt]\xc4\x05Q\xce\xc0\xc9\xcc\x1e\xe6\xc8\x9d\x19,\x17\xcd\xda^\xb5m6Kj\xb6RU\x83}\xa4\xf3\xd1`79\xb7\x85t\xd9K9\xeb\xa3\x9b\x80\xf6\x9dv\xf7%}\x13@+w\xf7c\xfe\xef\xeb\xfdW\x88\xfc\x95\x0e\xbb\xb4\x9c\xa5F\x0f\xb6\xb5z\xba^\xc6$\xfc\x165\"\xee\xe5f\x021\xb9&1\x89fd\xe5e\xed\x0c\xf2\xf4S\x9f\xbe\xcbl\x8f\xbb]v\xc4V\xdfs\xb2 	\x81Y\xb0\x9a\x05sr\xfa\xa8\xa6\x1e}s\xb55\xfa\xf2\xb9o/\xdfW\x1eC\xf7\xba\x94\xb3\x94\xb3\xa7\xcef]T\xdfl=\xc8Q\xffz\xe7t	\xe8{\xf9\xadC\xba\xf8\xd6\xd0km\x87\xbd.\xec\x8cv\xc6\x04\x85[T\x8a\x96\x8do\x1f\xb9)@\x9a\x02\x95\xbe\x82\xaf\xd2\x14n\x9ag\n\x197\xc8@\x8a\xcc\xe1,U\x03D\x06n\xe2d\xb2\xaaK4\xa8\xa1\xe0\xba\x99/`\xe7\xcb\x0bG\xeeO\xf3\x87\xbb\xc5\xbfm\x16ob\xbfg\xccz\xc6\xf9\xc5\xae\xe9]}\xc02\xed3N\x01_\x9bf\x0f\xf4%\xed\xd6\xf6MnX\xd7o5\xfd\xfe=\xfd\xbe\xdf\xdb\xe9\xab\xd1\xaeX\x89\x04\x8b\x84\xc4m\xadzw\x08\xe6s\x98-\x17\xeb\xdb=\x0d\xb2t\xf3\xec\n6=\x03e\x0fo\xc5t\xa2D'Y\x0f~\xf6\xf7R\xefr\x03\xa9\xf6v\xdf\x9d\xee\x15i\xf4u\xc8v\x93\xe67Om\xdd\xef\xef\xf7s\xed_b:\x1a\x85\xd1\x8a\xc4	\x84Q\xb2\x84\x12Y\xee\xc1\xf6\xd2\xf8\xe0>='\xf0g\xb0X\x93\x15\x8c\xdd\x9c\x80\x8bn$^\xb4\xf6>|\xed\xcf\xe5\xa3\x18\xb5z\xb9\x8e\x97\xb7/\x99\x7f\xdc\x90\xb8\xad\x15g\x10\xae\xdc\x0b!\x88\xe6\xee$\xf8\xdc#\x9f\x8e\xe6\xf1\xf2\xee\x1d\xef>R3\xc7\xe0\xb9\xe9h\xd1N\xf3\xbc)\xa9\xb3\xa0\xae\x06\xbd\xd7\x0d\x9b\xa7\x0dil\xd1\x0b\xe9\x81O\xb4\xeb\xe3\xb1\xafFH\x1b\xee\xffG\xd4\xb5\xf9\x01\xae\xdeg\xde\x008\x93\x0c\xa7\xa3\x7f\x07\x00PK\x07\x08\x87f\xa5\xac\x06\x04\x00\x00\x13\x0e\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000024_organization.sqlUT\x05\x00\x01\x80Cm8\xb4UAo\xdaL\x10\xbd\xfbW\xbc\x1bF\x1fDJ\x0e\x9f*qr\xedMb\x85\xd8\x911m\xd3\x8b\xb5\xd8\x03^\x05\xaf\xad\xf5\x02M~}e\xb3\x10L!\x89\xd4\x94\x9bwg\xde\x9by3\xfb\x18\x0e\xf1_!\x16\x8ak\xc2\xb4\xb2\x86C\x84j\xc1\xa5x\xe1Z\x94\xb2\x06W\x84\xb4,*.\x05\xd5(+R\\\x0b\xb9\x00\xc7|I\xa4Q\xce\xb1\xa6\\\xa4K\xaa/\x10\xe7$\x14\n*f\xa4\xea\x06+SbM\xd0JT56B\xe7\xd09\xed\xe3\x9b\xdc\xe6\xbb< \x1c \x13u\xc5u\x9a\x93\xaa\xc1e\x86r#\x0dX\xaa\xa8\xa9R\xe7T\xa0\x94\x98Q\xce\x97\xf3\x1dH\xcb\xa4\xea\x0b\xcb\x8d\x98\x133\xc4\xce\xd71\xeb@\xc3\xb6\x00@d\xd8\xfdV+\x91!\x08c\x04\xd3\xf1\x18\x1e\xbbv\xa6\xe3\xb8=M\x16$\x9bN)Y_\x16\xa9\xdd\x1f\xb4\xa9\x92\x17dR\xd3\x9c+\x9ejRXs\xf5,\xe4\xc2\xbe\xbc\xfa\xd2\xdf\x83m\xe3\xb7\x05g	\xd7\xd0\xa2\xa0Z\xf3\xa2\xc2w?\xbe\x0d\xa71b\xff\x9e\xe1g\x18\xb0=\xb1-\xcb\x8d\xdd\x87\x89\xc6K)	\xbd\x95N{\x86~Ue\x9f	\xe7\x86\xc1$\x8e\x1c?\x88;2%\xd5\x13=\xe3!\xf2\xef\x9d\xe8\x11w\xec\x11\xb6\xc8\xfaV\x7fd\x9d\x976\xd9\x8e\xdc(\xdc\xb9\x11YWf\xd3JM*97\x89m\x88*\x97;\xb1\xcf)\xfe\xff\x1b\x82\xb7\xbf\x7f)\xd3\xb6\xe5\x13j\x1d\xb5?\xd85\xfb1\xbcy\xa3\xfeu\x181\xff&8\x89\xd7G\xc4\xaeY\xc4\x02\x97M:\x08\xed\x9c\x10\x06\xf0\xd8\x98\xc5\x0c\xae3q\x1d\x8f}\x985\xb9\xea\xf2\xee\xca>\xe4k\xcfx\x9a\x96+\xa9\xff\x86\xaf\x19n\x92\xe6\x94>\xc1\xbde\xee\x1d\xec\xe6\x04~\x00\xbb\xd7>\xf8\xde\x00\xbdW'h\xbf\xda\x07\xde\xeb\xb7\xabh6\xd1\x0f<\xf6\xe3\xa4\x8c\xa6\xf8Dd\xbf\x1aIN\x84`:\xf1\x83\x1b\xcc\xb4\"zmvd5N\xf3\xcd8Zc.\\v\xb21\xe7\xcb%f<}\x82.[\xe71\xbb\xbf\xc9K(Z\x88Z\x93\xa2\xac\xb9)\x1a\xa8MN\xf2\x0f\x97\x83\xa8\x91\xd1\x924e\x17\x963\x8eYd\xfc\xcaX#\x1c\xcf\x83\x1b\x8e\xa7\xf7\xc1\xc9\xd74z#i?g\x83\x95\x1c\x01|\xe2\x86M\xd8\xf6\xc1\x1e\xcd\xe3\x1c\xb1\x99\x85\xb9\xee\xea\x7f\x14k\xe6\xb0\xffg\xf2\xca\x8d\xb4\xbc(|x\x9f\xe2\xb46m\xee\xc1R\x9e\x03h\xc4y\x13\xe1\xd4LF\x87\xa5\xbd\xb3\x8d&\xf6\xac\x87\x9e\xbd\x1fY\xbf\x07\x00PK\x07\x08d\xdbls\x7f\x02\x00\x00\xab\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x18\x00	\x000025_vehicle_details.sqlUT\x05\x00\x01\x80Cm8\xac\x95\xd1o\xea6\x14\xc6\xdf\xf3W|o\xc0\x06\x88uR_X+1\xe2\xadh,t\x90l\x93\xae\xae\"\x93\x9c\x12\xdf&6\xb5\x1d(\xf7\xaf\xbfrh(\xb4U\xd3\xde\xdb\x07D\xe4\x1c\xff\x8e}\xce\xf7\x9d\xf4z\xf8\xb9\x10+\xcd-!Z{\xbd\x1e\xfe\xa5L$9\x19\x94\x86RX\x85%\xa1\x94\xe2\xae$,wXj.S\xb8_\xa1R\xca\xc1\x13\xad\x8c\x01\xcfs\x17\xafM\x1faF;pM\x8e\xf5\xb8/\x17	ICX\xe7.\xd3\x9a4\xd4V\x92\x86\x90\xc6\x12O\xbb\xd8f\"\xc9 \x0clFPz\xc5\xa5\xf8\xca\xadP\x127J;\xd6\xa6>\x97\xba9yo\xfa\x8fG\xde\n\x9b\xa9\xd2\x82?\xcd\xa7)uy\x85\xed{\xa3i\xc8\xe6\x08G\xbfOY\xcd\x84?\x9f]c<\x0b\x16\xe1|4	\xc2z=\x16i|K\xbb\xe1\x8b{F\xbe\x8f\xf1l\x1a\xfd\x1d\xd4\xc9\xe2}\xb2$\xe3\x9a'\x9646\\\xef\x84\\\xb5\x7f9\xef \x98\x85\x08\xa2\xe9\x14>\xfbc\x14MC\xb4Z\x8d\\\xbb[\xbf\x1d\x97p\xddLLT\xae\xf4\x0b\xc8_\xcf\xbe\xef\x84\x05\xbf\x8f\xd7|\x97+\x9eB\x96\x05i\x91<\xe7\x0c\x1a\x8f\xe5\xf6\x0b\xb9\x8a\xb9&\x1eg$V\x99\xfd\x08\xdcF\xe5eA?B\xe2\x05Ia\x05\x19|1J.\x9f#Z\x9f>\xbfZ\xa5g\x92r=\x8d\x93\x8c\x92[\x8c\xaf\xd8\xf8/\xb4\xdd\n&\x01\xdaU\x0b\xbbhm\xb8t\x7fV\x97\xc9\xed\xfe\x81\x8b\x9ct\xab\xd3\x19z\xe39\x1b\x85\x0cQ0\xf9'b\x98\x04>\xfb\xff\x80>\x91\xa1\x13.fA\xfd\x12\xd1b\x12\xfc\x89\xa5\xd5Dh'\x8a\xe7d\x12j\x1f\x1b)\x16i\xb72q,\xd2N\xf7T\xd4\x1d\xfcw\xc5\xe6\xec\x89\xd2\x7f\xbb\xac4\xe2\xdc\xc9\xee\x85\xb1B\xae\xea|\xc6\x8d\x80J\xc0\xa9\xb3\xbf3\xb5k\xae3\xaf\xcdH\xe8\xbaG\xfbU!a\xee\xca\x87\xa1\x91\x90\xb4\xa2 \xab\xc9\xf4\xbd\xe8\xdaw\xf7}\xa0b\xc1\xc2\n\x8a\x0b\x8cG\x0b\xe6\x01p'\x0bp\xb8\xd1\x03w/\xa5\x9c\xe4\xcaf\xf8\xe9\xa0\x88ju+R\x9bu1\xe8\xe0\xf2\x02\xe7\x83\xc1`\x80\xf0\x8a\x05u\xc5?\x00zv\x04u\xdd\xac\x90l\xba`\xa8z\xec\xb1\xc0\xdf\x97\xed0{}\xb5\x95^5\x86\x1az:|\xd7\x00{T\xdb\xab\xfbN\xb5\xde\x1cZ\xdf|o\xb0\xb7\xc7\x1f\xf9\xbby\xd3\xd1li\x0e\xae\x06[s\x98\xabGs\xd4I\xd1\xdf\xe5\xee\xfd\x07\xa3\xb6g\xbb\xfaZvQ\xa8\x94\xf2\xce\xd0\xfb6\x00PK\x07\x080\xb928\x83\x02\x00\x00m\x07\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x15\x00	\x000026_vehicle_file.sqlUT\x05\x00\x01\x80Cm8\x84\x93Qo\x9b0\x14\x85\xdf\xf9\x15\xe7\x0d\xd0B\xa5V\xda4)O\x0c\x9c\x165\x85\x88\x10m\xdd\x0b\"\xf8&X	62N\xba\xf4\xd7O\x90\xd2\xa4i\xd5\xf2f\xdf\xfb\x9dc_\x1f<\x0f\xdfj\xb1\xd6\x85!,\x1a\xcb\xf30\xab\x94Q-\n\xc9\xc1U\xb9\xabI\x9a\x16j\x85=U\xa2\xdcR{\x85\xac\"\xa1Q*iH\x1a\x88\x16\x1bj\x0c\x84\x84\xa9\x08\xcb\xadZ\xa25J\x93\xe5y\xd8IN\x1a\x056t\x00'-\xf6\xc4\xb1\xd2\xaa\x86\xa9hP\xec\xad\xba\xf5Jl\xe9\xca\nR\xe6g\x0c\x99\xffk\xca\x06\xd3\xbc+\xc1\xb1\x00@p\x9c\xbe\xddNp\xc4I\x86x1\x9d\"d\x13\x7f1\xcd\xfa\xdd|M\x92\xbak\xe5\xfb\xeb\xbat\xdcQ\x0f\x0fz\x82_\xc2\xc7\xfaF\xc8\x93|Y\x15\xba(\x0di\xec\x0b}\x10r\xed\\\xffp_\xdd\x8e\x80,j\xfa\x04\xb8\xf9\xfe\x8ex\x99[n\x0e\x0d}dq\xf3\xf3\x92h\xc5\xf3\xc9c)\xd6B\x9a\x8b\x8eRSa\x88\xe7\x85\x01`DM\xad)\xea\x06\xbf\xa3\xec.Yd\xc8\xa2\x07\x86\xbfI\xcc^'\xe4H\xf5\xe4\xb8(L\xdf\x8dg%	\xf6\xce\x94\xf6\xcb\x9c\x82$\x9eg\xa9\x1f\xc5\xd9\x9b'\xc8\x9b\x0d\x1d0K\xa3\x07?}\xc4={\x84#\xf8\x17\xc8\xaaC&I\xca\xa2\xdb\xf8\x88\x0c\x8a\x82\xbbH\xd9\x84\xa5,\x0e\xd8|\xa0zI$1B6e\x19C\xe0\xcf\x03?d\x9f\x9bt\xef\x96\x97\x15\x95\x1b\x04w,\xb8\x87\xd3\xed \x8a\xe1\xd8M\x17h{\x04{\x88\xb3\xed\xba\x96;\x1e\x82\x16\xc5!\xfb\xf3\xf6\xc8\xc3B\xf0\\\xf0\x7f\xddY\xce\xcbX\xcc\xa3\xf8\x16K\xa3\x89\xce/3\xea\xd3\xe3\x8e-\xeb\xfc\xa7\n\xd5\x93\xb4\xc24\x99}m4>\xf6\xbdO\xfe\xd8\xfa?\x00PK\x07\x08\xecz\xce\x97\xc7\x01\x00\x00\xa7\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x19\x00	\x000027_user_avatar_blob.sqlUT\x05\x00\x01\x80Cm8\x94\x90\xcf\x8a\xab0\x14\xc6\xf7>\xc5G7]\xdc\xab/P\xee\xc2;\x15\xa6\xe0hi\x95Y\x0e\xc7xZC5\x91\xe4\xd8N\xdf~\x08\xb5 \xdd\xcd.\x9c/\xdf\x1f~q\x8c?\x83>;\x12F=Fq\x8c\xf4JB\xce\x83\x1c\xe3\xc2\xa3@\x1bH\xc7hz\xdb\xc0\x8bu\x0cm\xbc0\xb5	\xb2o\xedE\x9b3ha\x1a\xec\x95\xdb`q\x1c\xf2\x9a{x\xa3%!<\x9a\xb45\xb0'\xac\x14\xb9\xb354\xc9|gL\xe3\xea/n\x9dV\x1dT\xcf!0X\x95\xed\xa7\xc1\x84,:	\xbb\x1b\xb9\xd6'\xd8	\xb4G\xeb\xec8r\x0bk\x14\x83\xfa\x1e-\x8f\xbd\xbd\x0fl\xc4\xa3\xa3\xebb\x8ev\xcf\x99I\x94\xe6Uv@\x95\xfe\xcf3L\x9e\xdd\x17)e'#x\x08oe^\x7f\x14\xf3wl\x0f\xe5\x1eEY\xa1\xa8\xf3|\x13EKf[{3Kjs\x9b}E\x16h\x1a+\xb3\xde\x90\xba$Q\xbd\xdf\xa6\xd5K\xff1\xab\x9e\xb5\xff\xb0^\xe3\xf3=;d\xcf\xcb\xee8O\xf8\xd5\xfc\x10Y\x94\x15\x8a:\xcf7\xd1\xcf\x00PK\x07\x08Tk\xaf\xfd\x15\x01\x00\x00\xf1\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x14\x00	\x000028_outbox_dead.sqlUT\x05\x00\x01\x80Cm8\xc4\x92Ao\x9c0\x10\x85\xef\xfc\x8awk\xa3.\xf9\x03{\xa2\xc1j\x90\x08T\x1bP\xaa^\x90\xc1\xb3\x8bUl#{H\xe8\xbf\xaf \xa8Ym\x93\xf4\x98#\xcc\x9by\x1f\x8f\x17\xc7\xf8b\xf4\xc9K&\xd4c\x14\xc7\x10\x8fd9\xe0\xa9\xd7]\x8fN\xdaO\x8c\x960N\xed\xa0CO\n\xec \x87\x01ajC\xe7uK>@\x1e\x99<\xb8'\x189k3\x19\xd8\xc9\xb4\xe4\xa38\x86;B2\x93\x199\xec\xe0<\x8eR\x0f8:\x8f\x93sj\x07\xe9	\x8a\xa4\xbaF\xd5\xd3\xef\xf5\xf1\x17\x8d\xbc*\xb4\x0d#u\xac\x9dE;\xf1\xc2f\xe9\x91<\xbaAjC\n\xf2$\xb5]\x17A\xde;\xbfxqO\x18d\xe0\xd5g\xd1<{C\x07x\xea\x9cW\xa4\xae\xa3$\xaf\xc4\x01U\xf25\x17p\x13\xb7n\x8e\x00 IS\xdc\x94y}W\xac'\x9a\xe7\xa3L3\xef.\xe7\x8a\xa4j$\x83\xb5\xa1\xc0\xd2\x8cx\xc8\xaa\xdb\xb2\xaePew\x02?\xcbB\xec\xa3\xf4P~GV\xa4\xe2\xc7\xe6\xd2X\x9a\xb9\xd9\x90\x1a\xc9\x8dV\xf3>\xba9\x88\xa4\x12\xff\x15\xa2,\xb63\xa8\xef\xb3\xe2\x1bZ\xf6D\xf8|!\xbdZQ\x1fn\xc5A\xbc\xfc\xb4\x055\xbbGQ\xe79\x92\"\xfd\x8b\xbf\xbd{\x9d\xe1|\xfb=\x80s\xdd\xd5[\xcee\xb59E\xe7\x8dK\xdd\x93}%\xa6K\xe7\x8f\x8a\xf2\x9d\x18\xf7o\x95h\xfd\x9a\xadE[\xcc\xbb\x7f\x06/\xf5\xdaG\x7f\x06\x00PK\x07\x08\x14tB\xc9s\x01\x00\x00\x82\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1e\x00	\x000029_trip_planned_depature.sqlUT\x05\x00\x01\x80Cm8\x8c\x93\xc1n\xdb<\x10\x84\xefz\x8a\xb99\xc1\x1f\x0b\xf8\xcf\xae\x0fn\xc46\x06\x1c)\xb0i$\xe8\xc5`\xc4uD@\"\x05r\x13\xd5}\xfa\x82\xb4\xa3\xa4\xa9\x8b\xf6&\x80\xbb\xdf\xec\xee\x8c\xa6S\xfc\xd7\x99'\xaf\x98\xb0\xed\xb3\xe9\x14\xb2!h\xea\x15?{\x82\xb2\x1a\xca{\xf3\xa2Z\xb8=\x14\xd8\x9b\x1e\xca\x13<\xd5\xcek\xd2\x18\x1a\xb2\xe0\xd8\xe4\xcd\x0by\x04V\x9eC\xec\x8c\xb4\xbd\xb1&4\x14`\xf8\x04#\xfc \xef\xf0l\xd9\xb4\xb1\xcf\xe6I\xb2o\x95\xb5\xa4\xdf\xa4M@ \x1e\xf1\x11\x96\xc4M\x80\xdb\xef\xc9\x93N\xc0\xa4\x1a\xe0\xa93V\x93\x0fW\xa8\x95\xad\xa9m\x15\x1bg1\x18\xab\xdd\x90\xc6\xc1\xa0\x0c\xb7&p\xc8\xb3\xc5J\x8a5\xe4\xe2\xf3J$j\x06\x00\x8b\xa2\xc0u\xb5\xda\xde\x96\xaf\xd3\xec\xc6i\xd8t\x14Xu=\xee\x97\xf2\xa6\xdaJ\xc8\xe5\xad\xc0\xb7\xaa\x14\xb3\xacXWwX\x96\x85xH\xb0\xb1kg\xf4\xf7Yv\xbd\x16\x0b)\xde\xbf\x7f\xa4\xc7:Tez\xc4v\xb3,\xbf\xe2\x91=\x11.>V^\xe2\xfeF\xac\xc5\xeb\xbaz\xa7\x18\xcb\x0d\xca\xedj5\xcb\xe2\x89\xa47}\xc0\xd0\x98\xba\xc1@\x9e`)\x9ar\xb2A\xa3Q/\x84G\"\x8b\xdau}KL\x1a\x8fT\xab\xe7@\xd1`n\xc8\xf8dP\x84\x9d\x9c\xcf\xb1&\xd7'\x1b\\\xa0\x13<\x81\xac\xe3#,\x10sK\x1a\x07\xe2+\x04\x179\x87\x18\x93Hy\x13r\xb6\xa6\xf7Q\x19\xc3\xc1\x0duy\xb6\xbd+\xe2\xa1\xd2\x156B\xbe5\xc6-\xe7i\xc9\xe4\xd3\xf1\x04\xbf\xbc\xc6\x1bT2\x95`Q\x16cf?\xcd1\xa1\xde\xd5\xcd$u\xc6\xa7X'\x1e\x96\x1b\xb9\xc1\xc5F\xac\xc4\xb5\xc4\xff\xf8\xb2\xaen\xd1\x92~\"\xbf#\xcb\xfe\x00:\xc9P\x1e\xe7\xd9\x19\x8d9\xe2Wnt\xc2P\xce\x87\x9e0\xc7\xe4\xb8\xfb\xe4\xf2h\xc0\xf87\x15n\xb0\xbf%\xe3\x9c\xf3\xe7\x12\xf2\xf7d\xfcS\"\xce\xc6<\xcd\xf4\x87\x9c\xcf\xb2\x9f\x03\x00PK\x07\x08[\xcfo\x0e\xfc\x01\x00\x00\x11\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x19\x00	\x000030_receipt_discount.sqlUT\x05\x00\x01\x80Cm8t\x90\xc1j\xf30\x10\x84\xef~\x8a\xb9\xff\xbfC\xef9\xb9uzr\x93\x12\xec\x07P\xa4U\xbc4\xd2\x8a\xcd\xa6!o_,0-\x85^\xbf\x11\xdf\x8c\xb6m\xf1/\xf1Y\x9d\x11\xa6\xd2\xb4-\xc6\x99\xe0\x92\xdc\xb2A\"\x1c\x94<q1\xf0\x156\x13\xa2S\xc2\x95\xcc.\x14`RYP\xfe$\xfd\x8f\xfb\xcc~Fr\x0f\x04\x8e\x91t\xd1E\x95T\x1f\xf9\x9b*eCQ\xf6\xb4\xa8\x17h\xcaeS+\x03_\xfdZ\xba$E%	\xbc\x04\x82R J\x14\xaaN\xb4\xc6'\x91\x0f\xce\xe7eUq\x1cpzT\\.\xce\xa2h\x82\xcb\x01Yl\xe5\xca\x81t\xd3t\xc3\xb8;b\xec\x9e\x87\xdd\xfa\xaf\x06\x00\xba\xbe\xc7\xcba\x98\xde\xf6\xdf;N|\xe6l\xd8\x1fF\xec\xa7a@\xbf{\xed\xa6a\xc4\xd3\xb6i~^\xad\x97{\xfeS\xdc\x1f\x0f\xef\xbf\xcd\xdb\xe6k\x00PK\x07\x08\x15\xc0\x1b\xbd\xe3\x00\x00\x00u\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00#\x00	\x000031_organization_vehicle_owner.sqlUT\x05\x00\x01\x80Cm8\xb4S\xcdj\xdb@\x10\xbe\xeb)\xbe\x9b-\x1a	r.>\xa4\xb5J\x0c\x8e],\xb9?'\xb3\xd5\x8e\xb5\x0b\xda\x9d\xb0;\x96H\x9e\xbeH\xb1\x9c\x1aC\x0f\x85\xea$f\xe6\xfb\x85\xcd2|p\xb6	J\x08\xfb\xe7$\xcb\xf0\x8d\x8c\xad[\x8a\xe0#\x94\x07\x87Fy\xfb\xaa\xc4\xb2\x87\n\x04\xa3\xbc&\x0d\xee(@\x18V\"Z\xf6\x0dEA\x14\xe5\xb5\xf5\x0d\xb8\xf7\x14\x92,Co\xc8C\x0c\xc1\x91\xfbE\x01\xbda\x04jl\x14\n\xa4!\x86\x1cZR\x1d\xc5\x1c\x8f\xcaO\xb4\x86#\x0d\xfao\xa88\xc0\x06k\xaa\x0d\xa4\xf4\x0bZ:\xca\x1d\"C\x0c\xbd\x8c\xa6<\x0b4\xb5$\xa4\xa1Z\xf6\x0dz+f\xd8\xdb\x80#\x07G\x01\xaa\xae\xf9\xe4%O\xf6_\x97\x0fU\x81\xee-(:\x94E\x85S\xa4p\xb0\x1a\x0b\xcc\x13\x9c\xbf\xb2X\x17\x9f+\xb8|Z~\xd9m\x9f\xae\x1a9\x9cs\xb9\x0b\xe6\xfbc\xb1+\xe0\xf2\xab\xab\x91\xb7\xbb\x99=l\x96py\xe0\x96\xb0\xc0l,mv!\xda\xee\x96\xc5\x0e\x9f~\xc2\xe5u %\xa4\x0fJ\xb0^=\xad*\xdc\x8fW\xe9\x1dN\xcfz\xda,0\xf7\xdc\xcfS(\x81XGxeO\x98\x9d\xa4\x9e\xa5\xc9\xbb\xb3[\x17\xab\x12\x9bm\x85\xcd~\xbd\x1e\xef\x06W\xc3\xa0\xf8\xb1*\xab\x12\xf3s\x0b\xf7\x7fI\xff\x0f\xa9\xdf\xfb\xee\xa6\xff\xf4\"\xff\x7f\xa5\xaf\x0bO?&\xc9\x9f\xaf`\xc9\xbdO~\x0f\x00PK\x07\x08\xdf\xff\xb0	n\x01\x00\x00\x17\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x00\x00!(\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1d\x00	\x000032_organization_webhook.sqlUT\x05\x00\x01\x80Cm8\x84\x92Qo\xda0\x14\x85\xdf\xfd+\xce#hM\xff\x00\xd3\xa4,1m\xd4\xd4\x99\x12G\xdd\x9e\x90I.\x8dE\xb1\x91c\x92u\xbf~J(\x08\xca`\x8f\xf6=W\xe7~\xf7\x9e \xc0\x97\x8d~u\xca\x13\xca-\x0b\x02\xbc\xd0\xb2\xb1v\xddB9\x82\xed\x0d\xd5X\xbe\x83\xb4o\xc8Aa\xd7\x92\x83uP\x06\xd6\xbd*\xa3\xff(\xaf\xad\xb9\x87llK\xb0+(\xc3\x82\xe0\xac\x08G\x15\xe9\x8e\xe0\x1b\x02ud|\x0b\xbb\x1a_\xde\xe9m\x8b\xda\xe9\x8e\x0cz\xed\x1bh\xdf\xa2\xa3FWo\xd4B\x99z\x98i\x18eM[\x8f\xbe!3\xf6mh\xb3$\x87\xbe\xb1\xa8\x1c)O\xf5\xf0\xbd\xc1\x1b\xa9\x8e\xda{\x16\xa6\x92\xe7\x90\xe1\xf7\x94\xa3\xdf\x131\x00\xd8\xffGYZ>\x8b\x91e\xa1k\xc4y\xf6\x03\"\x93\x10e\x9a\xde\xeduq|P\x9d\x92\x0c\xea\xddN\xd7\xa7\"Q\xc8<L\x84<\xf8,>5,Vkz\xc7<\xcby\xf2 \xf0\xc4\x7fa\xf2I1E\xce\xe7<\xe7\"\xe2\xc5\xf9\xe2&\xba\x9e\"\x13\x88y\xca%G\x14\x16Q\x18\xf3\xdb\xee\xbd!\xb7\xa8\x1a\xaa\xd6\x88\x1ey\xf4\x84\xc9\xe4\x00\x9a\x14#\xe2\x14_\xbf]\x0cq,Ng,\xcay(9\x12\x11\xf3\x9fW\xb1t\xfd{\x18\xed\xa3\x8c\xb2H\xc4\x03\x96\xde\x11]\x02\xce\x18;MZl{\xc3\xc6\xad\xff\xd7b\xc6>\xd8\xe7y\xf6|4{y\xe49\xbf\xb8LR\x1c\xaf8\xbb\x9a\x80\xd1\xf6\xf6\xde\xeen\x0b\xcf]\xc7\xf3\x9eu\xfc+5w\xd7\xd3Wp	\x91I\x882Mg\xec\xef\x00PK\x07\x08\xf4\xb8)*\x93\x01\x00\x00\x91\x03\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(N%i\x05z\x00\x00\x00s\x00\x00\x00\x17\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x00\x00\x00\x000000_database_setup.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x18\xfe&bX\x01\x00\x00e\x03\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xc8\x00\x00\x000001_user_account.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(J6\x19\x108\x01\x00\x00\x0d\x03\x00\x00\x13\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81l\x02\x00\x000002_user_token.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x04\xeeP\xab\x91\x01\x00\x00\xbe\x03\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xee\x03\x00\x000003_vehicle.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xc2\xbd\xf1\x82\xae\x01\x00\x00\xcc\x04\x00\x00\x0d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xc6\x05\x00\x000004_trip.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(1Un\xa4\x9e\x01\x00\x00T\x04\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\xb8\x07\x00\x000005_rating.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xab\xb0\x16%B\x01\x00\x00\xe6\x02\x00\x00 \x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9c	\x00\x000006_user_email_verification.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xfb)\x80\x8a\xb9\x02\x00\x00\xb2\x0c\x00\x00\x16\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x815\x0b\x00\x000007_user_deletion.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(uN\x9a\xef\x97\x00\x00\x00\xe2\x00\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81;\x0e\x00\x000008_user_privacy.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe8\xb25EN\x03\x00\x00W\x08\x00\x00\x17\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1e\x0f\x00\x000009_rating_summary.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x8d\x1f\x04\x81\xbf\x00\x00\x001\x01\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xba\x12\x00\x000010_rating_role.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x8ao\x85\xd8\x04\x02\x00\x00E\x06\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc4\x13\x00\x000011_rating_moderation.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xaf\xfcX\xab\xdc\x01\x00\x00#\x05\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x19\x16\x00\x000012_message.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x89.\xfb*\xb5\x01\x00\x00\xee\x03\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81<\x18\x00\x000013_notification.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xb3zB\xe2+\x02\x00\x00_\x07\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81=\x1a\x00\x000014_webhook.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(~\x06%\x97\xa6\x02\x00\x00\x80\x07\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xaf\x1c\x00\x000015_outbox.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(V\x13\x1bZ#\x02\x00\x00\x07\x05\x00\x00\x0c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9b\x1f\x00\x000016_job.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xe0\xa2t\x8e\x06\x02\x00\x00U\x05\x00\x00\x1a\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x01\"\x00\x000017_trip_cancellation.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(I\x8a\x10\xca{\x02\x00\x00;\x06\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81X$\x00\x000018_booking.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(*\xc6\x15\xee\xf6\x01\x00\x00\xef\x04\x00\x00\x11\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1a'\x00\x000019_waitlist.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(I\xc1\x92\xd1\x8c\x04\x00\x00\"\x0f\x00\x00\x0f\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81X)\x00\x000020_ledger.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x8bZ\xef\x04\xd3\x02\x00\x00\x13\x08\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81*.\x00\x000021_payment.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x8dc	!D\x02\x00\x00\xc3\x05\x00\x00\x10\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81D1\x00\x000022_receipt.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x87f\xa5\xac\x06\x04\x00\x00\x13\x0e\x00\x00\x12\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcf3\x00\x000023_promotion.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(d\xdbls\x7f\x02\x00\x00\xab\x07\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x1e8\x00\x000024_organization.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(0\xb928\x83\x02\x00\x00m\x07\x00\x00\x18\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xe9:\x00\x000025_vehicle_details.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xecz\xce\x97\xc7\x01\x00\x00\xa7\x03\x00\x00\x15\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbb=\x00\x000026_vehicle_file.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(Tk\xaf\xfd\x15\x01\x00\x00\xf1\x01\x00\x00\x19\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xce?\x00\x000027_user_avatar_blob.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x14tB\xc9s\x01\x00\x00\x82\x03\x00\x00\x14\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x813A\x00\x000028_outbox_dead.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!([\xcfo\x0e\xfc\x01\x00\x00\x11\x04\x00\x00\x1e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf1B\x00\x000029_trip_planned_depature.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\x15\xc0\x1b\xbd\xe3\x00\x00\x00u\x01\x00\x00\x19\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81BE\x00\x000030_receipt_discount.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xdf\xff\xb0	n\x01\x00\x00\x17\x03\x00\x00#\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81uF\x00\x000031_organization_vehicle_owner.sqlUT\x05\x00\x01\x80Cm8PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x00\x00!(\xf4\xb8)*\x93\x01\x00\x00\x91\x03\x00\x00\x1d\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81=H\x00\x000032_organization_webhook.sqlUT\x05\x00\x01\x80Cm8PK\x05\x06\x00\x00\x00\x00!\x00!\x00\xc4	\x00\x00$J\x00\x00\x00\x00"
 	fs.RegisterWithNamespace("migrations", data)
 }
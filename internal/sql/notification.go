@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.NotificationRepository = (*NotificationRepository)(nil)
+
+const (
+	listNotificationsSQL        = "SELECT id, user_id, trip_id, event_id, type, title, read_at, created_at FROM notification WHERE user_id = $1 ORDER BY created_at DESC"
+	createNotificationSQL       = "INSERT INTO notification (user_id, trip_id, event_id, type, title) VALUES (:user_id, :trip_id, :event_id, :type, :title) ON CONFLICT (event_id) DO NOTHING RETURNING id, created_at"
+	markNotificationReadSQL     = "UPDATE notification SET read_at = coalesce(read_at, (now() at time zone 'utc')) WHERE id = $1 AND user_id = $2"
+	markAllNotificationsReadSQL = "UPDATE notification SET read_at = (now() at time zone 'utc') WHERE user_id = $1 AND read_at IS NULL"
+)
+
+// NotificationRepository provides access to the notification resource backed
+// by a Postgres SQL database.
+type NotificationRepository struct {
+	db *sqlx.DB
+
+	listStmt        *sqlx.Stmt
+	createStmt      *sqlx.NamedStmt
+	markReadStmt    *sqlx.Stmt
+	markAllReadStmt *sqlx.Stmt
+}
+
+// NewNotificationRepository returns a new NotificationRepository based on top
+// of the provided database connection.
+func NewNotificationRepository(ctx context.Context, db *sqlx.DB) (*NotificationRepository, error) {
+	s := &NotificationRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listNotificationsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list notifications statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createNotificationSQL); err != nil {
+		return nil, fmt.Errorf("prepare create notification statement: %w", err)
+	}
+	if s.markReadStmt, err = db.PreparexContext(ctx, markNotificationReadSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark notification read statement: %w", err)
+	}
+	if s.markAllReadStmt, err = db.PreparexContext(ctx, markAllNotificationsReadSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark all notifications read statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *NotificationRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list notifications statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create notification statement: %w", err)
+	}
+	if err := s.markReadStmt.Close(); err != nil {
+		return fmt.Errorf("close mark notification read statement: %w", err)
+	}
+	if err := s.markAllReadStmt.Close(); err != nil {
+		return fmt.Errorf("close mark all notifications read statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotifications lists all notifications of the user identified by his
+// unique ID, most recent first.
+func (s *NotificationRepository) ListNotifications(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Notification, error) {
+	notifications := make([]*cargonaut.Notification, 0)
+	if err := s.listStmt.SelectContext(ctx, &notifications, userID); err != nil {
+		return nil, fmt.Errorf("select notifications of user %q from database: %w", userID, err)
+	}
+	return notifications, nil
+}
+
+// CreateNotification creates a new notification.
+func (s *NotificationRepository) CreateNotification(ctx context.Context, notification *cargonaut.Notification) error {
+	// A notification for an event which has already been notified about is
+	// not created again.
+	if err := s.createStmt.GetContext(ctx, notification, notification); err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("create notification in database: %w", err)
+	}
+	return nil
+}
+
+// MarkNotificationRead marks the notification identified by its unique ID
+// which belongs to the user identified by his unique ID as read.
+func (s *NotificationRepository) MarkNotificationRead(ctx context.Context, id, userID uuid.UUID) error {
+	res, err := s.markReadStmt.ExecContext(ctx, id, userID)
+	if err != nil {
+		return fmt.Errorf("mark notification %q read in database: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("mark notification %q read in database: %w", id, err)
+	} else if n == 0 {
+		return cargonaut.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks all notifications of the user identified by
+// his unique ID as read.
+func (s *NotificationRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.markAllReadStmt.ExecContext(ctx, userID); err != nil {
+		return fmt.Errorf("mark all notifications of user %q read in database: %w", userID, err)
+	}
+	return nil
+}
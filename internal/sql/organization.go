@@ -0,0 +1,376 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.OrganizationRepository = (*OrganizationRepository)(nil)
+
+const (
+	listOrganizationsSQL        = "SELECT o.id, o.name, o.created_at, o.updated_at FROM organization o JOIN organization_member m ON m.organization_id = o.id WHERE m.user_id = $1 ORDER BY o.name"
+	getOrganizationSQL          = "SELECT id, name, created_at, updated_at FROM organization WHERE id = $1 LIMIT 1"
+	createOrganizationSQL       = "INSERT INTO organization (name) VALUES (:name) RETURNING id, created_at, updated_at"
+	updateOrganizationSQL       = "UPDATE organization SET name = :name, updated_at = (now() at time zone 'utc') WHERE id = :id"
+	deleteOrganizationSQL       = "DELETE FROM organization WHERE id = $1"
+	lockOrganizationSQL         = "SELECT id FROM organization WHERE id = $1 FOR UPDATE"
+	listOrganizationMembersSQL  = "SELECT organization_id, user_id, role, created_at FROM organization_member WHERE organization_id = $1 ORDER BY created_at"
+	getOrganizationMemberSQL    = "SELECT organization_id, user_id, role, created_at FROM organization_member WHERE organization_id = $1 AND user_id = $2 LIMIT 1"
+	saveOrganizationMemberSQL   = "INSERT INTO organization_member (organization_id, user_id, role) VALUES (:organization_id, :user_id, :role) ON CONFLICT (organization_id, user_id) DO UPDATE SET role = excluded.role RETURNING created_at"
+	removeOrganizationMemberSQL = "DELETE FROM organization_member WHERE organization_id = $1 AND user_id = $2"
+	longestOwnerSQL             = "SELECT user_id FROM organization_member WHERE organization_id = $1 AND role = 'owner' ORDER BY created_at LIMIT 1"
+	countOrganizationOwnersSQL  = "SELECT count(*) FROM organization_member WHERE organization_id = $1 AND role = 'owner'"
+	listOrganizationVehiclesSQL = "SELECT id, user_id, organization_id, license_plate, type, brand, model, color, passengers, max_payload, loading_area_length, loading_area_width, loading_area_height, loading_volume, amenities, created_at, updated_at FROM vehicle WHERE organization_id = $1 ORDER BY updated_at DESC"
+	handOverMemberVehiclesSQL   = "UPDATE vehicle SET user_id = (" + longestOwnerSQL + "), updated_at = (now() at time zone 'utc') WHERE organization_id = $1 AND user_id = $2"
+	handOverVehiclesSQL         = "UPDATE vehicle SET user_id = (" + longestOwnerSQL + "), updated_at = (now() at time zone 'utc') WHERE organization_id = $1"
+	listOrganizationTripsSQL    = "SELECT t.id, t.user_id, t.vehicle_id, t.rider_id, t.start, t.destination, t.price, t.approval_required, t.planned_depature, t.depature, t.arrival, t.completed_at, t.cancelled_at, t.cancellation_reason, t.created_at, t.updated_at FROM trip t JOIN vehicle v ON v.id = t.vehicle_id WHERE v.organization_id = $1 ORDER BY coalesce(t.planned_depature, t.depature) DESC"
+)
+
+// OrganizationRepository provides access to the organization resource backed
+// by a Postgres SQL database.
+type OrganizationRepository struct {
+	db *sqlx.DB
+
+	listStmt         *sqlx.Stmt
+	getStmt          *sqlx.Stmt
+	createStmt       *sqlx.NamedStmt
+	updateStmt       *sqlx.NamedStmt
+	deleteStmt       *sqlx.Stmt
+	lockStmt         *sqlx.Stmt
+	listMembersStmt  *sqlx.Stmt
+	getMemberStmt    *sqlx.Stmt
+	saveMemberStmt   *sqlx.NamedStmt
+	removeMemberStmt *sqlx.Stmt
+	countOwnersStmt  *sqlx.Stmt
+	listVehiclesStmt *sqlx.Stmt
+	listTripsStmt    *sqlx.Stmt
+
+	handOverMemberVehiclesStmt *sqlx.Stmt
+	handOverVehiclesStmt       *sqlx.Stmt
+}
+
+// NewOrganizationRepository returns a new OrganizationRepository based on top
+// of the provided database connection.
+func NewOrganizationRepository(ctx context.Context, db *sqlx.DB) (*OrganizationRepository, error) {
+	s := &OrganizationRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listOrganizationsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list organizations statement: %w", err)
+	}
+	if s.getStmt, err = db.PreparexContext(ctx, getOrganizationSQL); err != nil {
+		return nil, fmt.Errorf("prepare get organization statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createOrganizationSQL); err != nil {
+		return nil, fmt.Errorf("prepare create organization statement: %w", err)
+	}
+	if s.updateStmt, err = db.PrepareNamedContext(ctx, updateOrganizationSQL); err != nil {
+		return nil, fmt.Errorf("prepare update organization statement: %w", err)
+	}
+	if s.deleteStmt, err = db.PreparexContext(ctx, deleteOrganizationSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete organization statement: %w", err)
+	}
+	if s.lockStmt, err = db.PreparexContext(ctx, lockOrganizationSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock organization statement: %w", err)
+	}
+	if s.listMembersStmt, err = db.PreparexContext(ctx, listOrganizationMembersSQL); err != nil {
+		return nil, fmt.Errorf("prepare list organization members statement: %w", err)
+	}
+	if s.getMemberStmt, err = db.PreparexContext(ctx, getOrganizationMemberSQL); err != nil {
+		return nil, fmt.Errorf("prepare get organization member statement: %w", err)
+	}
+	if s.saveMemberStmt, err = db.PrepareNamedContext(ctx, saveOrganizationMemberSQL); err != nil {
+		return nil, fmt.Errorf("prepare save organization member statement: %w", err)
+	}
+	if s.removeMemberStmt, err = db.PreparexContext(ctx, removeOrganizationMemberSQL); err != nil {
+		return nil, fmt.Errorf("prepare remove organization member statement: %w", err)
+	}
+	if s.countOwnersStmt, err = db.PreparexContext(ctx, countOrganizationOwnersSQL); err != nil {
+		return nil, fmt.Errorf("prepare count organization owners statement: %w", err)
+	}
+	if s.listVehiclesStmt, err = db.PreparexContext(ctx, listOrganizationVehiclesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list organization vehicles statement: %w", err)
+	}
+	if s.listTripsStmt, err = db.PreparexContext(ctx, listOrganizationTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list organization trips statement: %w", err)
+	}
+	if s.handOverMemberVehiclesStmt, err = db.PreparexContext(ctx, handOverMemberVehiclesSQL); err != nil {
+		return nil, fmt.Errorf("prepare hand over organization member vehicles statement: %w", err)
+	}
+	if s.handOverVehiclesStmt, err = db.PreparexContext(ctx, handOverVehiclesSQL); err != nil {
+		return nil, fmt.Errorf("prepare hand over organization vehicles statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *OrganizationRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list organizations statement: %w", err)
+	}
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get organization statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create organization statement: %w", err)
+	}
+	if err := s.updateStmt.Close(); err != nil {
+		return fmt.Errorf("close update organization statement: %w", err)
+	}
+	if err := s.deleteStmt.Close(); err != nil {
+		return fmt.Errorf("close delete organization statement: %w", err)
+	}
+	if err := s.lockStmt.Close(); err != nil {
+		return fmt.Errorf("close lock organization statement: %w", err)
+	}
+	if err := s.listMembersStmt.Close(); err != nil {
+		return fmt.Errorf("close list organization members statement: %w", err)
+	}
+	if err := s.getMemberStmt.Close(); err != nil {
+		return fmt.Errorf("close get organization member statement: %w", err)
+	}
+	if err := s.saveMemberStmt.Close(); err != nil {
+		return fmt.Errorf("close save organization member statement: %w", err)
+	}
+	if err := s.removeMemberStmt.Close(); err != nil {
+		return fmt.Errorf("close remove organization member statement: %w", err)
+	}
+	if err := s.countOwnersStmt.Close(); err != nil {
+		return fmt.Errorf("close count organization owners statement: %w", err)
+	}
+	if err := s.listVehiclesStmt.Close(); err != nil {
+		return fmt.Errorf("close list organization vehicles statement: %w", err)
+	}
+	if err := s.listTripsStmt.Close(); err != nil {
+		return fmt.Errorf("close list organization trips statement: %w", err)
+	}
+	if err := s.handOverMemberVehiclesStmt.Close(); err != nil {
+		return fmt.Errorf("close hand over organization member vehicles statement: %w", err)
+	}
+	if err := s.handOverVehiclesStmt.Close(); err != nil {
+		return fmt.Errorf("close hand over organization vehicles statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListOrganizations lists the organizations the user identified by his unique
+// ID is a member of.
+func (s *OrganizationRepository) ListOrganizations(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Organization, error) {
+	organizations := make([]*cargonaut.Organization, 0)
+	if err := s.listStmt.SelectContext(ctx, &organizations, userID); err != nil {
+		return nil, fmt.Errorf("select organizations of user %q from database: %w", userID, err)
+	}
+	return organizations, nil
+}
+
+// GetOrganization returns an organization identified by its unique ID.
+func (s *OrganizationRepository) GetOrganization(ctx context.Context, id uuid.UUID) (*cargonaut.Organization, error) {
+	organization := new(cargonaut.Organization)
+	if err := s.getStmt.GetContext(ctx, organization, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrOrganizationNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get organization %q from database: %w", id, err)
+	}
+	return organization, nil
+}
+
+// CreateOrganization creates a new organization and adds the user identified
+// by his unique ID as its owner in the same transaction.
+func (s *OrganizationRepository) CreateOrganization(ctx context.Context, organization *cargonaut.Organization, ownerID uuid.UUID) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = tx.NamedStmtContext(ctx, s.createStmt).GetContext(ctx, organization, organization); err != nil {
+		return fmt.Errorf("create organization in database: %w", err)
+	}
+
+	owner := &cargonaut.OrganizationMember{
+		OrganizationID: organization.ID,
+		UserID:         ownerID,
+		Role:           cargonaut.OrganizationRoleOwner,
+	}
+	if err = tx.NamedStmtContext(ctx, s.saveMemberStmt).GetContext(ctx, owner, owner); err != nil {
+		return fmt.Errorf("add owner %q to organization %q in database: %w", ownerID, organization.ID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateOrganization updates a given organization.
+func (s *OrganizationRepository) UpdateOrganization(ctx context.Context, organization *cargonaut.Organization) error {
+	if _, err := s.updateStmt.ExecContext(ctx, organization); err != nil {
+		return fmt.Errorf("update organization %q in database: %w", organization.ID, err)
+	}
+	return nil
+}
+
+// DeleteOrganization deletes an organization identified by its unique ID along
+// with its memberships. Its vehicles are handed over to its longest standing
+// owner, who keeps them as personal vehicles.
+func (s *OrganizationRepository) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = s.lock(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.handOverVehiclesStmt).ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("hand over vehicles of organization %q in database: %w", id, err)
+	}
+	if _, err = tx.StmtxContext(ctx, s.deleteStmt).ExecContext(ctx, id); isAlreadyExistsError(err) {
+		return cargonaut.ErrVehicleExists
+	} else if err != nil {
+		return fmt.Errorf("delete organization %q from database: %w", id, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListMembers lists the members of the organization identified by its unique
+// ID, longest members first.
+func (s *OrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]*cargonaut.OrganizationMember, error) {
+	members := make([]*cargonaut.OrganizationMember, 0)
+	if err := s.listMembersStmt.SelectContext(ctx, &members, organizationID); err != nil {
+		return nil, fmt.Errorf("select members of organization %q from database: %w", organizationID, err)
+	}
+	return members, nil
+}
+
+// GetMember returns the membership of the user in the organization, both
+// identified by their unique ID.
+func (s *OrganizationRepository) GetMember(ctx context.Context, organizationID, userID uuid.UUID) (*cargonaut.OrganizationMember, error) {
+	member := new(cargonaut.OrganizationMember)
+	if err := s.getMemberStmt.GetContext(ctx, member, organizationID, userID); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrOrganizationMemberNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get member %q of organization %q from database: %w", userID, organizationID, err)
+	}
+	return member, nil
+}
+
+// SaveMember adds a member to an organization or changes the role of an
+// existing member. The organization is locked so concurrent changes can not
+// leave it without an owner.
+func (s *OrganizationRepository) SaveMember(ctx context.Context, member *cargonaut.OrganizationMember) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = s.lock(ctx, tx, member.OrganizationID); err != nil {
+		return err
+	}
+	if err = tx.NamedStmtContext(ctx, s.saveMemberStmt).GetContext(ctx, member, member); err != nil {
+		return fmt.Errorf("save member %q of organization %q in database: %w", member.UserID, member.OrganizationID, err)
+	}
+	if err = s.requireOwner(ctx, tx, member.OrganizationID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember removes the user from the organization, both identified by
+// their unique ID. The vehicles the member registered for the organization are
+// handed over to its longest standing owner. The organization is locked so
+// concurrent changes can not leave it without an owner.
+func (s *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = s.lock(ctx, tx, organizationID); err != nil {
+		return err
+	}
+	res, err := tx.StmtxContext(ctx, s.removeMemberStmt).ExecContext(ctx, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("remove member %q from organization %q in database: %w", userID, organizationID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("remove member %q from organization %q in database: %w", userID, organizationID, err)
+	} else if n == 0 {
+		return cargonaut.ErrOrganizationMemberNotFound
+	}
+	if err = s.requireOwner(ctx, tx, organizationID); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.handOverMemberVehiclesStmt).ExecContext(ctx, organizationID, userID); err != nil {
+		return fmt.Errorf("hand over vehicles of member %q of organization %q in database: %w", userID, organizationID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ListVehicles lists the vehicles of the organization identified by its unique
+// ID.
+func (s *OrganizationRepository) ListVehicles(ctx context.Context, organizationID uuid.UUID) ([]*cargonaut.Vehicle, error) {
+	vehicles := make([]*cargonaut.Vehicle, 0)
+	if err := s.listVehiclesStmt.SelectContext(ctx, &vehicles, organizationID); err != nil {
+		return nil, fmt.Errorf("select vehicles of organization %q from database: %w", organizationID, err)
+	}
+	return vehicles, nil
+}
+
+// ListTrips lists the trips driven with the vehicles of the organization
+// identified by its unique ID, latest planned depature first.
+func (s *OrganizationRepository) ListTrips(ctx context.Context, organizationID uuid.UUID) ([]*cargonaut.Trip, error) {
+	trips := make([]*cargonaut.Trip, 0)
+	if err := s.listTripsStmt.SelectContext(ctx, &trips, organizationID); err != nil {
+		return nil, fmt.Errorf("select trips of organization %q from database: %w", organizationID, err)
+	}
+	return trips, nil
+}
+
+// lock locks the organization identified by its unique ID until the
+// transaction ends.
+func (s *OrganizationRepository) lock(ctx context.Context, tx *sqlx.Tx, organizationID uuid.UUID) error {
+	var id uuid.UUID
+	if err := tx.StmtxContext(ctx, s.lockStmt).GetContext(ctx, &id, organizationID); err == sql.ErrNoRows {
+		return cargonaut.ErrOrganizationNotFound
+	} else if err != nil {
+		return fmt.Errorf("lock organization %q in database: %w", organizationID, err)
+	}
+	return nil
+}
+
+// requireOwner makes sure the organization identified by its unique ID still
+// has an owner.
+func (s *OrganizationRepository) requireOwner(ctx context.Context, tx *sqlx.Tx, organizationID uuid.UUID) error {
+	var owners int
+	if err := tx.StmtxContext(ctx, s.countOwnersStmt).GetContext(ctx, &owners, organizationID); err != nil {
+		return fmt.Errorf("count owners of organization %q in database: %w", organizationID, err)
+	} else if owners == 0 {
+		return cargonaut.ErrOrganizationOwnerRequired
+	}
+	return nil
+}
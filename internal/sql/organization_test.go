@@ -0,0 +1,214 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// createTestOrganization creates an organization owned by the user.
+func createTestOrganization(t *testing.T, repo *OrganizationRepository, ownerID uuid.UUID) *cargonaut.Organization {
+	organization := &cargonaut.Organization{Name: "Test Organization"}
+	require.NoError(t, repo.CreateOrganization(context.Background(), organization, ownerID))
+	return organization
+}
+
+func TestOrganizationRepositoryCreateOrganization(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewOrganizationRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	owner := createTestUser(t, db)
+	organization := createTestOrganization(t, repo, owner.ID)
+
+	member, err := repo.GetMember(ctx, organization.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cargonaut.OrganizationRoleOwner, member.Role)
+
+	organizations, err := repo.ListOrganizations(ctx, owner.ID)
+	require.NoError(t, err)
+	if assert.Len(t, organizations, 1) {
+		assert.Equal(t, organization.ID, organizations[0].ID)
+	}
+
+	_, err = repo.GetMember(ctx, organization.ID, createTestUser(t, db).ID)
+	assert.Equal(t, cargonaut.ErrOrganizationMemberNotFound, err)
+}
+
+func TestOrganizationRepositoryOwnerRequired(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewOrganizationRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	owner := createTestUser(t, db)
+	organization := createTestOrganization(t, repo, owner.ID)
+	member := func(userID uuid.UUID, role cargonaut.OrganizationRole) *cargonaut.OrganizationMember {
+		return &cargonaut.OrganizationMember{OrganizationID: organization.ID, UserID: userID, Role: role}
+	}
+
+	// The last owner can neither be demoted nor removed.
+	assert.Equal(t, cargonaut.ErrOrganizationOwnerRequired, repo.SaveMember(ctx, member(owner.ID, cargonaut.OrganizationRoleDispatcher)))
+	assert.Equal(t, cargonaut.ErrOrganizationOwnerRequired, repo.RemoveMember(ctx, organization.ID, owner.ID))
+	got, err := repo.GetMember(ctx, organization.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cargonaut.OrganizationRoleOwner, got.Role)
+
+	// With a second owner, the first one can step down and leave.
+	second := createTestUser(t, db)
+	require.NoError(t, repo.SaveMember(ctx, member(second.ID, cargonaut.OrganizationRoleOwner)))
+	require.NoError(t, repo.SaveMember(ctx, member(owner.ID, cargonaut.OrganizationRoleDriver)))
+	require.NoError(t, repo.RemoveMember(ctx, organization.ID, owner.ID))
+
+	members, err := repo.ListMembers(ctx, organization.ID)
+	require.NoError(t, err)
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, second.ID, members[0].UserID)
+	}
+
+	assert.Equal(t, cargonaut.ErrOrganizationMemberNotFound, repo.RemoveMember(ctx, organization.ID, owner.ID))
+	assert.Equal(t, cargonaut.ErrOrganizationNotFound, repo.SaveMember(ctx, &cargonaut.OrganizationMember{
+		OrganizationID: uuid.NewV4(),
+		UserID:         owner.ID,
+		Role:           cargonaut.OrganizationRoleOwner,
+	}))
+}
+
+func TestOrganizationScope(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	organizations, err := NewOrganizationRepository(ctx, db)
+	require.NoError(t, err)
+	defer organizations.Close()
+	vehicles, err := NewVehicleRepository(ctx, db)
+	require.NoError(t, err)
+	defer vehicles.Close()
+	users, err := NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	defer users.Close()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+
+	owner := createTestUser(t, db)
+	organization := createTestOrganization(t, organizations, owner.ID)
+	personalID := createTestVehicle(t, db, owner.ID)
+	fleetVehicle := &cargonaut.Vehicle{
+		UserID:         owner.ID,
+		OrganizationID: &organization.ID,
+		LicensePlate:   uuid.NewV4().String()[:8],
+		Type:           cargonaut.VehicleTypeVan,
+		Brand:          "Test",
+		Model:          "Van",
+	}
+	require.NoError(t, vehicles.CreateVehicle(ctx, fleetVehicle))
+	dispatched := createTestTrip(t, db, trips, func(trip *cargonaut.Trip) { trip.VehicleID = fleetVehicle.ID })
+
+	ids := func(vehicles []*cargonaut.Vehicle) []uuid.UUID {
+		ids := make([]uuid.UUID, len(vehicles))
+		for i, vehicle := range vehicles {
+			ids[i] = vehicle.ID
+		}
+		return ids
+	}
+
+	// Vehicles of organizations are only listed within their organization.
+	listed, err := vehicles.ListVehicles(ctx, nil)
+	require.NoError(t, err)
+	assert.Contains(t, ids(listed), personalID)
+	assert.NotContains(t, ids(listed), fleetVehicle.ID)
+
+	listed, err = vehicles.ListVehicles(ctx, &organization.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{fleetVehicle.ID}, ids(listed))
+
+	listed, err = users.ListVehicles(ctx, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{personalID}, ids(listed))
+
+	listed, err = organizations.ListVehicles(ctx, organization.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{fleetVehicle.ID}, ids(listed))
+
+	// Trips can be scoped to the organization owning their vehicle.
+	createTestTrip(t, db, trips, nil)
+	for _, sort := range []cargonaut.TripSort{cargonaut.TripSortUpdated, cargonaut.TripSortDriverScore} {
+		listed, err := trips.ListTrips(ctx, &cargonaut.TripFilter{Sort: sort, OrganizationID: &organization.ID})
+		require.NoError(t, err)
+		if assert.Len(t, listed, 1, string(sort)) {
+			assert.Equal(t, dispatched.ID, listed[0].ID, string(sort))
+		}
+	}
+}
+
+func TestOrganizationRepositoryHandOverVehicles(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	organizations, err := NewOrganizationRepository(ctx, db)
+	require.NoError(t, err)
+	defer organizations.Close()
+	vehicles, err := NewVehicleRepository(ctx, db)
+	require.NoError(t, err)
+	defer vehicles.Close()
+	users, err := NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	defer users.Close()
+
+	owner := createTestUser(t, db)
+	organization := createTestOrganization(t, organizations, owner.ID)
+	join := func(role cargonaut.OrganizationRole) uuid.UUID {
+		userID := createTestUser(t, db).ID
+		require.NoError(t, organizations.SaveMember(ctx, &cargonaut.OrganizationMember{OrganizationID: organization.ID, UserID: userID, Role: role}))
+		return userID
+	}
+	register := func(userID uuid.UUID) *cargonaut.Vehicle {
+		vehicle := &cargonaut.Vehicle{
+			UserID:         userID,
+			OrganizationID: &organization.ID,
+			LicensePlate:   uuid.NewV4().String()[:8],
+			Type:           cargonaut.VehicleTypeVan,
+			Brand:          "Test",
+			Model:          "Van",
+		}
+		require.NoError(t, vehicles.CreateVehicle(ctx, vehicle))
+		return vehicle
+	}
+	assertOwner := func(vehicleID, userID uuid.UUID, organizationID *uuid.UUID) {
+		vehicle, err := vehicles.GetVehicle(ctx, vehicleID)
+		require.NoError(t, err)
+		assert.Equal(t, userID, vehicle.UserID)
+		assert.Equal(t, organizationID, vehicle.OrganizationID)
+	}
+
+	// The vehicles of members leaving the organization or deleting their
+	// account are handed over to the owner.
+	leaving := join(cargonaut.OrganizationRoleDispatcher)
+	left := register(leaving)
+	require.NoError(t, organizations.RemoveMember(ctx, organization.ID, leaving))
+	assertOwner(left.ID, owner.ID, &organization.ID)
+
+	deleting := join(cargonaut.OrganizationRoleDriver)
+	deleted := register(deleting)
+	require.NoError(t, users.DeleteUser(ctx, deleting))
+	assertOwner(deleted.ID, owner.ID, &organization.ID)
+
+	// The last owner can not delete the account, but the organization. Its
+	// vehicles are kept by the owner then.
+	driver := join(cargonaut.OrganizationRoleDriver)
+	driven := register(driver)
+	assert.Equal(t, cargonaut.ErrOrganizationOwnerRequired, users.DeleteUser(ctx, owner.ID))
+	require.NoError(t, organizations.DeleteOrganization(ctx, organization.ID))
+	assertOwner(left.ID, owner.ID, nil)
+	assertOwner(driven.ID, owner.ID, nil)
+	assert.Equal(t, cargonaut.ErrOrganizationNotFound, organizations.DeleteOrganization(ctx, organization.ID))
+	require.NoError(t, users.DeleteUser(ctx, owner.ID))
+}
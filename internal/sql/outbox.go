@@ -0,0 +1,174 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.OutboxRepository = (*OutboxRepository)(nil)
+
+const (
+	createEventSQL          = "INSERT INTO outbox (id, type, user_id, trip_id, created_at) VALUES (:id, :type, :user_id, :trip_id, :created_at)"
+	claimPendingEventsSQL   = "UPDATE outbox SET next_attempt_at = (now() at time zone 'utc') + make_interval(secs => $2) WHERE id IN (SELECT id FROM outbox WHERE published_at IS NULL AND dead_at IS NULL AND next_attempt_at <= (now() at time zone 'utc') ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED) RETURNING id, type, user_id, trip_id, attempts, created_at"
+	listEventSubscribersSQL = "SELECT subscriber FROM outbox_delivery WHERE event_id = $1"
+	markEventDeliveredSQL   = "INSERT INTO outbox_delivery (event_id, subscriber) VALUES ($1, $2) ON CONFLICT (event_id, subscriber) DO NOTHING"
+	markEventPublishedSQL   = "UPDATE outbox SET published_at = (now() at time zone 'utc') WHERE id = $1"
+	retryEventSQL           = "UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1"
+	markEventDeadSQL        = "UPDATE outbox SET attempts = attempts + 1, dead_at = (now() at time zone 'utc'), last_error = $2 WHERE id = $1"
+	prunePublishedEventsSQL = "DELETE FROM outbox WHERE published_at < $1"
+)
+
+// createEvents writes the events to the outbox as part of the transaction.
+func createEvents(ctx context.Context, tx *sqlx.Tx, stmt *sqlx.NamedStmt, events []*cargonaut.Event) error {
+	for _, event := range events {
+		if _, err := tx.NamedStmtContext(ctx, stmt).ExecContext(ctx, event); err != nil {
+			return fmt.Errorf("write %s event %q to outbox: %w", event.Type, event.ID, err)
+		}
+	}
+	return nil
+}
+
+// OutboxRepository provides access to the outbox backed by a Postgres SQL
+// database.
+type OutboxRepository struct {
+	db *sqlx.DB
+
+	claimPendingStmt    *sqlx.Stmt
+	listSubscribersStmt *sqlx.Stmt
+	markDeliveredStmt   *sqlx.Stmt
+	markPublishedStmt   *sqlx.Stmt
+	retryStmt           *sqlx.Stmt
+	markDeadStmt        *sqlx.Stmt
+	pruneStmt           *sqlx.Stmt
+}
+
+// NewOutboxRepository returns a new OutboxRepository based on top of the
+// provided database connection.
+func NewOutboxRepository(ctx context.Context, db *sqlx.DB) (*OutboxRepository, error) {
+	s := &OutboxRepository{db: db}
+
+	var err error
+	if s.claimPendingStmt, err = db.PreparexContext(ctx, claimPendingEventsSQL); err != nil {
+		return nil, fmt.Errorf("prepare claim pending events statement: %w", err)
+	}
+	if s.listSubscribersStmt, err = db.PreparexContext(ctx, listEventSubscribersSQL); err != nil {
+		return nil, fmt.Errorf("prepare list event subscribers statement: %w", err)
+	}
+	if s.markDeliveredStmt, err = db.PreparexContext(ctx, markEventDeliveredSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark event delivered statement: %w", err)
+	}
+	if s.markPublishedStmt, err = db.PreparexContext(ctx, markEventPublishedSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark event published statement: %w", err)
+	}
+	if s.retryStmt, err = db.PreparexContext(ctx, retryEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare retry event statement: %w", err)
+	}
+	if s.markDeadStmt, err = db.PreparexContext(ctx, markEventDeadSQL); err != nil {
+		return nil, fmt.Errorf("prepare mark event dead statement: %w", err)
+	}
+	if s.pruneStmt, err = db.PreparexContext(ctx, prunePublishedEventsSQL); err != nil {
+		return nil, fmt.Errorf("prepare prune published events statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *OutboxRepository) Close() error {
+	if err := s.claimPendingStmt.Close(); err != nil {
+		return fmt.Errorf("close claim pending events statement: %w", err)
+	}
+	if err := s.listSubscribersStmt.Close(); err != nil {
+		return fmt.Errorf("close list event subscribers statement: %w", err)
+	}
+	if err := s.markDeliveredStmt.Close(); err != nil {
+		return fmt.Errorf("close mark event delivered statement: %w", err)
+	}
+	if err := s.markPublishedStmt.Close(); err != nil {
+		return fmt.Errorf("close mark event published statement: %w", err)
+	}
+	if err := s.retryStmt.Close(); err != nil {
+		return fmt.Errorf("close retry event statement: %w", err)
+	}
+	if err := s.markDeadStmt.Close(); err != nil {
+		return fmt.Errorf("close mark event dead statement: %w", err)
+	}
+	if err := s.pruneStmt.Close(); err != nil {
+		return fmt.Errorf("close prune published events statement: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingEvents claims up to limit unpublished events which are due for
+// the given duration. Concurrent claims skip events locked by others.
+func (s *OutboxRepository) ClaimPendingEvents(ctx context.Context, limit int, lease time.Duration) ([]*cargonaut.OutboxEvent, error) {
+	events := make([]*cargonaut.OutboxEvent, 0)
+	if err := s.claimPendingStmt.SelectContext(ctx, &events, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("claim pending events in database: %w", err)
+	}
+	return events, nil
+}
+
+// ListEventSubscribers lists the subscribers an event identified by its unique
+// ID has been delivered to.
+func (s *OutboxRepository) ListEventSubscribers(ctx context.Context, eventID uuid.UUID) ([]string, error) {
+	subscribers := make([]string, 0)
+	if err := s.listSubscribersStmt.SelectContext(ctx, &subscribers, eventID); err != nil {
+		return nil, fmt.Errorf("select subscribers of event %q from database: %w", eventID, err)
+	}
+	return subscribers, nil
+}
+
+// MarkEventDelivered records the delivery of an event identified by its unique
+// ID to a subscriber.
+func (s *OutboxRepository) MarkEventDelivered(ctx context.Context, eventID uuid.UUID, subscriber string) error {
+	if _, err := s.markDeliveredStmt.ExecContext(ctx, eventID, subscriber); err != nil {
+		return fmt.Errorf("mark event %q delivered to %s in database: %w", eventID, subscriber, err)
+	}
+	return nil
+}
+
+// MarkEventPublished marks an event identified by its unique ID as delivered
+// to all subscribers.
+func (s *OutboxRepository) MarkEventPublished(ctx context.Context, eventID uuid.UUID) error {
+	if _, err := s.markPublishedStmt.ExecContext(ctx, eventID); err != nil {
+		return fmt.Errorf("mark event %q published in database: %w", eventID, err)
+	}
+	return nil
+}
+
+// RetryEvent records a failed attempt to publish an event identified by its
+// unique ID along with its error and schedules the next one.
+func (s *OutboxRepository) RetryEvent(ctx context.Context, eventID uuid.UUID, next time.Time, reason string) error {
+	if _, err := s.retryStmt.ExecContext(ctx, eventID, next, reason); err != nil {
+		return fmt.Errorf("retry event %q in database: %w", eventID, err)
+	}
+	return nil
+}
+
+// MarkEventDead records the last failed attempt to publish an event identified
+// by its unique ID along with its error. The event is never claimed again.
+func (s *OutboxRepository) MarkEventDead(ctx context.Context, eventID uuid.UUID, reason string) error {
+	if _, err := s.markDeadStmt.ExecContext(ctx, eventID, reason); err != nil {
+		return fmt.Errorf("mark event %q dead in database: %w", eventID, err)
+	}
+	return nil
+}
+
+// PrunePublishedEvents deletes events which have been published before the
+// given time, along with their deliveries.
+func (s *OutboxRepository) PrunePublishedEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.pruneStmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune events published before %s in database: %w", before, err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,394 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.PaymentRepository = (*PaymentRepository)(nil)
+
+const (
+	listPaymentsSQL           = "SELECT id, user_id, type, amount, refunded, status, reference, redirect_url, created_at, updated_at FROM payment WHERE user_id = $1 ORDER BY created_at DESC, id"
+	getPaymentSQL             = "SELECT id, user_id, type, amount, refunded, status, reference, redirect_url, created_at, updated_at FROM payment WHERE id = $1"
+	getPaymentByReferenceSQL  = "SELECT id, user_id, type, amount, refunded, status, reference, redirect_url, created_at, updated_at FROM payment WHERE reference = $1"
+	lockPaymentSQL            = "SELECT id, user_id, type, amount, refunded, status, reference, redirect_url, created_at, updated_at FROM payment WHERE id = $1 FOR UPDATE"
+	lockPaymentByReferenceSQL = "SELECT id, user_id, type, amount, refunded, status, reference, redirect_url, created_at, updated_at FROM payment WHERE reference = $1 FOR UPDATE"
+	createPaymentSQL          = "INSERT INTO payment (user_id, type, amount) VALUES (:user_id, :type, :amount) RETURNING id, refunded, status, created_at, updated_at"
+	setPaymentIntentSQL       = "UPDATE payment SET reference = $2, redirect_url = NULLIF($3, ''), updated_at = (now() at time zone 'utc') WHERE id = $1"
+	setPaymentStatusSQL       = "UPDATE payment SET status = $2, updated_at = (now() at time zone 'utc') WHERE id = $1"
+	addPaymentRefundedSQL     = "UPDATE payment SET refunded = refunded + $2, updated_at = (now() at time zone 'utc') WHERE id = $1"
+	createPaymentEventSQL     = "INSERT INTO payment_webhook_event (idempotency_key, type, reference, amount) VALUES (:idempotency_key, :type, :reference, :amount) ON CONFLICT (idempotency_key) DO NOTHING"
+)
+
+// PaymentRepository provides access to the payment resource backed by a
+// Postgres SQL database.
+type PaymentRepository struct {
+	db *sqlx.DB
+
+	listStmt            *sqlx.Stmt
+	getStmt             *sqlx.Stmt
+	getByReferenceStmt  *sqlx.Stmt
+	lockStmt            *sqlx.Stmt
+	lockByReferenceStmt *sqlx.Stmt
+	createStmt          *sqlx.NamedStmt
+	setIntentStmt       *sqlx.Stmt
+	setStatusStmt       *sqlx.Stmt
+	addRefundedStmt     *sqlx.Stmt
+	createEventStmt     *sqlx.NamedStmt
+	ledgerStmts         *ledgerStatements
+}
+
+// NewPaymentRepository returns a new PaymentRepository based on top of the
+// provided database connection.
+func NewPaymentRepository(ctx context.Context, db *sqlx.DB) (*PaymentRepository, error) {
+	s := &PaymentRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listPaymentsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list payments statement: %w", err)
+	}
+	if s.getStmt, err = db.PreparexContext(ctx, getPaymentSQL); err != nil {
+		return nil, fmt.Errorf("prepare get payment statement: %w", err)
+	}
+	if s.getByReferenceStmt, err = db.PreparexContext(ctx, getPaymentByReferenceSQL); err != nil {
+		return nil, fmt.Errorf("prepare get payment by reference statement: %w", err)
+	}
+	if s.lockStmt, err = db.PreparexContext(ctx, lockPaymentSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock payment statement: %w", err)
+	}
+	if s.lockByReferenceStmt, err = db.PreparexContext(ctx, lockPaymentByReferenceSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock payment by reference statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createPaymentSQL); err != nil {
+		return nil, fmt.Errorf("prepare create payment statement: %w", err)
+	}
+	if s.setIntentStmt, err = db.PreparexContext(ctx, setPaymentIntentSQL); err != nil {
+		return nil, fmt.Errorf("prepare set payment intent statement: %w", err)
+	}
+	if s.setStatusStmt, err = db.PreparexContext(ctx, setPaymentStatusSQL); err != nil {
+		return nil, fmt.Errorf("prepare set payment status statement: %w", err)
+	}
+	if s.addRefundedStmt, err = db.PreparexContext(ctx, addPaymentRefundedSQL); err != nil {
+		return nil, fmt.Errorf("prepare add payment refunded statement: %w", err)
+	}
+	if s.createEventStmt, err = db.PrepareNamedContext(ctx, createPaymentEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare create payment event statement: %w", err)
+	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *PaymentRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list payments statement: %w", err)
+	}
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get payment statement: %w", err)
+	}
+	if err := s.getByReferenceStmt.Close(); err != nil {
+		return fmt.Errorf("close get payment by reference statement: %w", err)
+	}
+	if err := s.lockStmt.Close(); err != nil {
+		return fmt.Errorf("close lock payment statement: %w", err)
+	}
+	if err := s.lockByReferenceStmt.Close(); err != nil {
+		return fmt.Errorf("close lock payment by reference statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create payment statement: %w", err)
+	}
+	if err := s.setIntentStmt.Close(); err != nil {
+		return fmt.Errorf("close set payment intent statement: %w", err)
+	}
+	if err := s.setStatusStmt.Close(); err != nil {
+		return fmt.Errorf("close set payment status statement: %w", err)
+	}
+	if err := s.addRefundedStmt.Close(); err != nil {
+		return fmt.Errorf("close add payment refunded statement: %w", err)
+	}
+	if err := s.createEventStmt.Close(); err != nil {
+		return fmt.Errorf("close create payment event statement: %w", err)
+	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListPayments lists all payments of the user identified by his unique ID,
+// most recent first.
+func (s *PaymentRepository) ListPayments(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Payment, error) {
+	payments := make([]*cargonaut.Payment, 0)
+	if err := s.listStmt.SelectContext(ctx, &payments, userID); err != nil {
+		return nil, fmt.Errorf("select payments of user %q from database: %w", userID, err)
+	}
+	return payments, nil
+}
+
+// GetPayment returns a payment identified by its unique ID.
+func (s *PaymentRepository) GetPayment(ctx context.Context, id uuid.UUID) (*cargonaut.Payment, error) {
+	payment := new(cargonaut.Payment)
+	if err := s.getStmt.GetContext(ctx, payment, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrPaymentNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get payment %q from database: %w", id, err)
+	}
+	return payment, nil
+}
+
+// GetPaymentByReference returns a payment identified by the reference of its
+// intent.
+func (s *PaymentRepository) GetPaymentByReference(ctx context.Context, reference string) (*cargonaut.Payment, error) {
+	payment := new(cargonaut.Payment)
+	if err := s.getByReferenceStmt.GetContext(ctx, payment, reference); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrPaymentNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get payment with reference %q from database: %w", reference, err)
+	}
+	return payment, nil
+}
+
+// CreatePayment creates a new pending payment. The amount of a payout is
+// withdrawn from the wallet of the user in the same transaction.
+func (s *PaymentRepository) CreatePayment(ctx context.Context, payment *cargonaut.Payment) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = tx.NamedStmtContext(ctx, s.createStmt).GetContext(ctx, payment, payment); err != nil {
+		return fmt.Errorf("create payment in database: %w", err)
+	}
+	if payment.Type == cargonaut.PaymentTypePayout {
+		if err = s.ledgerStmts.withdraw(ctx, tx, payment.UserID, &payment.ID, payment.Amount); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// SetPaymentIntent sets the intent of the payment identified by its unique ID.
+func (s *PaymentRepository) SetPaymentIntent(ctx context.Context, id uuid.UUID, intent *cargonaut.PaymentIntent) error {
+	res, err := s.setIntentStmt.ExecContext(ctx, id, intent.Reference, intent.RedirectURL)
+	if err != nil {
+		return fmt.Errorf("set intent of payment %q in database: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("set intent of payment %q in database: %w", id, err)
+	} else if n == 0 {
+		return cargonaut.ErrPaymentNotFound
+	}
+	return nil
+}
+
+// FailPayment marks the payment identified by its unique ID as failed, unless
+// it succeeded or failed already. The amount of a payout is credited back to
+// the wallet of the user in the same transaction.
+func (s *PaymentRepository) FailPayment(ctx context.Context, id uuid.UUID) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	payment, err := s.lock(ctx, tx, s.lockStmt, id)
+	if err != nil {
+		return err
+	}
+	if err = s.fail(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RefundPayment withdraws the amount to refund from the wallet of the user of
+// the succeeded top-up identified by its unique ID. It returns
+// cargonaut.ErrPaymentNotRefundable if the payment is no succeeded top-up or
+// the amount exceeds what is left to refund.
+func (s *PaymentRepository) RefundPayment(ctx context.Context, id uuid.UUID, amount int64) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	payment, err := s.lock(ctx, tx, s.lockStmt, id)
+	if err != nil {
+		return err
+	}
+	if payment.Type != cargonaut.PaymentTypeTopUp || payment.Status != cargonaut.PaymentStatusSucceeded || amount > payment.Amount-payment.Refunded {
+		return cargonaut.ErrPaymentNotRefundable
+	}
+	if err = s.ledgerStmts.withdraw(ctx, tx, payment.UserID, &payment.ID, amount); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.addRefundedStmt).ExecContext(ctx, id, amount); err != nil {
+		return fmt.Errorf("refund payment %q in database: %w", id, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// FailRefund credits the amount of a failed refund of the top-up identified by
+// its unique ID back to the wallet of the user.
+func (s *PaymentRepository) FailRefund(ctx context.Context, id uuid.UUID, amount int64) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	payment, err := s.lock(ctx, tx, s.lockStmt, id)
+	if err != nil {
+		return err
+	}
+	if err = s.failRefund(ctx, tx, payment, amount); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ProcessPaymentEvent reconciles the payment the event refers to and the
+// wallet ledger with the event. The event is recorded in the same transaction,
+// so it is processed exactly once. Events which do not change the state of
+// the payment any further are recorded only.
+func (s *PaymentRepository) ProcessPaymentEvent(ctx context.Context, event *cargonaut.PaymentEvent) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.NamedStmtContext(ctx, s.createEventStmt).ExecContext(ctx, event)
+	if err != nil {
+		return fmt.Errorf("create payment event %q in database: %w", event.IdempotencyKey, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("create payment event %q in database: %w", event.IdempotencyKey, err)
+	} else if n == 0 {
+		return cargonaut.ErrPaymentEventProcessed
+	}
+
+	payment, err := s.lock(ctx, tx, s.lockByReferenceStmt, event.Reference)
+	if err != nil {
+		return err
+	}
+
+	pending := payment.Status == cargonaut.PaymentStatusPending || payment.Status == cargonaut.PaymentStatusAuthorized
+	switch event.Type {
+	case cargonaut.PaymentEventTypeAuthorized:
+		if payment.Status == cargonaut.PaymentStatusPending {
+			err = s.setStatus(ctx, tx, payment, cargonaut.PaymentStatusAuthorized)
+		}
+	case cargonaut.PaymentEventTypeCaptured:
+		if payment.Type != cargonaut.PaymentTypeTopUp || event.Amount != payment.Amount {
+			return cargonaut.ErrInvalidPaymentWebhook
+		}
+		// A captured top-up has been paid, even if it has been failed before
+		// because the intent could not be stored or a failure was reported
+		// first. It is credited all the same.
+		if pending || payment.Status == cargonaut.PaymentStatusFailed {
+			if err = s.setStatus(ctx, tx, payment, cargonaut.PaymentStatusSucceeded); err == nil {
+				err = s.ledgerStmts.deposit(ctx, tx, payment.UserID, &payment.ID, payment.Amount)
+			}
+		}
+	case cargonaut.PaymentEventTypePayoutPaid:
+		if pending {
+			err = s.setStatus(ctx, tx, payment, cargonaut.PaymentStatusSucceeded)
+		}
+	case cargonaut.PaymentEventTypeFailed, cargonaut.PaymentEventTypePayoutFailed:
+		err = s.fail(ctx, tx, payment)
+	case cargonaut.PaymentEventTypeRefundFailed:
+		err = s.failRefund(ctx, tx, payment, event.Amount)
+	case cargonaut.PaymentEventTypeRefunded:
+		// Refunds are withdrawn from the wallet when they are requested.
+	default:
+		return cargonaut.ErrInvalidPaymentWebhook
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// lock returns the payment identified by the argument of the statement and
+// locks it for the rest of the transaction.
+func (s *PaymentRepository) lock(ctx context.Context, tx *sqlx.Tx, stmt *sqlx.Stmt, arg interface{}) (*cargonaut.Payment, error) {
+	payment := new(cargonaut.Payment)
+	if err := tx.StmtxContext(ctx, stmt).GetContext(ctx, payment, arg); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrPaymentNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("lock payment %q in database: %w", arg, err)
+	}
+	return payment, nil
+}
+
+// setStatus sets the status of the payment.
+func (s *PaymentRepository) setStatus(ctx context.Context, tx *sqlx.Tx, payment *cargonaut.Payment, status cargonaut.PaymentStatus) error {
+	if _, err := tx.StmtxContext(ctx, s.setStatusStmt).ExecContext(ctx, payment.ID, status); err != nil {
+		return fmt.Errorf("set status of payment %q in database: %w", payment.ID, err)
+	}
+	payment.Status = status
+	return nil
+}
+
+// fail marks the payment as failed and credits the amount of a payout back to
+// the wallet of the user. Payments which succeeded or failed already are left
+// untouched.
+func (s *PaymentRepository) fail(ctx context.Context, tx *sqlx.Tx, payment *cargonaut.Payment) error {
+	if payment.Status == cargonaut.PaymentStatusSucceeded || payment.Status == cargonaut.PaymentStatusFailed {
+		return nil
+	}
+	if err := s.setStatus(ctx, tx, payment, cargonaut.PaymentStatusFailed); err != nil {
+		return err
+	}
+	if payment.Type == cargonaut.PaymentTypePayout {
+		return s.ledgerStmts.reverse(ctx, tx, payment.UserID, &payment.ID, payment.Amount)
+	}
+	return nil
+}
+
+// failRefund credits the amount of a failed refund of the payment back to the
+// wallet of the user. It returns cargonaut.ErrPaymentNotRefundable if the
+// amount exceeds what has been refunded.
+func (s *PaymentRepository) failRefund(ctx context.Context, tx *sqlx.Tx, payment *cargonaut.Payment, amount int64) error {
+	if amount <= 0 || amount > payment.Refunded {
+		return cargonaut.ErrPaymentNotRefundable
+	}
+	if _, err := tx.StmtxContext(ctx, s.addRefundedStmt).ExecContext(ctx, payment.ID, -amount); err != nil {
+		return fmt.Errorf("refund payment %q in database: %w", payment.ID, err)
+	}
+	return s.ledgerStmts.reverse(ctx, tx, payment.UserID, &payment.ID, amount)
+}
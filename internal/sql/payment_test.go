@@ -0,0 +1,150 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// paymentTest provides the payment and wallet repositories.
+type paymentTest struct {
+	payments *PaymentRepository
+	wallets  *WalletRepository
+}
+
+func newPaymentTest(t *testing.T) (*paymentTest, uuid.UUID) {
+	db := testDB(t)
+	ctx := context.Background()
+	p := new(paymentTest)
+
+	var err error
+	p.payments, err = NewPaymentRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.payments.Close() })
+	p.wallets, err = NewWalletRepository(ctx, db)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = p.wallets.Close() })
+	return p, createTestUser(t, db).ID
+}
+
+// create creates a payment of the user along with its intent.
+func (p *paymentTest) create(t *testing.T, userID uuid.UUID, typ cargonaut.PaymentType, amount int64) *cargonaut.Payment {
+	ctx := context.Background()
+	payment := &cargonaut.Payment{UserID: userID, Type: typ, Amount: amount}
+	require.NoError(t, p.payments.CreatePayment(ctx, payment))
+	reference := "test_" + uuid.NewV4().String()
+	require.NoError(t, p.payments.SetPaymentIntent(ctx, payment.ID, &cargonaut.PaymentIntent{Reference: reference}))
+	payment.Reference = &reference
+	return payment
+}
+
+// process processes an event with a new idempotency key for the payment.
+func (p *paymentTest) process(t *testing.T, payment *cargonaut.Payment, typ cargonaut.PaymentEventType, amount int64) (*cargonaut.PaymentEvent, error) {
+	event := &cargonaut.PaymentEvent{
+		IdempotencyKey: uuid.NewV4().String(),
+		Type:           typ,
+		Reference:      *payment.Reference,
+		Amount:         amount,
+	}
+	return event, p.payments.ProcessPaymentEvent(context.Background(), event)
+}
+
+// assertPayment asserts the status of the payment and the balance of the
+// wallet of its user.
+func (p *paymentTest) assertPayment(t *testing.T, payment *cargonaut.Payment, status cargonaut.PaymentStatus, balance int64) {
+	ctx := context.Background()
+	got, err := p.payments.GetPayment(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, status, got.Status, "status")
+	wallet, err := p.wallets.GetWallet(ctx, payment.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, balance, wallet.Balance, "balance")
+}
+
+func TestPaymentRepositoryTopUp(t *testing.T) {
+	p, userID := newPaymentTest(t)
+	payment := p.create(t, userID, cargonaut.PaymentTypeTopUp, 1000)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusPending, 0)
+
+	_, err := p.process(t, payment, cargonaut.PaymentEventTypeAuthorized, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusAuthorized, 0)
+
+	captured, err := p.process(t, payment, cargonaut.PaymentEventTypeCaptured, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusSucceeded, 1000)
+
+	// A redelivered event is processed once only and another capture of the
+	// same payment is not credited again.
+	assert.Equal(t, cargonaut.ErrPaymentEventProcessed, p.payments.ProcessPaymentEvent(context.Background(), captured))
+	_, err = p.process(t, payment, cargonaut.PaymentEventTypeCaptured, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusSucceeded, 1000)
+
+	// A failure reported late does not undo the capture.
+	_, err = p.process(t, payment, cargonaut.PaymentEventTypeFailed, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusSucceeded, 1000)
+}
+
+func TestPaymentRepositoryCaptureAfterFailure(t *testing.T) {
+	p, userID := newPaymentTest(t)
+	payment := p.create(t, userID, cargonaut.PaymentTypeTopUp, 1000)
+
+	_, err := p.process(t, payment, cargonaut.PaymentEventTypeFailed, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusFailed, 0)
+
+	_, err = p.process(t, payment, cargonaut.PaymentEventTypeCaptured, 1000)
+	require.NoError(t, err)
+	p.assertPayment(t, payment, cargonaut.PaymentStatusSucceeded, 1000)
+}
+
+func TestPaymentRepositoryInvalidCapture(t *testing.T) {
+	p, userID := newPaymentTest(t)
+	payment := p.create(t, userID, cargonaut.PaymentTypeTopUp, 1000)
+
+	// Neither a capture of another amount nor of a payout is credited, and
+	// the events are not recorded, so a valid redelivery is processed.
+	event, err := p.process(t, payment, cargonaut.PaymentEventTypeCaptured, 2000)
+	assert.Equal(t, cargonaut.ErrInvalidPaymentWebhook, err)
+	event.Amount = 1000
+	require.NoError(t, p.payments.ProcessPaymentEvent(context.Background(), event))
+	p.assertPayment(t, payment, cargonaut.PaymentStatusSucceeded, 1000)
+
+	payout := p.create(t, userID, cargonaut.PaymentTypePayout, 500)
+	_, err = p.process(t, payout, cargonaut.PaymentEventTypeCaptured, 500)
+	assert.Equal(t, cargonaut.ErrInvalidPaymentWebhook, err)
+	p.assertPayment(t, payout, cargonaut.PaymentStatusPending, 500)
+}
+
+func TestPaymentRepositoryPayout(t *testing.T) {
+	p, userID := newPaymentTest(t)
+	require.NoError(t, p.wallets.Deposit(context.Background(), userID, 2000))
+
+	// Payouts are withdrawn when they are created and credited back if they
+	// fail.
+	failed := p.create(t, userID, cargonaut.PaymentTypePayout, 1500)
+	p.assertPayment(t, failed, cargonaut.PaymentStatusPending, 500)
+	_, err := p.process(t, failed, cargonaut.PaymentEventTypePayoutFailed, 1500)
+	require.NoError(t, err)
+	p.assertPayment(t, failed, cargonaut.PaymentStatusFailed, 2000)
+	_, err = p.process(t, failed, cargonaut.PaymentEventTypePayoutFailed, 1500)
+	require.NoError(t, err)
+	p.assertPayment(t, failed, cargonaut.PaymentStatusFailed, 2000)
+
+	paid := p.create(t, userID, cargonaut.PaymentTypePayout, 1500)
+	_, err = p.process(t, paid, cargonaut.PaymentEventTypePayoutPaid, 1500)
+	require.NoError(t, err)
+	p.assertPayment(t, paid, cargonaut.PaymentStatusSucceeded, 500)
+
+	err = p.payments.CreatePayment(context.Background(), &cargonaut.Payment{UserID: userID, Type: cargonaut.PaymentTypePayout, Amount: 501})
+	assert.Equal(t, cargonaut.ErrInsufficientFunds, err)
+	p.assertPayment(t, paid, cargonaut.PaymentStatusSucceeded, 500)
+}
@@ -0,0 +1,215 @@
+package sql
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.PromotionRepository = (*PromotionRepository)(nil)
+
+const (
+	listPromoCodesSQL       = "SELECT c.id, c.code, c.type, c.value, c.max_redemptions, c.max_redemptions_per_user, c.first_trip_only, c.valid_from, c.valid_until, (SELECT count(*) FROM promo_redemption r JOIN booking b ON b.id = r.booking_id WHERE r.promo_code_id = c.id AND b.status IN ('pending', 'accepted')) AS redemptions, c.created_at, c.updated_at FROM promo_code c ORDER BY c.created_at DESC"
+	getPromoCodeSQL         = "SELECT c.id, c.code, c.type, c.value, c.max_redemptions, c.max_redemptions_per_user, c.first_trip_only, c.valid_from, c.valid_until, (SELECT count(*) FROM promo_redemption r JOIN booking b ON b.id = r.booking_id WHERE r.promo_code_id = c.id AND b.status IN ('pending', 'accepted')) AS redemptions, c.created_at, c.updated_at FROM promo_code c WHERE c.id = $1 LIMIT 1"
+	createPromoCodeSQL      = "INSERT INTO promo_code (code, type, value, max_redemptions, max_redemptions_per_user, first_trip_only, valid_from, valid_until) VALUES (:code, :type, :value, :max_redemptions, :max_redemptions_per_user, :first_trip_only, :valid_from, :valid_until) RETURNING id, created_at, updated_at"
+	updatePromoCodeSQL      = "UPDATE promo_code SET code = :code, type = :type, value = :value, max_redemptions = :max_redemptions, max_redemptions_per_user = :max_redemptions_per_user, first_trip_only = :first_trip_only, valid_from = :valid_from, valid_until = :valid_until, updated_at = (now() at time zone 'utc') WHERE id = :id"
+	listPromoRedemptionsSQL = "SELECT r.id, r.promo_code_id, r.booking_id, b.trip_id, b.user_id, b.status AS booking_status, r.amount, r.created_at FROM promo_redemption r JOIN booking b ON b.id = r.booking_id WHERE r.promo_code_id = $1 ORDER BY r.created_at DESC"
+	createReferralCodeSQL   = "INSERT INTO referral_code (user_id, code) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING"
+	getReferralCodeSQL      = "SELECT rc.user_id, rc.code, count(u.id) AS referred, count(u.referral_credited_at) AS credited, rc.created_at FROM referral_code rc LEFT JOIN user_account u ON u.referred_by = rc.user_id WHERE rc.user_id = $1 GROUP BY rc.user_id"
+	getReferrerSQL          = "SELECT user_id FROM referral_code WHERE code = upper($1)"
+)
+
+const (
+	// referralCodeAlphabet are the characters referral codes are made of.
+	// Characters which are easily confused are left out.
+	referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	// referralCodeLength is the length of referral codes.
+	referralCodeLength = 8
+	// referralCodeAttempts is the number of attempts to create a referral code
+	// which is not taken yet.
+	referralCodeAttempts = 3
+)
+
+// PromotionRepository provides access to promo codes and referral codes backed
+// by a Postgres SQL database.
+type PromotionRepository struct {
+	db *sqlx.DB
+
+	listStmt            *sqlx.Stmt
+	getStmt             *sqlx.Stmt
+	createStmt          *sqlx.NamedStmt
+	updateStmt          *sqlx.NamedStmt
+	listRedemptionsStmt *sqlx.Stmt
+	createReferralStmt  *sqlx.Stmt
+	getReferralStmt     *sqlx.Stmt
+	getReferrerStmt     *sqlx.Stmt
+}
+
+// NewPromotionRepository returns a new PromotionRepository based on top of the
+// provided database connection.
+func NewPromotionRepository(ctx context.Context, db *sqlx.DB) (*PromotionRepository, error) {
+	s := &PromotionRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listPromoCodesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list promo codes statement: %w", err)
+	}
+	if s.getStmt, err = db.PreparexContext(ctx, getPromoCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare get promo code statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createPromoCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare create promo code statement: %w", err)
+	}
+	if s.updateStmt, err = db.PrepareNamedContext(ctx, updatePromoCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare update promo code statement: %w", err)
+	}
+	if s.listRedemptionsStmt, err = db.PreparexContext(ctx, listPromoRedemptionsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list promo redemptions statement: %w", err)
+	}
+	if s.createReferralStmt, err = db.PreparexContext(ctx, createReferralCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare create referral code statement: %w", err)
+	}
+	if s.getReferralStmt, err = db.PreparexContext(ctx, getReferralCodeSQL); err != nil {
+		return nil, fmt.Errorf("prepare get referral code statement: %w", err)
+	}
+	if s.getReferrerStmt, err = db.PreparexContext(ctx, getReferrerSQL); err != nil {
+		return nil, fmt.Errorf("prepare get referrer statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *PromotionRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list promo codes statement: %w", err)
+	}
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get promo code statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create promo code statement: %w", err)
+	}
+	if err := s.updateStmt.Close(); err != nil {
+		return fmt.Errorf("close update promo code statement: %w", err)
+	}
+	if err := s.listRedemptionsStmt.Close(); err != nil {
+		return fmt.Errorf("close list promo redemptions statement: %w", err)
+	}
+	if err := s.createReferralStmt.Close(); err != nil {
+		return fmt.Errorf("close create referral code statement: %w", err)
+	}
+	if err := s.getReferralStmt.Close(); err != nil {
+		return fmt.Errorf("close get referral code statement: %w", err)
+	}
+	if err := s.getReferrerStmt.Close(); err != nil {
+		return fmt.Errorf("close get referrer statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListPromoCodes lists all promo codes, most recent first.
+func (s *PromotionRepository) ListPromoCodes(ctx context.Context) ([]*cargonaut.PromoCode, error) {
+	codes := make([]*cargonaut.PromoCode, 0)
+	if err := s.listStmt.SelectContext(ctx, &codes); err != nil {
+		return nil, fmt.Errorf("select promo codes from database: %w", err)
+	}
+	return codes, nil
+}
+
+// GetPromoCode returns a promo code identified by its unique ID.
+func (s *PromotionRepository) GetPromoCode(ctx context.Context, id uuid.UUID) (*cargonaut.PromoCode, error) {
+	code := new(cargonaut.PromoCode)
+	if err := s.getStmt.GetContext(ctx, code, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrPromoCodeNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get promo code %q from database: %w", id, err)
+	}
+	return code, nil
+}
+
+// CreatePromoCode creates a new promo code. Codes are unique regardless of
+// case.
+func (s *PromotionRepository) CreatePromoCode(ctx context.Context, code *cargonaut.PromoCode) error {
+	if err := s.createStmt.GetContext(ctx, code, code); isAlreadyExistsError(err) {
+		return cargonaut.ErrPromoCodeExists
+	} else if err != nil {
+		return fmt.Errorf("create promo code in database: %w", err)
+	}
+	return nil
+}
+
+// UpdatePromoCode updates a given promo code.
+func (s *PromotionRepository) UpdatePromoCode(ctx context.Context, code *cargonaut.PromoCode) error {
+	if _, err := s.updateStmt.ExecContext(ctx, code); isAlreadyExistsError(err) {
+		return cargonaut.ErrPromoCodeExists
+	} else if err != nil {
+		return fmt.Errorf("update promo code %q in database: %w", code.ID, err)
+	}
+	return nil
+}
+
+// ListPromoRedemptions lists the redemptions of the promo code identified by
+// its unique ID, most recent first.
+func (s *PromotionRepository) ListPromoRedemptions(ctx context.Context, promoCodeID uuid.UUID) ([]*cargonaut.PromoRedemption, error) {
+	redemptions := make([]*cargonaut.PromoRedemption, 0)
+	if err := s.listRedemptionsStmt.SelectContext(ctx, &redemptions, promoCodeID); err != nil {
+		return nil, fmt.Errorf("select redemptions of promo code %q from database: %w", promoCodeID, err)
+	}
+	return redemptions, nil
+}
+
+// GetReferralCode returns the referral code of the user identified by its
+// unique ID. It is created if the user does not have one yet. Creating it is
+// retried a few times in the unlikely case the random code is taken.
+func (s *PromotionRepository) GetReferralCode(ctx context.Context, userID uuid.UUID) (*cargonaut.ReferralCode, error) {
+	for i := 0; ; i++ {
+		code, err := newReferralCode()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = s.createReferralStmt.ExecContext(ctx, userID, code); err == nil {
+			break
+		} else if !isAlreadyExistsError(err) || i == referralCodeAttempts-1 {
+			return nil, fmt.Errorf("create referral code of user %q in database: %w", userID, err)
+		}
+	}
+
+	code := new(cargonaut.ReferralCode)
+	if err := s.getReferralStmt.GetContext(ctx, code, userID); err != nil {
+		return nil, fmt.Errorf("get referral code of user %q from database: %w", userID, err)
+	}
+	return code, nil
+}
+
+// GetReferrer returns the unique ID of the user the referral code belongs to.
+// Codes are compared regardless of case.
+func (s *PromotionRepository) GetReferrer(ctx context.Context, code string) (uuid.UUID, error) {
+	var userID uuid.UUID
+	if err := s.getReferrerStmt.GetContext(ctx, &userID, code); err == sql.ErrNoRows {
+		return uuid.Nil, cargonaut.ErrReferralCodeNotFound
+	} else if err != nil {
+		return uuid.Nil, fmt.Errorf("get referrer of referral code %q from database: %w", code, err)
+	}
+	return userID, nil
+}
+
+// newReferralCode returns a new random referral code.
+func newReferralCode() (string, error) {
+	b := make([]byte, referralCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate referral code: %w", err)
+	}
+	for i := range b {
+		b[i] = referralCodeAlphabet[int(b[i])%len(referralCodeAlphabet)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,194 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// createTestPromoCode creates a promo code with a unique code, which can be
+// redeemed once per user.
+func createTestPromoCode(t *testing.T, l *ledgerTest, typ cargonaut.PromoCodeType, value int64) string {
+	repo, err := NewPromotionRepository(context.Background(), l.db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	code := &cargonaut.PromoCode{
+		Code:                  uuid.NewV4().String()[:8],
+		Type:                  typ,
+		Value:                 value,
+		MaxRedemptionsPerUser: 1,
+	}
+	require.NoError(t, repo.CreatePromoCode(context.Background(), code))
+	return code.Code
+}
+
+// bookPromo creates a trip for the price and books it for the rider, redeeming
+// the promo code.
+func (l *ledgerTest) bookPromo(t *testing.T, riderID uuid.UUID, price float32, code string) (*cargonaut.Trip, *cargonaut.Booking, error) {
+	trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = price })
+	now := time.Now().UTC()
+	booking := &cargonaut.Booking{
+		TripID:    trip.ID,
+		UserID:    riderID,
+		Status:    cargonaut.BookingStatusAccepted,
+		ExpiresAt: now,
+		DecidedAt: &now,
+		CreatedAt: now,
+		PromoCode: code,
+	}
+	if err := l.bookings.CreateBooking(context.Background(), booking); err != nil {
+		return trip, booking, err
+	}
+	trip.RiderID = &riderID
+	return trip, booking, nil
+}
+
+func TestLedgerHoldReleasePromoCode(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	riderID := l.rider(t, 5000)
+	code := createTestPromoCode(t, l, cargonaut.PromoCodeTypeFixed, 500)
+	trip, booking, err := l.bookPromo(t, riderID, 20, code)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), booking.Discount)
+
+	// The full price is held, but the discount is paid by the platform.
+	l.assertWallet(t, riderID, 3500, 2000)
+
+	// Releasing the hold returns the discount to the platform and not to the
+	// rider.
+	require.NoError(t, l.trips.CancelTrip(ctx, &cargonaut.TripCancellation{
+		TripID: trip.ID,
+		UserID: riderID,
+		Role:   cargonaut.RatingRoleRider,
+	}))
+	l.assertWallet(t, riderID, 5000, 0)
+	l.assertBalanced(t, trip.ID)
+}
+
+func TestBookingRepositoryCreateBookingRedeem(t *testing.T) {
+	l := newLedgerTest(t)
+
+	riderID := l.rider(t, 5000)
+	code := createTestPromoCode(t, l, cargonaut.PromoCodeTypePercentage, 2500)
+	_, booking, err := l.bookPromo(t, riderID, 20, code)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), booking.Discount)
+	l.assertWallet(t, riderID, 3500, 2000)
+
+	tests := []struct {
+		name string
+		code string
+		err  error
+	}{
+		{"redeemed by the user before", code, cargonaut.ErrPromoCodeNotRedeemable},
+		{"unknown", "unknown", cargonaut.ErrPromoCodeNotFound},
+	}
+	for _, tt := range tests {
+		_, _, err := l.bookPromo(t, riderID, 20, tt.code)
+		assert.Equal(t, tt.err, err, tt.name)
+	}
+	l.assertWallet(t, riderID, 3500, 2000)
+}
+
+func TestTripRepositoryCompleteTripsReferral(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+
+	// referred creates a rider with the email address, who has been referred
+	// by the user and has the amount in cents deposited to the wallet.
+	referred := func(email string, referrerID uuid.UUID, amount int64) uuid.UUID {
+		rider := &cargonaut.User{
+			Email:       email,
+			Password:    "hash",
+			DisplayName: "Test User",
+			Birthday:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			ReferredBy:  &referrerID,
+		}
+		require.NoError(t, l.users.CreateUser(ctx, rider))
+		require.NoError(t, l.wallets.Deposit(ctx, rider.ID, amount))
+		return rider.ID
+	}
+
+	tests := []struct {
+		name     string
+		book     func() (riderID, referrerID uuid.UUID, trip *cargonaut.Trip)
+		credited bool
+	}{
+		{"paid", func() (uuid.UUID, uuid.UUID, *cargonaut.Trip) {
+			referrer := createTestUser(t, l.db)
+			riderID := referred(uuid.NewV4().String()+"@example.com", referrer.ID, 5000)
+			return riderID, referrer.ID, l.book(t, riderID, 20)
+		}, true},
+		{"paid by promo code", func() (uuid.UUID, uuid.UUID, *cargonaut.Trip) {
+			referrer := createTestUser(t, l.db)
+			riderID := referred(uuid.NewV4().String()+"@example.com", referrer.ID, 5000)
+			code := createTestPromoCode(t, l, cargonaut.PromoCodeTypePercentage, 10000)
+			trip, _, err := l.bookPromo(t, riderID, 20, code)
+			require.NoError(t, err)
+			return riderID, referrer.ID, trip
+		}, false},
+		{"free", func() (uuid.UUID, uuid.UUID, *cargonaut.Trip) {
+			referrer := createTestUser(t, l.db)
+			riderID := referred(uuid.NewV4().String()+"@example.com", referrer.ID, 5000)
+			return riderID, referrer.ID, l.book(t, riderID, 0)
+		}, false},
+		{"referred by the driver", func() (uuid.UUID, uuid.UUID, *cargonaut.Trip) {
+			trip := createTestTrip(t, l.db, l.trips, func(trip *cargonaut.Trip) { trip.Price = 20 })
+			riderID := referred(uuid.NewV4().String()+"@example.com", trip.UserID, 5000)
+			now := time.Now().UTC()
+			require.NoError(t, l.bookings.CreateBooking(ctx, &cargonaut.Booking{
+				TripID:    trip.ID,
+				UserID:    riderID,
+				Status:    cargonaut.BookingStatusAccepted,
+				ExpiresAt: now,
+				DecidedAt: &now,
+			}))
+			trip.RiderID = &riderID
+			return riderID, trip.UserID, trip
+		}, false},
+		{"same mailbox", func() (uuid.UUID, uuid.UUID, *cargonaut.Trip) {
+			local := uuid.NewV4().String()
+			referrer := createTestUser(t, l.db)
+			_, err := l.db.Exec("UPDATE user_account SET email = $2 WHERE id = $1", referrer.ID, local+"+referrer@example.com")
+			require.NoError(t, err)
+			riderID := referred(local+"@Example.com", referrer.ID, 5000)
+			return riderID, referrer.ID, l.book(t, riderID, 20)
+		}, false},
+	}
+	type result struct {
+		riderID, referrerID uuid.UUID
+	}
+	results := make([]result, len(tests))
+	for i, tt := range tests {
+		riderID, referrerID, trip := tt.book()
+		l.finish(t, trip.ID)
+		results[i] = result{riderID, referrerID}
+	}
+
+	_, err := l.trips.CompleteTrips(ctx, time.Now().UTC(), cargonaut.FeePolicy{}, cargonaut.ReferralPolicy{Credit: 500})
+	require.NoError(t, err)
+
+	for i, tt := range tests {
+		var credited bool
+		require.NoError(t, l.db.Get(&credited, "SELECT referral_credited_at IS NOT NULL FROM user_account WHERE id = $1", results[i].riderID))
+		assert.Equal(t, tt.credited, credited, tt.name)
+
+		var rewards int
+		require.NoError(t, l.db.Get(&rewards, "SELECT count(*) FROM ledger_entry e JOIN ledger_posting p ON p.entry_id = e.id JOIN ledger_account a ON a.id = p.account_id WHERE e.type = 'referral' AND a.user_id = $1", results[i].referrerID))
+		if tt.credited {
+			assert.Equal(t, 1, rewards, tt.name)
+		} else {
+			assert.Zero(t, rewards, tt.name)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.RatingRepository = (*RatingRepository)(nil)
+
+const (
+	getRatingSQL           = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE id = $1 LIMIT 1"
+	lockRatingSQL          = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE id = $1 LIMIT 1 FOR UPDATE"
+	replyToRatingSQL       = "UPDATE rating SET reply = $2, replied_at = (now() at time zone 'utc') WHERE id = $1 AND reply IS NULL"
+	setRatingStatusSQL     = "UPDATE rating SET status = $2 WHERE id = $1"
+	deleteRatingSQL        = "DELETE FROM rating WHERE id = $1"
+	removeRatingSummarySQL = "UPDATE rating_summary s SET count = s.count - 1, total = s.total - r.value, stars_1 = s.stars_1 - (round(r.value) <= 1)::int, stars_2 = s.stars_2 - (round(r.value) = 2)::int, stars_3 = s.stars_3 - (round(r.value) = 3)::int, stars_4 = s.stars_4 - (round(r.value) = 4)::int, stars_5 = s.stars_5 - (round(r.value) >= 5)::int, updated_at = (now() at time zone 'utc') FROM rating r WHERE r.id = $1 AND s.user_id = r.user_id AND s.role = r.role"
+	createReportSQL        = "INSERT INTO rating_report (rating_id, reporter_id, reason) VALUES (:rating_id, :reporter_id, :reason) RETURNING id"
+	listReportedRatingsSQL = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE id IN (SELECT rating_id FROM rating_report WHERE status = 'open') ORDER BY created_at"
+	listReportsSQL         = "SELECT id, rating_id, reporter_id, reason, status, moderator_id, created_at, resolved_at FROM rating_report WHERE rating_id = $1 ORDER BY created_at"
+	resolveReportsSQL      = "UPDATE rating_report SET status = $2, moderator_id = $3, resolved_at = (now() at time zone 'utc') WHERE rating_id = $1 AND status = 'open'"
+)
+
+// RatingRepository provides access to the rating resource backed by a Postgres
+// SQL database.
+type RatingRepository struct {
+	db *sqlx.DB
+
+	getStmt                 *sqlx.Stmt
+	lockStmt                *sqlx.Stmt
+	replyStmt               *sqlx.Stmt
+	setStatusStmt           *sqlx.Stmt
+	deleteStmt              *sqlx.Stmt
+	addSummaryStmt          *sqlx.Stmt
+	removeSummaryStmt       *sqlx.Stmt
+	createReportStmt        *sqlx.NamedStmt
+	listReportedRatingsStmt *sqlx.Stmt
+	listReportsStmt         *sqlx.Stmt
+	resolveReportsStmt      *sqlx.Stmt
+}
+
+// NewRatingRepository returns a new RatingRepository based on top of the
+// provided database connection.
+func NewRatingRepository(ctx context.Context, db *sqlx.DB) (*RatingRepository, error) {
+	s := &RatingRepository{db: db}
+
+	var err error
+	if s.getStmt, err = db.PreparexContext(ctx, getRatingSQL); err != nil {
+		return nil, fmt.Errorf("prepare get rating statement: %w", err)
+	}
+	if s.lockStmt, err = db.PreparexContext(ctx, lockRatingSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock rating statement: %w", err)
+	}
+	if s.replyStmt, err = db.PreparexContext(ctx, replyToRatingSQL); err != nil {
+		return nil, fmt.Errorf("prepare reply to rating statement: %w", err)
+	}
+	if s.setStatusStmt, err = db.PreparexContext(ctx, setRatingStatusSQL); err != nil {
+		return nil, fmt.Errorf("prepare set rating status statement: %w", err)
+	}
+	if s.deleteStmt, err = db.PreparexContext(ctx, deleteRatingSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete rating statement: %w", err)
+	}
+	if s.addSummaryStmt, err = db.PreparexContext(ctx, addRatingSummarySQL); err != nil {
+		return nil, fmt.Errorf("prepare add rating summary statement: %w", err)
+	}
+	if s.removeSummaryStmt, err = db.PreparexContext(ctx, removeRatingSummarySQL); err != nil {
+		return nil, fmt.Errorf("prepare remove rating summary statement: %w", err)
+	}
+	if s.createReportStmt, err = db.PrepareNamedContext(ctx, createReportSQL); err != nil {
+		return nil, fmt.Errorf("prepare create rating report statement: %w", err)
+	}
+	if s.listReportedRatingsStmt, err = db.PreparexContext(ctx, listReportedRatingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list reported ratings statement: %w", err)
+	}
+	if s.listReportsStmt, err = db.PreparexContext(ctx, listReportsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list rating reports statement: %w", err)
+	}
+	if s.resolveReportsStmt, err = db.PreparexContext(ctx, resolveReportsSQL); err != nil {
+		return nil, fmt.Errorf("prepare resolve rating reports statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *RatingRepository) Close() error {
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get rating statement: %w", err)
+	}
+	if err := s.lockStmt.Close(); err != nil {
+		return fmt.Errorf("close lock rating statement: %w", err)
+	}
+	if err := s.replyStmt.Close(); err != nil {
+		return fmt.Errorf("close reply to rating statement: %w", err)
+	}
+	if err := s.setStatusStmt.Close(); err != nil {
+		return fmt.Errorf("close set rating status statement: %w", err)
+	}
+	if err := s.deleteStmt.Close(); err != nil {
+		return fmt.Errorf("close delete rating statement: %w", err)
+	}
+	if err := s.addSummaryStmt.Close(); err != nil {
+		return fmt.Errorf("close add rating summary statement: %w", err)
+	}
+	if err := s.removeSummaryStmt.Close(); err != nil {
+		return fmt.Errorf("close remove rating summary statement: %w", err)
+	}
+	if err := s.createReportStmt.Close(); err != nil {
+		return fmt.Errorf("close create rating report statement: %w", err)
+	}
+	if err := s.listReportedRatingsStmt.Close(); err != nil {
+		return fmt.Errorf("close list reported ratings statement: %w", err)
+	}
+	if err := s.listReportsStmt.Close(); err != nil {
+		return fmt.Errorf("close list rating reports statement: %w", err)
+	}
+	if err := s.resolveReportsStmt.Close(); err != nil {
+		return fmt.Errorf("close resolve rating reports statement: %w", err)
+	}
+
+	return nil
+}
+
+// GetRating returns a rating identified by its unique ID.
+func (s *RatingRepository) GetRating(ctx context.Context, id uuid.UUID) (*cargonaut.Rating, error) {
+	rating := new(cargonaut.Rating)
+	if err := s.getStmt.GetContext(ctx, rating, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrRatingNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get rating %q from database: %w", id, err)
+	}
+	return rating, nil
+}
+
+// ReplyToRating stores the reply of the rated user to a rating identified by
+// its unique ID. A rating can only be replied to once.
+func (s *RatingRepository) ReplyToRating(ctx context.Context, id uuid.UUID, reply string) error {
+	res, err := s.replyStmt.ExecContext(ctx, id, reply)
+	if err != nil {
+		return fmt.Errorf("reply to rating %q in database: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("reply to rating %q in database: %w", id, err)
+	} else if n == 0 {
+		return cargonaut.ErrRatingReplyExists
+	}
+	return nil
+}
+
+// CreateReport creates a new report of a rating.
+func (s *RatingRepository) CreateReport(ctx context.Context, report *cargonaut.RatingReport) error {
+	if err := s.createReportStmt.GetContext(ctx, &report.ID, report); isAlreadyExistsError(err) {
+		return cargonaut.ErrRatingReportExists
+	} else if err != nil {
+		return fmt.Errorf("create report of rating %q in database: %w", report.RatingID, err)
+	}
+	return nil
+}
+
+// ListReportedRatings lists all ratings with open reports.
+func (s *RatingRepository) ListReportedRatings(ctx context.Context) ([]*cargonaut.Rating, error) {
+	ratings := make([]*cargonaut.Rating, 0)
+	if err := s.listReportedRatingsStmt.SelectContext(ctx, &ratings); err != nil {
+		return nil, fmt.Errorf("select reported ratings from database: %w", err)
+	}
+	return ratings, nil
+}
+
+// ListReports lists all reports of a rating identified by its unique ID.
+func (s *RatingRepository) ListReports(ctx context.Context, ratingID uuid.UUID) ([]*cargonaut.RatingReport, error) {
+	reports := make([]*cargonaut.RatingReport, 0)
+	if err := s.listReportsStmt.SelectContext(ctx, &reports, ratingID); err != nil {
+		return nil, fmt.Errorf("select reports of rating %q from database: %w", ratingID, err)
+	}
+	return reports, nil
+}
+
+// ModerateRating applies the moderation decision to the rating identified by
+// its unique ID and resolves all open reports of it. The rating summary of the
+// rated user is updated in the same transaction.
+func (s *RatingRepository) ModerateRating(ctx context.Context, id, moderatorID uuid.UUID, action cargonaut.ModerationAction) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rating := new(cargonaut.Rating)
+	if err = tx.StmtxContext(ctx, s.lockStmt).GetContext(ctx, rating, id); err == sql.ErrNoRows {
+		return cargonaut.ErrRatingNotFound
+	} else if err != nil {
+		return fmt.Errorf("lock rating %q in database: %w", id, err)
+	}
+	visible := rating.Status == cargonaut.RatingStatusVisible
+
+	var resolution cargonaut.RatingReportStatus
+	switch action {
+	case cargonaut.ModerationActionApprove:
+		resolution = cargonaut.RatingReportStatusApproved
+		if !visible {
+			if _, err = tx.StmtxContext(ctx, s.setStatusStmt).ExecContext(ctx, id, cargonaut.RatingStatusVisible); err != nil {
+				return fmt.Errorf("show rating %q in database: %w", id, err)
+			} else if _, err = tx.StmtxContext(ctx, s.addSummaryStmt).ExecContext(ctx, id); err != nil {
+				return fmt.Errorf("add rating %q to rating summary in database: %w", id, err)
+			}
+		}
+	case cargonaut.ModerationActionHide:
+		resolution = cargonaut.RatingReportStatusHidden
+		if visible {
+			if _, err = tx.StmtxContext(ctx, s.removeSummaryStmt).ExecContext(ctx, id); err != nil {
+				return fmt.Errorf("remove rating %q from rating summary in database: %w", id, err)
+			} else if _, err = tx.StmtxContext(ctx, s.setStatusStmt).ExecContext(ctx, id, cargonaut.RatingStatusHidden); err != nil {
+				return fmt.Errorf("hide rating %q in database: %w", id, err)
+			}
+		}
+	case cargonaut.ModerationActionDelete:
+		// The reports of the rating are deleted along with it.
+		if visible {
+			if _, err = tx.StmtxContext(ctx, s.removeSummaryStmt).ExecContext(ctx, id); err != nil {
+				return fmt.Errorf("remove rating %q from rating summary in database: %w", id, err)
+			}
+		}
+		if _, err = tx.StmtxContext(ctx, s.deleteStmt).ExecContext(ctx, id); err != nil {
+			return fmt.Errorf("delete rating %q from database: %w", id, err)
+		}
+	default:
+		return fmt.Errorf("invalid moderation action %q", action)
+	}
+
+	if resolution != "" {
+		if _, err = tx.StmtxContext(ctx, s.resolveReportsStmt).ExecContext(ctx, id, resolution, moderatorID); err != nil {
+			return fmt.Errorf("resolve reports of rating %q in database: %w", id, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// createTestRating rates the user in the role for the trip with the value by a
+// new author.
+func createTestRating(t *testing.T, db *sqlx.DB, repo *TripRepository, tripID, userID uuid.UUID, role cargonaut.RatingRole, value float32) *cargonaut.Rating {
+	rating := &cargonaut.Rating{
+		UserID:   userID,
+		AuthorID: createTestUser(t, db).ID,
+		TripID:   tripID,
+		Role:     role,
+		Value:    value,
+	}
+	require.NoError(t, repo.CreateRating(context.Background(), rating))
+	return rating
+}
+
+// assertRatingSummary asserts the count and the mean of the ratings the user
+// received in the role.
+func assertRatingSummary(t *testing.T, repo *UserRepository, userID uuid.UUID, role cargonaut.RatingRole, count int, mean float64) *cargonaut.RatingSummary {
+	summaries, err := repo.ListRatingSummaries(context.Background(), userID)
+	require.NoError(t, err)
+	for _, summary := range summaries {
+		if summary.Role == role {
+			assert.Equal(t, count, summary.Count, "count")
+			assert.InDelta(t, mean, summary.Mean, 0.001, "mean")
+			return summary
+		}
+	}
+	t.Fatalf("no rating summary for role %q", role)
+	return nil
+}
+
+func TestTripRepositoryCreateRatingSummary(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+	users, err := NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	defer users.Close()
+
+	trip := createTestTrip(t, db, trips, nil)
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 0, 0)
+
+	rating := createTestRating(t, db, trips, trip.ID, trip.UserID, cargonaut.RatingRoleDriver, 5)
+	createTestRating(t, db, trips, trip.ID, trip.UserID, cargonaut.RatingRoleDriver, 3)
+	summary := assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 2, 4)
+	assert.Equal(t, cargonaut.RatingHistogram{ThreeStars: 1, FiveStars: 1}, summary.Histogram)
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleRider, 0, 0)
+
+	// A second rating of the same author for the trip is refused and not
+	// counted.
+	duplicate := &cargonaut.Rating{UserID: trip.UserID, AuthorID: rating.AuthorID, TripID: trip.ID, Role: cargonaut.RatingRoleDriver, Value: 1}
+	assert.Equal(t, cargonaut.ErrRatingExists, trips.CreateRating(ctx, duplicate))
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 2, 4)
+
+	stats, err := users.GetUserStatistics(ctx, trip.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.RatingCount)
+	assert.InDelta(t, 4, stats.RatingAverage, 0.001)
+}
+
+func TestRatingRepositoryModerateRating(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+	users, err := NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	defer users.Close()
+	ratings, err := NewRatingRepository(ctx, db)
+	require.NoError(t, err)
+	defer ratings.Close()
+
+	trip := createTestTrip(t, db, trips, nil)
+	moderatorID := createTestUser(t, db).ID
+	reported := createTestRating(t, db, trips, trip.ID, trip.UserID, cargonaut.RatingRoleDriver, 1)
+	other := createTestRating(t, db, trips, trip.ID, trip.UserID, cargonaut.RatingRoleDriver, 5)
+	require.NoError(t, ratings.CreateReport(ctx, &cargonaut.RatingReport{RatingID: reported.ID, ReporterID: trip.UserID, Reason: "Offensive"}))
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 2, 3)
+
+	// Hiding the rating removes it from the summary and the listed ratings,
+	// hiding it again changes nothing.
+	require.NoError(t, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionHide))
+	require.NoError(t, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionHide))
+	summary := assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 1, 5)
+	assert.Equal(t, cargonaut.RatingHistogram{FiveStars: 1}, summary.Histogram)
+	listed, err := users.ListRatings(ctx, trip.UserID)
+	require.NoError(t, err)
+	if assert.Len(t, listed, 1) {
+		assert.Equal(t, other.ID, listed[0].ID)
+	}
+	reports, err := ratings.ListReports(ctx, reported.ID)
+	require.NoError(t, err)
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, cargonaut.RatingReportStatusHidden, reports[0].Status)
+	}
+
+	// Approving the hidden rating adds it back.
+	require.NoError(t, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionApprove))
+	summary = assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 2, 3)
+	assert.Equal(t, cargonaut.RatingHistogram{OneStar: 1, FiveStars: 1}, summary.Histogram)
+
+	// Deleting a visible rating removes it from the summary, deleting a
+	// hidden one leaves the summary alone.
+	require.NoError(t, ratings.ModerateRating(ctx, other.ID, moderatorID, cargonaut.ModerationActionDelete))
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 1, 1)
+	require.NoError(t, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionHide))
+	require.NoError(t, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionDelete))
+	assertRatingSummary(t, users, trip.UserID, cargonaut.RatingRoleDriver, 0, 0)
+	_, err = ratings.GetRating(ctx, reported.ID)
+	assert.Equal(t, cargonaut.ErrRatingNotFound, err)
+	assert.Equal(t, cargonaut.ErrRatingNotFound, ratings.ModerateRating(ctx, reported.ID, moderatorID, cargonaut.ModerationActionDelete))
+}
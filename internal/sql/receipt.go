@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.ReceiptRepository = (*ReceiptRepository)(nil)
+
+const (
+	getReceiptSQL          = "SELECT id, booking_id, trip_id, year, sequence, driver_name, rider_name, start, destination, depature, amount, discount, vat_rate, created_at FROM receipt WHERE booking_id = $1"
+	lockReceiptBookingSQL  = "SELECT b.id FROM booking b JOIN trip t ON t.id = b.trip_id WHERE b.id = $1 AND b.status = 'accepted' AND t.completed_at IS NOT NULL FOR UPDATE OF b"
+	nextReceiptSequenceSQL = "INSERT INTO receipt_counter (year, sequence) VALUES (extract(year FROM (now() at time zone 'utc'))::integer, 1) ON CONFLICT (year) DO UPDATE SET sequence = receipt_counter.sequence + 1 RETURNING year, sequence"
+	createReceiptSQL       = "INSERT INTO receipt (booking_id, trip_id, year, sequence, driver_name, rider_name, start, destination, depature, amount, discount, vat_rate) SELECT b.id, t.id, $2, $3, d.display_name, r.display_name, t.start, t.destination, t.depature, (SELECT coalesce(-sum(p.amount), 0) FROM ledger_entry e JOIN ledger_posting p ON p.entry_id = e.id JOIN ledger_account a ON a.id = p.account_id WHERE e.trip_id = t.id AND e.type = 'settle' AND a.type = 'hold'), (SELECT coalesce(sum(pr.amount), 0) FROM promo_redemption pr WHERE pr.booking_id = b.id), $4 FROM booking b JOIN trip t ON t.id = b.trip_id JOIN user_account d ON d.id = t.user_id JOIN user_account r ON r.id = b.user_id WHERE b.id = $1 RETURNING id, booking_id, trip_id, year, sequence, driver_name, rider_name, start, destination, depature, amount, discount, vat_rate, created_at"
+)
+
+// ReceiptRepository provides access to the receipts of completed bookings
+// backed by a Postgres SQL database.
+type ReceiptRepository struct {
+	db *sqlx.DB
+
+	getStmt          *sqlx.Stmt
+	lockBookingStmt  *sqlx.Stmt
+	nextSequenceStmt *sqlx.Stmt
+	createStmt       *sqlx.Stmt
+}
+
+// NewReceiptRepository returns a new ReceiptRepository based on top of the
+// provided database connection.
+func NewReceiptRepository(ctx context.Context, db *sqlx.DB) (*ReceiptRepository, error) {
+	s := &ReceiptRepository{db: db}
+
+	var err error
+	if s.getStmt, err = db.PreparexContext(ctx, getReceiptSQL); err != nil {
+		return nil, fmt.Errorf("prepare get receipt statement: %w", err)
+	}
+	if s.lockBookingStmt, err = db.PreparexContext(ctx, lockReceiptBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock receipt booking statement: %w", err)
+	}
+	if s.nextSequenceStmt, err = db.PreparexContext(ctx, nextReceiptSequenceSQL); err != nil {
+		return nil, fmt.Errorf("prepare next receipt sequence statement: %w", err)
+	}
+	if s.createStmt, err = db.PreparexContext(ctx, createReceiptSQL); err != nil {
+		return nil, fmt.Errorf("prepare create receipt statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *ReceiptRepository) Close() error {
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get receipt statement: %w", err)
+	}
+	if err := s.lockBookingStmt.Close(); err != nil {
+		return fmt.Errorf("close lock receipt booking statement: %w", err)
+	}
+	if err := s.nextSequenceStmt.Close(); err != nil {
+		return fmt.Errorf("close next receipt sequence statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create receipt statement: %w", err)
+	}
+
+	return nil
+}
+
+// IssueReceipt returns the receipt of the booking identified by its unique ID.
+// If it has not been issued before, the booking is locked and the receipt is
+// issued with the next number of the current year, which is taken from the
+// locked counter of the year in the same transaction.
+func (s *ReceiptRepository) IssueReceipt(ctx context.Context, bookingID uuid.UUID, vatRate int64) (*cargonaut.Receipt, error) {
+	receipt := new(cargonaut.Receipt)
+	if err := s.getStmt.GetContext(ctx, receipt, bookingID); err == nil {
+		return receipt, nil
+	} else if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("get receipt of booking %q from database: %w", bookingID, err)
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id uuid.UUID
+	if err = tx.StmtxContext(ctx, s.lockBookingStmt).GetContext(ctx, &id, bookingID); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrReceiptNotAvailable
+	} else if err != nil {
+		return nil, fmt.Errorf("lock booking %q in database: %w", bookingID, err)
+	}
+
+	// The receipt may have been issued while waiting for the lock.
+	if err = tx.StmtxContext(ctx, s.getStmt).GetContext(ctx, receipt, bookingID); err == nil {
+		return receipt, nil
+	} else if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("get receipt of booking %q from database: %w", bookingID, err)
+	}
+
+	var year, sequence int
+	if err = tx.StmtxContext(ctx, s.nextSequenceStmt).QueryRowxContext(ctx).Scan(&year, &sequence); err != nil {
+		return nil, fmt.Errorf("get next receipt sequence from database: %w", err)
+	}
+	if err = tx.StmtxContext(ctx, s.createStmt).GetContext(ctx, receipt, bookingID, year, sequence, vatRate); err != nil {
+		return nil, fmt.Errorf("create receipt of booking %q in database: %w", bookingID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return receipt, nil
+}
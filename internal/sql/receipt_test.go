@@ -0,0 +1,48 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestReceiptRepositoryIssueReceipt(t *testing.T) {
+	l := newLedgerTest(t)
+	ctx := context.Background()
+	repo, err := NewReceiptRepository(ctx, l.db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	riderID := l.rider(t, 5000)
+	code := createTestPromoCode(t, l, cargonaut.PromoCodeTypeFixed, 500)
+	trip, booking, err := l.bookPromo(t, riderID, 20, code)
+	require.NoError(t, err)
+
+	_, err = repo.IssueReceipt(ctx, booking.ID, 1900)
+	assert.Equal(t, cargonaut.ErrReceiptNotAvailable, err)
+
+	l.finish(t, trip.ID)
+	_, err = l.trips.CompleteTrips(ctx, time.Now().UTC(), cargonaut.FeePolicy{}, cargonaut.ReferralPolicy{})
+	require.NoError(t, err)
+
+	// The receipt shows the settled fare, even if the price of the trip has
+	// been changed since.
+	_, err = l.db.Exec("UPDATE trip SET price = 99 WHERE id = $1", trip.ID)
+	require.NoError(t, err)
+
+	receipt, err := repo.IssueReceipt(ctx, booking.ID, 1900)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), receipt.Amount)
+	assert.Equal(t, int64(500), receipt.Discount)
+	assert.Equal(t, int64(1500), receipt.Total())
+
+	again, err := repo.IssueReceipt(ctx, booking.ID, 1900)
+	require.NoError(t, err)
+	assert.Equal(t, receipt.Number(), again.Number())
+}
@@ -9,6 +9,14 @@ import (
 	migrate "github.com/rubenv/sql-migrate"
 )
 
+// tombstoneCondition matches all rows except the tombstone user account and
+// vehicle. Both are identified by the nil UUID and take over completed trips
+// and ratings of deleted users.
+const tombstoneCondition = "id <> '00000000-0000-0000-0000-000000000000'"
+
+// completedTripCondition matches trips which have been booked and completed.
+const completedTripCondition = "rider_id IS NOT NULL AND completed_at IS NOT NULL"
+
 // Migrate database schema into the given direction.
 func Migrate(db *sqlx.DB, direction migrate.MigrationDirection) (int, error) {
 	migrations, err := fs.NewWithNamespace("migrations")
@@ -0,0 +1,89 @@
+package sql_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq" // Postgres driver
+	migrate "github.com/rubenv/sql-migrate"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+// testDB connects to the Postgres database given by the
+// CARGONAUT_TEST_POSTGRES_URL environment variable and migrates its schema.
+// Tests using it are skipped if the variable is not set. Tests only rely on
+// the rows they create themselves, so the database may be shared.
+func testDB(t *testing.T) *sqlx.DB {
+	url := os.Getenv("CARGONAUT_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("CARGONAUT_TEST_POSTGRES_URL not set")
+	}
+
+	db, err := sqlx.Connect("postgres", url)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = Migrate(db, migrate.Up)
+	require.NoError(t, err)
+	return db
+}
+
+// createTestUser creates a user with a unique e-mail address.
+func createTestUser(t *testing.T, db *sqlx.DB) *cargonaut.User {
+	repo, err := NewUserRepository(context.Background(), db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	user := &cargonaut.User{
+		Email:       uuid.NewV4().String() + "@example.com",
+		Password:    "hash",
+		DisplayName: "Test User",
+		Birthday:    time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.CreateUser(context.Background(), user))
+	return user
+}
+
+// createTestVehicle creates a car of the user and returns its unique ID.
+func createTestVehicle(t *testing.T, db *sqlx.DB, userID uuid.UUID) uuid.UUID {
+	repo, err := NewVehicleRepository(context.Background(), db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	vehicle := &cargonaut.Vehicle{
+		UserID:       userID,
+		LicensePlate: uuid.NewV4().String()[:8],
+		Type:         cargonaut.VehicleTypeCar,
+		Brand:        "Test",
+		Model:        "Car",
+		Passengers:   4,
+	}
+	require.NoError(t, repo.CreateVehicle(context.Background(), vehicle))
+	return vehicle.ID
+}
+
+// createTestTrip creates a trip of a new driver, adjusted by fn before it is
+// stored.
+func createTestTrip(t *testing.T, db *sqlx.DB, repo *TripRepository, fn func(*cargonaut.Trip)) *cargonaut.Trip {
+	driver := createTestUser(t, db)
+	trip := &cargonaut.Trip{
+		ID:          uuid.NewV4(),
+		UserID:      driver.ID,
+		VehicleID:   createTestVehicle(t, db, driver.ID),
+		Start:       "Berlin",
+		Destination: "Hamburg",
+		Price:       10,
+	}
+	if fn != nil {
+		fn(trip)
+	}
+	require.NoError(t, repo.CreateTrip(context.Background(), trip))
+	return trip
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	uuid "github.com/satori/go.uuid"
@@ -15,13 +16,27 @@ import (
 var _ cargonaut.TripRepository = (*TripRepository)(nil)
 
 const (
-	listTripsSQL    = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, depature, arrival, created_at, updated_at FROM trip ORDER BY updated_at DESC"
-	getTripSQL      = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, depature, arrival, created_at, updated_at FROM trip WHERE id = $1 LIMIT 1"
-	createTripSQL   = "INSERT INTO trip (user_id, vehicle_id, rider_id, start, destination, price, depature, arrival) VALUES (:user_id, :vehicle_id, :rider_id, :start, :destination, :price, :depature, :arrival)"
-	updateTripSQL   = "UPDATE trip SET vehicle_id = :vehicle_id, rider_id = :rider_id, start = :start, destination = :destination, price = :price, depature = :depature, arrival = :arrival, updated_at = :updated_at WHERE id = :id"
-	deleteTripSQL   = "DELETE FROM trip WHERE id = $1"
-	getRatingSQL    = "SELECT id, user_id, author_id, trip_id, value, comment, created_at FROM rating WHERE trip_id = $1 LIMIT 1"
-	createRatingSQL = "INSERT INTO rating (user_id, author_id, trip_id, comment, value) VALUES (:user_id, :author_id, :trip_id, :comment, :value)"
+	listTripsSQL              = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, approval_required, planned_depature, depature, arrival, completed_at, cancelled_at, cancellation_reason, created_at, updated_at FROM trip WHERE cancelled_at IS NULL AND ($1::uuid IS NULL OR vehicle_id IN (SELECT id FROM vehicle WHERE organization_id = $1)) ORDER BY updated_at DESC"
+	listTripsByDriverScoreSQL = "SELECT t.id, t.user_id, t.vehicle_id, t.rider_id, t.start, t.destination, t.price, t.approval_required, t.planned_depature, t.depature, t.arrival, t.completed_at, t.cancelled_at, t.cancellation_reason, t.created_at, t.updated_at FROM trip t LEFT JOIN rating_score rs ON rs.user_id = t.user_id AND rs.role = 'driver' WHERE t.cancelled_at IS NULL AND ($1::uuid IS NULL OR t.vehicle_id IN (SELECT id FROM vehicle WHERE organization_id = $1)) ORDER BY rs.score DESC NULLS LAST, t.updated_at DESC"
+	getTripSQL                = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, approval_required, planned_depature, depature, arrival, completed_at, cancelled_at, cancellation_reason, created_at, updated_at FROM trip WHERE id = $1 LIMIT 1"
+	createTripSQL             = "INSERT INTO trip (id, user_id, vehicle_id, start, destination, price, approval_required, planned_depature, depature, arrival) VALUES (:id, :user_id, :vehicle_id, :start, :destination, :price, :approval_required, :planned_depature, :depature, :arrival)"
+	updateTripSQL             = "UPDATE trip SET vehicle_id = :vehicle_id, start = :start, destination = :destination, price = :price, approval_required = :approval_required, planned_depature = :planned_depature, reminded_at = CASE WHEN planned_depature IS DISTINCT FROM :planned_depature THEN NULL ELSE reminded_at END, updated_at = (now() at time zone 'utc') WHERE id = :id AND (rider_id IS NULL OR (price = :price AND vehicle_id = :vehicle_id))"
+	startTripSQL              = "UPDATE trip SET depature = $2, updated_at = (now() at time zone 'utc') WHERE id = $1 AND cancelled_at IS NULL AND depature <= 'epoch'"
+	finishTripSQL             = "UPDATE trip SET arrival = $2, updated_at = (now() at time zone 'utc') WHERE id = $1 AND cancelled_at IS NULL AND depature > 'epoch' AND depature < $2 AND arrival <= 'epoch'"
+	deleteTripSQL             = "DELETE FROM trip WHERE id = $1"
+	listPendingBookersSQL     = "SELECT user_id FROM booking WHERE trip_id = $1 AND status = 'pending'"
+	cancelTripSQL             = "UPDATE trip SET cancelled_at = (now() at time zone 'utc'), cancellation_reason = :reason, updated_at = (now() at time zone 'utc') WHERE id = :trip_id AND user_id = :user_id AND cancelled_at IS NULL AND completed_at IS NULL AND arrival <= 'epoch'"
+	cancelBookingSQL          = "UPDATE trip SET rider_id = NULL, updated_at = (now() at time zone 'utc') WHERE id = :trip_id AND rider_id = :user_id AND cancelled_at IS NULL AND completed_at IS NULL AND arrival <= 'epoch'"
+	cancelTripBookingsSQL     = "UPDATE booking SET status = CASE WHEN status = 'pending' THEN 'declined' ELSE 'cancelled' END, decided_at = coalesce(decided_at, (now() at time zone 'utc')) WHERE trip_id = :trip_id AND status IN ('pending', 'accepted') RETURNING user_id"
+	cancelRiderBookingSQL     = "UPDATE booking SET status = 'cancelled' WHERE trip_id = :trip_id AND user_id = :user_id AND status = 'accepted' RETURNING user_id"
+	createCancellationSQL     = "INSERT INTO trip_cancellation (trip_id, user_id, role, reason, late) VALUES (:trip_id, :user_id, :role, :reason, :late) RETURNING id, created_at"
+	remindTripsSQL            = "UPDATE trip SET reminded_at = (now() at time zone 'utc') WHERE reminded_at IS NULL AND cancelled_at IS NULL AND depature <= 'epoch' AND planned_depature > (now() at time zone 'utc') AND planned_depature <= $1 RETURNING id, user_id, rider_id"
+	completeTripsSQL          = "UPDATE trip SET completed_at = (now() at time zone 'utc') WHERE completed_at IS NULL AND cancelled_at IS NULL AND arrival > 'epoch' AND arrival <= $1 RETURNING id, user_id, rider_id, depature, arrival"
+	creditReferralSQL         = "UPDATE user_account u SET referral_credited_at = (now() at time zone 'utc') WHERE u.id = $1 AND u.referred_by IS NOT NULL AND u.referred_by NOT IN ($2, $3) AND u.referral_credited_at IS NULL AND NOT EXISTS (SELECT 1 FROM user_account r WHERE r.id = u.referred_by AND regexp_replace(lower(r.email), '\\+[^@]*@', '@') = regexp_replace(lower(u.email), '\\+[^@]*@', '@')) RETURNING u.referred_by"
+	listRoutePricesSQL        = "SELECT t.price FROM trip t JOIN vehicle v ON v.id = t.vehicle_id WHERE lower(t.start) = lower($1) AND lower(t.destination) = lower($2) AND t.cancelled_at IS NULL AND t.created_at >= $3 AND v.type = $4 ORDER BY t.created_at DESC LIMIT 100"
+	listTripRatingsSQL        = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE trip_id = $1 AND status = 'visible' ORDER BY created_at"
+	createRatingSQL           = "INSERT INTO rating (user_id, author_id, trip_id, role, comment, value) VALUES (:user_id, :author_id, :trip_id, :role, :comment, :value) RETURNING id"
+	addRatingSummarySQL       = "INSERT INTO rating_summary (user_id, role, count, total, stars_1, stars_2, stars_3, stars_4, stars_5) SELECT user_id, role, 1, value, (round(value) <= 1)::int, (round(value) = 2)::int, (round(value) = 3)::int, (round(value) = 4)::int, (round(value) >= 5)::int FROM rating WHERE id = $1 ON CONFLICT (user_id, role) DO UPDATE SET count = rating_summary.count + excluded.count, total = rating_summary.total + excluded.total, stars_1 = rating_summary.stars_1 + excluded.stars_1, stars_2 = rating_summary.stars_2 + excluded.stars_2, stars_3 = rating_summary.stars_3 + excluded.stars_3, stars_4 = rating_summary.stars_4 + excluded.stars_4, stars_5 = rating_summary.stars_5 + excluded.stars_5, updated_at = (now() at time zone 'utc')"
 )
 
 // TripRepository provides access to the trip resource backed by a Postgres SQL
@@ -29,13 +44,29 @@ const (
 type TripRepository struct {
 	db *sqlx.DB
 
-	listTripsStmt    *sqlx.Stmt
-	getStmt          *sqlx.Stmt
-	createStmt       *sqlx.NamedStmt
-	updateStmt       *sqlx.NamedStmt
-	deleteStmt       *sqlx.Stmt
-	getRatingStmt    *sqlx.Stmt
-	createRatingStmt *sqlx.NamedStmt
+	listTripsStmt              *sqlx.Stmt
+	listTripsByDriverScoreStmt *sqlx.Stmt
+	getStmt                    *sqlx.Stmt
+	createStmt                 *sqlx.NamedStmt
+	updateStmt                 *sqlx.NamedStmt
+	startStmt                  *sqlx.Stmt
+	finishStmt                 *sqlx.Stmt
+	deleteStmt                 *sqlx.Stmt
+	listPendingBookersStmt     *sqlx.Stmt
+	cancelStmt                 *sqlx.NamedStmt
+	cancelBookingStmt          *sqlx.NamedStmt
+	cancelTripBookingsStmt     *sqlx.NamedStmt
+	cancelRiderBookingStmt     *sqlx.NamedStmt
+	createCancellationStmt     *sqlx.NamedStmt
+	remindStmt                 *sqlx.Stmt
+	completeStmt               *sqlx.Stmt
+	creditReferralStmt         *sqlx.Stmt
+	listRoutePricesStmt        *sqlx.Stmt
+	listRatingsStmt            *sqlx.Stmt
+	createRatingStmt           *sqlx.NamedStmt
+	addRatingSummaryStmt       *sqlx.Stmt
+	createEventStmt            *sqlx.NamedStmt
+	ledgerStmts                *ledgerStatements
 }
 
 // NewTripRepository returns a new TripRepository based on top of the provided
@@ -47,6 +78,9 @@ func NewTripRepository(ctx context.Context, db *sqlx.DB) (*TripRepository, error
 	if s.listTripsStmt, err = db.PreparexContext(ctx, listTripsSQL); err != nil {
 		return nil, fmt.Errorf("prepare list trips statement: %w", err)
 	}
+	if s.listTripsByDriverScoreStmt, err = db.PreparexContext(ctx, listTripsByDriverScoreSQL); err != nil {
+		return nil, fmt.Errorf("prepare list trips by driver score statement: %w", err)
+	}
 	if s.getStmt, err = db.PreparexContext(ctx, getTripSQL); err != nil {
 		return nil, fmt.Errorf("prepare get trip statement: %w", err)
 	}
@@ -56,15 +90,60 @@ func NewTripRepository(ctx context.Context, db *sqlx.DB) (*TripRepository, error
 	if s.updateStmt, err = db.PrepareNamedContext(ctx, updateTripSQL); err != nil {
 		return nil, fmt.Errorf("prepare update trip statement: %w", err)
 	}
+	if s.startStmt, err = db.PreparexContext(ctx, startTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare start trip statement: %w", err)
+	}
+	if s.finishStmt, err = db.PreparexContext(ctx, finishTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare finish trip statement: %w", err)
+	}
 	if s.deleteStmt, err = db.PreparexContext(ctx, deleteTripSQL); err != nil {
 		return nil, fmt.Errorf("prepare delete trip statement: %w", err)
 	}
-	if s.getRatingStmt, err = db.PreparexContext(ctx, getRatingSQL); err != nil {
-		return nil, fmt.Errorf("prepare get trip rating statement: %w", err)
+	if s.listPendingBookersStmt, err = db.PreparexContext(ctx, listPendingBookersSQL); err != nil {
+		return nil, fmt.Errorf("prepare list pending bookers statement: %w", err)
+	}
+	if s.cancelStmt, err = db.PrepareNamedContext(ctx, cancelTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare cancel trip statement: %w", err)
+	}
+	if s.cancelBookingStmt, err = db.PrepareNamedContext(ctx, cancelBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare cancel trip booking statement: %w", err)
+	}
+	if s.cancelTripBookingsStmt, err = db.PrepareNamedContext(ctx, cancelTripBookingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare cancel trip bookings statement: %w", err)
+	}
+	if s.cancelRiderBookingStmt, err = db.PrepareNamedContext(ctx, cancelRiderBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare cancel rider booking statement: %w", err)
+	}
+	if s.createCancellationStmt, err = db.PrepareNamedContext(ctx, createCancellationSQL); err != nil {
+		return nil, fmt.Errorf("prepare create trip cancellation statement: %w", err)
+	}
+	if s.remindStmt, err = db.PreparexContext(ctx, remindTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare remind trips statement: %w", err)
+	}
+	if s.completeStmt, err = db.PreparexContext(ctx, completeTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare complete trips statement: %w", err)
+	}
+	if s.creditReferralStmt, err = db.PreparexContext(ctx, creditReferralSQL); err != nil {
+		return nil, fmt.Errorf("prepare credit referral statement: %w", err)
+	}
+	if s.listRoutePricesStmt, err = db.PreparexContext(ctx, listRoutePricesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list route prices statement: %w", err)
+	}
+	if s.listRatingsStmt, err = db.PreparexContext(ctx, listTripRatingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list trip ratings statement: %w", err)
 	}
 	if s.createRatingStmt, err = db.PrepareNamedContext(ctx, createRatingSQL); err != nil {
 		return nil, fmt.Errorf("prepare create trip rating statement: %w", err)
 	}
+	if s.addRatingSummaryStmt, err = db.PreparexContext(ctx, addRatingSummarySQL); err != nil {
+		return nil, fmt.Errorf("prepare add rating summary statement: %w", err)
+	}
+	if s.createEventStmt, err = db.PrepareNamedContext(ctx, createEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare create event statement: %w", err)
+	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
 
 	return s, nil
 }
@@ -74,6 +153,9 @@ func (s *TripRepository) Close() error {
 	if err := s.listTripsStmt.Close(); err != nil {
 		return fmt.Errorf("close list trip statement: %w", err)
 	}
+	if err := s.listTripsByDriverScoreStmt.Close(); err != nil {
+		return fmt.Errorf("close list trips by driver score statement: %w", err)
+	}
 	if err := s.getStmt.Close(); err != nil {
 		return fmt.Errorf("close get trip statement: %w", err)
 	}
@@ -83,23 +165,76 @@ func (s *TripRepository) Close() error {
 	if err := s.updateStmt.Close(); err != nil {
 		return fmt.Errorf("close update trip statement: %w", err)
 	}
+	if err := s.startStmt.Close(); err != nil {
+		return fmt.Errorf("close start trip statement: %w", err)
+	}
+	if err := s.finishStmt.Close(); err != nil {
+		return fmt.Errorf("close finish trip statement: %w", err)
+	}
 	if err := s.deleteStmt.Close(); err != nil {
 		return fmt.Errorf("close delete trip statement: %w", err)
 	}
-	if err := s.getRatingStmt.Close(); err != nil {
-		return fmt.Errorf("close get trip rating statement: %w", err)
+	if err := s.listPendingBookersStmt.Close(); err != nil {
+		return fmt.Errorf("close list pending bookers statement: %w", err)
+	}
+	if err := s.cancelStmt.Close(); err != nil {
+		return fmt.Errorf("close cancel trip statement: %w", err)
+	}
+	if err := s.cancelBookingStmt.Close(); err != nil {
+		return fmt.Errorf("close cancel trip booking statement: %w", err)
+	}
+	if err := s.cancelTripBookingsStmt.Close(); err != nil {
+		return fmt.Errorf("close cancel trip bookings statement: %w", err)
+	}
+	if err := s.cancelRiderBookingStmt.Close(); err != nil {
+		return fmt.Errorf("close cancel rider booking statement: %w", err)
+	}
+	if err := s.createCancellationStmt.Close(); err != nil {
+		return fmt.Errorf("close create trip cancellation statement: %w", err)
+	}
+	if err := s.remindStmt.Close(); err != nil {
+		return fmt.Errorf("close remind trips statement: %w", err)
+	}
+	if err := s.completeStmt.Close(); err != nil {
+		return fmt.Errorf("close complete trips statement: %w", err)
+	}
+	if err := s.creditReferralStmt.Close(); err != nil {
+		return fmt.Errorf("close credit referral statement: %w", err)
+	}
+	if err := s.listRoutePricesStmt.Close(); err != nil {
+		return fmt.Errorf("close list route prices statement: %w", err)
+	}
+	if err := s.listRatingsStmt.Close(); err != nil {
+		return fmt.Errorf("close list trip ratings statement: %w", err)
 	}
 	if err := s.createRatingStmt.Close(); err != nil {
 		return fmt.Errorf("close create trip rating statement: %w", err)
 	}
+	if err := s.addRatingSummaryStmt.Close(); err != nil {
+		return fmt.Errorf("close add rating summary statement: %w", err)
+	}
+	if err := s.createEventStmt.Close(); err != nil {
+		return fmt.Errorf("close create event statement: %w", err)
+	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
-// ListTrips lists all trips.
-func (s *TripRepository) ListTrips(ctx context.Context) ([]*cargonaut.Trip, error) {
+// ListTrips lists all trips matching the filter.
+func (s *TripRepository) ListTrips(ctx context.Context, filter *cargonaut.TripFilter) ([]*cargonaut.Trip, error) {
+	if filter == nil {
+		filter = new(cargonaut.TripFilter)
+	}
+	stmt := s.listTripsStmt
+	if filter.Sort == cargonaut.TripSortDriverScore {
+		stmt = s.listTripsByDriverScoreStmt
+	}
+
 	trips := make([]*cargonaut.Trip, 0)
-	if err := s.listTripsStmt.SelectContext(ctx, &trips); err != nil {
+	if err := stmt.SelectContext(ctx, &trips, filter.OrganizationID); err != nil {
 		return nil, fmt.Errorf("select trips from database: %w", err)
 	}
 	return trips, nil
@@ -116,52 +251,333 @@ func (s *TripRepository) GetTrip(ctx context.Context, id uuid.UUID) (*cargonaut.
 	return trip, nil
 }
 
-// CreateTrip creates a new trip.
-func (s *TripRepository) CreateTrip(ctx context.Context, trip *cargonaut.Trip) error {
-	if _, err := s.createStmt.ExecContext(ctx, trip); isAlreadyExistsError(err) {
+// CreateTrip creates a new trip. Its ID is generated unless set. The events are
+// written to the outbox in the same transaction.
+func (s *TripRepository) CreateTrip(ctx context.Context, trip *cargonaut.Trip, events ...*cargonaut.Event) error {
+	if uuid.Equal(trip.ID, uuid.Nil) {
+		trip.ID = uuid.NewV4()
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.NamedStmtContext(ctx, s.createStmt).ExecContext(ctx, trip); isAlreadyExistsError(err) {
 		return cargonaut.ErrTripExists
 	} else if err != nil {
 		return fmt.Errorf("create trip in database: %w", err)
 	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
-// UpdateTrip updates a given trip.
-func (s *TripRepository) UpdateTrip(ctx context.Context, trip *cargonaut.Trip) error {
-	if _, err := s.updateStmt.ExecContext(ctx, trip); isAlreadyExistsError(err) {
+// UpdateTrip updates a given trip. Its rider is only changed by booking the
+// trip and its depature and arrival by starting and finishing it. The price
+// and vehicle of a booked trip are not changed, but ErrTripBooked is returned.
+// The events are written to the outbox in the same transaction.
+func (s *TripRepository) UpdateTrip(ctx context.Context, trip *cargonaut.Trip, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.NamedStmtContext(ctx, s.updateStmt).ExecContext(ctx, trip)
+	if isAlreadyExistsError(err) {
 		return cargonaut.ErrTripExists
 	} else if err != nil {
 		return fmt.Errorf("update trip %q in database: %w", trip.ID, err)
 	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("update trip %q in database: %w", trip.ID, err)
+	} else if n == 0 {
+		return cargonaut.ErrTripBooked
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// StartTrip records the depature of a trip which has neither been started nor
+// cancelled yet. Otherwise ErrTripNotStartable is returned. The events are
+// written to the outbox in the same transaction.
+func (s *TripRepository) StartTrip(ctx context.Context, id uuid.UUID, at time.Time, events ...*cargonaut.Event) error {
+	return s.recordProgress(ctx, s.startStmt, id, at, cargonaut.ErrTripNotStartable, events)
+}
+
+// FinishTrip records the arrival of a trip which has been started before the
+// given time and has neither been finished nor cancelled yet. Otherwise
+// ErrTripNotFinishable is returned. The events are written to the outbox in
+// the same transaction.
+func (s *TripRepository) FinishTrip(ctx context.Context, id uuid.UUID, at time.Time, events ...*cargonaut.Event) error {
+	return s.recordProgress(ctx, s.finishStmt, id, at, cargonaut.ErrTripNotFinishable, events)
+}
+
+// recordProgress records the depature or arrival of the trip at the given time
+// with the statement, which only updates trips in the expected state. If the
+// trip is not, errState is returned.
+func (s *TripRepository) recordProgress(ctx context.Context, stmt *sqlx.Stmt, id uuid.UUID, at time.Time, errState error, events []*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.StmtxContext(ctx, stmt).ExecContext(ctx, id, at)
+	if err != nil {
+		return fmt.Errorf("record progress of trip %q in database: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("record progress of trip %q in database: %w", id, err)
+	} else if n == 0 {
+		return errState
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
-// DeleteTrip deletes a trip identified by his unique ID.
+// DeleteTrip deletes a trip identified by his unique ID. The balance held for
+// its pending bookings is released in the same transaction.
 func (s *TripRepository) DeleteTrip(ctx context.Context, id uuid.UUID) error {
-	if _, err := s.deleteStmt.ExecContext(ctx, id); err != nil {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	riders := make([]uuid.UUID, 0)
+	if err = tx.StmtxContext(ctx, s.listPendingBookersStmt).SelectContext(ctx, &riders, id); err != nil {
+		return fmt.Errorf("select pending bookers of trip %q from database: %w", id, err)
+	}
+	for _, riderID := range riders {
+		if err = s.ledgerStmts.release(ctx, tx, id, riderID); err != nil {
+			return err
+		}
+	}
+	if _, err = tx.StmtxContext(ctx, s.deleteStmt).ExecContext(ctx, id); err != nil {
 		return fmt.Errorf("delete trip %q from database: %w", id, err)
 	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
-// GetRating gets a rating for a trip.
-func (s *TripRepository) GetRating(ctx context.Context, id uuid.UUID) (*cargonaut.Rating, error) {
-	rating := new(cargonaut.Rating)
-	if err := s.getRatingStmt.GetContext(ctx, rating, id); err == sql.ErrNoRows {
-		return nil, cargonaut.ErrRatingNotFound
-	} else if err != nil {
-		return nil, fmt.Errorf("get rating for trip %q from database: %w", id, err)
+// ListRatings lists the ratings of the driver and the rider of the trip
+// identified by its unique ID.
+func (s *TripRepository) ListRatings(ctx context.Context, tripID uuid.UUID) ([]*cargonaut.Rating, error) {
+	ratings := make([]*cargonaut.Rating, 0, 2)
+	if err := s.listRatingsStmt.SelectContext(ctx, &ratings, tripID); err != nil {
+		return nil, fmt.Errorf("select ratings for trip %q from database: %w", tripID, err)
 	}
-	return rating, nil
+	return ratings, nil
 }
 
-// CreateRating creates a new rating fro a trip.
-func (s *TripRepository) CreateRating(ctx context.Context, rating *cargonaut.Rating) error {
-	if _, err := s.createRatingStmt.ExecContext(ctx, rating); err != nil {
+// CreateRating creates a new rating fro a trip. The rating summary of the rated
+// user is updated and the events are written to the outbox in the same
+// transaction.
+func (s *TripRepository) CreateRating(ctx context.Context, rating *cargonaut.Rating, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = tx.NamedStmtContext(ctx, s.createRatingStmt).GetContext(ctx, &rating.ID, rating); err != nil {
 		if isAlreadyExistsError(err) {
 			return cargonaut.ErrRatingExists
 		}
 		return fmt.Errorf("create rating for trip %q in database: %w", rating.TripID, err)
 	}
+	if _, err = tx.StmtxContext(ctx, s.addRatingSummaryStmt).ExecContext(ctx, rating.ID); err != nil {
+		return fmt.Errorf("add rating %q to rating summary in database: %w", rating.ID, err)
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CancelTrip cancels the trip as its driver or the booking of the trip as its
+// rider, depending on the role of the cancellation. Affected bookings are
+// cancelled or declined and the balance held for them is released. The
+// cancellation is recorded and the events are written to the outbox in the
+// same transaction.
+func (s *TripRepository) CancelTrip(ctx context.Context, cancellation *cargonaut.TripCancellation, events ...*cargonaut.Event) error {
+	stmt, bookingsStmt := s.cancelStmt, s.cancelTripBookingsStmt
+	if cancellation.Role == cargonaut.RatingRoleRider {
+		stmt, bookingsStmt = s.cancelBookingStmt, s.cancelRiderBookingStmt
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.NamedStmtContext(ctx, stmt).ExecContext(ctx, cancellation)
+	if err != nil {
+		return fmt.Errorf("cancel trip %q in database: %w", cancellation.TripID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("cancel trip %q in database: %w", cancellation.TripID, err)
+	} else if n == 0 {
+		return cargonaut.ErrTripNotCancellable
+	}
+	riders := make([]uuid.UUID, 0)
+	if err = tx.NamedStmtContext(ctx, bookingsStmt).SelectContext(ctx, &riders, cancellation); err != nil {
+		return fmt.Errorf("cancel bookings of trip %q in database: %w", cancellation.TripID, err)
+	}
+	for _, riderID := range riders {
+		if err = s.ledgerStmts.release(ctx, tx, cancellation.TripID, riderID); err != nil {
+			return err
+		}
+	}
+	if err = tx.NamedStmtContext(ctx, s.createCancellationStmt).GetContext(ctx, cancellation, cancellation); err != nil {
+		return fmt.Errorf("create cancellation of trip %q in database: %w", cancellation.TripID, err)
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
+
+// RemindTrips marks trips departing between now and the given time, which have
+// not been reminded of yet, as reminded. Trip reminder events for the driver
+// and the rider are written to the outbox in the same transaction.
+func (s *TripRepository) RemindTrips(ctx context.Context, before time.Time) (int64, error) {
+	n, err := s.markTrips(ctx, s.remindStmt, cargonaut.EventTypeTripReminder, nil, before)
+	if err != nil {
+		return 0, fmt.Errorf("remind trips departing before %s: %w", before, err)
+	}
+	return n, nil
+}
+
+// CompleteTrips marks trips which arrived before the given time as completed
+// and settles the balance held for them to their drivers, withholding the
+// platform fee. Drivers and riders completing their first booked trip, who
+// have been referred by another user, are credited along with the referring
+// user. This requires the rider to have paid for the trip from their wallet
+// and the trip to have arrived after its depature. Trip completed events for
+// the driver and the rider are written to the outbox in the same transaction.
+func (s *TripRepository) CompleteTrips(ctx context.Context, before time.Time, fees cargonaut.FeePolicy, referrals cargonaut.ReferralPolicy) (int64, error) {
+	settle := func(ctx context.Context, tx *sqlx.Tx, trip *cargonaut.Trip) error {
+		if trip.RiderID == nil {
+			return nil
+		}
+		paid, err := s.ledgerStmts.settle(ctx, tx, trip.ID, *trip.RiderID, trip.UserID, fees)
+		if err != nil {
+			return err
+		}
+		if referrals.Credit <= 0 || paid <= 0 || !trip.Arrival.After(trip.Depature) {
+			return nil
+		}
+		for _, userID := range []uuid.UUID{trip.UserID, *trip.RiderID} {
+			if err := s.creditReferral(ctx, tx, trip, userID, referrals.Credit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	n, err := s.markTrips(ctx, s.completeStmt, cargonaut.EventTypeTripCompleted, settle, before)
+	if err != nil {
+		return 0, fmt.Errorf("complete trips arrived before %s: %w", before, err)
+	}
+	return n, nil
+}
+
+// creditReferral credits the user of the trip and the user who referred them,
+// unless the referral has been credited before. Accounts linked to each other
+// are not credited, which is the case if the referring user took part in the
+// trip or both share the same mailbox, ignoring sub-addresses.
+func (s *TripRepository) creditReferral(ctx context.Context, tx *sqlx.Tx, trip *cargonaut.Trip, userID uuid.UUID, credit int64) error {
+	var referrerID uuid.UUID
+	if err := tx.StmtxContext(ctx, s.creditReferralStmt).GetContext(ctx, &referrerID, userID, trip.UserID, *trip.RiderID); err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("credit referral of user %q in database: %w", userID, err)
+	}
+	if err := s.ledgerStmts.reward(ctx, tx, cargonaut.LedgerEntryTypeReferral, userID, &trip.ID, credit); err != nil {
+		return err
+	}
+	return s.ledgerStmts.reward(ctx, tx, cargonaut.LedgerEntryTypeReferral, referrerID, &trip.ID, credit)
+}
+
+// ListRoutePrices lists the prices of trips from the start to the destination
+// with vehicles of the given type, which have been created since the given
+// time and were not cancelled, most recent first. Locations are compared case
+// insensitive.
+func (s *TripRepository) ListRoutePrices(ctx context.Context, start, destination string, typ cargonaut.VehicleType, since time.Time) ([]float32, error) {
+	prices := make([]float32, 0)
+	if err := s.listRoutePricesStmt.SelectContext(ctx, &prices, start, destination, since, typ); err != nil {
+		return nil, fmt.Errorf("select prices of trips from %q to %q from database: %w", start, destination, err)
+	}
+	return prices, nil
+}
+
+// markTrips executes a statement which updates trips and returns their IDs,
+// user IDs and rider IDs. If given, fn is called for each updated trip within
+// the transaction. An event of the given type is written to the outbox for the
+// driver and the rider of each updated trip.
+func (s *TripRepository) markTrips(ctx context.Context, stmt *sqlx.Stmt, typ cargonaut.EventType, fn func(context.Context, *sqlx.Tx, *cargonaut.Trip) error, args ...interface{}) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	trips := make([]*cargonaut.Trip, 0)
+	if err = tx.StmtxContext(ctx, stmt).SelectContext(ctx, &trips, args...); err != nil {
+		return 0, fmt.Errorf("update trips in database: %w", err)
+	}
+
+	events := make([]*cargonaut.Event, 0, 2*len(trips))
+	for _, trip := range trips {
+		if fn != nil {
+			if err = fn(ctx, tx, trip); err != nil {
+				return 0, err
+			}
+		}
+		events = append(events, cargonaut.NewEvent(typ, trip.UserID, trip.ID))
+		if trip.RiderID != nil {
+			events = append(events, cargonaut.NewEvent(typ, *trip.RiderID, trip.ID))
+		}
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return int64(len(trips)), nil
+}
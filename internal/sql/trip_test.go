@@ -0,0 +1,155 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestTripRepositoryRider(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	// Riders can neither be set by creating nor by updating a trip.
+	riderID := createTestUser(t, db).ID
+	trip := createTestTrip(t, db, repo, func(trip *cargonaut.Trip) { trip.RiderID = &riderID })
+	got, err := repo.GetTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.RiderID)
+
+	got.RiderID = &riderID
+	require.NoError(t, repo.UpdateTrip(ctx, got))
+	got, err = repo.GetTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.RiderID)
+}
+
+func TestTripRepositoryRemindTrips(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	now := time.Now().UTC()
+	planned := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	tests := []struct {
+		name     string
+		trip     func(*cargonaut.Trip)
+		reminded bool
+	}{
+		{"departing soon", func(t *cargonaut.Trip) { t.PlannedDepature = planned(30 * time.Minute) }, true},
+		{"departing later", func(t *cargonaut.Trip) { t.PlannedDepature = planned(3 * time.Hour) }, false},
+		{"departed", func(t *cargonaut.Trip) { t.PlannedDepature = planned(-30 * time.Minute) }, false},
+		{"started", func(t *cargonaut.Trip) {
+			t.PlannedDepature = planned(30 * time.Minute)
+			t.Depature = now.Add(-time.Minute)
+		}, false},
+		{"not planned", nil, false},
+	}
+	trips := make([]*cargonaut.Trip, len(tests))
+	for i, tt := range tests {
+		trips[i] = createTestTrip(t, db, repo, tt.trip)
+	}
+
+	_, err = repo.RemindTrips(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	for i, tt := range tests {
+		var reminded bool
+		require.NoError(t, db.Get(&reminded, "SELECT reminded_at IS NOT NULL FROM trip WHERE id = $1", trips[i].ID))
+		assert.Equal(t, tt.reminded, reminded, tt.name)
+	}
+}
+
+func TestTripRepositoryCompleteTrips(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	now := time.Now().UTC()
+	tests := []struct {
+		name      string
+		trip      func(*cargonaut.Trip)
+		completed bool
+	}{
+		{"not started", nil, false},
+		{"in transit", func(t *cargonaut.Trip) { t.Depature = now.Add(-time.Hour) }, false},
+		{"finished", func(t *cargonaut.Trip) {
+			t.Depature = now.Add(-time.Hour)
+			t.Arrival = now.Add(-time.Minute)
+		}, true},
+		{"cancelled", func(t *cargonaut.Trip) {
+			t.Depature = now.Add(-time.Hour)
+			t.Arrival = now.Add(-time.Minute)
+			t.CancelledAt = &now
+		}, false},
+	}
+	trips := make([]*cargonaut.Trip, len(tests))
+	for i, tt := range tests {
+		trips[i] = createTestTrip(t, db, repo, tt.trip)
+		if trips[i].CancelledAt != nil {
+			_, err = db.Exec("UPDATE trip SET cancelled_at = $2 WHERE id = $1", trips[i].ID, now)
+			require.NoError(t, err)
+		}
+	}
+
+	_, err = repo.CompleteTrips(ctx, now, cargonaut.FeePolicy{}, cargonaut.ReferralPolicy{})
+	require.NoError(t, err)
+
+	for i, tt := range tests {
+		trip, err := repo.GetTrip(ctx, trips[i].ID)
+		require.NoError(t, err)
+		assert.Equal(t, tt.completed, trip.CompletedAt != nil, tt.name)
+	}
+}
+
+func TestTripRepositoryCancelTrip(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	repo, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	now := time.Now().UTC()
+	tests := []struct {
+		name string
+		trip func(*cargonaut.Trip)
+		err  error
+	}{
+		{"offered", func(t *cargonaut.Trip) {
+			at := now.Add(10 * time.Minute)
+			t.PlannedDepature = &at
+		}, nil},
+		{"in transit", func(t *cargonaut.Trip) { t.Depature = now.Add(-time.Hour) }, nil},
+		{"finished", func(t *cargonaut.Trip) {
+			t.Depature = now.Add(-time.Hour)
+			t.Arrival = now.Add(-time.Minute)
+		}, cargonaut.ErrTripNotCancellable},
+	}
+	for _, tt := range tests {
+		trip := createTestTrip(t, db, repo, tt.trip)
+		err := repo.CancelTrip(ctx, &cargonaut.TripCancellation{
+			TripID: trip.ID,
+			UserID: trip.UserID,
+			Role:   cargonaut.RatingRoleDriver,
+			Reason: "test",
+		})
+		assert.Equal(t, tt.err, err, tt.name)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	uuid "github.com/satori/go.uuid"
@@ -15,17 +16,32 @@ import (
 var _ cargonaut.UserRepository = (*UserRepository)(nil)
 
 const (
-	listUsersSQL        = "SELECT id, email, password_hash, display_name, birthday, avatar, created_at, updated_at FROM user_account ORDER BY updated_at DESC"
-	getUserSQL          = "SELECT id, email, password_hash, display_name, birthday, avatar, created_at, updated_at FROM user_account WHERE id = $1 LIMIT 1"
-	getUserByEmailSQL   = "SELECT id, email, password_hash, display_name, birthday, avatar, created_at, updated_at FROM user_account WHERE email = $1 LIMIT 1"
-	createUserSQL       = "INSERT INTO user_account (email, password_hash, display_name, birthday, avatar) VALUES (:email, :password_hash, :display_name, :birthday, :avatar)"
-	updateUserSQL       = "UPDATE user_account SET email = :email, password_hash = :password_hash, display_name = :display_name, birthday = :birthday, avatar = :avatar, updated_at = :updated_at WHERE id = :id"
-	deleteUserSQL       = "DELETE FROM user_account WHERE id = $1"
-	listTokensSQL       = "SELECT id, user_id, expires_at, created_at FROM user_token WHERE user_id = $1"
-	createTokenSQL      = "INSERT INTO user_token (id, user_id, expires_at) VALUES (:id, :user_id, :expires_at)"
-	deleteTokenSQL      = "DELETE FROM user_token WHERE user_id = $1 AND id = $2"
-	listRatingsSQL      = "SELECT id, user_id, author_id, trip_id, comment, value, created_at FROM rating WHERE user_id = $1"
-	listUserVehiclesSQL = "SELECT id, user_id, brand, model, passengers, loading_area_length, loading_area_width, created_at, updated_at FROM vehicle WHERE user_id = $1 ORDER BY updated_at DESC"
+	listUsersSQL               = "SELECT id, email, password_hash, display_name, birthday, privacy, notifications, role, created_at, updated_at FROM user_account WHERE " + tombstoneCondition + " ORDER BY updated_at DESC"
+	getUserSQL                 = "SELECT id, email, password_hash, display_name, birthday, privacy, notifications, role, created_at, updated_at FROM user_account WHERE id = $1 LIMIT 1"
+	getUserByEmailSQL          = "SELECT id, email, password_hash, display_name, birthday, privacy, notifications, role, created_at, updated_at FROM user_account WHERE email = $1 LIMIT 1"
+	getUserStatisticsSQL       = "SELECT (SELECT coalesce(sum(count), 0) FROM rating_summary WHERE user_id = $1) AS rating_count, (SELECT coalesce(sum(total) / nullif(sum(count), 0), 0) FROM rating_summary WHERE user_id = $1) AS rating_average, (SELECT count(*) FROM trip WHERE (user_id = $1 OR rider_id = $1) AND " + completedTripCondition + ") AS trip_count, (SELECT count(*) FROM trip_cancellation WHERE user_id = $1) AS cancellation_count, (SELECT count(*) FROM trip_cancellation WHERE user_id = $1 AND late) AS late_cancellation_count"
+	createUserSQL              = "INSERT INTO user_account (email, password_hash, display_name, birthday, referred_by) VALUES (:email, :password_hash, :display_name, :birthday, :referred_by) RETURNING id, created_at, updated_at"
+	updateUserSQL              = "UPDATE user_account SET email = :email, password_hash = :password_hash, display_name = :display_name, birthday = :birthday, privacy = :privacy, notifications = :notifications, updated_at = (now() at time zone 'utc') WHERE id = :id"
+	deleteUserSQL              = "DELETE FROM user_account WHERE id = $1"
+	listUserHoldsSQL           = "SELECT e.trip_id, a.user_id FROM ledger_posting p JOIN ledger_entry e ON e.id = p.entry_id JOIN ledger_account a ON a.id = p.account_id JOIN trip t ON t.id = e.trip_id WHERE a.type = 'hold' AND (t.user_id = $1 OR a.user_id = $1) AND NOT (t.rider_id IS NOT NULL AND t.completed_at IS NOT NULL) GROUP BY e.trip_id, a.user_id HAVING sum(p.amount) <> 0"
+	lockUserOrganizationsSQL   = "SELECT o.id FROM organization o JOIN organization_member m ON m.organization_id = o.id WHERE m.user_id = $1 ORDER BY o.id FOR UPDATE OF o"
+	countSoleOwnershipsSQL     = "SELECT count(*) FROM organization_member m WHERE m.user_id = $1 AND m.role = 'owner' AND NOT EXISTS (SELECT 1 FROM organization_member o WHERE o.organization_id = m.organization_id AND o.role = 'owner' AND o.user_id <> $1)"
+	handOverUserVehiclesSQL    = "UPDATE vehicle v SET user_id = (SELECT m.user_id FROM organization_member m WHERE m.organization_id = v.organization_id AND m.role = 'owner' AND m.user_id <> $1 ORDER BY m.created_at LIMIT 1), updated_at = (now() at time zone 'utc') WHERE v.user_id = $1 AND v.organization_id IS NOT NULL"
+	deleteUserTripsSQL         = "DELETE FROM trip WHERE user_id = $1 AND NOT (" + completedTripCondition + ")"
+	cancelUserRidesSQL         = "UPDATE trip SET rider_id = NULL, updated_at = (now() at time zone 'utc') WHERE rider_id = $1 AND NOT (" + completedTripCondition + ")"
+	listTokensSQL              = "SELECT id, user_id, expires_at, created_at FROM user_token WHERE user_id = $1"
+	createTokenSQL             = "INSERT INTO user_token (id, user_id, expires_at) VALUES (:id, :user_id, :expires_at)"
+	deleteTokenSQL             = "DELETE FROM user_token WHERE user_id = $1 AND id = $2"
+	deleteExpiredTokensSQL     = "DELETE FROM user_token WHERE expires_at < $1"
+	createEmailVerificationSQL = "INSERT INTO user_email_verification (id, user_id, email, expires_at) VALUES (:id, :user_id, :email, :expires_at) ON CONFLICT (user_id) DO UPDATE SET id = excluded.id, email = excluded.email, expires_at = excluded.expires_at, created_at = (now() at time zone 'utc')"
+	deleteEmailVerificationSQL = "DELETE FROM user_email_verification WHERE id = $1 RETURNING id, user_id, email, expires_at, created_at"
+	updateUserEmailSQL         = "UPDATE user_account SET email = $2, updated_at = (now() at time zone 'utc') WHERE id = $1"
+	listRatingsSQL             = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE user_id = $1 AND status = 'visible'"
+	listRatingSummariesSQL     = "SELECT $1::uuid AS user_id, roles.role, coalesce(rs.count, 0) AS count, coalesce(rs.mean, 0) AS mean, coalesce(rs.score, 0) AS score, coalesce(rs.stars_1, 0) AS \"histogram.stars_1\", coalesce(rs.stars_2, 0) AS \"histogram.stars_2\", coalesce(rs.stars_3, 0) AS \"histogram.stars_3\", coalesce(rs.stars_4, 0) AS \"histogram.stars_4\", coalesce(rs.stars_5, 0) AS \"histogram.stars_5\" FROM (VALUES ('driver'), ('rider')) AS roles (role) LEFT JOIN rating_score rs ON rs.user_id = $1 AND rs.role = roles.role ORDER BY roles.role"
+	listAuthoredRatingsSQL     = "SELECT id, user_id, author_id, trip_id, role, comment, value, status, reply, replied_at, created_at FROM rating WHERE author_id = $1"
+	listUserTripsSQL           = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, approval_required, planned_depature, depature, arrival, completed_at, cancelled_at, cancellation_reason, created_at, updated_at FROM trip WHERE user_id = $1 ORDER BY updated_at DESC"
+	listUserRidesSQL           = "SELECT id, user_id, vehicle_id, rider_id, start, destination, price, approval_required, planned_depature, depature, arrival, completed_at, cancelled_at, cancellation_reason, created_at, updated_at FROM trip WHERE rider_id = $1 ORDER BY updated_at DESC"
+	listUserVehiclesSQL        = "SELECT id, user_id, organization_id, license_plate, type, brand, model, color, passengers, max_payload, loading_area_length, loading_area_width, loading_area_height, loading_volume, amenities, created_at, updated_at FROM vehicle WHERE user_id = $1 AND organization_id IS NULL ORDER BY updated_at DESC"
 )
 
 // UserRepository provides access to the user resource backed by a Postgres SQL
@@ -33,17 +49,33 @@ const (
 type UserRepository struct {
 	db *sqlx.DB
 
-	listUsersStmt        *sqlx.Stmt
-	getUserStmt          *sqlx.Stmt
-	getByEmailUserStmt   *sqlx.Stmt
-	createUserStmt       *sqlx.NamedStmt
-	updateUserStmt       *sqlx.NamedStmt
-	deleteUserStmt       *sqlx.Stmt
-	listTokensStmt       *sqlx.Stmt
-	createTokenStmt      *sqlx.NamedStmt
-	deleteTokenStmt      *sqlx.Stmt
-	listRatingsStmt      *sqlx.Stmt
-	listUserVehiclesStmt *sqlx.Stmt
+	listUsersStmt               *sqlx.Stmt
+	getUserStmt                 *sqlx.Stmt
+	getByEmailUserStmt          *sqlx.Stmt
+	getUserStatisticsStmt       *sqlx.Stmt
+	createUserStmt              *sqlx.NamedStmt
+	updateUserStmt              *sqlx.NamedStmt
+	deleteUserStmt              *sqlx.Stmt
+	listUserHoldsStmt           *sqlx.Stmt
+	lockUserOrganizationsStmt   *sqlx.Stmt
+	countSoleOwnershipsStmt     *sqlx.Stmt
+	handOverUserVehiclesStmt    *sqlx.Stmt
+	deleteUserTripsStmt         *sqlx.Stmt
+	cancelUserRidesStmt         *sqlx.Stmt
+	listTokensStmt              *sqlx.Stmt
+	createTokenStmt             *sqlx.NamedStmt
+	deleteTokenStmt             *sqlx.Stmt
+	deleteExpiredTokensStmt     *sqlx.Stmt
+	createEmailVerificationStmt *sqlx.NamedStmt
+	deleteEmailVerificationStmt *sqlx.Stmt
+	updateUserEmailStmt         *sqlx.Stmt
+	listRatingsStmt             *sqlx.Stmt
+	listRatingSummariesStmt     *sqlx.Stmt
+	listAuthoredRatingsStmt     *sqlx.Stmt
+	listUserTripsStmt           *sqlx.Stmt
+	listUserRidesStmt           *sqlx.Stmt
+	listUserVehiclesStmt        *sqlx.Stmt
+	ledgerStmts                 *ledgerStatements
 }
 
 // NewUserRepository returns a new UserRepository based on top of the provided
@@ -61,6 +93,9 @@ func NewUserRepository(ctx context.Context, db *sqlx.DB) (*UserRepository, error
 	if s.getByEmailUserStmt, err = db.PreparexContext(ctx, getUserByEmailSQL); err != nil {
 		return nil, fmt.Errorf("prepare get user by email statement: %w", err)
 	}
+	if s.getUserStatisticsStmt, err = db.PreparexContext(ctx, getUserStatisticsSQL); err != nil {
+		return nil, fmt.Errorf("prepare get user statistics statement: %w", err)
+	}
 	if s.createUserStmt, err = db.PrepareNamedContext(ctx, createUserSQL); err != nil {
 		return nil, fmt.Errorf("prepare create user statement: %w", err)
 	}
@@ -70,6 +105,24 @@ func NewUserRepository(ctx context.Context, db *sqlx.DB) (*UserRepository, error
 	if s.deleteUserStmt, err = db.PreparexContext(ctx, deleteUserSQL); err != nil {
 		return nil, fmt.Errorf("prepare delete user statement: %w", err)
 	}
+	if s.listUserHoldsStmt, err = db.PreparexContext(ctx, listUserHoldsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list user holds statement: %w", err)
+	}
+	if s.lockUserOrganizationsStmt, err = db.PreparexContext(ctx, lockUserOrganizationsSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock user organizations statement: %w", err)
+	}
+	if s.countSoleOwnershipsStmt, err = db.PreparexContext(ctx, countSoleOwnershipsSQL); err != nil {
+		return nil, fmt.Errorf("prepare count sole ownerships statement: %w", err)
+	}
+	if s.handOverUserVehiclesStmt, err = db.PreparexContext(ctx, handOverUserVehiclesSQL); err != nil {
+		return nil, fmt.Errorf("prepare hand over user vehicles statement: %w", err)
+	}
+	if s.deleteUserTripsStmt, err = db.PreparexContext(ctx, deleteUserTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete user trips statement: %w", err)
+	}
+	if s.cancelUserRidesStmt, err = db.PreparexContext(ctx, cancelUserRidesSQL); err != nil {
+		return nil, fmt.Errorf("prepare cancel user rides statement: %w", err)
+	}
 	if s.listTokensStmt, err = db.PreparexContext(ctx, listTokensSQL); err != nil {
 		return nil, fmt.Errorf("prepare list user tokens statement: %w", err)
 	}
@@ -79,12 +132,39 @@ func NewUserRepository(ctx context.Context, db *sqlx.DB) (*UserRepository, error
 	if s.deleteTokenStmt, err = db.PreparexContext(ctx, deleteTokenSQL); err != nil {
 		return nil, fmt.Errorf("prepare delete user token statement: %w", err)
 	}
+	if s.deleteExpiredTokensStmt, err = db.PreparexContext(ctx, deleteExpiredTokensSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete expired user tokens statement: %w", err)
+	}
+	if s.createEmailVerificationStmt, err = db.PrepareNamedContext(ctx, createEmailVerificationSQL); err != nil {
+		return nil, fmt.Errorf("prepare create user email verification statement: %w", err)
+	}
+	if s.deleteEmailVerificationStmt, err = db.PreparexContext(ctx, deleteEmailVerificationSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete user email verification statement: %w", err)
+	}
+	if s.updateUserEmailStmt, err = db.PreparexContext(ctx, updateUserEmailSQL); err != nil {
+		return nil, fmt.Errorf("prepare update user email statement: %w", err)
+	}
 	if s.listRatingsStmt, err = db.PreparexContext(ctx, listRatingsSQL); err != nil {
 		return nil, fmt.Errorf("prepare list user ratings statement: %w", err)
 	}
+	if s.listRatingSummariesStmt, err = db.PreparexContext(ctx, listRatingSummariesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list user rating summaries statement: %w", err)
+	}
+	if s.listAuthoredRatingsStmt, err = db.PreparexContext(ctx, listAuthoredRatingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list authored ratings statement: %w", err)
+	}
+	if s.listUserTripsStmt, err = db.PreparexContext(ctx, listUserTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list user trips statement: %w", err)
+	}
+	if s.listUserRidesStmt, err = db.PreparexContext(ctx, listUserRidesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list user rides statement: %w", err)
+	}
 	if s.listUserVehiclesStmt, err = db.PreparexContext(ctx, listUserVehiclesSQL); err != nil {
 		return nil, fmt.Errorf("prepare list user vehicles statement: %w", err)
 	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
 
 	return s, nil
 }
@@ -100,6 +180,9 @@ func (s *UserRepository) Close() error {
 	if err := s.getByEmailUserStmt.Close(); err != nil {
 		return fmt.Errorf("close get user by email statement: %w", err)
 	}
+	if err := s.getUserStatisticsStmt.Close(); err != nil {
+		return fmt.Errorf("close get user statistics statement: %w", err)
+	}
 	if err := s.createUserStmt.Close(); err != nil {
 		return fmt.Errorf("close create user statement: %w", err)
 	}
@@ -109,6 +192,24 @@ func (s *UserRepository) Close() error {
 	if err := s.deleteUserStmt.Close(); err != nil {
 		return fmt.Errorf("close delete user statement: %w", err)
 	}
+	if err := s.listUserHoldsStmt.Close(); err != nil {
+		return fmt.Errorf("close list user holds statement: %w", err)
+	}
+	if err := s.lockUserOrganizationsStmt.Close(); err != nil {
+		return fmt.Errorf("close lock user organizations statement: %w", err)
+	}
+	if err := s.countSoleOwnershipsStmt.Close(); err != nil {
+		return fmt.Errorf("close count sole ownerships statement: %w", err)
+	}
+	if err := s.handOverUserVehiclesStmt.Close(); err != nil {
+		return fmt.Errorf("close hand over user vehicles statement: %w", err)
+	}
+	if err := s.deleteUserTripsStmt.Close(); err != nil {
+		return fmt.Errorf("close delete user trips statement: %w", err)
+	}
+	if err := s.cancelUserRidesStmt.Close(); err != nil {
+		return fmt.Errorf("close cancel user rides statement: %w", err)
+	}
 	if err := s.listTokensStmt.Close(); err != nil {
 		return fmt.Errorf("close create user token statement: %w", err)
 	}
@@ -118,12 +219,39 @@ func (s *UserRepository) Close() error {
 	if err := s.deleteTokenStmt.Close(); err != nil {
 		return fmt.Errorf("close delete user token statement: %w", err)
 	}
+	if err := s.deleteExpiredTokensStmt.Close(); err != nil {
+		return fmt.Errorf("close delete expired user tokens statement: %w", err)
+	}
+	if err := s.createEmailVerificationStmt.Close(); err != nil {
+		return fmt.Errorf("close create user email verification statement: %w", err)
+	}
+	if err := s.deleteEmailVerificationStmt.Close(); err != nil {
+		return fmt.Errorf("close delete user email verification statement: %w", err)
+	}
+	if err := s.updateUserEmailStmt.Close(); err != nil {
+		return fmt.Errorf("close update user email statement: %w", err)
+	}
 	if err := s.listRatingsStmt.Close(); err != nil {
 		return fmt.Errorf("close list user ratings statement: %w", err)
 	}
+	if err := s.listRatingSummariesStmt.Close(); err != nil {
+		return fmt.Errorf("close list user rating summaries statement: %w", err)
+	}
+	if err := s.listAuthoredRatingsStmt.Close(); err != nil {
+		return fmt.Errorf("close list authored ratings statement: %w", err)
+	}
+	if err := s.listUserTripsStmt.Close(); err != nil {
+		return fmt.Errorf("close list user trips statement: %w", err)
+	}
+	if err := s.listUserRidesStmt.Close(); err != nil {
+		return fmt.Errorf("close list user rides statement: %w", err)
+	}
 	if err := s.listUserVehiclesStmt.Close(); err != nil {
 		return fmt.Errorf("close list user vehicles statement: %w", err)
 	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -159,9 +287,28 @@ func (s *UserRepository) GetUserByEmail(ctx context.Context, email string) (*car
 	return user, nil
 }
 
-// CreateUser creates a new user.
+// GetUserStatistics returns the statistics of the user identified by his unique
+// ID.
+func (s *UserRepository) GetUserStatistics(ctx context.Context, userID uuid.UUID) (*cargonaut.UserStatistics, error) {
+	stats := new(cargonaut.UserStatistics)
+	if err := s.getUserStatisticsStmt.GetContext(ctx, stats, userID); err != nil {
+		return nil, fmt.Errorf("get statistics of user %q from database: %w", userID, err)
+	}
+	if n := stats.TripCount + stats.CancellationCount; n > 0 {
+		stats.CancellationRate = float64(stats.CancellationCount) / float64(n)
+	}
+
+	var err error
+	if stats.Ratings, err = s.ListRatingSummaries(ctx, userID); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// CreateUser creates a new user. The ID and timestamps of the user are set to
+// the ones assigned by the database.
 func (s *UserRepository) CreateUser(ctx context.Context, user *cargonaut.User) error {
-	if _, err := s.createUserStmt.ExecContext(ctx, user); isAlreadyExistsError(err) {
+	if err := s.createUserStmt.GetContext(ctx, user, user); isAlreadyExistsError(err) {
 		return cargonaut.ErrUserExists
 	} else if err != nil {
 		return fmt.Errorf("create user in database: %w", err)
@@ -179,11 +326,65 @@ func (s *UserRepository) UpdateUser(ctx context.Context, user *cargonaut.User) e
 	return nil
 }
 
-// DeleteUser deletes a user identified by his unique ID.
+// DeleteUser deletes a user identified by his unique ID. Trips the user offers
+// or booked are deleted or cancelled, unless they are completed, and the
+// balance held for them is released. Completed trips and ratings are kept, but
+// anonymized by reassigning them to the tombstone user. It returns
+// cargonaut.ErrWalletNotEmpty if the wallet of the user has a balance left
+// after releasing his holds, which must be withdrawn first.
 func (s *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	if _, err := s.deleteUserStmt.ExecContext(ctx, id); err != nil {
+	if uuid.Equal(id, uuid.Nil) {
+		return cargonaut.ErrUserNotFound
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The organizations of the user are locked so concurrent changes to their
+	// memberships can not leave them without an owner. Their other owners take
+	// over the vehicles the user registered for them.
+	var organizationIDs []uuid.UUID
+	if err = tx.StmtxContext(ctx, s.lockUserOrganizationsStmt).SelectContext(ctx, &organizationIDs, id); err != nil {
+		return fmt.Errorf("lock organizations of user %q in database: %w", id, err)
+	}
+	var soleOwnerships int
+	if err = tx.StmtxContext(ctx, s.countSoleOwnershipsStmt).GetContext(ctx, &soleOwnerships, id); err != nil {
+		return fmt.Errorf("count sole ownerships of user %q in database: %w", id, err)
+	} else if soleOwnerships > 0 {
+		return cargonaut.ErrOrganizationOwnerRequired
+	}
+	if _, err = tx.StmtxContext(ctx, s.handOverUserVehiclesStmt).ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("hand over organization vehicles of user %q in database: %w", id, err)
+	}
+
+	holds := make([]*cargonaut.Booking, 0)
+	if err = tx.StmtxContext(ctx, s.listUserHoldsStmt).SelectContext(ctx, &holds, id); err != nil {
+		return fmt.Errorf("select holds of user %q from database: %w", id, err)
+	}
+	for _, hold := range holds {
+		if err = s.ledgerStmts.release(ctx, tx, hold.TripID, hold.UserID); err != nil {
+			return err
+		}
+	}
+	if err = s.ledgerStmts.empty(ctx, tx, id); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.deleteUserTripsStmt).ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("delete trips of user %q from database: %w", id, err)
+	}
+	if _, err = tx.StmtxContext(ctx, s.cancelUserRidesStmt).ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("cancel rides of user %q in database: %w", id, err)
+	}
+	if _, err = tx.StmtxContext(ctx, s.deleteUserStmt).ExecContext(ctx, id); err != nil {
 		return fmt.Errorf("delete user %q from database: %w", id, err)
 	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
 	return nil
 }
 
@@ -220,6 +421,70 @@ func (s *UserRepository) DeleteToken(ctx context.Context, userID, tokenID uuid.U
 	return nil
 }
 
+// DeleteExpiredTokens deletes all authentication tokens which expired before
+// the given time.
+func (s *UserRepository) DeleteExpiredTokens(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.deleteExpiredTokensStmt.ExecContext(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired user tokens from database: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// CreateEmailVerification creates a pending E-Mail address change for the user
+// identified by the verifications unique user ID. A previously pending change
+// of the same user is replaced.
+func (s *UserRepository) CreateEmailVerification(ctx context.Context, verification *cargonaut.EmailVerification) error {
+	if _, err := s.createEmailVerificationStmt.ExecContext(ctx, verification); err != nil {
+		return fmt.Errorf("create email verification for user %q in database: %w", verification.UserID, err)
+	}
+	return nil
+}
+
+// VerifyEmail applies the pending E-Mail address change identified by its
+// unique ID and returns the updated user. The pending change is consumed, even
+// if it has already expired.
+func (s *UserRepository) VerifyEmail(ctx context.Context, id uuid.UUID) (*cargonaut.User, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	verification := new(cargonaut.EmailVerification)
+	if err = tx.StmtxContext(ctx, s.deleteEmailVerificationStmt).GetContext(ctx, verification, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrEmailVerificationNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("delete email verification %q from database: %w", id, err)
+	}
+
+	// An expired verification is removed but never applied.
+	if time.Now().After(verification.ExpiresAt) {
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("commit transaction: %w", err)
+		}
+		return nil, cargonaut.ErrEmailVerificationNotFound
+	}
+
+	if _, err = tx.StmtxContext(ctx, s.updateUserEmailStmt).ExecContext(ctx, verification.UserID, verification.Email); isAlreadyExistsError(err) {
+		return nil, cargonaut.ErrUserExists
+	} else if err != nil {
+		return nil, fmt.Errorf("update email of user %q in database: %w", verification.UserID, err)
+	}
+
+	user := new(cargonaut.User)
+	if err = tx.StmtxContext(ctx, s.getUserStmt).GetContext(ctx, user, verification.UserID); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrUserNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get user %q from database: %w", verification.UserID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return user, nil
+}
+
 // ListRatings lists all ratings for the user identified by his unique ID.
 func (s *UserRepository) ListRatings(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Rating, error) {
 	ratings := make([]*cargonaut.Rating, 0)
@@ -231,7 +496,47 @@ func (s *UserRepository) ListRatings(ctx context.Context, userID uuid.UUID) ([]*
 	return ratings, nil
 }
 
-// ListVehicles lists all vehicles for the user identified by his unique ID.
+// ListRatingSummaries lists the rating summaries of all roles for the user
+// identified by his unique ID. Roles the user has not been rated in yet are
+// included with empty summaries.
+func (s *UserRepository) ListRatingSummaries(ctx context.Context, userID uuid.UUID) ([]*cargonaut.RatingSummary, error) {
+	summaries := make([]*cargonaut.RatingSummary, 0, 2)
+	if err := s.listRatingSummariesStmt.SelectContext(ctx, &summaries, userID); err != nil {
+		return nil, fmt.Errorf("select rating summaries of user %q from database: %w", userID, err)
+	}
+	return summaries, nil
+}
+
+// ListAuthoredRatings lists all ratings given by the user identified by his
+// unique ID.
+func (s *UserRepository) ListAuthoredRatings(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Rating, error) {
+	ratings := make([]*cargonaut.Rating, 0)
+	if err := s.listAuthoredRatingsStmt.SelectContext(ctx, &ratings, userID); err != nil {
+		return nil, fmt.Errorf("select ratings authored by user %q from database: %w", userID, err)
+	}
+	return ratings, nil
+}
+
+// ListTrips lists all trips offered by the user identified by his unique ID.
+func (s *UserRepository) ListTrips(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Trip, error) {
+	trips := make([]*cargonaut.Trip, 0)
+	if err := s.listUserTripsStmt.SelectContext(ctx, &trips, userID); err != nil {
+		return nil, fmt.Errorf("select trips of user %q from database: %w", userID, err)
+	}
+	return trips, nil
+}
+
+// ListRides lists all trips booked by the user identified by his unique ID.
+func (s *UserRepository) ListRides(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Trip, error) {
+	trips := make([]*cargonaut.Trip, 0)
+	if err := s.listUserRidesStmt.SelectContext(ctx, &trips, userID); err != nil {
+		return nil, fmt.Errorf("select rides of user %q from database: %w", userID, err)
+	}
+	return trips, nil
+}
+
+// ListVehicles lists all vehicles for the user identified by his unique ID,
+// except those registered for an organization.
 func (s *UserRepository) ListVehicles(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Vehicle, error) {
 	vehicles := make([]*cargonaut.Vehicle, 0)
 	if err := s.listUserVehiclesStmt.SelectContext(ctx, &vehicles, userID); err != nil {
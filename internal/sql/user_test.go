@@ -0,0 +1,87 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestUserRepositoryDeleteUser(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	users, err := NewUserRepository(ctx, db)
+	require.NoError(t, err)
+	defer users.Close()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+
+	// The driver completed a trip with the rider, offers another one and
+	// booked a third trip as rider.
+	now := time.Now().UTC()
+	rider := createTestUser(t, db)
+	completed := createTestTrip(t, db, trips, func(trip *cargonaut.Trip) {
+		trip.Depature = now.Add(-2 * time.Hour)
+		trip.Arrival = now.Add(-time.Hour)
+	})
+	_, err = db.Exec("UPDATE trip SET rider_id = $2, completed_at = $3 WHERE id = $1", completed.ID, rider.ID, now)
+	require.NoError(t, err)
+	driverID := completed.UserID
+	offered := createTestTrip(t, db, trips, func(trip *cargonaut.Trip) {
+		trip.UserID = driverID
+		trip.VehicleID = completed.VehicleID
+	})
+	booked := createTestTrip(t, db, trips, nil)
+	_, err = db.Exec("UPDATE trip SET rider_id = $2 WHERE id = $1", booked.ID, driverID)
+	require.NoError(t, err)
+
+	received := &cargonaut.Rating{UserID: driverID, AuthorID: rider.ID, TripID: completed.ID, Role: cargonaut.RatingRoleDriver, Value: 5}
+	require.NoError(t, trips.CreateRating(ctx, received))
+	given := &cargonaut.Rating{UserID: rider.ID, AuthorID: driverID, TripID: completed.ID, Role: cargonaut.RatingRoleRider, Value: 4}
+	require.NoError(t, trips.CreateRating(ctx, given))
+
+	require.NoError(t, users.DeleteUser(ctx, driverID))
+	_, err = users.GetUser(ctx, driverID)
+	assert.Equal(t, cargonaut.ErrUserNotFound, err)
+
+	// The completed trip is kept for the rider, but its driver and vehicle
+	// are replaced by the tombstones.
+	got, err := trips.GetTrip(ctx, completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, got.UserID)
+	assert.Equal(t, uuid.Nil, got.VehicleID)
+	assert.Equal(t, &rider.ID, got.RiderID)
+
+	// The offered trip is gone and the booked one lost its rider.
+	_, err = trips.GetTrip(ctx, offered.ID)
+	assert.Equal(t, cargonaut.ErrTripNotFound, err)
+	got, err = trips.GetTrip(ctx, booked.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.RiderID)
+
+	// The ratings are kept, but the deleted user is anonymized.
+	ratings, err := users.ListAuthoredRatings(ctx, rider.ID)
+	require.NoError(t, err)
+	if assert.Len(t, ratings, 1) {
+		assert.Equal(t, received.ID, ratings[0].ID)
+		assert.Equal(t, uuid.Nil, ratings[0].UserID)
+	}
+	ratings, err = users.ListRatings(ctx, rider.ID)
+	require.NoError(t, err)
+	if assert.Len(t, ratings, 1) {
+		assert.Equal(t, given.ID, ratings[0].ID)
+		assert.Equal(t, uuid.Nil, ratings[0].AuthorID)
+	}
+
+	// The tombstone itself can not be deleted.
+	assert.Equal(t, cargonaut.ErrUserNotFound, users.DeleteUser(ctx, uuid.Nil))
+	_, err = users.GetUser(ctx, uuid.Nil)
+	assert.NoError(t, err)
+}
@@ -15,11 +15,15 @@ import (
 var _ cargonaut.VehicleRepository = (*VehicleRepository)(nil)
 
 const (
-	listVehiclesSQL  = "SELECT id, user_id, brand, model, passengers, loading_area_length, loading_area_width, created_at, updated_at FROM vehicle ORDER BY updated_at DESC"
-	getVehicleSQL    = "SELECT id, user_id, brand, model, passengers, loading_area_length, loading_area_width, created_at, updated_at FROM vehicle WHERE id = $1 LIMIT 1"
-	createVehicleSQL = "INSERT INTO vehicle (user_id, brand, model, passengers, loading_area_length, loading_area_width) VALUES (:user_id, :brand, :model, :passengers, :loading_area_length, :loading_area_width)"
-	updateVehicleSQL = "UPDATE vehicle SET brand = :brand, model = :model, passengers = :passengers, loading_area_length = :loading_area_length, loading_area_width = :loading_area_width, updated_at = :updated_at WHERE id = :id"
+	listVehiclesSQL  = "SELECT id, user_id, organization_id, license_plate, type, brand, model, color, passengers, max_payload, loading_area_length, loading_area_width, loading_area_height, loading_volume, amenities, created_at, updated_at FROM vehicle WHERE " + tombstoneCondition + " AND organization_id IS NOT DISTINCT FROM $1::uuid ORDER BY updated_at DESC"
+	getVehicleSQL    = "SELECT id, user_id, organization_id, license_plate, type, brand, model, color, passengers, max_payload, loading_area_length, loading_area_width, loading_area_height, loading_volume, amenities, created_at, updated_at FROM vehicle WHERE id = $1 LIMIT 1"
+	createVehicleSQL = "INSERT INTO vehicle (user_id, organization_id, license_plate, type, brand, model, color, passengers, max_payload, loading_area_length, loading_area_width, loading_area_height, loading_volume, amenities) VALUES (:user_id, :organization_id, :license_plate, :type, :brand, :model, :color, :passengers, :max_payload, :loading_area_length, :loading_area_width, :loading_area_height, :loading_volume, :amenities) RETURNING id, created_at, updated_at"
+	updateVehicleSQL = "UPDATE vehicle SET license_plate = :license_plate, type = :type, brand = :brand, model = :model, color = :color, passengers = :passengers, max_payload = :max_payload, loading_area_length = :loading_area_length, loading_area_width = :loading_area_width, loading_area_height = :loading_area_height, loading_volume = :loading_volume, amenities = :amenities, updated_at = (now() at time zone 'utc') WHERE id = :id"
 	deleteVehicleSQL = "DELETE FROM vehicle WHERE id = $1"
+	listFilesSQL     = "SELECT id, vehicle_id, kind, name, content_type, size, created_at FROM vehicle_file WHERE vehicle_id = $1 AND kind = $2 ORDER BY created_at"
+	getFileSQL       = "SELECT id, vehicle_id, kind, name, content_type, size, created_at FROM vehicle_file WHERE id = $1 LIMIT 1"
+	createFileSQL    = "INSERT INTO vehicle_file (id, vehicle_id, kind, name, content_type, size) VALUES (:id, :vehicle_id, :kind, :name, :content_type, :size) RETURNING created_at"
+	deleteFileSQL    = "DELETE FROM vehicle_file WHERE id = $1"
 )
 
 // VehicleRepository provides access to the vehicle resource backed by a Postgres
@@ -32,6 +36,11 @@ type VehicleRepository struct {
 	createStmt *sqlx.NamedStmt
 	updateStmt *sqlx.NamedStmt
 	deleteStmt *sqlx.Stmt
+
+	listFilesStmt  *sqlx.Stmt
+	getFileStmt    *sqlx.Stmt
+	createFileStmt *sqlx.NamedStmt
+	deleteFileStmt *sqlx.Stmt
 }
 
 // NewVehicleRepository returns a new VehicleRepository based on top of the
@@ -55,6 +64,18 @@ func NewVehicleRepository(ctx context.Context, db *sqlx.DB) (*VehicleRepository,
 	if s.deleteStmt, err = db.PreparexContext(ctx, deleteVehicleSQL); err != nil {
 		return nil, fmt.Errorf("prepare delete vehicle statement: %w", err)
 	}
+	if s.listFilesStmt, err = db.PreparexContext(ctx, listFilesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list vehicle files statement: %w", err)
+	}
+	if s.getFileStmt, err = db.PreparexContext(ctx, getFileSQL); err != nil {
+		return nil, fmt.Errorf("prepare get vehicle file statement: %w", err)
+	}
+	if s.createFileStmt, err = db.PrepareNamedContext(ctx, createFileSQL); err != nil {
+		return nil, fmt.Errorf("prepare create vehicle file statement: %w", err)
+	}
+	if s.deleteFileStmt, err = db.PreparexContext(ctx, deleteFileSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete vehicle file statement: %w", err)
+	}
 
 	return s, nil
 }
@@ -76,14 +97,27 @@ func (s *VehicleRepository) Close() error {
 	if err := s.deleteStmt.Close(); err != nil {
 		return fmt.Errorf("close delete vehicle statement: %w", err)
 	}
+	if err := s.listFilesStmt.Close(); err != nil {
+		return fmt.Errorf("close list vehicle files statement: %w", err)
+	}
+	if err := s.getFileStmt.Close(); err != nil {
+		return fmt.Errorf("close get vehicle file statement: %w", err)
+	}
+	if err := s.createFileStmt.Close(); err != nil {
+		return fmt.Errorf("close create vehicle file statement: %w", err)
+	}
+	if err := s.deleteFileStmt.Close(); err != nil {
+		return fmt.Errorf("close delete vehicle file statement: %w", err)
+	}
 
 	return nil
 }
 
-// ListVehicles lists all vehicles.
-func (s *VehicleRepository) ListVehicles(ctx context.Context) ([]*cargonaut.Vehicle, error) {
+// ListVehicles lists all vehicles of the organization identified by its unique
+// ID or, if it is nil, all vehicles not owned by an organization.
+func (s *VehicleRepository) ListVehicles(ctx context.Context, organizationID *uuid.UUID) ([]*cargonaut.Vehicle, error) {
 	vehicles := make([]*cargonaut.Vehicle, 0)
-	if err := s.listStmt.SelectContext(ctx, &vehicles); err != nil {
+	if err := s.listStmt.SelectContext(ctx, &vehicles, organizationID); err != nil {
 		return nil, fmt.Errorf("select vehicles from database: %w", err)
 	}
 	return vehicles, nil
@@ -100,9 +134,9 @@ func (s *VehicleRepository) GetVehicle(ctx context.Context, id uuid.UUID) (*carg
 	return vehicle, nil
 }
 
-// CreateVehicle creates a new vehicle.
+// CreateVehicle creates a new vehicle. Its ID is generated.
 func (s *VehicleRepository) CreateVehicle(ctx context.Context, vehicle *cargonaut.Vehicle) error {
-	if _, err := s.createStmt.ExecContext(ctx, vehicle); isAlreadyExistsError(err) {
+	if err := s.createStmt.GetContext(ctx, vehicle, vehicle); isAlreadyExistsError(err) {
 		return cargonaut.ErrVehicleExists
 	} else if err != nil {
 		return fmt.Errorf("create vehicle in database: %w", err)
@@ -127,3 +161,43 @@ func (s *VehicleRepository) DeleteVehicle(ctx context.Context, id uuid.UUID) err
 	}
 	return nil
 }
+
+// ListFiles lists the files of the given kind of the vehicle identified by its
+// unique ID, oldest first.
+func (s *VehicleRepository) ListFiles(ctx context.Context, vehicleID uuid.UUID, kind cargonaut.VehicleFileKind) ([]*cargonaut.VehicleFile, error) {
+	files := make([]*cargonaut.VehicleFile, 0)
+	if err := s.listFilesStmt.SelectContext(ctx, &files, vehicleID, kind); err != nil {
+		return nil, fmt.Errorf("select %s files of vehicle %q from database: %w", kind, vehicleID, err)
+	}
+	return files, nil
+}
+
+// GetFile returns a vehicle file identified by its unique ID.
+func (s *VehicleRepository) GetFile(ctx context.Context, id uuid.UUID) (*cargonaut.VehicleFile, error) {
+	file := new(cargonaut.VehicleFile)
+	if err := s.getFileStmt.GetContext(ctx, file, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrVehicleFileNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get vehicle file %q from database: %w", id, err)
+	}
+	return file, nil
+}
+
+// CreateFile creates a new vehicle file. Its ID is generated unless set.
+func (s *VehicleRepository) CreateFile(ctx context.Context, file *cargonaut.VehicleFile) error {
+	if uuid.Equal(file.ID, uuid.Nil) {
+		file.ID = uuid.NewV4()
+	}
+	if err := s.createFileStmt.GetContext(ctx, file, file); err != nil {
+		return fmt.Errorf("create vehicle file in database: %w", err)
+	}
+	return nil
+}
+
+// DeleteFile deletes a vehicle file identified by its unique ID.
+func (s *VehicleRepository) DeleteFile(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.deleteFileStmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("delete vehicle file %q from database: %w", id, err)
+	}
+	return nil
+}
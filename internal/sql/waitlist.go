@@ -0,0 +1,289 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.WaitlistRepository = (*WaitlistRepository)(nil)
+
+const (
+	listWaitlistSQL          = "SELECT id, trip_id, user_id, status, hold_until, created_at, updated_at FROM waitlist_entry WHERE trip_id = $1 AND status IN ('waiting', 'offered') ORDER BY created_at, id"
+	lockWaitlistTripSQL      = "SELECT rider_id IS NOT NULL OR EXISTS (SELECT 1 FROM waitlist_entry WHERE trip_id = trip.id AND status IN ('waiting', 'offered')) FROM trip WHERE id = $1 AND cancelled_at IS NULL AND completed_at IS NULL AND depature <= 'epoch' FOR UPDATE"
+	joinWaitlistSQL          = "INSERT INTO waitlist_entry (trip_id, user_id) VALUES (:trip_id, :user_id) RETURNING id, status, created_at, updated_at"
+	leaveWaitlistSQL         = "UPDATE waitlist_entry SET status = 'left', updated_at = (now() at time zone 'utc') WHERE trip_id = $1 AND user_id = $2 AND status IN ('waiting', 'offered')"
+	lockWaitlistOfferSQL     = "SELECT id FROM waitlist_entry WHERE trip_id = $1 AND user_id = $2 AND status = 'offered' AND hold_until > (now() at time zone 'utc') FOR UPDATE"
+	confirmWaitlistOfferSQL  = "UPDATE waitlist_entry SET status = 'confirmed', updated_at = (now() at time zone 'utc') WHERE id = $1"
+	bookWaitlistTripSQL      = "UPDATE trip SET rider_id = $2, updated_at = (now() at time zone 'utc') WHERE id = $1 AND rider_id IS NULL AND cancelled_at IS NULL AND completed_at IS NULL AND depature <= 'epoch'"
+	createWaitlistBookingSQL = "INSERT INTO booking (trip_id, user_id, status, expires_at, decided_at) VALUES ($1, $2, 'accepted', (now() at time zone 'utc'), (now() at time zone 'utc'))"
+	expireWaitlistOffersSQL  = "UPDATE waitlist_entry SET status = 'expired', updated_at = (now() at time zone 'utc') WHERE status = 'offered' AND hold_until <= $1 RETURNING id, trip_id, user_id"
+	expireWaitlistEntriesSQL = "UPDATE waitlist_entry SET status = 'expired', updated_at = (now() at time zone 'utc') FROM trip WHERE trip.id = waitlist_entry.trip_id AND waitlist_entry.status IN ('waiting', 'offered') AND (trip.cancelled_at IS NOT NULL OR trip.completed_at IS NOT NULL OR trip.depature > 'epoch' OR trip.planned_depature <= $1)"
+	offerWaitlistedTripsSQL  = "UPDATE waitlist_entry SET status = 'offered', hold_until = LEAST($2, trip.planned_depature), updated_at = (now() at time zone 'utc') FROM trip WHERE trip.id = waitlist_entry.trip_id AND waitlist_entry.id IN (SELECT DISTINCT ON (w.trip_id) w.id FROM waitlist_entry w JOIN trip t ON t.id = w.trip_id WHERE w.status = 'waiting' AND t.rider_id IS NULL AND t.cancelled_at IS NULL AND t.completed_at IS NULL AND t.depature <= 'epoch' AND (t.planned_depature IS NULL OR t.planned_depature > $1) AND NOT EXISTS (SELECT 1 FROM waitlist_entry o WHERE o.trip_id = w.trip_id AND o.status = 'offered') ORDER BY w.trip_id, w.created_at, w.id) RETURNING waitlist_entry.id, waitlist_entry.trip_id, waitlist_entry.user_id"
+)
+
+// WaitlistRepository provides access to the waitlists of trips backed by a
+// Postgres SQL database.
+type WaitlistRepository struct {
+	db *sqlx.DB
+
+	listStmt          *sqlx.Stmt
+	lockTripStmt      *sqlx.Stmt
+	joinStmt          *sqlx.NamedStmt
+	leaveStmt         *sqlx.Stmt
+	lockOfferStmt     *sqlx.Stmt
+	confirmOfferStmt  *sqlx.Stmt
+	bookTripStmt      *sqlx.Stmt
+	createBookingStmt *sqlx.Stmt
+	expireOffersStmt  *sqlx.Stmt
+	expireEntriesStmt *sqlx.Stmt
+	offerTripsStmt    *sqlx.Stmt
+	createEventStmt   *sqlx.NamedStmt
+	ledgerStmts       *ledgerStatements
+}
+
+// NewWaitlistRepository returns a new WaitlistRepository based on top of the
+// provided database connection.
+func NewWaitlistRepository(ctx context.Context, db *sqlx.DB) (*WaitlistRepository, error) {
+	s := &WaitlistRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listWaitlistSQL); err != nil {
+		return nil, fmt.Errorf("prepare list waitlist statement: %w", err)
+	}
+	if s.lockTripStmt, err = db.PreparexContext(ctx, lockWaitlistTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock waitlist trip statement: %w", err)
+	}
+	if s.joinStmt, err = db.PrepareNamedContext(ctx, joinWaitlistSQL); err != nil {
+		return nil, fmt.Errorf("prepare join waitlist statement: %w", err)
+	}
+	if s.leaveStmt, err = db.PreparexContext(ctx, leaveWaitlistSQL); err != nil {
+		return nil, fmt.Errorf("prepare leave waitlist statement: %w", err)
+	}
+	if s.lockOfferStmt, err = db.PreparexContext(ctx, lockWaitlistOfferSQL); err != nil {
+		return nil, fmt.Errorf("prepare lock waitlist offer statement: %w", err)
+	}
+	if s.confirmOfferStmt, err = db.PreparexContext(ctx, confirmWaitlistOfferSQL); err != nil {
+		return nil, fmt.Errorf("prepare confirm waitlist offer statement: %w", err)
+	}
+	if s.bookTripStmt, err = db.PreparexContext(ctx, bookWaitlistTripSQL); err != nil {
+		return nil, fmt.Errorf("prepare book waitlist trip statement: %w", err)
+	}
+	if s.createBookingStmt, err = db.PreparexContext(ctx, createWaitlistBookingSQL); err != nil {
+		return nil, fmt.Errorf("prepare create waitlist booking statement: %w", err)
+	}
+	if s.expireOffersStmt, err = db.PreparexContext(ctx, expireWaitlistOffersSQL); err != nil {
+		return nil, fmt.Errorf("prepare expire waitlist offers statement: %w", err)
+	}
+	if s.expireEntriesStmt, err = db.PreparexContext(ctx, expireWaitlistEntriesSQL); err != nil {
+		return nil, fmt.Errorf("prepare expire waitlist entries statement: %w", err)
+	}
+	if s.offerTripsStmt, err = db.PreparexContext(ctx, offerWaitlistedTripsSQL); err != nil {
+		return nil, fmt.Errorf("prepare offer waitlisted trips statement: %w", err)
+	}
+	if s.createEventStmt, err = db.PrepareNamedContext(ctx, createEventSQL); err != nil {
+		return nil, fmt.Errorf("prepare create event statement: %w", err)
+	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *WaitlistRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list waitlist statement: %w", err)
+	}
+	if err := s.lockTripStmt.Close(); err != nil {
+		return fmt.Errorf("close lock waitlist trip statement: %w", err)
+	}
+	if err := s.joinStmt.Close(); err != nil {
+		return fmt.Errorf("close join waitlist statement: %w", err)
+	}
+	if err := s.leaveStmt.Close(); err != nil {
+		return fmt.Errorf("close leave waitlist statement: %w", err)
+	}
+	if err := s.lockOfferStmt.Close(); err != nil {
+		return fmt.Errorf("close lock waitlist offer statement: %w", err)
+	}
+	if err := s.confirmOfferStmt.Close(); err != nil {
+		return fmt.Errorf("close confirm waitlist offer statement: %w", err)
+	}
+	if err := s.bookTripStmt.Close(); err != nil {
+		return fmt.Errorf("close book waitlist trip statement: %w", err)
+	}
+	if err := s.createBookingStmt.Close(); err != nil {
+		return fmt.Errorf("close create waitlist booking statement: %w", err)
+	}
+	if err := s.expireOffersStmt.Close(); err != nil {
+		return fmt.Errorf("close expire waitlist offers statement: %w", err)
+	}
+	if err := s.expireEntriesStmt.Close(); err != nil {
+		return fmt.Errorf("close expire waitlist entries statement: %w", err)
+	}
+	if err := s.offerTripsStmt.Close(); err != nil {
+		return fmt.Errorf("close offer waitlisted trips statement: %w", err)
+	}
+	if err := s.createEventStmt.Close(); err != nil {
+		return fmt.Errorf("close create event statement: %w", err)
+	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListWaitlist lists the waiting and offered entries of the waitlist of the
+// trip identified by its unique ID, in order.
+func (s *WaitlistRepository) ListWaitlist(ctx context.Context, tripID uuid.UUID) ([]*cargonaut.WaitlistEntry, error) {
+	entries := make([]*cargonaut.WaitlistEntry, 0)
+	if err := s.listStmt.SelectContext(ctx, &entries, tripID); err != nil {
+		return nil, fmt.Errorf("select waitlist of trip %q from database: %w", tripID, err)
+	}
+	return entries, nil
+}
+
+// JoinWaitlist appends a new entry to the waitlist of a trip, which must be
+// fully booked or have riders waiting for it already.
+func (s *WaitlistRepository) JoinWaitlist(ctx context.Context, entry *cargonaut.WaitlistEntry) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var full bool
+	if err = tx.StmtxContext(ctx, s.lockTripStmt).GetContext(ctx, &full, entry.TripID); err == sql.ErrNoRows {
+		return cargonaut.ErrTripNotAvailable
+	} else if err != nil {
+		return fmt.Errorf("lock trip %q in database: %w", entry.TripID, err)
+	} else if !full {
+		return cargonaut.ErrTripNotFull
+	}
+	if err = tx.NamedStmtContext(ctx, s.joinStmt).GetContext(ctx, entry, entry); isAlreadyExistsError(err) {
+		return cargonaut.ErrWaitlistEntryExists
+	} else if err != nil {
+		return fmt.Errorf("create waitlist entry of trip %q in database: %w", entry.TripID, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// LeaveWaitlist removes the rider identified by his unique ID from the
+// waitlist of the trip identified by its unique ID. An offer the rider holds
+// is passed on to the next rider when the waitlists advance.
+func (s *WaitlistRepository) LeaveWaitlist(ctx context.Context, tripID, userID uuid.UUID) error {
+	res, err := s.leaveStmt.ExecContext(ctx, tripID, userID)
+	if err != nil {
+		return fmt.Errorf("leave waitlist of trip %q in database: %w", tripID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("leave waitlist of trip %q in database: %w", tripID, err)
+	} else if n == 0 {
+		return cargonaut.ErrWaitlistEntryNotFound
+	}
+	return nil
+}
+
+// ConfirmWaitlistOffer books the trip identified by its unique ID for the
+// rider identified by his unique ID, who must hold an offer for it. The trip
+// is booked right away, as the rider waited in line for it, and its price is
+// held on the wallet of the rider. The events are written to the outbox in the
+// same transaction.
+func (s *WaitlistRepository) ConfirmWaitlistOffer(ctx context.Context, tripID, userID uuid.UUID, events ...*cargonaut.Event) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var id uuid.UUID
+	if err = tx.StmtxContext(ctx, s.lockOfferStmt).GetContext(ctx, &id, tripID, userID); err == sql.ErrNoRows {
+		return cargonaut.ErrWaitlistOfferNotFound
+	} else if err != nil {
+		return fmt.Errorf("lock waitlist offer of trip %q in database: %w", tripID, err)
+	}
+
+	res, err := tx.StmtxContext(ctx, s.bookTripStmt).ExecContext(ctx, tripID, userID)
+	if err != nil {
+		return fmt.Errorf("book trip %q in database: %w", tripID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("book trip %q in database: %w", tripID, err)
+	} else if n == 0 {
+		return cargonaut.ErrTripNotAvailable
+	}
+	if _, err = tx.StmtxContext(ctx, s.createBookingStmt).ExecContext(ctx, tripID, userID); err != nil {
+		return fmt.Errorf("create booking of trip %q in database: %w", tripID, err)
+	}
+	if err = s.ledgerStmts.hold(ctx, tx, tripID, userID, 0); err != nil {
+		return err
+	}
+	if _, err = tx.StmtxContext(ctx, s.confirmOfferStmt).ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("confirm waitlist offer %q in database: %w", id, err)
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AdvanceWaitlists expires offers not confirmed at the given time, along with
+// the entries of trips which are no longer available, and offers available
+// trips to the first waiting rider. Trips which have been started or whose
+// planned departure has passed are no longer available. The rider holds the
+// trip until holdUntil or the planned departure, whichever comes first. Waitlist offered and offer expired
+// events for the riders are written to the outbox in the same transaction. It
+// returns the number of offers made.
+func (s *WaitlistRepository) AdvanceWaitlists(ctx context.Context, now, holdUntil time.Time) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	expired := make([]*cargonaut.WaitlistEntry, 0)
+	if err = tx.StmtxContext(ctx, s.expireOffersStmt).SelectContext(ctx, &expired, now); err != nil {
+		return 0, fmt.Errorf("expire waitlist offers in database: %w", err)
+	}
+	if _, err = tx.StmtxContext(ctx, s.expireEntriesStmt).ExecContext(ctx, now); err != nil {
+		return 0, fmt.Errorf("expire waitlist entries in database: %w", err)
+	}
+	offered := make([]*cargonaut.WaitlistEntry, 0)
+	if err = tx.StmtxContext(ctx, s.offerTripsStmt).SelectContext(ctx, &offered, now, holdUntil); err != nil {
+		return 0, fmt.Errorf("offer waitlisted trips in database: %w", err)
+	}
+
+	events := make([]*cargonaut.Event, 0, len(expired)+len(offered))
+	for _, entry := range expired {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeWaitlistOfferExpired, entry.UserID, entry.TripID))
+	}
+	for _, entry := range offered {
+		events = append(events, cargonaut.NewEvent(cargonaut.EventTypeWaitlistOffered, entry.UserID, entry.TripID))
+	}
+	if err = createEvents(ctx, tx, s.createEventStmt, events); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transaction: %w", err)
+	}
+	return int64(len(offered)), nil
+}
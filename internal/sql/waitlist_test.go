@@ -0,0 +1,72 @@
+package sql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/my-cargonaut/cargonaut"
+	. "github.com/my-cargonaut/cargonaut/internal/sql"
+)
+
+func TestWaitlistRepositoryAdvanceWaitlists(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	trips, err := NewTripRepository(ctx, db)
+	require.NoError(t, err)
+	defer trips.Close()
+	repo, err := NewWaitlistRepository(ctx, db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	holdUntil := now.Add(time.Hour)
+	planned := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+
+	tests := []struct {
+		name      string
+		planned   *time.Time
+		started   bool
+		status    cargonaut.WaitlistStatus
+		holdUntil *time.Time
+	}{
+		{"departing later", planned(2 * time.Hour), false, cargonaut.WaitlistStatusOffered, &holdUntil},
+		{"departing soon", planned(30 * time.Minute), false, cargonaut.WaitlistStatusOffered, planned(30 * time.Minute)},
+		{"not planned", nil, false, cargonaut.WaitlistStatusOffered, &holdUntil},
+		{"departed", planned(-time.Minute), false, cargonaut.WaitlistStatusExpired, nil},
+		{"started", planned(2 * time.Hour), true, cargonaut.WaitlistStatusExpired, nil},
+	}
+	for _, tt := range tests {
+		rider := createTestUser(t, db)
+		trip := createTestTrip(t, db, trips, func(trip *cargonaut.Trip) { trip.PlannedDepature = tt.planned })
+		_, err = db.Exec("UPDATE trip SET rider_id = $2 WHERE id = $1", trip.ID, rider.ID)
+		require.NoError(t, err)
+		entry := &cargonaut.WaitlistEntry{TripID: trip.ID, UserID: createTestUser(t, db).ID}
+		require.NoError(t, repo.JoinWaitlist(ctx, entry), tt.name)
+
+		// The rider leaves and the trip becomes available again, unless it has
+		// been started in the meantime.
+		depature := time.Time{}
+		if tt.started {
+			depature = now.Add(-time.Minute)
+		}
+		_, err = db.Exec("UPDATE trip SET rider_id = NULL, depature = $2 WHERE id = $1", trip.ID, depature)
+		require.NoError(t, err)
+
+		_, err = repo.AdvanceWaitlists(ctx, now, holdUntil)
+		require.NoError(t, err)
+
+		var got cargonaut.WaitlistEntry
+		require.NoError(t, db.Get(&got, "SELECT status, hold_until FROM waitlist_entry WHERE id = $1", entry.ID))
+		assert.Equal(t, tt.status, got.Status, tt.name)
+		if tt.holdUntil != nil && assert.NotNil(t, got.HoldUntil, tt.name) {
+			assert.True(t, tt.holdUntil.Equal(*got.HoldUntil), "%s: got hold until %s, want %s", tt.name, got.HoldUntil, tt.holdUntil)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.WalletRepository = (*WalletRepository)(nil)
+
+const (
+	getWalletSQL    = "SELECT $1::uuid AS user_id, coalesce(sum(p.amount) FILTER (WHERE a.type = 'wallet'), 0) AS balance, coalesce(sum(p.amount) FILTER (WHERE a.type = 'hold'), 0) AS held FROM ledger_account a JOIN ledger_posting p ON p.account_id = a.id WHERE a.user_id = $1"
+	listPostingsSQL = "SELECT p.id, p.entry_id, e.type AS entry_type, e.trip_id, e.payment_id, a.type AS account_type, p.amount, p.created_at FROM ledger_posting p JOIN ledger_entry e ON e.id = p.entry_id JOIN ledger_account a ON a.id = p.account_id WHERE a.user_id = $1 ORDER BY p.created_at DESC, p.id"
+)
+
+// WalletRepository provides access to the wallets of users backed by the
+// wallet ledger in a Postgres SQL database.
+type WalletRepository struct {
+	db *sqlx.DB
+
+	getStmt          *sqlx.Stmt
+	listPostingsStmt *sqlx.Stmt
+	ledgerStmts      *ledgerStatements
+}
+
+// NewWalletRepository returns a new WalletRepository based on top of the
+// provided database connection.
+func NewWalletRepository(ctx context.Context, db *sqlx.DB) (*WalletRepository, error) {
+	s := &WalletRepository{db: db}
+
+	var err error
+	if s.getStmt, err = db.PreparexContext(ctx, getWalletSQL); err != nil {
+		return nil, fmt.Errorf("prepare get wallet statement: %w", err)
+	}
+	if s.listPostingsStmt, err = db.PreparexContext(ctx, listPostingsSQL); err != nil {
+		return nil, fmt.Errorf("prepare list postings statement: %w", err)
+	}
+	if s.ledgerStmts, err = prepareLedgerStatements(ctx, db); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *WalletRepository) Close() error {
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get wallet statement: %w", err)
+	}
+	if err := s.listPostingsStmt.Close(); err != nil {
+		return fmt.Errorf("close list postings statement: %w", err)
+	}
+	if err := s.ledgerStmts.Close(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWallet returns the wallet of the user identified by his unique ID. Users
+// who never had money moved have an empty wallet.
+func (s *WalletRepository) GetWallet(ctx context.Context, userID uuid.UUID) (*cargonaut.Wallet, error) {
+	wallet := new(cargonaut.Wallet)
+	if err := s.getStmt.GetContext(ctx, wallet, userID); err != nil {
+		return nil, fmt.Errorf("get wallet of user %q from database: %w", userID, err)
+	}
+	return wallet, nil
+}
+
+// ListPostings lists the postings to the accounts of the user identified by
+// his unique ID, most recent first.
+func (s *WalletRepository) ListPostings(ctx context.Context, userID uuid.UUID) ([]*cargonaut.LedgerPosting, error) {
+	postings := make([]*cargonaut.LedgerPosting, 0)
+	if err := s.listPostingsStmt.SelectContext(ctx, &postings, userID); err != nil {
+		return nil, fmt.Errorf("select postings of user %q from database: %w", userID, err)
+	}
+	return postings, nil
+}
+
+// Deposit credits the amount in cents to the wallet of the user identified by
+// his unique ID.
+func (s *WalletRepository) Deposit(ctx context.Context, userID uuid.UUID, amount int64) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err = s.ledgerStmts.deposit(ctx, tx, userID, nil, amount); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/my-cargonaut/cargonaut"
+	_ "github.com/my-cargonaut/cargonaut/internal/sql/migrations" // Migrations
+)
+
+var _ cargonaut.WebhookRepository = (*WebhookRepository)(nil)
+
+const (
+	listWebhooksSQL       = "SELECT id, user_id, organization_id, url, secret, events, created_at, updated_at FROM webhook WHERE user_id = $1 ORDER BY created_at"
+	listOrgWebhooksSQL    = "SELECT id, user_id, organization_id, url, secret, events, created_at, updated_at FROM webhook WHERE organization_id = $1 ORDER BY created_at"
+	getWebhookSQL         = "SELECT id, user_id, organization_id, url, secret, events, created_at, updated_at FROM webhook WHERE id = $1 LIMIT 1"
+	createWebhookSQL      = "INSERT INTO webhook (user_id, organization_id, url, secret, events) VALUES (:user_id, :organization_id, :url, :secret, :events) RETURNING id, created_at, updated_at"
+	deleteWebhookSQL      = "DELETE FROM webhook WHERE id = $1"
+	listDeliveriesSQL     = "SELECT id, webhook_id, event_id, event_type, payload, redelivery, status, attempts, response_code, error, next_attempt_at, delivered_at, created_at FROM webhook_delivery WHERE webhook_id = $1 ORDER BY created_at DESC"
+	getDeliverySQL        = "SELECT id, webhook_id, event_id, event_type, payload, redelivery, status, attempts, response_code, error, next_attempt_at, delivered_at, created_at FROM webhook_delivery WHERE id = $1 LIMIT 1"
+	createDeliverySQL     = "INSERT INTO webhook_delivery (webhook_id, event_id, event_type, payload, redelivery) VALUES (:webhook_id, :event_id, :event_type, :payload, :redelivery) ON CONFLICT (webhook_id, event_id) WHERE NOT redelivery DO NOTHING RETURNING id, status, attempts, next_attempt_at, created_at"
+	claimDueDeliveriesSQL = "UPDATE webhook_delivery SET next_attempt_at = (now() at time zone 'utc') + make_interval(secs => $2) WHERE id IN (SELECT id FROM webhook_delivery WHERE status = 'pending' AND next_attempt_at <= (now() at time zone 'utc') ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED) RETURNING id, webhook_id, event_id, event_type, payload, redelivery, status, attempts, response_code, error, next_attempt_at, delivered_at, created_at"
+	updateDeliverySQL     = "UPDATE webhook_delivery SET status = :status, attempts = :attempts, response_code = :response_code, error = :error, next_attempt_at = :next_attempt_at, delivered_at = :delivered_at WHERE id = :id"
+)
+
+// WebhookRepository provides access to the webhook resource backed by a
+// Postgres SQL database.
+type WebhookRepository struct {
+	db *sqlx.DB
+
+	listStmt               *sqlx.Stmt
+	listOrgStmt            *sqlx.Stmt
+	getStmt                *sqlx.Stmt
+	createStmt             *sqlx.NamedStmt
+	deleteStmt             *sqlx.Stmt
+	listDeliveriesStmt     *sqlx.Stmt
+	getDeliveryStmt        *sqlx.Stmt
+	createDeliveryStmt     *sqlx.NamedStmt
+	claimDueDeliveriesStmt *sqlx.Stmt
+	updateDeliveryStmt     *sqlx.NamedStmt
+}
+
+// NewWebhookRepository returns a new WebhookRepository based on top of the
+// provided database connection.
+func NewWebhookRepository(ctx context.Context, db *sqlx.DB) (*WebhookRepository, error) {
+	s := &WebhookRepository{db: db}
+
+	var err error
+	if s.listStmt, err = db.PreparexContext(ctx, listWebhooksSQL); err != nil {
+		return nil, fmt.Errorf("prepare list webhooks statement: %w", err)
+	}
+	if s.listOrgStmt, err = db.PreparexContext(ctx, listOrgWebhooksSQL); err != nil {
+		return nil, fmt.Errorf("prepare list organization webhooks statement: %w", err)
+	}
+	if s.getStmt, err = db.PreparexContext(ctx, getWebhookSQL); err != nil {
+		return nil, fmt.Errorf("prepare get webhook statement: %w", err)
+	}
+	if s.createStmt, err = db.PrepareNamedContext(ctx, createWebhookSQL); err != nil {
+		return nil, fmt.Errorf("prepare create webhook statement: %w", err)
+	}
+	if s.deleteStmt, err = db.PreparexContext(ctx, deleteWebhookSQL); err != nil {
+		return nil, fmt.Errorf("prepare delete webhook statement: %w", err)
+	}
+	if s.listDeliveriesStmt, err = db.PreparexContext(ctx, listDeliveriesSQL); err != nil {
+		return nil, fmt.Errorf("prepare list webhook deliveries statement: %w", err)
+	}
+	if s.getDeliveryStmt, err = db.PreparexContext(ctx, getDeliverySQL); err != nil {
+		return nil, fmt.Errorf("prepare get webhook delivery statement: %w", err)
+	}
+	if s.createDeliveryStmt, err = db.PrepareNamedContext(ctx, createDeliverySQL); err != nil {
+		return nil, fmt.Errorf("prepare create webhook delivery statement: %w", err)
+	}
+	if s.claimDueDeliveriesStmt, err = db.PreparexContext(ctx, claimDueDeliveriesSQL); err != nil {
+		return nil, fmt.Errorf("prepare claim due webhook deliveries statement: %w", err)
+	}
+	if s.updateDeliveryStmt, err = db.PrepareNamedContext(ctx, updateDeliverySQL); err != nil {
+		return nil, fmt.Errorf("prepare update webhook delivery statement: %w", err)
+	}
+
+	return s, nil
+}
+
+// Close all prepared statements.
+func (s *WebhookRepository) Close() error {
+	if err := s.listStmt.Close(); err != nil {
+		return fmt.Errorf("close list webhooks statement: %w", err)
+	}
+	if err := s.listOrgStmt.Close(); err != nil {
+		return fmt.Errorf("close list organization webhooks statement: %w", err)
+	}
+	if err := s.getStmt.Close(); err != nil {
+		return fmt.Errorf("close get webhook statement: %w", err)
+	}
+	if err := s.createStmt.Close(); err != nil {
+		return fmt.Errorf("close create webhook statement: %w", err)
+	}
+	if err := s.deleteStmt.Close(); err != nil {
+		return fmt.Errorf("close delete webhook statement: %w", err)
+	}
+	if err := s.listDeliveriesStmt.Close(); err != nil {
+		return fmt.Errorf("close list webhook deliveries statement: %w", err)
+	}
+	if err := s.getDeliveryStmt.Close(); err != nil {
+		return fmt.Errorf("close get webhook delivery statement: %w", err)
+	}
+	if err := s.createDeliveryStmt.Close(); err != nil {
+		return fmt.Errorf("close create webhook delivery statement: %w", err)
+	}
+	if err := s.claimDueDeliveriesStmt.Close(); err != nil {
+		return fmt.Errorf("close claim due webhook deliveries statement: %w", err)
+	}
+	if err := s.updateDeliveryStmt.Close(); err != nil {
+		return fmt.Errorf("close update webhook delivery statement: %w", err)
+	}
+
+	return nil
+}
+
+// ListWebhooks lists all webhooks of the user identified by his unique ID.
+func (s *WebhookRepository) ListWebhooks(ctx context.Context, userID uuid.UUID) ([]*cargonaut.Webhook, error) {
+	webhooks := make([]*cargonaut.Webhook, 0)
+	if err := s.listStmt.SelectContext(ctx, &webhooks, userID); err != nil {
+		return nil, fmt.Errorf("select webhooks of user %q from database: %w", userID, err)
+	}
+	return webhooks, nil
+}
+
+// ListOrganizationWebhooks lists all webhooks of the organization identified
+// by its unique ID.
+func (s *WebhookRepository) ListOrganizationWebhooks(ctx context.Context, organizationID uuid.UUID) ([]*cargonaut.Webhook, error) {
+	webhooks := make([]*cargonaut.Webhook, 0)
+	if err := s.listOrgStmt.SelectContext(ctx, &webhooks, organizationID); err != nil {
+		return nil, fmt.Errorf("select webhooks of organization %q from database: %w", organizationID, err)
+	}
+	return webhooks, nil
+}
+
+// GetWebhook returns a webhook identified by its unique ID.
+func (s *WebhookRepository) GetWebhook(ctx context.Context, id uuid.UUID) (*cargonaut.Webhook, error) {
+	webhook := new(cargonaut.Webhook)
+	if err := s.getStmt.GetContext(ctx, webhook, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrWebhookNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get webhook %q from database: %w", id, err)
+	}
+	return webhook, nil
+}
+
+// CreateWebhook creates a new webhook.
+func (s *WebhookRepository) CreateWebhook(ctx context.Context, webhook *cargonaut.Webhook) error {
+	if err := s.createStmt.GetContext(ctx, webhook, webhook); err != nil {
+		return fmt.Errorf("create webhook in database: %w", err)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes a webhook identified by its unique ID.
+func (s *WebhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.deleteStmt.ExecContext(ctx, id); err != nil {
+		return fmt.Errorf("delete webhook %q from database: %w", id, err)
+	}
+	return nil
+}
+
+// ListDeliveries lists all deliveries of the webhook identified by its unique
+// ID, most recent first.
+func (s *WebhookRepository) ListDeliveries(ctx context.Context, webhookID uuid.UUID) ([]*cargonaut.WebhookDelivery, error) {
+	deliveries := make([]*cargonaut.WebhookDelivery, 0)
+	if err := s.listDeliveriesStmt.SelectContext(ctx, &deliveries, webhookID); err != nil {
+		return nil, fmt.Errorf("select deliveries of webhook %q from database: %w", webhookID, err)
+	}
+	return deliveries, nil
+}
+
+// GetDelivery returns a webhook delivery identified by its unique ID.
+func (s *WebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*cargonaut.WebhookDelivery, error) {
+	delivery := new(cargonaut.WebhookDelivery)
+	if err := s.getDeliveryStmt.GetContext(ctx, delivery, id); err == sql.ErrNoRows {
+		return nil, cargonaut.ErrWebhookDeliveryNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("get webhook delivery %q from database: %w", id, err)
+	}
+	return delivery, nil
+}
+
+// CreateDelivery creates a new pending webhook delivery which is due
+// immediately.
+func (s *WebhookRepository) CreateDelivery(ctx context.Context, delivery *cargonaut.WebhookDelivery) error {
+	// Only a single delivery is created per webhook and event, apart from
+	// redeliveries.
+	if err := s.createDeliveryStmt.GetContext(ctx, delivery, delivery); err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("create delivery of webhook %q in database: %w", delivery.WebhookID, err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries claims up to limit pending deliveries which are due for
+// the given duration. Concurrent claims skip deliveries locked by others.
+func (s *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*cargonaut.WebhookDelivery, error) {
+	deliveries := make([]*cargonaut.WebhookDelivery, 0)
+	if err := s.claimDueDeliveriesStmt.SelectContext(ctx, &deliveries, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("claim due webhook deliveries in database: %w", err)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery updates the status, attempts and result of a webhook
+// delivery.
+func (s *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *cargonaut.WebhookDelivery) error {
+	if _, err := s.updateDeliveryStmt.ExecContext(ctx, delivery); err != nil {
+		return fmt.Errorf("update webhook delivery %q in database: %w", delivery.ID, err)
+	}
+	return nil
+}
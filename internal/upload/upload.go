@@ -0,0 +1,114 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/jpeg" // JPEG format
+	_ "image/png"  // PNG format
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+)
+
+const (
+	// multipartOverhead is the allowance for the multipart framing of an
+	// uploaded file on top of its size.
+	multipartOverhead = 1 << 20
+	// MaxImagePixels is the maximum number of pixels of uploaded images. A
+	// small file can declare huge dimensions, so they are checked before the
+	// image is decoded.
+	MaxImagePixels = 50 * 1000 * 1000
+)
+
+var (
+	// ErrTooLarge is returned when an uploaded file exceeds its maximum size.
+	ErrTooLarge = errors.New("upload too large")
+	// ErrContentType is returned when the content type sniffed from an
+	// uploaded file is not accepted.
+	ErrContentType = errors.New("content type not accepted")
+	// ErrImageTooLarge is returned when an image has more than MaxImagePixels.
+	ErrImageTooLarge = errors.New("image too large")
+)
+
+// File is a file uploaded with a multipart form.
+type File struct {
+	// Name is the base name of the file as announced by the client.
+	Name string
+	// ContentType is the content type sniffed from the content.
+	ContentType string
+	// Content is the content of the file.
+	Content []byte
+}
+
+// ReadFile reads the file uploaded as field of the multipart form of the
+// request. Requests announcing a size which can not fit a file of maxSize
+// bytes are rejected before they are read and the body is capped in any case.
+// Temporary files of the parsed form are removed before ReadFile returns.
+// The content type announced by the client is not trusted, but sniffed from
+// the content and must be one of the given content types.
+func ReadFile(w http.ResponseWriter, r *http.Request, field string, maxSize int64, contentTypes ...string) (*File, error) {
+	if r.ContentLength > maxSize+multipartOverhead {
+		return nil, ErrTooLarge
+	}
+	// The cap reads one byte past its limit to detect exceeding bodies, so
+	// counting the bytes below it tells its error apart from malformed forms.
+	body := &countingReader{ReadCloser: r.Body}
+	r.Body = http.MaxBytesReader(w, body, maxSize+multipartOverhead)
+
+	upload, header, err := r.FormFile(field)
+	if r.MultipartForm != nil {
+		defer r.MultipartForm.RemoveAll()
+	}
+	if err != nil && body.n > maxSize+multipartOverhead {
+		return nil, ErrTooLarge
+	} else if err != nil {
+		return nil, err
+	}
+	defer upload.Close()
+
+	content, err := ioutil.ReadAll(io.LimitReader(upload, maxSize+1))
+	if err != nil {
+		return nil, err
+	} else if int64(len(content)) > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	file := &File{
+		Name:        filepath.Base(header.Filename),
+		ContentType: http.DetectContentType(content),
+		Content:     content,
+	}
+	for _, contentType := range contentTypes {
+		if file.ContentType == contentType {
+			return file, nil
+		}
+	}
+	return nil, ErrContentType
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// DecodeImage decodes the PNG or JPEG image. It returns ErrImageTooLarge
+// without decoding the image if its dimensions exceed MaxImagePixels.
+func DecodeImage(content []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	} else if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+	img, _, err := image.Decode(bytes.NewReader(content))
+	return img, err
+}
@@ -0,0 +1,138 @@
+package upload_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/my-cargonaut/cargonaut/internal/upload"
+)
+
+// testPNG returns a PNG image of the given dimensions.
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+// withDimensions returns a copy of the PNG image with the dimensions declared
+// in its header replaced. The image data itself is left as is.
+func withDimensions(content []byte, width, height uint32) []byte {
+	content = append([]byte(nil), content...)
+	// The IHDR chunk follows the 8 byte signature. Its type and data start
+	// after the 4 byte length and are followed by their checksum.
+	binary.BigEndian.PutUint32(content[16:], width)
+	binary.BigEndian.PutUint32(content[20:], height)
+	binary.BigEndian.PutUint32(content[29:], crc32.ChecksumIEEE(content[12:29]))
+	return content
+}
+
+// uploadRequest returns a request uploading the content as file of the field
+// of a multipart form.
+func uploadRequest(t *testing.T, field, name string, content []byte) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(field, name)
+	require.NoError(t, err)
+	_, err = fw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestReadFile(t *testing.T) {
+	const maxSize = 1 << 10
+	pdf := []byte("%PDF-1.4\n")
+	photo := testPNG(t, 8, 8)
+
+	tests := []struct {
+		name        string
+		filename    string
+		content     []byte
+		contentType string
+		err         error
+	}{
+		{"png", "van.png", photo, "image/png", nil},
+		{"pdf", "registration.pdf", pdf, "application/pdf", nil},
+		{"pdf named as png", "van.png", pdf, "application/pdf", nil},
+		{"text", "van.png", []byte("not an image"), "", ErrContentType},
+		{"html", "van.png", []byte("<html><script>alert(1)</script></html>"), "", ErrContentType},
+		{"largest", "registration.pdf", append(pdf, make([]byte, maxSize-len(pdf))...), "application/pdf", nil},
+		{"too large", "registration.pdf", append(pdf, make([]byte, maxSize-len(pdf)+1)...), "", ErrTooLarge},
+	}
+	for _, tt := range tests {
+		file, err := ReadFile(httptest.NewRecorder(), uploadRequest(t, "file", tt.filename, tt.content), "file", maxSize, "application/pdf", "image/png")
+		if tt.err != nil {
+			assert.Equal(t, tt.err, err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.filename, file.Name, tt.name)
+		assert.Equal(t, tt.contentType, file.ContentType, tt.name)
+		assert.Equal(t, tt.content, file.Content, tt.name)
+	}
+
+	// Only the base name of the file is kept.
+	file, err := ReadFile(httptest.NewRecorder(), uploadRequest(t, "file", "../../van.png", photo), "file", maxSize, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "van.png", file.Name)
+
+	_, err = ReadFile(httptest.NewRecorder(), uploadRequest(t, "other", "van.png", photo), "file", maxSize, "image/png")
+	assert.Error(t, err)
+}
+
+func TestReadFileContentLength(t *testing.T) {
+	// Requests announcing a size which can not fit the file are rejected
+	// before they are read.
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(nil))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	r.ContentLength = 3 << 20
+	_, err := ReadFile(httptest.NewRecorder(), r, "file", 1<<20, "image/png")
+	assert.Equal(t, ErrTooLarge, err)
+
+	// Requests not announcing their size are capped.
+	r = uploadRequest(t, "file", "van.png", make([]byte, 3<<20))
+	r.ContentLength = -1
+	_, err = ReadFile(httptest.NewRecorder(), r, "file", 1<<10, "image/png")
+	assert.Equal(t, ErrTooLarge, err)
+
+	// Malformed forms below the cap are not mistaken for exceeding ones.
+	r = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte("--x\r\nnot a part")))
+	r.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	r.ContentLength = -1
+	_, err = ReadFile(httptest.NewRecorder(), r, "file", 1<<10, "image/png")
+	assert.Error(t, err)
+	assert.NotEqual(t, ErrTooLarge, err)
+}
+
+func TestDecodeImage(t *testing.T) {
+	photo := testPNG(t, 40, 20)
+
+	img, err := DecodeImage(photo)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+
+	_, err = DecodeImage(withDimensions(photo, 100000, 100000))
+	assert.Equal(t, ErrImageTooLarge, err)
+
+	_, err = DecodeImage(withDimensions(photo, 1<<31-1, 1<<31-1))
+	assert.Error(t, err)
+
+	_, err = DecodeImage(photo[:64])
+	assert.Error(t, err)
+
+	_, err = DecodeImage([]byte("not an image"))
+	assert.Error(t, err)
+}
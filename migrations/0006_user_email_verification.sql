@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE user_email_verification (
+    id         uuid NOT NULL,
+    user_id    uuid NOT NULL,
+    email      character varying(128) NOT NULL,
+    expires_at timestamp WITHOUT TIME ZONE NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT user_email_verification_pkey PRIMARY KEY (id),
+    CONSTRAINT user_email_verification_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    CONSTRAINT user_email_verification_user_id_key UNIQUE (user_id)
+);
+CREATE INDEX user_email_verification_user_id_idx ON user_email_verification USING btree (user_id);
+
+-- +migrate Down
+DROP INDEX user_email_verification_user_id_idx;
+DROP TABLE user_email_verification;
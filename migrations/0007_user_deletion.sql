@@ -0,0 +1,57 @@
+-- +migrate Up
+-- The nil UUID identifies a tombstone user account and vehicle. When a user is
+-- deleted, completed trips and ratings are kept but reassigned to the
+-- tombstones instead of being deleted along with the user.
+INSERT INTO user_account (id, email, password_hash, display_name, birthday, avatar)
+VALUES ('00000000-0000-0000-0000-000000000000', 'deleted-user', '', 'Deleted user', '1970-01-01', '');
+INSERT INTO vehicle (id, user_id, brand, model)
+VALUES ('00000000-0000-0000-0000-000000000000', '00000000-0000-0000-0000-000000000000', 'Deleted', 'vehicle');
+
+ALTER TABLE trip
+    ALTER COLUMN user_id SET DEFAULT '00000000-0000-0000-0000-000000000000',
+    ALTER COLUMN vehicle_id SET DEFAULT '00000000-0000-0000-0000-000000000000',
+    ALTER COLUMN rider_id SET DEFAULT '00000000-0000-0000-0000-000000000000',
+    DROP CONSTRAINT trip_fkey,
+    DROP CONSTRAINT trip_fkey_2,
+    DROP CONSTRAINT trip_fkey_3,
+    ADD CONSTRAINT trip_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE SET DEFAULT,
+    ADD CONSTRAINT trip_fkey_2 FOREIGN KEY (rider_id) REFERENCES user_account (id) ON DELETE SET DEFAULT,
+    ADD CONSTRAINT trip_fkey_3 FOREIGN KEY (vehicle_id) REFERENCES vehicle (id) ON DELETE SET DEFAULT;
+
+ALTER TABLE rating
+    ALTER COLUMN user_id SET DEFAULT '00000000-0000-0000-0000-000000000000',
+    ALTER COLUMN author_id SET DEFAULT '00000000-0000-0000-0000-000000000000',
+    DROP CONSTRAINT rating_fkey,
+    DROP CONSTRAINT rating_fkey_2,
+    DROP CONSTRAINT rating_author_id_trip_id_key,
+    ADD CONSTRAINT rating_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE SET DEFAULT,
+    ADD CONSTRAINT rating_fkey_2 FOREIGN KEY (author_id) REFERENCES user_account (id) ON DELETE SET DEFAULT;
+CREATE UNIQUE INDEX rating_author_id_trip_id_key ON rating USING btree (author_id, trip_id)
+    WHERE author_id <> '00000000-0000-0000-0000-000000000000';
+CREATE INDEX rating_author_id_idx ON rating USING btree (author_id);
+
+-- +migrate Down
+DROP INDEX rating_author_id_idx;
+DROP INDEX rating_author_id_trip_id_key;
+DELETE FROM rating WHERE user_id = '00000000-0000-0000-0000-000000000000' OR author_id = '00000000-0000-0000-0000-000000000000';
+ALTER TABLE rating
+    ALTER COLUMN user_id DROP DEFAULT,
+    ALTER COLUMN author_id DROP DEFAULT,
+    DROP CONSTRAINT rating_fkey,
+    DROP CONSTRAINT rating_fkey_2,
+    ADD CONSTRAINT rating_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    ADD CONSTRAINT rating_fkey_2 FOREIGN KEY (author_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    ADD CONSTRAINT rating_author_id_trip_id_key UNIQUE (author_id, trip_id);
+
+ALTER TABLE trip
+    ALTER COLUMN user_id DROP DEFAULT,
+    ALTER COLUMN vehicle_id DROP DEFAULT,
+    ALTER COLUMN rider_id DROP DEFAULT,
+    DROP CONSTRAINT trip_fkey,
+    DROP CONSTRAINT trip_fkey_2,
+    DROP CONSTRAINT trip_fkey_3,
+    ADD CONSTRAINT trip_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    ADD CONSTRAINT trip_fkey_2 FOREIGN KEY (rider_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    ADD CONSTRAINT trip_fkey_3 FOREIGN KEY (vehicle_id) REFERENCES vehicle (id) ON DELETE CASCADE;
+
+DELETE FROM user_account WHERE id = '00000000-0000-0000-0000-000000000000';
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE user_account
+    ADD COLUMN privacy jsonb NOT NULL DEFAULT '{"show_age_bracket": true, "show_rating": true, "show_trip_count": true}';
+
+-- +migrate Down
+ALTER TABLE user_account DROP COLUMN privacy;
@@ -0,0 +1,57 @@
+-- +migrate Up
+CREATE TABLE rating_summary (
+    user_id    uuid NOT NULL,
+    role       character varying(16) NOT NULL,
+    count      integer NOT NULL DEFAULT 0,
+    total      numeric NOT NULL DEFAULT 0,
+    stars_1    integer NOT NULL DEFAULT 0,
+    stars_2    integer NOT NULL DEFAULT 0,
+    stars_3    integer NOT NULL DEFAULT 0,
+    stars_4    integer NOT NULL DEFAULT 0,
+    stars_5    integer NOT NULL DEFAULT 0,
+    updated_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT rating_summary_pkey PRIMARY KEY (user_id, role),
+    CONSTRAINT rating_summary_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+
+-- Backfill the summaries from the existing ratings. A rating given to the
+-- owner of the rated trip is a rating as driver, every other one as rider.
+INSERT INTO rating_summary (user_id, role, count, total, stars_1, stars_2, stars_3, stars_4, stars_5)
+SELECT r.user_id,
+       CASE WHEN r.user_id = t.user_id THEN 'driver' ELSE 'rider' END AS role,
+       count(*),
+       sum(r.value),
+       count(*) FILTER (WHERE round(r.value) <= 1),
+       count(*) FILTER (WHERE round(r.value) = 2),
+       count(*) FILTER (WHERE round(r.value) = 3),
+       count(*) FILTER (WHERE round(r.value) = 4),
+       count(*) FILTER (WHERE round(r.value) >= 5)
+FROM rating r
+JOIN trip t ON t.id = r.trip_id
+WHERE r.user_id <> '00000000-0000-0000-0000-000000000000'
+GROUP BY 1, 2;
+
+-- The score is the Bayesian average of a users ratings in a role. It assumes
+-- five prior ratings of the mean rating of all users in that role, which
+-- protects against few extreme ratings.
+CREATE VIEW rating_score AS
+SELECT s.user_id,
+       s.role,
+       s.count,
+       s.total / nullif(s.count, 0) AS mean,
+       (s.total + 5 * g.mean) / (s.count + 5) AS score,
+       s.stars_1,
+       s.stars_2,
+       s.stars_3,
+       s.stars_4,
+       s.stars_5
+FROM rating_summary s
+JOIN (
+    SELECT role, coalesce(sum(total) / nullif(sum(count), 0), 0) AS mean
+    FROM rating_summary
+    GROUP BY role
+) g ON g.role = s.role;
+
+-- +migrate Down
+DROP VIEW rating_score;
+DROP TABLE rating_summary;
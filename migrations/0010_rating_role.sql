@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE rating ADD COLUMN role character varying(16);
+UPDATE rating r SET role = CASE WHEN r.user_id = t.user_id THEN 'driver' ELSE 'rider' END FROM trip t WHERE t.id = r.trip_id;
+ALTER TABLE rating ALTER COLUMN role SET NOT NULL;
+
+-- +migrate Down
+ALTER TABLE rating DROP COLUMN role;
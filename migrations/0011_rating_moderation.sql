@@ -0,0 +1,35 @@
+-- +migrate Up
+ALTER TABLE user_account ADD COLUMN role character varying(16) NOT NULL DEFAULT 'user';
+
+ALTER TABLE rating
+    ADD COLUMN status     character varying(16) NOT NULL DEFAULT 'visible',
+    ADD COLUMN reply      character varying(512),
+    ADD COLUMN replied_at timestamp WITHOUT TIME ZONE;
+
+CREATE TABLE rating_report (
+    id           uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    rating_id    uuid NOT NULL,
+    reporter_id  uuid NOT NULL,
+    reason       character varying(512) NOT NULL,
+    status       character varying(16) NOT NULL DEFAULT 'open',
+    moderator_id uuid,
+    created_at   timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    resolved_at  timestamp WITHOUT TIME ZONE,
+    CONSTRAINT rating_report_pkey PRIMARY KEY (id),
+    CONSTRAINT rating_report_fkey FOREIGN KEY (rating_id) REFERENCES rating (id) ON DELETE CASCADE,
+    CONSTRAINT rating_report_fkey_2 FOREIGN KEY (reporter_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    CONSTRAINT rating_report_fkey_3 FOREIGN KEY (moderator_id) REFERENCES user_account (id) ON DELETE SET NULL,
+    CONSTRAINT rating_report_rating_id_reporter_id_key UNIQUE (rating_id, reporter_id)
+);
+CREATE INDEX rating_report_rating_id_idx ON rating_report USING btree (rating_id);
+CREATE INDEX rating_report_status_idx ON rating_report USING btree (status);
+
+-- +migrate Down
+DROP INDEX rating_report_rating_id_idx;
+DROP INDEX rating_report_status_idx;
+DROP TABLE rating_report;
+ALTER TABLE rating
+    DROP COLUMN status,
+    DROP COLUMN reply,
+    DROP COLUMN replied_at;
+ALTER TABLE user_account DROP COLUMN role;
@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE message (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    trip_id    uuid NOT NULL,
+    author_id  uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    body       character varying(1024) NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT message_pkey PRIMARY KEY (id),
+    CONSTRAINT message_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE,
+    CONSTRAINT message_fkey_2 FOREIGN KEY (author_id) REFERENCES user_account (id) ON DELETE SET DEFAULT
+);
+CREATE INDEX message_trip_id_created_at_idx ON message USING btree (trip_id, created_at);
+
+-- A message receipt stores up to when a participant has read the conversation
+-- of a trip.
+CREATE TABLE message_receipt (
+    trip_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    read_at timestamp WITHOUT TIME ZONE NOT NULL DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT message_receipt_pkey PRIMARY KEY (trip_id, user_id),
+    CONSTRAINT message_receipt_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE,
+    CONSTRAINT message_receipt_fkey_2 FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+
+-- +migrate Down
+DROP TABLE message_receipt;
+DROP INDEX message_trip_id_created_at_idx;
+DROP TABLE message;
@@ -0,0 +1,22 @@
+-- +migrate Up
+ALTER TABLE user_account
+    ADD COLUMN notifications jsonb NOT NULL DEFAULT '{"inbox": true, "email": true, "language": "en"}';
+
+CREATE TABLE notification (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    user_id    uuid NOT NULL,
+    trip_id    uuid NOT NULL,
+    type       character varying(32) NOT NULL,
+    title      character varying(256) NOT NULL,
+    read_at    timestamp WITHOUT TIME ZONE,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT notification_pkey PRIMARY KEY (id),
+    CONSTRAINT notification_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    CONSTRAINT notification_fkey_2 FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE
+);
+CREATE INDEX notification_user_id_created_at_idx ON notification USING btree (user_id, created_at);
+
+-- +migrate Down
+DROP INDEX notification_user_id_created_at_idx;
+DROP TABLE notification;
+ALTER TABLE user_account DROP COLUMN notifications;
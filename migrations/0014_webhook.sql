@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE webhook (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    user_id    uuid NOT NULL,
+    url        character varying(2048) NOT NULL,
+    secret     character varying(64) NOT NULL,
+    events     jsonb NOT NULL DEFAULT '[]',
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    updated_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT webhook_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+CREATE INDEX webhook_user_id_idx ON webhook USING btree (user_id);
+
+CREATE TABLE webhook_delivery (
+    id              uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    webhook_id      uuid NOT NULL,
+    event_id        uuid NOT NULL,
+    event_type      character varying(32) NOT NULL,
+    payload         text NOT NULL,
+    status          character varying(16) NOT NULL DEFAULT 'pending',
+    attempts        integer NOT NULL DEFAULT 0,
+    response_code   integer,
+    error           text,
+    next_attempt_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    delivered_at    timestamp WITHOUT TIME ZONE,
+    created_at      timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT webhook_delivery_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_delivery_fkey FOREIGN KEY (webhook_id) REFERENCES webhook (id) ON DELETE CASCADE
+);
+CREATE INDEX webhook_delivery_webhook_id_created_at_idx ON webhook_delivery USING btree (webhook_id, created_at);
+CREATE INDEX webhook_delivery_next_attempt_at_idx ON webhook_delivery USING btree (next_attempt_at) WHERE status = 'pending';
+
+-- +migrate Down
+DROP INDEX webhook_delivery_next_attempt_at_idx;
+DROP INDEX webhook_delivery_webhook_id_created_at_idx;
+DROP TABLE webhook_delivery;
+DROP INDEX webhook_user_id_idx;
+DROP TABLE webhook;
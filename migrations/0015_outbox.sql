@@ -0,0 +1,41 @@
+-- +migrate Up
+-- Events are written to the outbox in the same transaction as the change they
+-- describe. The relay publishes them to all subscribers and records each
+-- successful delivery, so a subscriber receives an event at least once.
+CREATE TABLE outbox (
+    id              uuid NOT NULL,
+    type            character varying(32) NOT NULL,
+    user_id         uuid NOT NULL,
+    trip_id         uuid NOT NULL,
+    attempts        integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    published_at    timestamp WITHOUT TIME ZONE,
+    created_at      timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT outbox_pkey PRIMARY KEY (id)
+);
+CREATE INDEX outbox_next_attempt_at_idx ON outbox USING btree (next_attempt_at) WHERE published_at IS NULL;
+
+CREATE TABLE outbox_delivery (
+    event_id     uuid NOT NULL,
+    subscriber   character varying(32) NOT NULL,
+    delivered_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT outbox_delivery_pkey PRIMARY KEY (event_id, subscriber),
+    CONSTRAINT outbox_delivery_fkey FOREIGN KEY (event_id) REFERENCES outbox (id) ON DELETE CASCADE
+);
+
+-- Subscribers use the event ID as idempotency key.
+ALTER TABLE notification ADD COLUMN event_id uuid;
+CREATE UNIQUE INDEX notification_event_id_key ON notification USING btree (event_id);
+
+ALTER TABLE webhook_delivery ADD COLUMN redelivery boolean NOT NULL DEFAULT false;
+CREATE UNIQUE INDEX webhook_delivery_webhook_id_event_id_key ON webhook_delivery USING btree (webhook_id, event_id)
+    WHERE NOT redelivery;
+
+-- +migrate Down
+DROP INDEX webhook_delivery_webhook_id_event_id_key;
+ALTER TABLE webhook_delivery DROP COLUMN redelivery;
+DROP INDEX notification_event_id_key;
+ALTER TABLE notification DROP COLUMN event_id;
+DROP TABLE outbox_delivery;
+DROP INDEX outbox_next_attempt_at_idx;
+DROP TABLE outbox;
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- Background jobs are coordinated across server instances through leases. An
+-- instance runs a job only after taking its lease, which expires on its own if
+-- the instance crashes.
+CREATE TABLE job (
+    name             character varying(64) NOT NULL,
+    schedule         character varying(64) NOT NULL,
+    locked_by        character varying(128),
+    locked_until     timestamp WITHOUT TIME ZONE,
+    last_run_at      timestamp WITHOUT TIME ZONE,
+    last_finished_at timestamp WITHOUT TIME ZONE,
+    last_error       text,
+    next_run_at      timestamp WITHOUT TIME ZONE NOT NULL,
+    CONSTRAINT job_pkey PRIMARY KEY (name)
+);
+
+ALTER TABLE trip
+    ADD COLUMN reminded_at timestamp WITHOUT TIME ZONE,
+    ADD COLUMN completed_at timestamp WITHOUT TIME ZONE;
+CREATE INDEX trip_depature_idx ON trip USING btree (depature) WHERE reminded_at IS NULL;
+CREATE INDEX trip_arrival_idx ON trip USING btree (arrival) WHERE completed_at IS NULL;
+
+-- Trips which arrived in the past are completed without notifying anyone.
+UPDATE trip SET completed_at = arrival WHERE arrival <= (now() at time zone 'utc');
+
+-- +migrate Down
+DROP INDEX trip_arrival_idx;
+DROP INDEX trip_depature_idx;
+ALTER TABLE trip
+    DROP COLUMN completed_at,
+    DROP COLUMN reminded_at;
+DROP TABLE job;
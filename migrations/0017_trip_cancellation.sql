@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE trip
+    ADD COLUMN cancelled_at timestamp WITHOUT TIME ZONE,
+    ADD COLUMN cancellation_reason character varying(512);
+
+-- Every cancellation by a driver or rider is recorded to compute cancellation
+-- rates. Late cancellations happened within the free cancellation window
+-- before departure.
+CREATE TABLE trip_cancellation (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    trip_id    uuid NOT NULL,
+    user_id    uuid NOT NULL,
+    role       character varying(16) NOT NULL,
+    reason     character varying(512) NOT NULL DEFAULT '',
+    late       boolean NOT NULL DEFAULT false,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT trip_cancellation_pkey PRIMARY KEY (id),
+    CONSTRAINT trip_cancellation_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE,
+    CONSTRAINT trip_cancellation_fkey_2 FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+CREATE INDEX trip_cancellation_trip_id_idx ON trip_cancellation USING btree (trip_id);
+CREATE INDEX trip_cancellation_user_id_idx ON trip_cancellation USING btree (user_id);
+
+-- +migrate Down
+DROP INDEX trip_cancellation_user_id_idx;
+DROP INDEX trip_cancellation_trip_id_idx;
+DROP TABLE trip_cancellation;
+ALTER TABLE trip
+    DROP COLUMN cancellation_reason,
+    DROP COLUMN cancelled_at;
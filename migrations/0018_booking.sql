@@ -0,0 +1,32 @@
+-- +migrate Up
+ALTER TABLE trip ADD COLUMN approval_required boolean NOT NULL DEFAULT false;
+
+-- Bookings of trips which require approval are pending until the driver
+-- accepts or declines them, or they expire. Instant bookings are accepted
+-- right away.
+CREATE TABLE booking (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    trip_id    uuid NOT NULL,
+    user_id    uuid NOT NULL,
+    status     character varying(16) NOT NULL DEFAULT 'pending',
+    expires_at timestamp WITHOUT TIME ZONE NOT NULL,
+    decided_at timestamp WITHOUT TIME ZONE,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT booking_pkey PRIMARY KEY (id),
+    CONSTRAINT booking_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE,
+    CONSTRAINT booking_fkey_2 FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+CREATE INDEX booking_trip_id_idx ON booking USING btree (trip_id);
+CREATE INDEX booking_expires_at_idx ON booking USING btree (expires_at) WHERE status = 'pending';
+CREATE UNIQUE INDEX booking_trip_id_user_id_key ON booking USING btree (trip_id, user_id) WHERE status = 'pending';
+
+-- Existing bookings have been instant bookings.
+INSERT INTO booking (trip_id, user_id, status, expires_at, decided_at, created_at)
+    SELECT id, rider_id, 'accepted', updated_at, updated_at, updated_at FROM trip WHERE rider_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX booking_trip_id_user_id_key;
+DROP INDEX booking_expires_at_idx;
+DROP INDEX booking_trip_id_idx;
+DROP TABLE booking;
+ALTER TABLE trip DROP COLUMN approval_required;
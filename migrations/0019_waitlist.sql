@@ -0,0 +1,23 @@
+-- +migrate Up
+-- Riders wait in line for fully booked trips. When a trip becomes available
+-- again, the first waiting rider is offered it and holds it until the offer
+-- expires.
+CREATE TABLE waitlist_entry (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    trip_id    uuid NOT NULL,
+    user_id    uuid NOT NULL,
+    status     character varying(16) NOT NULL DEFAULT 'waiting',
+    hold_until timestamp WITHOUT TIME ZONE,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    updated_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT waitlist_entry_pkey PRIMARY KEY (id),
+    CONSTRAINT waitlist_entry_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE CASCADE,
+    CONSTRAINT waitlist_entry_fkey_2 FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+CREATE INDEX waitlist_entry_trip_id_idx ON waitlist_entry USING btree (trip_id, created_at) WHERE status IN ('waiting', 'offered');
+CREATE UNIQUE INDEX waitlist_entry_trip_id_user_id_key ON waitlist_entry USING btree (trip_id, user_id) WHERE status IN ('waiting', 'offered');
+
+-- +migrate Down
+DROP INDEX waitlist_entry_trip_id_user_id_key;
+DROP INDEX waitlist_entry_trip_id_idx;
+DROP TABLE waitlist_entry;
@@ -0,0 +1,82 @@
+-- +migrate Up
+-- Every user has a wallet account holding the available balance and a hold
+-- account holding the balance reserved for booked trips. The platform has a
+-- fees account and a funding account, which money enters and leaves the
+-- platform through. Amounts are in cents and the balance of an account is the
+-- sum of its postings, so the balances of all accounts sum up to zero.
+CREATE TABLE ledger_account (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    user_id    uuid DEFAULT '00000000-0000-0000-0000-000000000000',
+    type       character varying(16) NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT ledger_account_pkey PRIMARY KEY (id),
+    CONSTRAINT ledger_account_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE SET DEFAULT
+);
+CREATE UNIQUE INDEX ledger_account_user_id_type_key ON ledger_account USING btree (user_id, type)
+    WHERE user_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX ledger_account_type_key ON ledger_account USING btree (type) WHERE user_id IS NULL;
+
+INSERT INTO ledger_account (user_id, type) VALUES (NULL, 'fees'), (NULL, 'funding');
+
+CREATE TABLE ledger_entry (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    type       character varying(16) NOT NULL,
+    trip_id    uuid,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT ledger_entry_pkey PRIMARY KEY (id),
+    CONSTRAINT ledger_entry_fkey FOREIGN KEY (trip_id) REFERENCES trip (id) ON DELETE SET NULL
+);
+CREATE INDEX ledger_entry_trip_id_idx ON ledger_entry USING btree (trip_id);
+
+CREATE TABLE ledger_posting (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    entry_id   uuid NOT NULL,
+    account_id uuid NOT NULL,
+    amount     bigint NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT ledger_posting_pkey PRIMARY KEY (id),
+    CONSTRAINT ledger_posting_fkey FOREIGN KEY (entry_id) REFERENCES ledger_entry (id),
+    CONSTRAINT ledger_posting_fkey_2 FOREIGN KEY (account_id) REFERENCES ledger_account (id),
+    CONSTRAINT ledger_posting_amount_check CHECK (amount <> 0)
+);
+CREATE INDEX ledger_posting_entry_id_idx ON ledger_posting USING btree (entry_id);
+CREATE INDEX ledger_posting_account_id_idx ON ledger_posting USING btree (account_id);
+
+-- Postings are immutable. Mistakes are corrected by posting another entry.
+-- +migrate StatementBegin
+CREATE FUNCTION ledger_posting_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger postings are immutable';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+CREATE TRIGGER ledger_posting_immutable BEFORE UPDATE OR DELETE ON ledger_posting
+    FOR EACH ROW EXECUTE PROCEDURE ledger_posting_immutable();
+
+-- The postings of an entry must sum up to zero once the transaction commits.
+-- +migrate StatementBegin
+CREATE FUNCTION ledger_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT sum(amount) FROM ledger_posting WHERE entry_id = NEW.entry_id) <> 0 THEN
+        RAISE EXCEPTION 'ledger entry % is unbalanced', NEW.entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+CREATE CONSTRAINT TRIGGER ledger_entry_balanced AFTER INSERT ON ledger_posting
+    DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE PROCEDURE ledger_entry_balanced();
+
+-- +migrate Down
+DROP TRIGGER ledger_entry_balanced ON ledger_posting;
+DROP FUNCTION ledger_entry_balanced();
+DROP TRIGGER ledger_posting_immutable ON ledger_posting;
+DROP FUNCTION ledger_posting_immutable();
+DROP INDEX ledger_posting_account_id_idx;
+DROP INDEX ledger_posting_entry_id_idx;
+DROP TABLE ledger_posting;
+DROP INDEX ledger_entry_trip_id_idx;
+DROP TABLE ledger_entry;
+DROP INDEX ledger_account_type_key;
+DROP INDEX ledger_account_user_id_type_key;
+DROP TABLE ledger_account;
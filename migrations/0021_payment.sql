@@ -0,0 +1,42 @@
+-- +migrate Up
+-- Payments are top-ups of wallets and payouts from them through the payment
+-- provider. The reference identifies the intent of a payment at the provider.
+CREATE TABLE payment (
+    id           uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    user_id      uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    type         character varying(16) NOT NULL,
+    amount       bigint NOT NULL,
+    refunded     bigint NOT NULL DEFAULT 0,
+    status       character varying(16) NOT NULL DEFAULT 'pending',
+    reference    character varying(255),
+    redirect_url text,
+    created_at   timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    updated_at   timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT payment_pkey PRIMARY KEY (id),
+    CONSTRAINT payment_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE SET DEFAULT,
+    CONSTRAINT payment_amount_check CHECK (amount > 0 AND refunded >= 0 AND refunded <= amount)
+);
+CREATE INDEX payment_user_id_idx ON payment USING btree (user_id);
+CREATE UNIQUE INDEX payment_reference_key ON payment USING btree (reference);
+
+-- Webhooks of the payment provider are processed once per idempotency key.
+CREATE TABLE payment_webhook_event (
+    idempotency_key character varying(255) NOT NULL,
+    type            character varying(32) NOT NULL,
+    reference       character varying(255) NOT NULL,
+    amount          bigint NOT NULL,
+    created_at      timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT payment_webhook_event_pkey PRIMARY KEY (idempotency_key)
+);
+
+ALTER TABLE ledger_entry ADD COLUMN payment_id uuid,
+    ADD CONSTRAINT ledger_entry_fkey_2 FOREIGN KEY (payment_id) REFERENCES payment (id);
+CREATE INDEX ledger_entry_payment_id_idx ON ledger_entry USING btree (payment_id);
+
+-- +migrate Down
+DROP INDEX ledger_entry_payment_id_idx;
+ALTER TABLE ledger_entry DROP COLUMN payment_id;
+DROP TABLE payment_webhook_event;
+DROP INDEX payment_reference_key;
+DROP INDEX payment_user_id_idx;
+DROP TABLE payment;
@@ -0,0 +1,36 @@
+-- +migrate Up
+-- Receipts are numbered sequentially per year. The counter row of a year is
+-- locked while a receipt is issued, so numbers are assigned without gaps.
+CREATE TABLE receipt_counter (
+    year     integer NOT NULL,
+    sequence integer NOT NULL,
+    CONSTRAINT receipt_counter_pkey PRIMARY KEY (year)
+);
+
+-- Receipts keep a copy of the details of the booking at the time they are
+-- issued. They are retained even if the booking, the trip or the users are
+-- deleted, so there are no foreign keys.
+CREATE TABLE receipt (
+    id          uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    booking_id  uuid NOT NULL,
+    trip_id     uuid NOT NULL,
+    year        integer NOT NULL,
+    sequence    integer NOT NULL,
+    driver_name character varying(128) NOT NULL,
+    rider_name  character varying(128) NOT NULL,
+    start       character varying(128) NOT NULL,
+    destination character varying(128) NOT NULL,
+    depature    timestamp WITHOUT TIME ZONE NOT NULL,
+    amount      bigint NOT NULL,
+    vat_rate    bigint NOT NULL,
+    created_at  timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT receipt_pkey PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX receipt_booking_id_key ON receipt USING btree (booking_id);
+CREATE UNIQUE INDEX receipt_year_sequence_key ON receipt USING btree (year, sequence);
+
+-- +migrate Down
+DROP INDEX receipt_year_sequence_key;
+DROP INDEX receipt_booking_id_key;
+DROP TABLE receipt;
+DROP TABLE receipt_counter;
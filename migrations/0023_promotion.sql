@@ -0,0 +1,68 @@
+-- +migrate Up
+-- Promo codes grant riders a discount on the price of a trip when booking it.
+-- Percentage discounts are given in basis points, fixed discounts in cents.
+CREATE TABLE promo_code (
+    id                       uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    code                     character varying(32) NOT NULL,
+    type                     character varying(16) NOT NULL,
+    value                    bigint NOT NULL,
+    max_redemptions          integer,
+    max_redemptions_per_user integer NOT NULL DEFAULT 1,
+    first_trip_only          boolean NOT NULL DEFAULT false,
+    valid_from               timestamp WITHOUT TIME ZONE,
+    valid_until              timestamp WITHOUT TIME ZONE,
+    created_at               timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    updated_at               timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT promo_code_pkey PRIMARY KEY (id),
+    CONSTRAINT promo_code_value_check CHECK (value > 0)
+);
+CREATE UNIQUE INDEX promo_code_code_key ON promo_code USING btree (upper(code));
+
+-- Redemptions count against the limits of their promo code as long as their
+-- booking is pending or accepted. The discount is paid from the promotions
+-- account of the platform.
+CREATE TABLE promo_redemption (
+    id            uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    promo_code_id uuid NOT NULL,
+    booking_id    uuid NOT NULL,
+    amount        bigint NOT NULL,
+    created_at    timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT promo_redemption_pkey PRIMARY KEY (id),
+    CONSTRAINT promo_redemption_fkey FOREIGN KEY (promo_code_id) REFERENCES promo_code (id),
+    CONSTRAINT promo_redemption_fkey_2 FOREIGN KEY (booking_id) REFERENCES booking (id) ON DELETE CASCADE,
+    CONSTRAINT promo_redemption_amount_check CHECK (amount >= 0)
+);
+CREATE INDEX promo_redemption_promo_code_id_idx ON promo_redemption USING btree (promo_code_id);
+CREATE UNIQUE INDEX promo_redemption_booking_id_key ON promo_redemption USING btree (booking_id);
+
+-- Users share their referral code with others. Both are credited once the
+-- referred user completes his first trip.
+CREATE TABLE referral_code (
+    user_id    uuid NOT NULL,
+    code       character varying(16) NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT referral_code_pkey PRIMARY KEY (user_id),
+    CONSTRAINT referral_code_fkey FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX referral_code_code_key ON referral_code USING btree (code);
+
+ALTER TABLE user_account ADD COLUMN referred_by uuid;
+ALTER TABLE user_account ADD COLUMN referral_credited_at timestamp WITHOUT TIME ZONE;
+ALTER TABLE user_account ADD CONSTRAINT user_account_referred_by_fkey FOREIGN KEY (referred_by) REFERENCES user_account (id) ON DELETE SET NULL;
+CREATE INDEX user_account_referred_by_idx ON user_account USING btree (referred_by);
+
+INSERT INTO ledger_account (user_id, type) VALUES (NULL, 'promotions');
+
+-- +migrate Down
+DELETE FROM ledger_account WHERE user_id IS NULL AND type = 'promotions';
+DROP INDEX user_account_referred_by_idx;
+ALTER TABLE user_account DROP CONSTRAINT user_account_referred_by_fkey;
+ALTER TABLE user_account DROP COLUMN referral_credited_at;
+ALTER TABLE user_account DROP COLUMN referred_by;
+DROP INDEX referral_code_code_key;
+DROP TABLE referral_code;
+DROP INDEX promo_redemption_booking_id_key;
+DROP INDEX promo_redemption_promo_code_id_idx;
+DROP TABLE promo_redemption;
+DROP INDEX promo_code_code_key;
+DROP TABLE promo_code;
@@ -0,0 +1,37 @@
+-- +migrate Up
+-- Organizations are companies operating a fleet of vehicles. Their members
+-- drive trips with the vehicles of the organization, dispatchers and owners
+-- create them on behalf of the drivers.
+CREATE TABLE organization (
+    id         uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    name       character varying(128) NOT NULL,
+    created_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    updated_at timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT organization_pkey PRIMARY KEY (id)
+);
+
+CREATE TABLE organization_member (
+    organization_id uuid NOT NULL,
+    user_id         uuid NOT NULL,
+    role            character varying(16) NOT NULL,
+    created_at      timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT organization_member_pkey PRIMARY KEY (organization_id, user_id),
+    CONSTRAINT organization_member_fkey FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE,
+    CONSTRAINT organization_member_fkey_2 FOREIGN KEY (user_id) REFERENCES user_account (id) ON DELETE CASCADE,
+    CONSTRAINT organization_member_role_check CHECK (role IN ('owner', 'dispatcher', 'driver'))
+);
+CREATE INDEX organization_member_user_id_idx ON organization_member USING btree (user_id);
+
+-- Vehicles of an organization fall back to the member who registered them
+-- when the organization is deleted.
+ALTER TABLE vehicle ADD COLUMN organization_id uuid;
+ALTER TABLE vehicle ADD CONSTRAINT vehicle_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE SET NULL;
+CREATE INDEX vehicle_organization_id_idx ON vehicle USING btree (organization_id);
+
+-- +migrate Down
+DROP INDEX vehicle_organization_id_idx;
+ALTER TABLE vehicle DROP CONSTRAINT vehicle_organization_id_fkey;
+ALTER TABLE vehicle DROP COLUMN organization_id;
+DROP INDEX organization_member_user_id_idx;
+DROP TABLE organization_member;
+DROP TABLE organization;
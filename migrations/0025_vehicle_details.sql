@@ -0,0 +1,34 @@
+-- +migrate Up
+-- Vehicles used to be unique by brand and model across all users. They are
+-- unique by license plate per owner instead, which is the organization for
+-- vehicles of organizations. Vehicles without a license plate predate it.
+ALTER TABLE vehicle DROP CONSTRAINT vehicle_id_key;
+ALTER TABLE vehicle ADD COLUMN license_plate character varying(16) NOT NULL DEFAULT '';
+ALTER TABLE vehicle ADD COLUMN type character varying(16) NOT NULL DEFAULT 'car';
+ALTER TABLE vehicle ADD COLUMN color character varying(32) NOT NULL DEFAULT '';
+ALTER TABLE vehicle ADD COLUMN max_payload numeric NOT NULL DEFAULT 0;
+ALTER TABLE vehicle ADD COLUMN loading_area_height numeric NOT NULL DEFAULT 0;
+ALTER TABLE vehicle ADD COLUMN loading_volume numeric NOT NULL DEFAULT 0;
+ALTER TABLE vehicle ADD COLUMN amenities jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE vehicle ADD CONSTRAINT vehicle_type_check CHECK (type IN ('car', 'van', 'truck', 'trailer'));
+CREATE UNIQUE INDEX vehicle_license_plate_key ON vehicle USING btree (coalesce(organization_id, user_id), license_plate) WHERE license_plate <> '';
+
+-- Existing vehicles are typed by the area of their loading area in square
+-- centimetres.
+UPDATE vehicle SET type = CASE
+    WHEN coalesce(loading_area_length * loading_area_width, 0) >= 60000 THEN 'truck'
+    WHEN coalesce(loading_area_length * loading_area_width, 0) >= 20000 THEN 'van'
+    ELSE 'car'
+END;
+
+-- +migrate Down
+DROP INDEX vehicle_license_plate_key;
+ALTER TABLE vehicle DROP CONSTRAINT vehicle_type_check;
+ALTER TABLE vehicle DROP COLUMN amenities;
+ALTER TABLE vehicle DROP COLUMN loading_volume;
+ALTER TABLE vehicle DROP COLUMN loading_area_height;
+ALTER TABLE vehicle DROP COLUMN max_payload;
+ALTER TABLE vehicle DROP COLUMN color;
+ALTER TABLE vehicle DROP COLUMN type;
+ALTER TABLE vehicle DROP COLUMN license_plate;
+ALTER TABLE vehicle ADD CONSTRAINT vehicle_id_key UNIQUE (brand, model);
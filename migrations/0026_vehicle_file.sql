@@ -0,0 +1,20 @@
+-- +migrate Up
+-- Photos and documents of vehicles. Their content is kept in the blob store
+-- under a key derived from the vehicle and the file.
+CREATE TABLE vehicle_file (
+    id           uuid NOT NULL DEFAULT uuid_generate_v1mc(),
+    vehicle_id   uuid NOT NULL,
+    kind         character varying(16) NOT NULL,
+    name         character varying(256) NOT NULL,
+    content_type character varying(128) NOT NULL,
+    size         bigint NOT NULL,
+    created_at   timestamp WITHOUT TIME ZONE DEFAULT (now() at time zone 'utc'),
+    CONSTRAINT vehicle_file_pkey PRIMARY KEY (id),
+    CONSTRAINT vehicle_file_fkey FOREIGN KEY (vehicle_id) REFERENCES vehicle (id) ON DELETE CASCADE,
+    CONSTRAINT vehicle_file_kind_check CHECK (kind IN ('photo', 'document'))
+);
+CREATE INDEX vehicle_file_vehicle_id_idx ON vehicle_file USING btree (vehicle_id, kind);
+
+-- +migrate Down
+DROP INDEX vehicle_file_vehicle_id_idx;
+DROP TABLE vehicle_file;
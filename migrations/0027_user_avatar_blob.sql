@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Avatars are kept in the blob store instead. Existing avatars are moved there
+-- by the data migration of "cargonaut migrate up", which clears the column
+-- afterwards. It is dropped once all deployments have moved their avatars.
+ALTER TABLE user_account ALTER COLUMN avatar DROP NOT NULL;
+
+-- +migrate Down
+-- Avatars moved to the blob store are not moved back.
+UPDATE user_account SET avatar = '' WHERE avatar IS NULL;
+ALTER TABLE user_account ALTER COLUMN avatar SET NOT NULL;
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- Events which can't be published to all subscribers after the maximum number
+-- of attempts, or fail for good, are dead. They are kept for inspection but
+-- never claimed again. The error of the last failed attempt is recorded.
+ALTER TABLE outbox
+    ADD COLUMN last_error text,
+    ADD COLUMN dead_at timestamp WITHOUT TIME ZONE;
+DROP INDEX outbox_next_attempt_at_idx;
+CREATE INDEX outbox_next_attempt_at_idx ON outbox USING btree (next_attempt_at)
+    WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX outbox_published_at_idx ON outbox USING btree (published_at) WHERE published_at IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX outbox_published_at_idx;
+DROP INDEX outbox_next_attempt_at_idx;
+CREATE INDEX outbox_next_attempt_at_idx ON outbox USING btree (next_attempt_at) WHERE published_at IS NULL;
+ALTER TABLE outbox
+    DROP COLUMN dead_at,
+    DROP COLUMN last_error;
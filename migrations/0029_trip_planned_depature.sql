@@ -0,0 +1,21 @@
+-- +migrate Up
+-- The depature and arrival of a trip are recorded when the driver starts and
+-- finishes it and are zero until then. The planned depature is set when the
+-- trip is offered and drives reminders, cancellation windows and waitlists.
+ALTER TABLE trip
+    ADD COLUMN planned_depature timestamp WITHOUT TIME ZONE;
+DROP INDEX trip_depature_idx;
+CREATE INDEX trip_planned_depature_idx ON trip USING btree (planned_depature) WHERE reminded_at IS NULL;
+
+-- Trips which were never finished have been completed because of their zero
+-- arrival. Reopen those which have not been settled yet, so they are
+-- completed once the driver finishes them.
+UPDATE trip SET completed_at = NULL
+    WHERE completed_at IS NOT NULL AND arrival <= 'epoch'
+    AND NOT EXISTS (SELECT 1 FROM ledger_entry e WHERE e.trip_id = trip.id AND e.type = 'settle');
+
+-- +migrate Down
+DROP INDEX trip_planned_depature_idx;
+CREATE INDEX trip_depature_idx ON trip USING btree (depature) WHERE reminded_at IS NULL;
+ALTER TABLE trip
+    DROP COLUMN planned_depature;
@@ -0,0 +1,10 @@
+-- +migrate Up
+-- The amount of a receipt is the fare settled to the driver, which may differ
+-- from the current price of the trip. The discount of the promo code redeemed
+-- for the booking is paid by the platform and not by the rider.
+ALTER TABLE receipt
+    ADD COLUMN discount bigint NOT NULL DEFAULT 0;
+
+-- +migrate Down
+ALTER TABLE receipt
+    DROP COLUMN discount;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- Vehicles of an organization are handed over to its longest standing owner
+-- when the member who registered them leaves. Hand over those of members who
+-- already left, so they are not deleted along with their former account.
+UPDATE vehicle v SET user_id = (
+        SELECT m.user_id FROM organization_member m
+        WHERE m.organization_id = v.organization_id AND m.role = 'owner'
+        ORDER BY m.created_at LIMIT 1
+    ), updated_at = (now() at time zone 'utc')
+    WHERE v.organization_id IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM organization_member m WHERE m.organization_id = v.organization_id AND m.user_id = v.user_id)
+    AND EXISTS (SELECT 1 FROM organization_member m WHERE m.organization_id = v.organization_id AND m.role = 'owner');
+
+-- +migrate Down
@@ -0,0 +1,19 @@
+-- +migrate Up
+-- Webhooks are owned by either a user or an organization. Those of an
+-- organization receive the events of the trips driven with its vehicles and
+-- are kept when the member who created them leaves.
+ALTER TABLE webhook
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN organization_id uuid,
+    ADD CONSTRAINT webhook_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE,
+    ADD CONSTRAINT webhook_owner_check CHECK ((user_id IS NULL) <> (organization_id IS NULL));
+CREATE INDEX webhook_organization_id_idx ON webhook USING btree (organization_id);
+
+-- +migrate Down
+DROP INDEX webhook_organization_id_idx;
+DELETE FROM webhook WHERE organization_id IS NOT NULL;
+ALTER TABLE webhook
+    DROP CONSTRAINT webhook_owner_check,
+    DROP CONSTRAINT webhook_organization_id_fkey,
+    DROP COLUMN organization_id,
+    ALTER COLUMN user_id SET NOT NULL;
@@ -0,0 +1,23 @@
+package backoff
+
+import "time"
+
+// Exponential returns the delay before the next attempt after the given number
+// of failed attempts. The delay starts at the base delay and doubles with every
+// failed attempt but never exceeds the maximum delay.
+func Exponential(attempts int, base, max time.Duration) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < attempts; i++ {
+		if delay *= 2; delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,37 @@
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/backoff"
+)
+
+func TestExponential(t *testing.T) {
+	const (
+		base = 30 * time.Second
+		max  = time.Hour
+	)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{4, 4 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Exponential(tt.attempts, base, max), "attempts %d", tt.attempts)
+	}
+
+	// The base delay is capped as well.
+	assert.Equal(t, time.Hour, Exponential(1, 2*time.Hour, time.Hour))
+}
@@ -0,0 +1,4 @@
+// Package backoff implements delays between retries of failed operations. The
+// delay grows exponentially with the number of failed attempts to give the
+// failing party time to recover.
+package backoff
@@ -0,0 +1,201 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule indicates that a schedule expression is malformed.
+var ErrInvalidSchedule = errors.New("cron: invalid schedule")
+
+// descriptors are the predefined schedules.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// bounds are the inclusive lower and upper bounds of a field.
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds  = bounds{0, 59}
+	hourBounds    = bounds{0, 23}
+	domBounds     = bounds{1, 31}
+	monthBounds   = bounds{1, 12}
+	weekdayBounds = bounds{0, 7} // Both 0 and 7 are Sunday.
+)
+
+// maxSearch limits the search for the next activation time. Schedules which
+// never activate, like the 30th of February, return the zero time.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron schedule. Each field is a bit set of the values
+// it matches.
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, weekday uint64
+
+	// Like in most cron implementations, a time matches if either the day of
+	// month or the day of week matches, unless one of them is a wildcard.
+	domWildcard, weekdayWildcard bool
+}
+
+// Parse parses a cron schedule expression.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if d, ok := descriptors[spec]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields but got %d", ErrInvalidSchedule, len(fields))
+	}
+
+	s := &Schedule{
+		expr:            expr,
+		domWildcard:     strings.HasPrefix(fields[2], "*"),
+		weekdayWildcard: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("%w: minute: %s", ErrInvalidSchedule, err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("%w: hour: %s", ErrInvalidSchedule, err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("%w: day of month: %s", ErrInvalidSchedule, err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("%w: month: %s", ErrInvalidSchedule, err)
+	}
+	if s.weekday, err = parseField(fields[4], weekdayBounds); err != nil {
+		return nil, fmt.Errorf("%w: day of week: %s", ErrInvalidSchedule, err)
+	}
+	if s.weekday&(1<<7) != 0 {
+		s.weekday |= 1 << 0
+	}
+	return s, nil
+}
+
+// MustParse is like Parse but panics if the expression can not be parsed. It
+// simplifies the initialization of global schedules.
+func MustParse(expr string) *Schedule {
+	s, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseField parses a comma separated list of values, ranges and wildcards
+// with optional steps into a bit set.
+func parseField(field string, b bounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step, stepped := part, 1, false
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			stepped = true
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			rng = part[:i]
+		}
+
+		lo, hi := b.min, b.max
+		switch i := strings.IndexByte(rng, '-'); {
+		case rng == "*":
+		case i >= 0:
+			var err error
+			if lo, err = parseValue(rng[:i], b); err != nil {
+				return 0, err
+			}
+			if hi, err = parseValue(rng[i+1:], b); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q", rng)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rng, b); err != nil {
+				return 0, err
+			}
+			// A single value with a step ranges up to the maximum.
+			if hi = lo; stepped {
+				hi = b.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseValue parses a single value within the bounds.
+func parseValue(s string, b bounds) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+// Next returns the first activation time of the schedule strictly after the
+// given time, in the location of the given time. If the schedule never
+// activates, the zero time is returned.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxSearch)
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for !t.After(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchDay returns true if the day of the given time matches the day of month
+// and day of week fields.
+func (s *Schedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	weekday := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.domWildcard || s.weekdayWildcard {
+		return dom && weekday
+	}
+	return dom || weekday
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
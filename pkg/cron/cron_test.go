@@ -0,0 +1,93 @@
+package cron_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/cron"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr  string
+		valid bool
+	}{
+		{"* * * * *", true},
+		{"*/5 * * * *", true},
+		{"0 3 * * 1-5", true},
+		{"0,30 8-18/2 1 1,6 7", true},
+		{"15/20 * * * *", true},
+		{"@hourly", true},
+		{"@daily", true},
+		{"", false},
+		{"* * * *", false},
+		{"* * * * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"5-1 * * * *", false},
+		{"*/0 * * * *", false},
+		{"a * * * *", false},
+		{"@fortnightly", false},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if tt.valid {
+			assert.NoError(t, err, tt.expr)
+			assert.Equal(t, tt.expr, s.String())
+		} else {
+			assert.True(t, errors.Is(err, ErrInvalidSchedule), tt.expr)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday, 15th of January 2020.
+	now := time.Date(2020, time.January, 15, 10, 17, 42, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.January, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2020, time.January, 15, 10, 20, 0, 0, time.UTC)},
+		{"15/20 * * * *", time.Date(2020, time.January, 15, 10, 35, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2020, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2020, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"30 9 * * *", time.Date(2020, time.January, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 3 * * 1-5", time.Date(2020, time.January, 16, 3, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// Either day of month or day of week has to match, if both are set.
+		{"0 0 20 * 5", time.Date(2020, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		// Never activates.
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, s.Next(now), tt.expr)
+	}
+}
+
+func TestScheduleNextLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2020, time.January, 15, 23, 30, 0, 0, loc)
+
+	next := MustParse("0 1 * * *").Next(now)
+	assert.Equal(t, time.Date(2020, time.January, 16, 1, 0, 0, 0, loc), next)
+	assert.Equal(t, loc, next.Location())
+}
+
+func TestMustParse(t *testing.T) {
+	assert.Panics(t, func() { MustParse("invalid") })
+}
@@ -0,0 +1,6 @@
+// Package cron implements schedules in the classic five field cron syntax.
+// A schedule consists of the minute, hour, day of month, month and day of
+// week fields, each being a wildcard, a value, a range or a list of those,
+// optionally combined with a step. The descriptors @yearly, @monthly,
+// @weekly, @daily and @hourly are supported as well.
+package cron
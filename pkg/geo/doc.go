@@ -0,0 +1,4 @@
+// Package geo implements calculations on geographic coordinates. Distances are
+// great-circle distances on a spherical earth, which are accurate to about half
+// a percent.
+package geo
@@ -0,0 +1,27 @@
+package geo
+
+import "math"
+
+// earthRadius is the mean radius of the earth in kilometres.
+const earthRadius = 6371.0088
+
+// Point is a position on the earth given by its latitude and longitude in
+// degrees.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Distance returns the great-circle distance between two points in
+// kilometres, calculated using the haversine formula.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat, dLon := lat2-lat1, radians(b.Lon-a.Lon)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
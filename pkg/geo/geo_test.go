@@ -0,0 +1,34 @@
+package geo_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/geo"
+)
+
+func TestDistance(t *testing.T) {
+	var (
+		berlin  = Point{Lat: 52.5200, Lon: 13.4050}
+		hamburg = Point{Lat: 53.5511, Lon: 9.9937}
+		munich  = Point{Lat: 48.1351, Lon: 11.5820}
+		sydney  = Point{Lat: -33.8688, Lon: 151.2093}
+	)
+
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{"same point", berlin, berlin, 0},
+		{"berlin to hamburg", berlin, hamburg, 255},
+		{"berlin to munich", berlin, munich, 504},
+		{"munich to sydney", munich, sydney, 16310},
+		{"antipodes", Point{Lat: 0, Lon: 0}, Point{Lat: 0, Lon: 180}, 20015},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, Distance(tt.a, tt.b), tt.want*0.005+0.001, tt.name)
+		assert.InDelta(t, Distance(tt.a, tt.b), Distance(tt.b, tt.a), 1e-9, "%s is symmetric", tt.name)
+	}
+}
@@ -33,13 +33,14 @@ func NewServer(log *log.Logger, addr string, handler http.Handler) (*Server, err
 	}
 	lis = netutil.LimitListener(lis, 512)
 
+	// No write timeout is set as it would terminate long lived responses like
+	// event streams. Handlers are expected to time out requests themselves.
 	srv := &http.Server{
-		Addr:         addr,
-		Handler:      h2c.NewHandler(handler, &http2.Server{}),
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		IdleTimeout:  60 * time.Second,
-		ErrorLog:     log,
+		Addr:        addr,
+		Handler:     h2c.NewHandler(handler, &http2.Server{}),
+		ReadTimeout: 5 * time.Second,
+		IdleTimeout: 60 * time.Second,
+		ErrorLog:    log,
 	}
 
 	return &Server{
@@ -0,0 +1,4 @@
+// Package identicon generates identicons, symmetric patterns of colored cells
+// derived from a hash of a seed like a user ID. They are shown in place of
+// missing avatars, so users remain distinguishable at a glance.
+package identicon
@@ -0,0 +1,72 @@
+package identicon
+
+import (
+	"crypto/sha256"
+	"image"
+	"image/color"
+)
+
+// cells is the number of cells per row and column of an identicon.
+const cells = 5
+
+// Background is the color of the cells which are not filled.
+var Background = color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// Generate returns a square identicon of the given size in pixels for the
+// seed. The same seed always results in the same identicon. The pattern is
+// mirrored along the vertical axis and surrounded by a margin of half a cell.
+func Generate(seed []byte, size int) image.Image {
+	sum := sha256.Sum256(seed)
+
+	// The color is taken from the end of the hash, the pattern from its
+	// beginning. Channels are limited to keep the color readable on the
+	// background.
+	fg := color.NRGBA{
+		R: 0x20 + sum[29]%0xa0,
+		G: 0x20 + sum[30]%0xa0,
+		B: 0x20 + sum[31]%0xa0,
+		A: 0xff,
+	}
+	var filled [cells][cells]bool
+	for row := 0; row < cells; row++ {
+		for col := 0; col < (cells+1)/2; col++ {
+			bit := row*((cells+1)/2) + col
+			on := sum[bit/8]&(1<<uint(bit%8)) != 0
+			filled[row][col] = on
+			filled[row][cells-1-col] = on
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		row := cell(y, size)
+		for x := 0; x < size; x++ {
+			// Pixels of the right half are looked up by their mirror image,
+			// so the pattern stays symmetric when the size can't be divided
+			// evenly into cells.
+			mx := x
+			if mx >= size-mx {
+				mx = size - 1 - mx
+			}
+			col := cell(mx, size)
+			if row >= 0 && col >= 0 && filled[row][col] {
+				img.SetNRGBA(x, y, fg)
+			} else {
+				img.SetNRGBA(x, y, Background)
+			}
+		}
+	}
+	return img
+}
+
+// cell returns the cell the pixel at the position of an identicon of the given
+// size falls into or -1, if it falls into the margin.
+func cell(pos, size int) int {
+	// The identicon is divided into two halves of a cell per cell plus one
+	// half of a cell of margin on either side.
+	half := pos * (2*cells + 2) / size
+	if half < 1 || half > 2*cells {
+		return -1
+	}
+	return (half - 1) / 2
+}
@@ -0,0 +1,36 @@
+package identicon_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/identicon"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, size := range []int{1, 12, 48, 128, 256} {
+		img := Generate([]byte("cargonaut"), size)
+		assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds(), "size %d", size)
+
+		// The margin is never filled.
+		assert.Equal(t, Background, color.NRGBAModel.Convert(img.At(0, 0)), "size %d", size)
+		assert.Equal(t, Background, color.NRGBAModel.Convert(img.At(size-1, size-1)), "size %d", size)
+
+		// The pattern is mirrored along the vertical axis.
+		for y := 0; y < size; y++ {
+			for x := 0; x < size/2; x++ {
+				if !assert.Equal(t, img.At(x, y), img.At(size-1-x, y), "size %d at %d,%d", size, x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	assert.Equal(t, Generate([]byte("cargonaut"), 48), Generate([]byte("cargonaut"), 48))
+	assert.NotEqual(t, Generate([]byte("cargonaut"), 48), Generate([]byte("cargonauts"), 48))
+}
@@ -0,0 +1,5 @@
+// Package ledger implements double-entry bookkeeping. Money is moved between
+// accounts by journal entries made up of postings, which carry signed amounts
+// in minor currency units. Every entry balances, so the balances of all
+// accounts always sum up to zero.
+package ledger
@@ -0,0 +1,133 @@
+package ledger
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrEmptyEntry is raised when an entry has less than two postings.
+	ErrEmptyEntry = errors.New("entry has less than two postings")
+	// ErrUnbalanced is raised when the amounts of the postings of an entry do
+	// not sum up to zero.
+	ErrUnbalanced = errors.New("entry is unbalanced")
+	// ErrZeroAmount is raised when a posting has an amount of zero.
+	ErrZeroAmount = errors.New("posting has zero amount")
+)
+
+// Posting adds the amount to the balance of an account. Negative amounts are
+// taken from the account.
+type Posting struct {
+	Account string
+	Amount  int64
+}
+
+// Entry is a journal entry which moves money between accounts.
+type Entry []Posting
+
+// Validate returns an error if the entry does not balance.
+func (e Entry) Validate() error {
+	if len(e) < 2 {
+		return ErrEmptyEntry
+	}
+
+	var sum int64
+	for _, p := range e {
+		if p.Amount == 0 {
+			return ErrZeroAmount
+		}
+		sum += p.Amount
+	}
+	if sum != 0 {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+// Transfer returns an entry which moves the amount from one account to
+// another.
+func Transfer(from, to string, amount int64) Entry {
+	return Entry{
+		{Account: from, Amount: -amount},
+		{Account: to, Amount: amount},
+	}
+}
+
+// Settle returns an entry which moves the amount from one account to another,
+// withholding the fee for the fee account. Postings of zero amounts are left
+// out, so no fee posting is made if the fee is zero.
+func Settle(from, to, feeAccount string, amount, fee int64) Entry {
+	e := Entry{{Account: from, Amount: -amount}}
+	if amount != fee {
+		e = append(e, Posting{Account: to, Amount: amount - fee})
+	}
+	if fee != 0 {
+		e = append(e, Posting{Account: feeAccount, Amount: fee})
+	}
+	return e
+}
+
+// Subsidize returns an entry which moves the amount to an account, of which
+// the subsidy is taken from the subsidy account and the rest from the other
+// account. Postings of zero amounts are left out, so no subsidy posting is
+// made if the subsidy is zero.
+func Subsidize(from, to, subsidyAccount string, amount, subsidy int64) Entry {
+	var e Entry
+	if amount != subsidy {
+		e = append(e, Posting{Account: from, Amount: subsidy - amount})
+	}
+	if subsidy != 0 {
+		e = append(e, Posting{Account: subsidyAccount, Amount: -subsidy})
+	}
+	return append(e, Posting{Account: to, Amount: amount})
+}
+
+// Fee returns the fee for the amount at the rate given in basis points,
+// rounded half up. The fee is never negative and never exceeds the amount.
+func Fee(amount, rate int64) int64 {
+	if amount <= 0 || rate <= 0 {
+		return 0
+	} else if rate >= 10000 {
+		return amount
+	}
+	return (amount*rate + 5000) / 10000
+}
+
+// Tax returns the tax included in the gross amount at the rate given in basis
+// points, rounded half up.
+func Tax(gross, rate int64) int64 {
+	if gross <= 0 || rate <= 0 {
+		return 0
+	}
+	return (2*gross*rate + 10000 + rate) / (2 * (10000 + rate))
+}
+
+// Cents returns the amount in minor currency units, rounded to the nearest
+// unit.
+func Cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+// Balances are the balances of accounts.
+type Balances map[string]int64
+
+// Post validates the entry and adds its postings to the balances.
+func (b Balances) Post(e Entry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	for _, p := range e {
+		b[p.Account] += p.Amount
+	}
+	return nil
+}
+
+// Total returns the sum of all balances, which is zero as long as only
+// balanced entries have been posted.
+func (b Balances) Total() int64 {
+	var total int64
+	for _, balance := range b {
+		total += balance
+	}
+	return total
+}
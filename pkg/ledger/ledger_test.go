@@ -0,0 +1,191 @@
+package ledger_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/ledger"
+)
+
+func TestEntryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		err   error
+	}{
+		{"empty", Entry{}, ErrEmptyEntry},
+		{"single posting", Entry{{"a", 100}}, ErrEmptyEntry},
+		{"zero amount", Entry{{"a", 0}, {"b", 0}}, ErrZeroAmount},
+		{"unbalanced", Entry{{"a", -100}, {"b", 99}}, ErrUnbalanced},
+		{"transfer", Transfer("a", "b", 100), nil},
+		{"split", Entry{{"a", -100}, {"b", 90}, {"c", 10}}, nil},
+	}
+	for _, tt := range tests {
+		err := tt.entry.Validate()
+		assert.True(t, errors.Is(err, tt.err), "%s: got %v, want %v", tt.name, err, tt.err)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	assert.Equal(t, Entry{{"a", -250}, {"b", 250}}, Transfer("a", "b", 250))
+}
+
+func TestSettle(t *testing.T) {
+	assert.Equal(t, Entry{{"hold", -1000}, {"driver", 900}, {"fees", 100}}, Settle("hold", "driver", "fees", 1000, 100))
+	assert.Equal(t, Entry{{"hold", -1000}, {"driver", 1000}}, Settle("hold", "driver", "fees", 1000, 0))
+	assert.Equal(t, Entry{{"hold", -1000}, {"fees", 1000}}, Settle("hold", "driver", "fees", 1000, 1000))
+}
+
+func TestSubsidize(t *testing.T) {
+	assert.Equal(t, Entry{{"wallet", -800}, {"promotions", -200}, {"hold", 1000}}, Subsidize("wallet", "hold", "promotions", 1000, 200))
+	assert.Equal(t, Entry{{"wallet", -1000}, {"hold", 1000}}, Subsidize("wallet", "hold", "promotions", 1000, 0))
+	assert.Equal(t, Entry{{"promotions", -1000}, {"hold", 1000}}, Subsidize("wallet", "hold", "promotions", 1000, 1000))
+}
+
+func TestFee(t *testing.T) {
+	tests := []struct {
+		amount int64
+		rate   int64
+		want   int64
+	}{
+		{1000, 1000, 100},
+		{1005, 1000, 101},
+		{1004, 1000, 100},
+		{1, 1000, 0},
+		{5, 1000, 1},
+		{1000, 0, 0},
+		{1000, -100, 0},
+		{0, 1000, 0},
+		{-1000, 1000, 0},
+		{1000, 10000, 1000},
+		{1000, 20000, 1000},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Fee(tt.amount, tt.rate), "amount %d at rate %d", tt.amount, tt.rate)
+	}
+}
+
+func TestTax(t *testing.T) {
+	tests := []struct {
+		gross int64
+		rate  int64
+		want  int64
+	}{
+		{1190, 1900, 190},
+		{100, 1900, 16},
+		{107, 700, 7},
+		{1, 1900, 0},
+		{6, 1900, 1},
+		{1000, 0, 0},
+		{0, 1900, 0},
+		{-1190, 1900, 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Tax(tt.gross, tt.rate), "gross %d at rate %d", tt.gross, tt.rate)
+	}
+}
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   int64
+	}{
+		{0, 0},
+		{12.5, 1250},
+		{float64(float32(2.3)), 230},
+		{float64(float32(19.99)), 1999},
+		{0.005, 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Cents(tt.amount), "amount %v", tt.amount)
+	}
+}
+
+func TestBalancesPost(t *testing.T) {
+	b := Balances{}
+	require.NoError(t, b.Post(Transfer("funding", "wallet", 500)))
+	assert.Equal(t, Balances{"funding": -500, "wallet": 500}, b)
+
+	// Unbalanced entries are rejected without touching the balances.
+	assert.True(t, errors.Is(b.Post(Entry{{"wallet", -100}, {"hold", 50}}), ErrUnbalanced))
+	assert.Equal(t, Balances{"funding": -500, "wallet": 500}, b)
+}
+
+// TestInvariants runs random sequences of deposits, holds, releases and
+// settlements, as they happen when riders book, cancel and complete trips,
+// and checks that the ledger balances after every entry.
+func TestInvariants(t *testing.T) {
+	const (
+		users = 5
+		steps = 1000
+		rate  = 1250
+	)
+
+	type hold struct {
+		user   int
+		amount int64
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for run := 0; run < 20; run++ {
+		b := Balances{}
+		holds := make([]hold, 0)
+		var deposited, fees int64
+
+		for step := 0; step < steps; step++ {
+			user := rnd.Intn(users)
+			wallet, held := fmt.Sprintf("wallet:%d", user), fmt.Sprintf("hold:%d", user)
+
+			switch op := rnd.Intn(4); {
+			case op == 0:
+				amount := rnd.Int63n(10000) + 1
+				require.NoError(t, b.Post(Transfer("funding", wallet, amount)))
+				deposited += amount
+			case op == 1:
+				amount := rnd.Int63n(5000) + 1
+				if b[wallet] < amount {
+					continue
+				}
+				require.NoError(t, b.Post(Transfer(wallet, held, amount)))
+				holds = append(holds, hold{user, amount})
+			case len(holds) > 0:
+				i := rnd.Intn(len(holds))
+				h := holds[i]
+				holds = append(holds[:i], holds[i+1:]...)
+				from := fmt.Sprintf("hold:%d", h.user)
+				if op == 2 {
+					require.NoError(t, b.Post(Transfer(from, fmt.Sprintf("wallet:%d", h.user), h.amount)))
+				} else {
+					fee := Fee(h.amount, rate)
+					require.NoError(t, b.Post(Settle(from, fmt.Sprintf("wallet:%d", (h.user+1)%users), "fees", h.amount, fee)))
+					fees += fee
+				}
+			}
+
+			require.Zero(t, b.Total(), "run %d, step %d", run, step)
+			for account, balance := range b {
+				if account != "funding" {
+					require.True(t, balance >= 0, "run %d, step %d: account %s has negative balance %d", run, step, account, balance)
+				}
+			}
+		}
+
+		// Every held amount is still accounted for and the fees match the
+		// fees withheld on settlement.
+		var open, held int64
+		for _, h := range holds {
+			open += h.amount
+		}
+		for user := 0; user < users; user++ {
+			held += b[fmt.Sprintf("hold:%d", user)]
+		}
+		assert.Equal(t, open, held)
+		assert.Equal(t, fees, b["fees"])
+		assert.Equal(t, -deposited, b["funding"])
+	}
+}
@@ -0,0 +1,6 @@
+// Package mergepatch implements JSON Merge Patch as specified in RFC 7396. A
+// merge patch describes changes to a JSON document using a syntax that closely
+// mimics the document being modified: members present in the patch replace the
+// members of the target, members set to null are removed and members not
+// present in the patch are left untouched.
+package mergepatch
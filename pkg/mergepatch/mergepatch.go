@@ -0,0 +1,69 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ContentType is the media type of a JSON Merge Patch document.
+const ContentType = "application/merge-patch+json"
+
+// ErrInvalidPatch indicates that the patch document is not valid JSON.
+var ErrInvalidPatch = errors.New("mergepatch: invalid patch document")
+
+// Apply applies the JSON Merge Patch to the JSON document and returns the
+// patched document. If the document is empty, it is treated as an empty JSON
+// object.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var patchVal interface{}
+	if err := unmarshal(patch, &patchVal); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err)
+	}
+
+	var docVal interface{}
+	if len(bytes.TrimSpace(doc)) > 0 {
+		if err := unmarshal(doc, &docVal); err != nil {
+			return nil, fmt.Errorf("mergepatch: invalid document: %w", err)
+		}
+	}
+
+	return json.Marshal(merge(docVal, patchVal))
+}
+
+// merge implements the MergePatch function as defined in section 2 of RFC
+// 7396.
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{}, len(patchObj))
+	}
+
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+		} else {
+			targetObj[name] = merge(targetObj[name], value)
+		}
+	}
+
+	return targetObj
+}
+
+// unmarshal decodes JSON data preserving the exact representation of numbers.
+func unmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	} else if dec.More() {
+		return errors.New("unexpected data after top-level value")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package mergepatch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	// Test cases are taken from appendix A of RFC 7396.
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"remove one of many", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"replace with array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"nested objects", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"arrays are not merged", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"array replaces document", `["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{"object replaces array", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"null patch", `{"a":"foo"}`, `null`, `null`},
+		{"string patch", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null member is kept", `{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{"array document", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"deeply nested", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"empty document", ``, `{"a":"b"}`, `{"a":"b"}`},
+		{"number precision", `{"a":1.10}`, `{"b":12345678901234567890}`, `{"a":1.10,"b":12345678901234567890}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	_, err := Apply([]byte(`{}`), []byte(`{"a":`))
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+
+	_, err = Apply([]byte(`{}`), []byte(`{} {}`))
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+
+	_, err = Apply([]byte(`{"a":`), []byte(`{}`))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidPatch))
+}
@@ -0,0 +1,5 @@
+// Package pdf implements a minimal writer for PDF documents. Documents are
+// made up of pages carrying text set in the standard Helvetica fonts, which
+// every PDF reader provides, and lines. Text is encoded using the Windows
+// code page 1252, so characters outside of it can not be represented.
+package pdf
@@ -0,0 +1,231 @@
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Dimensions of an A4 page in points.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font is one of the standard fonts.
+type Font int
+
+// All available fonts.
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// fontNames are the base font names of the fonts, in order.
+var fontNames = []string{"Helvetica", "Helvetica-Bold"}
+
+// Document is a PDF document.
+type Document struct {
+	// Title is the title of the document shown by PDF readers.
+	Title string
+
+	pages []*Page
+}
+
+// New returns a new, empty document.
+func New() *Document {
+	return new(Document)
+}
+
+// AddPage appends a new page of the given dimensions in points to the
+// document.
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{width: width, height: height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Page is a page of a document. Coordinates are given in points, with the
+// origin at the bottom left corner of the page.
+type Page struct {
+	width, height float64
+	content       bytes.Buffer
+}
+
+// Text draws the text with its baseline starting at the given position.
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n", font+1, number(size), number(x), number(y), escape(encode(text)))
+}
+
+// TextRight draws the text with its baseline ending at the given position.
+func (p *Page) TextRight(x, y float64, font Font, size float64, text string) {
+	p.Text(x-TextWidth(font, size, text), y, font, size, text)
+}
+
+// Line draws a line of the given width between two points.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+// WriteTo writes the document to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+
+	// Objects are numbered from one. The catalog, the page tree, the fonts
+	// and the document information come first, followed by every page and
+	// its content stream.
+	nFonts := len(fontNames)
+	pagesObj := 2
+	infoObj := 3 + nFonts
+	firstPageObj := infoObj + 1
+	offsets := make([]int64, 0, infoObj+2*len(d.pages))
+
+	obj := func(body string) {
+		offsets = append(offsets, cw.n)
+		fmt.Fprintf(cw, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	_, _ = io.WriteString(cw, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	obj(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, name := range fontNames {
+		obj(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (cargonaut) >>", escape(encode(d.Title))))
+
+	fonts := make([]string, nFonts)
+	for i := range fontNames {
+		fonts[i] = fmt.Sprintf("/F%d %d 0 R", i+1, 3+i)
+	}
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			pagesObj, number(p.width), number(p.height), strings.Join(fonts, " "), firstPageObj+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.Bytes()))
+	}
+
+	xref := cw.n
+	fmt.Fprintf(cw, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(cw, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(cw, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, infoObj, xref)
+
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+// TextWidth returns the width of the text in points when set in the font at
+// the given size. Characters outside of ASCII are assumed to be as wide as a
+// digit.
+func TextWidth(font Font, size float64, text string) float64 {
+	widths := helveticaWidths
+	if font == HelveticaBold {
+		widths = helveticaBoldWidths
+	}
+
+	var w int
+	for _, b := range encode(text) {
+		if b >= ' ' && b <= '~' {
+			w += widths[b-' ']
+		} else {
+			w += 556
+		}
+	}
+	return float64(w) * size / 1000
+}
+
+// encode encodes the text using the Windows code page 1252. Characters which
+// can not be represented are replaced by a question mark.
+func encode(text string) []byte {
+	b := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			b = append(b, byte(r))
+		case r == '€':
+			b = append(b, 0x80)
+		case r == '–':
+			b = append(b, 0x96)
+		case r == '—':
+			b = append(b, 0x97)
+		default:
+			b = append(b, '?')
+		}
+	}
+	return b
+}
+
+// escape escapes the characters of a literal string which have a special
+// meaning.
+func escape(b []byte) []byte {
+	e := make([]byte, 0, len(b))
+	for _, c := range b {
+		switch c {
+		case '\\', '(', ')':
+			e = append(e, '\\', c)
+		case '\r':
+			e = append(e, '\\', 'r')
+		case '\n':
+			e = append(e, '\\', 'n')
+		default:
+			e = append(e, c)
+		}
+	}
+	return e
+}
+
+// number formats a number with at most two decimal places.
+func number(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// countingWriter counts the bytes written and keeps the first error, so the
+// byte offsets of objects are known and errors are checked once.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
+
+// helveticaWidths and helveticaBoldWidths are the widths of the printable
+// ASCII characters in thousandths of the font size, taken from the font
+// metrics of the standard fonts.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
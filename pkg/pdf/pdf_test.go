@@ -0,0 +1,85 @@
+package pdf_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/pdf"
+)
+
+func TestDocumentWriteTo(t *testing.T) {
+	doc := New()
+	doc.Title = "Receipt (copy)"
+	page := doc.AddPage(A4Width, A4Height)
+	page.Text(50, 800, HelveticaBold, 18, "Receipt")
+	page.Text(50, 780, Helvetica, 10, `Köln – Berlin (10 \ 20) €`)
+	page.Line(50, 770, 545.28, 770, 0.5)
+	doc.AddPage(A4Width, A4Height)
+
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.EqualValues(t, buf.Len(), n)
+
+	out := buf.Bytes()
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, buf.String(), "/Title (Receipt \\(copy\\))")
+	assert.Contains(t, buf.String(), "/Count 2")
+	assert.Contains(t, buf.String(), "BT /F2 18 Tf 50 800 Td (Receipt) Tj ET\n")
+	assert.Contains(t, buf.String(), "BT /F1 10 Tf 50 780 Td (K\xf6ln \x96 Berlin \\(10 \\\\ 20\\) \x80) Tj ET\n")
+	assert.Contains(t, buf.String(), "0.5 w 50 770 m 545.28 770 l S\n")
+
+	// Every entry of the cross-reference table must point to its object.
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 9)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+	assert.Contains(t, buf.String(), "trailer\n<< /Size 10 /Root 1 0 R /Info 5 0 R >>")
+}
+
+func TestDocumentWriteToStreamLength(t *testing.T) {
+	doc := New()
+	doc.AddPage(100, 100).Text(10, 10, Helvetica, 12, "Hello")
+
+	var buf bytes.Buffer
+	_, err := doc.WriteTo(&buf)
+	require.NoError(t, err)
+
+	stream := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindSubmatch(buf.Bytes())
+	require.NotNil(t, stream)
+	assert.Equal(t, string(stream[1]), strconv.Itoa(len(stream[2])))
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		size float64
+		text string
+		want float64
+	}{
+		{Helvetica, 10, "", 0},
+		{Helvetica, 10, "0", 5.56},
+		{Helvetica, 1000, "Hi", 944},
+		{HelveticaBold, 1000, "Hi", 1000},
+		{Helvetica, 1000, "ü", 556},
+		{Helvetica, 1000, "€", 556},
+	}
+	for _, tt := range tests {
+		assert.InDelta(t, tt.want, TextWidth(tt.font, tt.size, tt.text), 0.0001, "%q", tt.text)
+	}
+}
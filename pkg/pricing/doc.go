@@ -0,0 +1,5 @@
+// Package pricing implements price suggestions. A per-km cost model estimates
+// the cost of a trip, which is blended with the prices charged for comparable
+// trips in the past, as far as there are enough of them. Prices are given in
+// minor currency units.
+package pricing
@@ -0,0 +1,83 @@
+package pricing
+
+import (
+	"math"
+	"sort"
+)
+
+// MinSamples is the minimum number of historic prices needed to take them into
+// account.
+const MinSamples = 3
+
+// Model is a cost model for trips. The cost of a trip is the base price plus
+// the price per km, scaled by a factor depending on the vehicle. Suggested
+// ranges spread around the suggested price by the spread given in basis
+// points.
+type Model struct {
+	Base   int64
+	PerKm  int64
+	Spread int64
+}
+
+// Range is a suggested price range.
+type Range struct {
+	Min   int64
+	Price int64
+	Max   int64
+}
+
+// Cost returns the cost of a trip over the distance in km with a vehicle of
+// the given factor, rounded to the nearest unit.
+func (m Model) Cost(km, factor float64) int64 {
+	return m.Base + int64(math.Round(float64(m.PerKm)*km*factor))
+}
+
+// Suggest returns a price range around the cost. If there are at least
+// MinSamples historic prices, the suggested price is the mean of the cost and
+// their median. A cost of zero or less is unknown, so the range is based on
+// historic prices alone. It returns false if there is neither a cost nor
+// enough historic prices.
+func (m Model) Suggest(cost int64, historic []int64) (Range, bool) {
+	price := cost
+	if len(historic) >= MinSamples {
+		if median := Median(historic); cost > 0 {
+			price = (cost + median + 1) / 2
+		} else {
+			price = median
+		}
+	}
+	if price <= 0 {
+		return Range{}, false
+	}
+
+	spread := (price*m.Spread + 5000) / 10000
+	return Range{
+		Min:   price - spread,
+		Price: price,
+		Max:   price + spread,
+	}, true
+}
+
+// Far returns true if the price is far outside of the range, that is below
+// half of the minimum or above twice the maximum.
+func (r Range) Far(price int64) bool {
+	return 2*price < r.Min || price > 2*r.Max
+}
+
+// Median returns the median of the prices, rounded half up. It returns zero if
+// there are no prices. The prices are left untouched.
+func Median(prices []int64) int64 {
+	if len(prices) == 0 {
+		return 0
+	}
+
+	sorted := make([]int64, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid] + 1) / 2
+}
@@ -0,0 +1,82 @@
+package pricing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/pricing"
+)
+
+func TestModelCost(t *testing.T) {
+	m := Model{Base: 200, PerKm: 12}
+
+	assert.EqualValues(t, 200, m.Cost(0, 1))
+	assert.EqualValues(t, 3260, m.Cost(255, 1))
+	assert.EqualValues(t, 4790, m.Cost(255, 1.5))
+	assert.EqualValues(t, 201, m.Cost(0.05, 1))
+}
+
+func TestModelSuggest(t *testing.T) {
+	m := Model{Spread: 2000}
+
+	tests := []struct {
+		name     string
+		cost     int64
+		historic []int64
+		want     Range
+		ok       bool
+	}{
+		{"cost only", 1000, nil, Range{800, 1000, 1200}, true},
+		{"too few historic prices", 1000, []int64{5000, 5000}, Range{800, 1000, 1200}, true},
+		{"blended", 1000, []int64{1400, 1600, 900}, Range{960, 1200, 1440}, true},
+		{"historic prices only", 0, []int64{1400, 1600, 900}, Range{1120, 1400, 1680}, true},
+		{"rounded", 1001, []int64{1000, 1000, 1000}, Range{801, 1001, 1201}, true},
+		{"unknown", 0, []int64{1000}, Range{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Suggest(tt.cost, tt.historic)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func TestRangeFar(t *testing.T) {
+	r := Range{Min: 800, Price: 1000, Max: 1200}
+
+	tests := []struct {
+		price int64
+		want  bool
+	}{
+		{1000, false},
+		{400, false},
+		{399, true},
+		{2400, false},
+		{2401, true},
+		{0, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.Far(tt.price), "price %d", tt.price)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		prices []int64
+		want   int64
+	}{
+		{nil, 0},
+		{[]int64{5}, 5},
+		{[]int64{3, 1, 2}, 2},
+		{[]int64{4, 1, 3, 2}, 3},
+		{[]int64{1, 2}, 2},
+		{[]int64{10, 20, 30, 40}, 25},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Median(tt.prices), "%v", tt.prices)
+	}
+
+	prices := []int64{3, 1, 2}
+	Median(prices)
+	assert.Equal(t, []int64{3, 1, 2}, prices)
+}
@@ -0,0 +1,4 @@
+// Package sigv4 implements the AWS Signature Version 4 signing of HTTP
+// requests, which S3 and S3 compatible object stores like MinIO use to
+// authenticate requests.
+package sigv4
@@ -0,0 +1,6 @@
+// Package webhook implements signing of webhook payloads. A payload is signed
+// with HMAC-SHA256 using a secret shared with the receiver, which verifies the
+// signature to make sure the payload was sent by the holder of the secret and
+// has not been tampered with. Targets of webhooks are restricted to public
+// addresses, so webhooks can't be used to reach into the network of the sender.
+package webhook
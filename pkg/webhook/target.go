@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// ErrForbiddenTarget is returned for webhook targets which are not reachable
+// through the public internet, like loopback, private or link-local addresses.
+// Delivering to them would let users probe the network of the sender.
+var ErrForbiddenTarget = errors.New("webhook target address is not public")
+
+// nonPublicNetworks are the IP networks, besides loopback, link-local,
+// multicast and unspecified addresses, which are not reachable through the
+// public internet.
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",       // "This" network
+	"10.0.0.0/8",      // Private
+	"100.64.0.0/10",   // Shared address space (carrier-grade NAT)
+	"172.16.0.0/12",   // Private
+	"192.0.0.0/24",    // IETF protocol assignments
+	"192.0.2.0/24",    // Documentation
+	"192.88.99.0/24",  // 6to4 relay anycast
+	"192.168.0.0/16",  // Private
+	"198.18.0.0/15",   // Benchmarking
+	"198.51.100.0/24", // Documentation
+	"203.0.113.0/24",  // Documentation
+	"240.0.0.0/4",     // Reserved, including broadcast
+	"64:ff9b::/96",    // IPv4/IPv6 translation
+	"64:ff9b:1::/48",  // Local-use IPv4/IPv6 translation
+	"100::/64",        // Discard-only
+	"2001::/32",       // Teredo, which embeds IPv4 addresses
+	"2001:db8::/32",   // Documentation
+	"2002::/16",       // 6to4, which embeds IPv4 addresses
+	"fc00::/7",        // Unique local
+	"fec0::/10",       // Site-local (deprecated)
+)
+
+// PublicIP returns true if the IP address is reachable through the public
+// internet, so webhooks may be delivered to it.
+func PublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckTarget resolves the host of the webhook URL and returns
+// ErrForbiddenTarget if any of its addresses is not public.
+func CheckTarget(ctx context.Context, resolver *net.Resolver, rawurl string) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return err
+	}
+	addrs, err := resolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("resolve webhook target: %w", err)
+	}
+	for _, addr := range addrs {
+		if !PublicIP(addr.IP) {
+			return ErrForbiddenTarget
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer control function which refuses to connect to
+// addresses which are not public. Checking the address right before the
+// connection is established covers redirects and DNS records which changed
+// since the target has been checked.
+func Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+		return ErrForbiddenTarget
+	}
+	return nil
+}
+
+// mustParseCIDRs parses the CIDR notations of IP networks and panics if one
+// is invalid.
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader is the HTTP header carrying the signature of a payload.
+const SignatureHeader = "X-Cargonaut-Signature"
+
+// signaturePrefix identifies the algorithm of a signature.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of the payload. The signature is the hex encoded
+// HMAC-SHA256 of the payload prefixed with the algorithm, "sha256=".
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if the signature is a valid signature of the payload.
+// The comparison is performed in constant time.
+func Verify(secret, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	sum, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(payload)
+	return hmac.Equal(sum, mac.Sum(nil))
+}
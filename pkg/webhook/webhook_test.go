@@ -0,0 +1,87 @@
+package webhook_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/my-cargonaut/cargonaut/pkg/webhook"
+)
+
+func TestSign(t *testing.T) {
+	// Test vector of RFC 4231, test case 2.
+	signature := Sign([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature)
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	payload := []byte(`{"type":"trip_booked"}`)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{"valid signature", secret, payload, Sign(secret, payload), true},
+		{"wrong secret", []byte("other"), payload, Sign(secret, payload), false},
+		{"tampered payload", secret, []byte(`{"type":"trip_cancelled"}`), Sign(secret, payload), false},
+		{"missing prefix", secret, payload, Sign(secret, payload)[len("sha256="):], false},
+		{"invalid hex", secret, payload, "sha256=zz", false},
+		{"empty signature", secret, payload, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Verify(tt.secret, tt.payload, tt.signature))
+		})
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"172.32.0.1", true},
+		{"192.168.1.1", false},
+		{"100.64.0.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, PublicIP(net.ParseIP(tt.ip)), tt.ip)
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, CheckTarget(ctx, net.DefaultResolver, "https://93.184.216.34/hook"))
+	assert.Equal(t, ErrForbiddenTarget, CheckTarget(ctx, net.DefaultResolver, "http://127.0.0.1:8080/hook"))
+	assert.Equal(t, ErrForbiddenTarget, CheckTarget(ctx, net.DefaultResolver, "http://[::1]/hook"))
+	assert.Equal(t, ErrForbiddenTarget, CheckTarget(ctx, net.DefaultResolver, "http://169.254.169.254/latest/meta-data"))
+}
+
+func TestControl(t *testing.T) {
+	assert.NoError(t, Control("tcp", "93.184.216.34:443", nil))
+	assert.Equal(t, ErrForbiddenTarget, Control("tcp", "127.0.0.1:80", nil))
+	assert.Equal(t, ErrForbiddenTarget, Control("tcp6", "[fd00::1]:443", nil))
+}
@@ -16,6 +16,12 @@ export default {
   delete(id) {
     return client.delete(`/trips/` + id);
   },
+  start(id) {
+    return client.post(`/trips/` + id + `/start`);
+  },
+  finish(id) {
+    return client.post(`/trips/` + id + `/finish`);
+  },
   getRating(id) {
     return client.get(`/trips/` + id + `/ratings`);
   },
@@ -96,6 +96,42 @@ const trips = {
       });
     },
 
+    start({ commit }, id) {
+      return new Promise((resolve, reject) => {
+        commit("SET_LOADING", true);
+        tripsAPI
+          .start(id)
+          .then(response => {
+            resolve(response);
+          })
+          .catch(e => {
+            commit("alert/SET", getAlert(e), { root: true });
+            reject(e);
+          })
+          .finally(() => {
+            commit("SET_LOADING", false);
+          });
+      });
+    },
+
+    finish({ commit }, id) {
+      return new Promise((resolve, reject) => {
+        commit("SET_LOADING", true);
+        tripsAPI
+          .finish(id)
+          .then(response => {
+            resolve(response);
+          })
+          .catch(e => {
+            commit("alert/SET", getAlert(e), { root: true });
+            reject(e);
+          })
+          .finally(() => {
+            commit("SET_LOADING", false);
+          });
+      });
+    },
+
     getRating({ commit }, id) {
       return new Promise((resolve, reject) => {
         commit("SET_LOADING", true);